@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net"
@@ -12,6 +11,7 @@ import (
 	"syscall"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
@@ -20,31 +20,62 @@ import (
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/mcpserver"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/middleware"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/openaicompat"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/search"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/server"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/shutdown"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/tlsconfig"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/tracing"
 	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
 	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
 	ingestionv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/ingestion/v1"
 )
 
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(middleware.NewRequestIDHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 	slog.SetDefault(logger)
 
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.ServiceName, cfg.OTelEndpoint)
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Create the Cortex server
-	cortexServer := server.NewCortexServer(logger)
+	cortexServer := server.NewCortexServer(logger, cfg)
 	defer cortexServer.Close()
+	cortexServer.StartSessionSweeper()
+	cortexServer.StartMetricsAutoSave()
 
 	// Connect to downstream services (non-fatal if they're not available)
 	if err := cortexServer.ConnectDownstream(cfg.FrontalLobeAddr, cfg.HippocampusAddr); err != nil {
 		logger.Warn("failed to connect to some downstream services", "error", err)
+	} else if cfg.DownstreamWait > 0 {
+		waitCtx, cancel := context.WithTimeout(context.Background(), cfg.DownstreamWait)
+		if err := cortexServer.WaitForDownstream(waitCtx); err != nil {
+			logger.Warn("downstream services not ready, continuing in degraded mode", "error", err)
+		}
+		cancel()
 	}
 
-	// Configure gRPC server with interceptors and keepalive
-	grpcServer := grpc.NewServer(
+	// Configure gRPC server with TLS, tracing, interceptors and keepalive
+	tlsOpts, err := tlsconfig.ServerOptions(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		logger.Error("failed to load TLS credentials", "error", err)
+		os.Exit(1)
+	}
+	latency := cortexServer.MetricsStore().Latency()
+	limiter := middleware.NewRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst)
+	grpcServer := grpc.NewServer(append(tlsOpts,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle:     15 * time.Minute,
 			MaxConnectionAge:      30 * time.Minute,
@@ -53,14 +84,23 @@ func main() {
 			Timeout:               1 * time.Second,
 		}),
 		grpc.ChainUnaryInterceptor(
+			middleware.UnaryRequestID(),
 			middleware.UnaryRecovery(logger),
 			middleware.UnaryLogging(logger),
+			middleware.UnaryMetrics(latency),
+			middleware.UnaryAuth(cfg.AuthTokens),
+			middleware.UnaryRateLimit(limiter),
 			middleware.UnaryTimeout(cfg.DefaultTimeout),
 		),
 		grpc.ChainStreamInterceptor(
+			middleware.StreamRequestID(),
+			middleware.StreamRecovery(logger),
 			middleware.StreamLogging(logger),
+			middleware.StreamMetrics(latency),
+			middleware.StreamAuth(cfg.AuthTokens),
+			middleware.StreamRateLimit(limiter),
 		),
-	)
+	)...)
 
 	// Register services
 	agentv1.RegisterReasoningEngineServer(grpcServer, cortexServer)
@@ -79,8 +119,21 @@ func main() {
 	// Set up OpenAI-compatible HTTP API
 	availableModels := []string{"secondbrain", "mock"}
 	openaiHandler := openaicompat.NewHandler(logger, availableModels)
+	// Never fall back to plaintext: the handler sends the downstream token.
+	creds, err := tlsconfig.ClientCredentials(cfg.DownstreamTLS, cfg.TLSCAFile)
+	if err != nil {
+		logger.Error("failed to load downstream TLS credentials for OpenAI handler", "error", err)
+		os.Exit(1)
+	}
+	openaiHandler.SetTransportCredentials(creds)
+	openaiHandler.SetAuthToken(cfg.DownstreamAuthToken)
+	openaiHandler.SetReasoningTimeout(cfg.ReasoningTimeout)
+	openaiHandler.SetHeartbeatInterval(cfg.HeartbeatInterval)
+	openaiHandler.SetRetryAfter(cfg.RetryAfter)
 	if err := openaiHandler.ConnectFrontalLobe(cfg.FrontalLobeAddr); err != nil {
 		logger.Warn("failed to connect OpenAI handler to frontal lobe", "error", err)
+	} else {
+		defer openaiHandler.StartModelRefresher(cfg.ModelRefreshInterval)()
 	}
 	defer openaiHandler.Close()
 
@@ -91,16 +144,25 @@ func main() {
 	mcpSrv := mcpserver.NewServer(logger, cortexServer.MemoryClient())
 	httpMux.Handle("POST /mcp", mcpSrv)
 
+	// REST search endpoint
+	search.NewHandler(logger, cortexServer.MemoryClient()).RegisterRoutes(httpMux)
+
 	// Metrics endpoint
-	metricsStore := cortexServer.MetricsStore()
-	httpMux.HandleFunc("GET /v1/metrics", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(metricsStore.Summary())
-	})
+	cortexServer.MetricsStore().RegisterRoutes(httpMux)
+
+	// Review generation and classification endpoints for cron jobs and scripts
+	cortexServer.RegisterReviewRoutes(httpMux)
+	cortexServer.RegisterClassifyRoutes(httpMux)
+
+	// HTTP ingestion endpoint for scripts without a gRPC client
+	cortexServer.RegisterIngestRoutes(httpMux)
+
+	// Kubernetes liveness and readiness probes
+	cortexServer.RegisterProbeRoutes(httpMux)
 	httpAddr := fmt.Sprintf(":%d", cfg.HTTPPort)
 	httpServer := &http.Server{
 		Addr:    httpAddr,
-		Handler: httpMux,
+		Handler: middleware.HTTPAuth(cfg.AuthTokens, httpMux, server.AuthenticatedRoutes...),
 	}
 
 	// Graceful shutdown
@@ -123,8 +185,15 @@ func main() {
 	}()
 
 	<-ctx.Done()
-	logger.Info("shutting down cortex service...")
-	grpcServer.GracefulStop()
-	httpServer.Shutdown(context.Background())
+	logger.Info("shutting down cortex service...", "timeout", cfg.ShutdownTimeout)
+
+	// Stop accepting requests and let in-flight streams finish; the deferred
+	// Close calls then tear down the OpenAI handler's and the server's
+	// downstream connections once nothing is using them.
+	drainCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := shutdown.Drain(drainCtx, grpcServer, httpServer); err != nil {
+		logger.Warn("in-flight requests did not finish before the shutdown deadline", "error", err)
+	}
 	logger.Info("cortex service stopped")
 }
@@ -1,55 +1,209 @@
 package config
 
 import (
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all configuration for the Cortex service.
 type Config struct {
 	// Server settings
-	GRPCPort    int
-	HTTPPort    int
-	ServiceName string
+	GRPCPort    int    `yaml:"grpc_port"`
+	HTTPPort    int    `yaml:"http_port"`
+	ServiceName string `yaml:"service_name"`
 
 	// Downstream services
-	FrontalLobeAddr  string
-	HippocampusAddr  string
-	GatewayAddr      string
+	FrontalLobeAddr string `yaml:"frontal_lobe_addr"`
+	HippocampusAddr string `yaml:"hippocampus_addr"`
+	GatewayAddr     string `yaml:"gateway_addr"`
+
+	// TLS
+	TLSCertFile   string `yaml:"tls_cert_file"`  // server certificate; empty serves plaintext
+	TLSKeyFile    string `yaml:"tls_key_file"`   // server private key
+	TLSCAFile     string `yaml:"tls_ca_file"`    // CA bundle for verifying downstream certificates; empty uses system roots
+	DownstreamTLS bool   `yaml:"downstream_tls"` // dial downstream services over TLS
+
+	// Health aggregation
+	RequiredDependencies []string      `yaml:"health_required_deps"` // downstreams that must be SERVING for cortex to report SERVING
+	HealthCheckTimeout   time.Duration `yaml:"health_check_timeout"` // per-dependency health probe timeout
+
+	// Downstream connection backoff
+	BackoffBaseDelay time.Duration `yaml:"downstream_backoff_base"` // first reconnect delay after a failed dial
+	BackoffMaxDelay  time.Duration `yaml:"downstream_backoff_max"`  // upper bound on the reconnect delay
+	DownstreamWait   time.Duration `yaml:"downstream_wait_timeout"` // how long startup blocks for downstreams to be READY; 0 doesn't wait
 
 	// MCP settings
-	MCPServerURL  string
-	NotionToken   string
+	MCPServerURL string `yaml:"mcp_server_url"`
+	NotionToken  string `yaml:"notion_token"`
+
+	// Sessions
+	SessionStorePath     string        `yaml:"session_store_path"`     // JSON file for persisting sessions; empty keeps them in memory
+	SessionTTL           time.Duration `yaml:"session_ttl"`            // idle time before a session is evicted; 0 disables expiry
+	MaxSessions          int           `yaml:"session_max"`            // cap on live sessions (LRU eviction); 0 means unlimited
+	SessionSweepInterval time.Duration `yaml:"session_sweep_interval"` // how often expired sessions are swept
+	SessionMaxTurns      int           `yaml:"session_max_turns"`      // episodic turns kept per session (oldest evicted first)
+	SessionMaxTokens     int           `yaml:"session_max_tokens"`     // approximate token budget for a session's episodic memory; 0 means no budget
+	SessionSummarize     bool          `yaml:"session_summarize"`      // summarize evicted turns via the Frontal Lobe (costs an extra LLM call)
+
+	// Context retrieval
+	ContextTopK         int     `yaml:"context_top_k"`         // default number of memory chunks retrieved per query
+	MaxContextTopK      int     `yaml:"context_top_k_max"`     // upper bound for per-request topK overrides
+	MinContextRelevance float64 `yaml:"context_min_relevance"` // chunks scoring below this are left out of the prompt; 0 keeps all
+
+	// Ingestion idempotency
+	IdempotencyTTL     time.Duration `yaml:"idempotency_ttl"`      // how long IngestItem remembers an idempotency key; 0 disables deduplication
+	IdempotencyMaxKeys int           `yaml:"idempotency_max_keys"` // cap on remembered keys (oldest evicted first); 0 means unlimited
+
+	// Metrics
+	TopicTaxonomyPath  string  `yaml:"topic_taxonomy_path"`   // JSON topic->keywords file for query topic classification; empty uses the built-in taxonomy
+	TopicAliasesPath   string  `yaml:"topic_aliases_path"`    // JSON alias->topic file merging synonymous topics for knowledge coverage; empty disables merging
+	LoopWarnCoverage   float64 `yaml:"loop_warn_coverage"`    // knowledge coverage below which a degenerate feedback loop is flagged; 0 disables the warning
+	LoopWarnMinQueries int     `yaml:"loop_warn_min_queries"` // interactions needed before low coverage is flagged
+
+	// Metrics persistence
+	MetricsStorePath    string        `yaml:"metrics_store_path"`    // JSON file for persisting metrics; empty keeps them in memory
+	MetricsSaveInterval time.Duration `yaml:"metrics_save_interval"` // how often metrics are auto-saved
+
+	// Quality evaluation (LLM-as-judge, opt-in because it costs an extra LLM call)
+	QualityEvalEnabled bool          `yaml:"quality_eval_enabled"`
+	QualityEvalTimeout time.Duration `yaml:"quality_eval_timeout"`
 
 	// Timeouts
-	DefaultTimeout time.Duration
-	StreamTimeout  time.Duration
+	DefaultTimeout   time.Duration `yaml:"default_timeout"`
+	StreamTimeout    time.Duration `yaml:"stream_timeout"`
+	ShutdownTimeout  time.Duration `yaml:"shutdown_timeout"`  // how long shutdown waits for in-flight requests before forcing them closed
+	ReasoningTimeout time.Duration `yaml:"reasoning_timeout"` // upper bound on a Frontal Lobe reasoning stream; 0 disables it
+	RetryAfter       time.Duration `yaml:"retry_after"`       // Retry-After sent when the Frontal Lobe is unavailable or rate limited
+
+	// HeartbeatInterval is how often a streamed chat completion sends a
+	// keep-alive comment before the first output; 0 disables it.
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+
+	// ModelRefreshInterval is how often /v1/models is refreshed from the
+	// Frontal Lobe's routed models; 0 lists only the built-in models.
+	ModelRefreshInterval time.Duration `yaml:"model_refresh_interval"`
 
 	// Auth
-	OAuthClientID     string
-	OAuthClientSecret string
+	OAuthClientID       string   `yaml:"oauth_client_id"`
+	OAuthClientSecret   string   `yaml:"oauth_client_secret"`
+	AuthTokens          []string `yaml:"auth_tokens"`           // bearer tokens accepted on gRPC calls; empty disables auth
+	DownstreamAuthToken string   `yaml:"downstream_auth_token"` // bearer token sent to downstream services
+
+	// Rate limiting (token bucket per client)
+	RateLimitRPS   float64 `yaml:"rate_limit_rps"`   // sustained calls per second per client; 0 disables limiting
+	RateLimitBurst int     `yaml:"rate_limit_burst"` // calls a client may make at once
 
 	// Observability
-	OTelEndpoint string
+	OTelEndpoint string `yaml:"otel_endpoint"`
+}
+
+// Load reads configuration from a YAML file, then environment variables,
+// which take precedence over file values. A file named by CONFIG_FILE must
+// load cleanly; an unreadable default config.yaml is logged and ignored.
+func Load() (*Config, error) {
+	base := defaults()
+	path, explicit := configFilePath()
+	if err := loadFile(base, path, explicit); err != nil {
+		if explicit {
+			return nil, err
+		}
+		slog.Warn("ignoring config file", "path", path, "error", err)
+		base = defaults()
+	}
+
+	return &Config{
+		GRPCPort:             getEnvInt("CORTEX_GRPC_PORT", base.GRPCPort),
+		HTTPPort:             getEnvInt("CORTEX_HTTP_PORT", base.HTTPPort),
+		ServiceName:          getEnv("CORTEX_SERVICE_NAME", base.ServiceName),
+		FrontalLobeAddr:      getEnv("FRONTAL_LOBE_ADDR", base.FrontalLobeAddr),
+		HippocampusAddr:      getEnv("HIPPOCAMPUS_ADDR", base.HippocampusAddr),
+		GatewayAddr:          getEnv("GATEWAY_ADDR", base.GatewayAddr),
+		TLSCertFile:          getEnv("TLS_CERT_FILE", base.TLSCertFile),
+		TLSKeyFile:           getEnv("TLS_KEY_FILE", base.TLSKeyFile),
+		TLSCAFile:            getEnv("TLS_CA_FILE", base.TLSCAFile),
+		DownstreamTLS:        getEnvBool("DOWNSTREAM_TLS", base.DownstreamTLS),
+		RequiredDependencies: getEnvList("HEALTH_REQUIRED_DEPS", base.RequiredDependencies),
+		HealthCheckTimeout:   getDurationEnv("HEALTH_CHECK_TIMEOUT", base.HealthCheckTimeout),
+		BackoffBaseDelay:     getDurationEnv("DOWNSTREAM_BACKOFF_BASE", base.BackoffBaseDelay),
+		BackoffMaxDelay:      getDurationEnv("DOWNSTREAM_BACKOFF_MAX", base.BackoffMaxDelay),
+		DownstreamWait:       getDurationEnv("DOWNSTREAM_WAIT_TIMEOUT", base.DownstreamWait),
+		MCPServerURL:         getEnv("MCP_SERVER_URL", base.MCPServerURL),
+		NotionToken:          getEnv("NOTION_TOKEN", base.NotionToken),
+		SessionStorePath:     getEnv("SESSION_STORE_PATH", base.SessionStorePath),
+		SessionTTL:           getDurationEnv("SESSION_TTL", base.SessionTTL),
+		MaxSessions:          getEnvInt("SESSION_MAX", base.MaxSessions),
+		SessionSweepInterval: getDurationEnv("SESSION_SWEEP_INTERVAL", base.SessionSweepInterval),
+		SessionMaxTurns:      getEnvInt("SESSION_MAX_TURNS", base.SessionMaxTurns),
+		SessionMaxTokens:     getEnvInt("SESSION_MAX_TOKENS", base.SessionMaxTokens),
+		SessionSummarize:     getEnvBool("SESSION_SUMMARIZE", base.SessionSummarize),
+		ContextTopK:          getEnvInt("CONTEXT_TOP_K", base.ContextTopK),
+		MaxContextTopK:       getEnvInt("CONTEXT_TOP_K_MAX", base.MaxContextTopK),
+		MinContextRelevance:  getEnvFloat("CONTEXT_MIN_RELEVANCE", base.MinContextRelevance),
+		IdempotencyTTL:       getDurationEnv("IDEMPOTENCY_TTL", base.IdempotencyTTL),
+		IdempotencyMaxKeys:   getEnvInt("IDEMPOTENCY_MAX_KEYS", base.IdempotencyMaxKeys),
+		TopicTaxonomyPath:    getEnv("TOPIC_TAXONOMY_PATH", base.TopicTaxonomyPath),
+		TopicAliasesPath:     getEnv("TOPIC_ALIASES_PATH", base.TopicAliasesPath),
+		LoopWarnCoverage:     getEnvFloat("LOOP_WARN_COVERAGE", base.LoopWarnCoverage),
+		LoopWarnMinQueries:   getEnvInt("LOOP_WARN_MIN_QUERIES", base.LoopWarnMinQueries),
+		MetricsStorePath:     getEnv("METRICS_STORE_PATH", base.MetricsStorePath),
+		MetricsSaveInterval:  getDurationEnv("METRICS_SAVE_INTERVAL", base.MetricsSaveInterval),
+		QualityEvalEnabled:   getEnvBool("QUALITY_EVAL_ENABLED", base.QualityEvalEnabled),
+		QualityEvalTimeout:   getDurationEnv("QUALITY_EVAL_TIMEOUT", base.QualityEvalTimeout),
+		DefaultTimeout:       getDurationEnv("DEFAULT_TIMEOUT", base.DefaultTimeout),
+		StreamTimeout:        getDurationEnv("STREAM_TIMEOUT", base.StreamTimeout),
+		ShutdownTimeout:      getDurationEnv("SHUTDOWN_TIMEOUT", base.ShutdownTimeout),
+		ReasoningTimeout:     getDurationEnv("REASONING_TIMEOUT", base.ReasoningTimeout),
+		RetryAfter:           getDurationEnv("RETRY_AFTER", base.RetryAfter),
+		HeartbeatInterval:    getDurationEnv("STREAM_HEARTBEAT_INTERVAL", base.HeartbeatInterval),
+		ModelRefreshInterval: getDurationEnv("MODEL_REFRESH_INTERVAL", base.ModelRefreshInterval),
+		OAuthClientID:        getEnv("OAUTH_CLIENT_ID", base.OAuthClientID),
+		OAuthClientSecret:    getEnv("OAUTH_CLIENT_SECRET", base.OAuthClientSecret),
+		AuthTokens:           getEnvList("AUTH_TOKENS", base.AuthTokens),
+		DownstreamAuthToken:  getEnv("DOWNSTREAM_AUTH_TOKEN", base.DownstreamAuthToken),
+		RateLimitRPS:         getEnvFloat("RATE_LIMIT_RPS", base.RateLimitRPS),
+		RateLimitBurst:       getEnvInt("RATE_LIMIT_BURST", base.RateLimitBurst),
+		OTelEndpoint:         getEnv("OTEL_ENDPOINT", base.OTelEndpoint),
+	}, nil
 }
 
-// Load reads configuration from environment variables with sensible defaults.
-func Load() *Config {
+// defaults returns the configuration used when neither the config file nor
+// the environment sets a value.
+func defaults() *Config {
 	return &Config{
-		GRPCPort:          getEnvInt("CORTEX_GRPC_PORT", 50051),
-		HTTPPort:          getEnvInt("CORTEX_HTTP_PORT", 8080),
-		ServiceName:       getEnv("CORTEX_SERVICE_NAME", "cortex"),
-		FrontalLobeAddr:   getEnv("FRONTAL_LOBE_ADDR", "localhost:50052"),
-		HippocampusAddr:   getEnv("HIPPOCAMPUS_ADDR", "localhost:50053"),
-		GatewayAddr:       getEnv("GATEWAY_ADDR", "localhost:50054"),
-		MCPServerURL:      getEnv("MCP_SERVER_URL", "http://localhost:3000"),
-		NotionToken:       getEnv("NOTION_TOKEN", ""),
-		DefaultTimeout:    getDurationEnv("DEFAULT_TIMEOUT", 30*time.Second),
-		StreamTimeout:     getDurationEnv("STREAM_TIMEOUT", 5*time.Minute),
-		OAuthClientID:     getEnv("OAUTH_CLIENT_ID", ""),
-		OAuthClientSecret: getEnv("OAUTH_CLIENT_SECRET", ""),
-		OTelEndpoint:      getEnv("OTEL_ENDPOINT", ""),
+		GRPCPort:             50051,
+		HTTPPort:             8080,
+		ServiceName:          "cortex",
+		FrontalLobeAddr:      "localhost:50052",
+		HippocampusAddr:      "localhost:50053",
+		GatewayAddr:          "localhost:50054",
+		RequiredDependencies: []string{"frontal_lobe"},
+		HealthCheckTimeout:   2 * time.Second,
+		BackoffBaseDelay:     time.Second,
+		BackoffMaxDelay:      30 * time.Second,
+		MCPServerURL:         "http://localhost:3000",
+		SessionTTL:           24 * time.Hour,
+		MaxSessions:          10000,
+		SessionSweepInterval: 5 * time.Minute,
+		SessionMaxTurns:      50,
+		ContextTopK:          5,
+		MaxContextTopK:       50,
+		IdempotencyTTL:       24 * time.Hour,
+		IdempotencyMaxKeys:   10000,
+		LoopWarnCoverage:     0.3,
+		LoopWarnMinQueries:   50,
+		MetricsSaveInterval:  time.Minute,
+		QualityEvalTimeout:   30 * time.Second,
+		DefaultTimeout:       30 * time.Second,
+		StreamTimeout:        5 * time.Minute,
+		ShutdownTimeout:      30 * time.Second,
+		ReasoningTimeout:     5 * time.Minute,
+		RetryAfter:           5 * time.Second,
+		HeartbeatInterval:    15 * time.Second,
+		ModelRefreshInterval: 5 * time.Minute,
+		RateLimitBurst:       10,
 	}
 }
 
@@ -69,6 +223,39 @@ func getEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+func getEnvFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// getEnvList parses a comma-separated list, ignoring empty entries.
+func getEnvList(key string, fallback []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func getDurationEnv(key string, fallback time.Duration) time.Duration {
 	if v := os.Getenv(key); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testConfigYAML = `grpc_port: 6000
+frontal_lobe_addr: frontal:7000
+session_ttl: 1h
+health_required_deps: [frontal_lobe, hippocampus]
+quality_eval_enabled: true
+`
+
+// writeConfigFile writes content to a temp file and points CONFIG_FILE at it.
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+}
+
+// mustLoad calls Load and fails the test on error.
+func mustLoad(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+func TestLoadFromFile(t *testing.T) {
+	writeConfigFile(t, testConfigYAML)
+
+	cfg := mustLoad(t)
+
+	if got := cfg.GRPCPort; got != 6000 {
+		t.Errorf("cfg.GRPCPort: expected %d, got %d", 6000, got)
+	}
+	if got := cfg.FrontalLobeAddr; got != "frontal:7000" {
+		t.Errorf("cfg.FrontalLobeAddr: expected %q, got %q", "frontal:7000", got)
+	}
+	if got := cfg.SessionTTL; got != time.Hour {
+		t.Errorf("cfg.SessionTTL: expected %v, got %v", time.Hour, got)
+	}
+	if got := len(cfg.RequiredDependencies); got != 2 {
+		t.Errorf("len(cfg.RequiredDependencies): expected %d, got %d", 2, got)
+	}
+	if got := cfg.QualityEvalEnabled; got != true {
+		t.Errorf("cfg.QualityEvalEnabled: expected %v, got %v", true, got)
+	}
+	if got := cfg.HTTPPort; got != 8080 {
+		t.Errorf("cfg.HTTPPort: expected %d, got %d", 8080, got)
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	writeConfigFile(t, testConfigYAML)
+	t.Setenv("CORTEX_GRPC_PORT", "7001")
+
+	cfg := mustLoad(t)
+
+	if got := cfg.GRPCPort; got != 7001 {
+		t.Errorf("cfg.GRPCPort: expected %d, got %d", 7001, got)
+	}
+	if got := cfg.FrontalLobeAddr; got != "frontal:7000" {
+		t.Errorf("cfg.FrontalLobeAddr: expected %q, got %q", "frontal:7000", got)
+	}
+}
+
+func TestLoadMissingExplicitFileFails(t *testing.T) {
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected an error for a missing CONFIG_FILE")
+	}
+}
+
+func TestLoadMalformedExplicitFileFails(t *testing.T) {
+	writeConfigFile(t, "grpc_port: [not a port\n")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected an error for a malformed CONFIG_FILE")
+	}
+}
+
+func TestLoadMissingDefaultFileUsesDefaults(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+
+	cfg := mustLoad(t)
+
+	if got := cfg.GRPCPort; got != 50051 {
+		t.Errorf("cfg.GRPCPort: expected %d, got %d", 50051, got)
+	}
+}
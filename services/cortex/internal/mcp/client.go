@@ -3,21 +3,69 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	// protocolVersion is the MCP revision requested during initialize.
+	protocolVersion = "2024-11-05"
+	// maxAttempts bounds how many times a request is sent when it fails
+	// with a network error or a 5xx status.
+	maxAttempts = 3
+	// defaultRetryBackoff is the wait before the first retry; it doubles on
+	// each subsequent attempt.
+	defaultRetryBackoff = 200 * time.Millisecond
+	// sessionHeader carries the session ID assigned by streamable HTTP servers.
+	sessionHeader = "Mcp-Session-Id"
+	// defaultListTimeout bounds tools/list, which should be quick.
+	defaultListTimeout = 10 * time.Second
+	// defaultCallTimeout bounds tools/call.
+	defaultCallTimeout = 30 * time.Second
+)
+
+// ClientOptions configures a Client. Zero values use the defaults.
+type ClientOptions struct {
+	ListTimeout time.Duration // default deadline for ListTools
+	CallTimeout time.Duration // default deadline for CallTool
+}
+
+// CallOption adjusts a single ListTools or CallTool request.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout time.Duration
+}
+
+// WithTimeout overrides the client's default deadline for one request.
+// The context deadline still applies if it is sooner.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) { o.timeout = d }
+}
+
 // Client implements the MCP (Model Context Protocol) client
-// for communicating with the Notion MCP server.
+// for communicating with the Notion MCP server. The initialize handshake
+// runs lazily before the first tool request.
 type Client struct {
-	serverURL  string
-	token      string
-	httpClient *http.Client
-	mu         sync.RWMutex
+	serverURL    string
+	token        string
+	httpClient   *http.Client
+	retryBackoff time.Duration
+	listTimeout  time.Duration
+	callTimeout  time.Duration
+	nextID       atomic.Int64
+	mu           sync.RWMutex
+
+	initMu          sync.Mutex
+	initialized     bool
+	protocolVersion string // negotiated during initialize
+	sessionID       string
 }
 
 // Tool represents an MCP tool definition.
@@ -39,26 +87,57 @@ type ContentBlock struct {
 	Text string `json:"text,omitempty"`
 }
 
-// NewClient creates a new MCP client.
+// NewClient creates a new MCP client with default timeouts.
 func NewClient(serverURL, token string) *Client {
+	return NewClientWithOptions(serverURL, token, ClientOptions{})
+}
+
+// NewClientWithOptions creates a new MCP client with the given options.
+// Requests are bounded by the per-call timeout and the caller's context,
+// not by an HTTP client timeout, so cancelling the context aborts the
+// in-flight request.
+func NewClientWithOptions(serverURL, token string, opts ClientOptions) *Client {
+	if opts.ListTimeout <= 0 {
+		opts.ListTimeout = defaultListTimeout
+	}
+	if opts.CallTimeout <= 0 {
+		opts.CallTimeout = defaultCallTimeout
+	}
 	return &Client{
-		serverURL: strings.TrimRight(serverURL, "/"),
-		token:     token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		serverURL:    strings.TrimRight(serverURL, "/"),
+		token:        token,
+		httpClient:   &http.Client{},
+		retryBackoff: defaultRetryBackoff,
+		listTimeout:  opts.ListTimeout,
+		callTimeout:  opts.CallTimeout,
 	}
 }
 
+// withTimeout derives the context for one request from the default timeout
+// and any per-call override.
+func withTimeout(ctx context.Context, def time.Duration, opts []CallOption) (context.Context, context.CancelFunc) {
+	o := callOptions{timeout: def}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithTimeout(ctx, o.timeout)
+}
+
+// ProtocolVersion returns the protocol version negotiated with the server,
+// or "" before the first successful initialize.
+func (c *Client) ProtocolVersion() string {
+	c.initMu.Lock()
+	defer c.initMu.Unlock()
+	return c.protocolVersion
+}
+
 // ListTools retrieves available tools from the MCP server.
-func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
-	req, err := c.newRequest(ctx, "POST", "/", map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      1,
-		"method":  "tools/list",
-	})
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+func (c *Client) ListTools(ctx context.Context, opts ...CallOption) ([]Tool, error) {
+	ctx, cancel := withTimeout(ctx, c.listTimeout, opts)
+	defer cancel()
+
+	if err := c.ensureInitialized(ctx); err != nil {
+		return nil, err
 	}
 
 	var result struct {
@@ -70,7 +149,7 @@ func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
 		} `json:"error"`
 	}
 
-	if err := c.doJSON(req, &result); err != nil {
+	if err := c.call(ctx, "tools/list", nil, &result); err != nil {
 		return nil, fmt.Errorf("listing tools: %w", err)
 	}
 
@@ -82,18 +161,12 @@ func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
 }
 
 // CallTool executes a tool on the MCP server.
-func (c *Client) CallTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*ToolCallResult, error) {
-	req, err := c.newRequest(ctx, "POST", "/", map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      2,
-		"method":  "tools/call",
-		"params": map[string]interface{}{
-			"name":      toolName,
-			"arguments": arguments,
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+func (c *Client) CallTool(ctx context.Context, toolName string, arguments map[string]interface{}, opts ...CallOption) (*ToolCallResult, error) {
+	ctx, cancel := withTimeout(ctx, c.callTimeout, opts)
+	defer cancel()
+
+	if err := c.ensureInitialized(ctx); err != nil {
+		return nil, err
 	}
 
 	var result struct {
@@ -103,7 +176,11 @@ func (c *Client) CallTool(ctx context.Context, toolName string, arguments map[st
 		} `json:"error"`
 	}
 
-	if err := c.doJSON(req, &result); err != nil {
+	params := map[string]interface{}{
+		"name":      toolName,
+		"arguments": arguments,
+	}
+	if err := c.call(ctx, "tools/call", params, &result); err != nil {
 		return nil, fmt.Errorf("calling tool %s: %w", toolName, err)
 	}
 
@@ -121,6 +198,105 @@ func (c *Client) SetToken(token string) {
 	c.token = token
 }
 
+// ensureInitialized performs the initialize handshake once per client,
+// caching the negotiated protocol version. A failed handshake is retried on
+// the next request.
+func (c *Client) ensureInitialized(ctx context.Context) error {
+	c.initMu.Lock()
+	defer c.initMu.Unlock()
+	if c.initialized {
+		return nil
+	}
+
+	var result struct {
+		Result struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	params := map[string]interface{}{
+		"protocolVersion": protocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo": map[string]interface{}{
+			"name":    "secondbrain-cortex",
+			"version": "0.1.0",
+		},
+	}
+	header, err := c.send(ctx, c.envelope("initialize", params, true), &result)
+	if err != nil {
+		return fmt.Errorf("initializing MCP session: %w", err)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("MCP initialize error: %s", result.Error.Message)
+	}
+
+	c.sessionID = header.Get(sessionHeader)
+	c.protocolVersion = result.Result.ProtocolVersion
+	if c.protocolVersion == "" {
+		c.protocolVersion = protocolVersion
+	}
+	c.initialized = true
+
+	// The server does not answer notifications, so only transport errors matter.
+	if _, err := c.send(ctx, c.envelope("notifications/initialized", nil, false), nil); err != nil {
+		return fmt.Errorf("sending initialized notification: %w", err)
+	}
+	return nil
+}
+
+// call sends a JSON-RPC request and decodes the response into result.
+func (c *Client) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	_, err := c.send(ctx, c.envelope(method, params, true), result)
+	return err
+}
+
+// envelope builds a JSON-RPC 2.0 message; notifications carry no ID.
+func (c *Client) envelope(method string, params interface{}, withID bool) map[string]interface{} {
+	msg := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+	}
+	if withID {
+		msg["id"] = c.nextID.Add(1)
+	}
+	if params != nil {
+		msg["params"] = params
+	}
+	return msg
+}
+
+// send posts body to the server, retrying network errors and 5xx responses
+// with exponential backoff until maxAttempts or the context is done. When
+// result is nil the response body is ignored.
+func (c *Client) send(ctx context.Context, body interface{}, result interface{}) (http.Header, error) {
+	backoff := c.retryBackoff
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		req, err := c.newRequest(ctx, "POST", "/", body)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		header, err := c.doJSON(req, result)
+		if err == nil {
+			return header, nil
+		}
+		lastErr = err
+		if !isRetryable(err) || attempt >= maxAttempts || ctx.Err() != nil {
+			return nil, lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w (retry aborted: %v)", lastErr, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
 func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
@@ -134,6 +310,10 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body inter
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.sessionID != "" {
+		req.Header.Set(sessionHeader, c.sessionID)
+	}
 	c.mu.RLock()
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
@@ -143,25 +323,55 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body inter
 	return req, nil
 }
 
-func (c *Client) doJSON(req *http.Request, result interface{}) error {
+// statusError is a non-success HTTP response from the server.
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.code, e.body)
+}
+
+// isRetryable reports whether err is transient: a network failure or a 5xx
+// response.
+func isRetryable(err error) bool {
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.code >= 500
+	}
+	var ne *networkError
+	return errors.As(err, &ne)
+}
+
+// networkError is a failure to get any response from the server.
+type networkError struct{ err error }
+
+func (e *networkError) Error() string { return "executing request: " + e.err.Error() }
+func (e *networkError) Unwrap() error { return e.err }
+
+func (c *Client) doJSON(req *http.Request, result interface{}) (http.Header, error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+		return nil, &networkError{err: err}
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("reading response: %w", err)
+		return nil, fmt.Errorf("reading response: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &statusError{code: resp.StatusCode, body: string(body)}
 	}
 
+	if result == nil {
+		return resp.Header, nil
+	}
 	if err := json.Unmarshal(body, result); err != nil {
-		return fmt.Errorf("unmarshaling response: %w", err)
+		return nil, fmt.Errorf("unmarshaling response: %w", err)
 	}
 
-	return nil
+	return resp.Header, nil
 }
@@ -3,11 +3,32 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
+// handleHandshake answers the initialize request and initialized
+// notification, reporting whether body was one of them.
+func handleHandshake(w http.ResponseWriter, body map[string]interface{}) bool {
+	switch body["method"] {
+	case "initialize":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      body["id"],
+			"result":  map[string]interface{}{"protocolVersion": "2024-11-05"},
+		})
+		return true
+	case "notifications/initialized":
+		w.WriteHeader(http.StatusAccepted)
+		return true
+	}
+	return false
+}
+
 func TestListTools(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -16,6 +37,9 @@ func TestListTools(t *testing.T) {
 
 		var body map[string]interface{}
 		json.NewDecoder(r.Body).Decode(&body)
+		if handleHandshake(w, body) {
+			return
+		}
 
 		if body["method"] != "tools/list" {
 			t.Errorf("expected method tools/list, got %v", body["method"])
@@ -53,6 +77,9 @@ func TestCallTool(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var body map[string]interface{}
 		json.NewDecoder(r.Body).Decode(&body)
+		if handleHandshake(w, body) {
+			return
+		}
 
 		if body["method"] != "tools/call" {
 			t.Errorf("expected method tools/call, got %v", body["method"])
@@ -93,6 +120,7 @@ func TestCallToolServerError(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
+	client.retryBackoff = time.Millisecond
 	_, err := client.CallTool(context.Background(), "bad_tool", nil)
 	if err == nil {
 		t.Fatal("expected error")
@@ -127,3 +155,209 @@ func TestAuthorizationHeader(t *testing.T) {
 		t.Errorf("expected 'Bearer my-secret-token', got %q", gotAuth)
 	}
 }
+
+func TestRetryTransientServerError(t *testing.T) {
+	var toolCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if handleHandshake(w, body) {
+			return
+		}
+
+		if toolCalls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("temporarily unavailable"))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": "ok"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	client.retryBackoff = time.Millisecond
+	result, err := client.CallTool(context.Background(), "notion_search", nil)
+	if err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if result.Content[0].Text != "ok" {
+		t.Errorf("unexpected content: %+v", result.Content)
+	}
+	if n := toolCalls.Load(); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "bad-token")
+	client.retryBackoff = time.Millisecond
+	if _, err := client.ListTools(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected a single attempt for a 4xx response, got %d", n)
+	}
+}
+
+func TestRetryBoundedByContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	client.retryBackoff = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.ListTools(ctx); err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the context to cut the backoff short, took %v", elapsed)
+	}
+}
+
+func TestInitializeBeforeToolCalls(t *testing.T) {
+	const sessionID = "session-123"
+	var initCalls atomic.Int32
+	var notified atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		switch body["method"] {
+		case "initialize":
+			initCalls.Add(1)
+			params, _ := body["params"].(map[string]interface{})
+			if params["protocolVersion"] == nil || params["clientInfo"] == nil {
+				t.Errorf("initialize is missing protocolVersion or clientInfo: %v", params)
+			}
+			w.Header().Set("Mcp-Session-Id", sessionID)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      body["id"],
+				"result":  map[string]interface{}{"protocolVersion": "2025-03-26"},
+			})
+			return
+		case "notifications/initialized":
+			if _, ok := body["id"]; ok {
+				t.Error("notification must not carry an id")
+			}
+			notified.Store(true)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		if r.Header.Get("Mcp-Session-Id") != sessionID || !notified.Load() {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]interface{}{"code": -32002, "message": "server not initialized"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"tools":   []map[string]interface{}{{"name": "notion_search"}},
+				"content": []map[string]interface{}{{"type": "text", "text": "done"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	if v := client.ProtocolVersion(); v != "" {
+		t.Errorf("expected no protocol version before first use, got %q", v)
+	}
+
+	tools, err := client.ListTools(context.Background())
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	if len(tools) != 1 {
+		t.Errorf("expected 1 tool, got %d", len(tools))
+	}
+	if _, err := client.CallTool(context.Background(), "notion_search", nil); err != nil {
+		t.Fatalf("call tool: %v", err)
+	}
+
+	if n := initCalls.Load(); n != 1 {
+		t.Errorf("expected initialize once, got %d", n)
+	}
+	if v := client.ProtocolVersion(); v != "2025-03-26" {
+		t.Errorf("expected negotiated protocol version 2025-03-26, got %q", v)
+	}
+}
+
+// newSlowToolServer answers the handshake immediately but holds tool
+// requests until the client gives up.
+func newSlowToolServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if handleHandshake(w, body) {
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestContextDeadlineCancelsSlowCall(t *testing.T) {
+	client := NewClient(newSlowToolServer(t).URL, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.CallTool(ctx, "notion_search", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the call to be aborted promptly, took %v", elapsed)
+	}
+}
+
+func TestPerCallTimeout(t *testing.T) {
+	client := NewClientWithOptions(newSlowToolServer(t).URL, "", ClientOptions{
+		ListTimeout: time.Minute,
+		CallTimeout: time.Minute,
+	})
+
+	start := time.Now()
+	_, err := client.ListTools(context.Background(), WithTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the per-call timeout to apply, took %v", elapsed)
+	}
+}
+
+func TestDefaultTimeoutsFromOptions(t *testing.T) {
+	client := NewClientWithOptions(newSlowToolServer(t).URL, "", ClientOptions{CallTimeout: 50 * time.Millisecond})
+	if client.listTimeout != defaultListTimeout {
+		t.Errorf("expected default list timeout, got %v", client.listTimeout)
+	}
+
+	if _, err := client.CallTool(context.Background(), "notion_search", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the configured call timeout to apply, got %v", err)
+	}
+}
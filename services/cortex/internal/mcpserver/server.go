@@ -1,9 +1,11 @@
 package mcpserver
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -55,19 +57,82 @@ type toolDef struct {
 	InputSchema map[string]interface{} `json:"inputSchema"`
 }
 
-// ServeHTTP handles MCP JSON-RPC requests.
+// ServeHTTP handles MCP JSON-RPC requests. The body may be a single request
+// object or a JSON-RPC batch (an array of request objects). Clients that send
+// "Accept: text/event-stream" receive the responses as Server-Sent Events
+// followed by a final done event; all other clients receive plain JSON.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, nil, -32700, "parse error")
+		return
+	}
+
+	if flusher, ok := w.(http.Flusher); ok && acceptsEventStream(r) {
+		s.serveEventStream(w, flusher, r.Context(), body)
+		return
+	}
+
+	if isBatch(body) {
+		s.serveBatch(w, r.Context(), body)
+		return
+	}
+
 	var req jsonRPCRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, nil, -32700, "parse error")
+		return
+	}
+
+	writeJSON(w, s.dispatch(r.Context(), req))
+}
+
+// serveBatch processes each request of a JSON-RPC batch in order and writes
+// the array of responses. Notifications (requests without an id) are executed
+// but produce no response; if every entry is a notification nothing is returned.
+func (s *Server) serveBatch(w http.ResponseWriter, ctx context.Context, body []byte) {
+	var rawReqs []json.RawMessage
+	if err := json.Unmarshal(body, &rawReqs); err != nil {
 		writeError(w, nil, -32700, "parse error")
 		return
 	}
+	if len(rawReqs) == 0 {
+		writeError(w, nil, -32600, "invalid request: empty batch")
+		return
+	}
+
+	responses := make([]jsonRPCResponse, 0, len(rawReqs))
+	for _, raw := range rawReqs {
+		var req jsonRPCRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			responses = append(responses, jsonRPCResponse{
+				JSONRPC: "2.0",
+				Error:   &jsonRPCError{Code: -32600, Message: "invalid request"},
+			})
+			continue
+		}
+
+		resp := s.dispatch(ctx, req)
+		if req.ID == nil {
+			continue
+		}
+		responses = append(responses, resp)
+	}
+
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, responses)
+}
 
+// dispatch routes a single JSON-RPC request to its method handler.
+func (s *Server) dispatch(ctx context.Context, req jsonRPCRequest) jsonRPCResponse {
 	var resp jsonRPCResponse
 	resp.JSONRPC = "2.0"
 	resp.ID = req.ID
@@ -78,7 +143,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case "tools/list":
 		resp.Result = s.handleToolsList()
 	case "tools/call":
-		result, err := s.handleToolsCall(r.Context(), req.Params)
+		result, err := s.handleToolsCall(ctx, req.Params)
 		if err != nil {
 			resp.Error = &jsonRPCError{Code: -32603, Message: err.Error()}
 		} else {
@@ -88,8 +153,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		resp.Error = &jsonRPCError{Code: -32601, Message: fmt.Sprintf("method not found: %s", req.Method)}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	return resp
 }
 
 func (s *Server) handleInitialize() map[string]interface{} {
@@ -116,6 +180,7 @@ func (s *Server) handleToolsList() map[string]interface{} {
 					"query":     map[string]interface{}{"type": "string", "description": "Natural language search query"},
 					"limit":     map[string]interface{}{"type": "number", "description": "Maximum results (default: 5)"},
 					"min_score": map[string]interface{}{"type": "number", "description": "Minimum relevance score 0-1"},
+					"filters":   map[string]interface{}{"type": "object", "description": "Metadata values results must match, e.g. {\"type\": \"research\"}", "additionalProperties": map[string]interface{}{"type": "string"}},
 				},
 				"required": []string{"query"},
 			},
@@ -129,6 +194,7 @@ func (s *Server) handleToolsList() map[string]interface{} {
 					"query":     map[string]interface{}{"type": "string", "description": "Keyword search query"},
 					"limit":     map[string]interface{}{"type": "number", "description": "Maximum results (default: 5)"},
 					"min_score": map[string]interface{}{"type": "number", "description": "Minimum relevance score 0-1"},
+					"filters":   map[string]interface{}{"type": "object", "description": "Metadata values results must match, e.g. {\"type\": \"research\"}", "additionalProperties": map[string]interface{}{"type": "string"}},
 				},
 				"required": []string{"query"},
 			},
@@ -142,6 +208,7 @@ func (s *Server) handleToolsList() map[string]interface{} {
 					"query":     map[string]interface{}{"type": "string", "description": "Natural language search query"},
 					"limit":     map[string]interface{}{"type": "number", "description": "Maximum results (default: 5)"},
 					"min_score": map[string]interface{}{"type": "number", "description": "Minimum relevance score 0-1"},
+					"filters":   map[string]interface{}{"type": "object", "description": "Metadata values results must match, e.g. {\"type\": \"research\"}", "additionalProperties": map[string]interface{}{"type": "string"}},
 				},
 				"required": []string{"query"},
 			},
@@ -150,8 +217,10 @@ func (s *Server) handleToolsList() map[string]interface{} {
 			Name:        "status",
 			Description: "Show index health: document counts, chunk counts, and graph triple counts.",
 			InputSchema: map[string]interface{}{
-				"type":       "object",
-				"properties": map[string]interface{}{},
+				"type": "object",
+				"properties": map[string]interface{}{
+					"collection": map[string]interface{}{"type": "string", "description": "Limit counts to one collection (default: all collections)"},
+				},
 			},
 		},
 	}
@@ -173,7 +242,7 @@ func (s *Server) handleToolsCall(ctx context.Context, params map[string]interfac
 	case "hybrid":
 		return s.toolHybridSearch(ctx, args)
 	case "status":
-		return s.toolStatus(ctx)
+		return s.toolStatus(ctx, args)
 	default:
 		return nil, fmt.Errorf("unknown tool: %s", name)
 	}
@@ -187,6 +256,10 @@ func (s *Server) toolSearch(ctx context.Context, args map[string]interface{}) (i
 
 	topK := getInt(args, "limit", 5)
 	minScore := getFloat(args, "min_score", 0)
+	filters, err := getFilters(args)
+	if err != nil {
+		return errorContent(err.Error()), nil
+	}
 
 	if s.memoryClient == nil {
 		return errorContent("memory service not connected"), nil
@@ -196,12 +269,13 @@ func (s *Server) toolSearch(ctx context.Context, args map[string]interface{}) (i
 		Query:    query,
 		TopK:     int32(topK),
 		MinScore: float32(minScore),
+		Filters:  filters,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("semantic search: %w", err)
 	}
 
-	return formatSearchResults(resp.GetResults(), query), nil
+	return formatSearchResults(resp, query), nil
 }
 
 func (s *Server) toolFullTextSearch(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -212,6 +286,10 @@ func (s *Server) toolFullTextSearch(ctx context.Context, args map[string]interfa
 
 	topK := getInt(args, "limit", 5)
 	minScore := getFloat(args, "min_score", 0)
+	filters, err := getFilters(args)
+	if err != nil {
+		return errorContent(err.Error()), nil
+	}
 
 	if s.memoryClient == nil {
 		return errorContent("memory service not connected"), nil
@@ -221,12 +299,13 @@ func (s *Server) toolFullTextSearch(ctx context.Context, args map[string]interfa
 		Query:    query,
 		TopK:     int32(topK),
 		MinScore: float32(minScore),
+		Filters:  filters,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("full-text search: %w", err)
 	}
 
-	return formatSearchResults(resp.GetResults(), query), nil
+	return formatSearchResults(resp, query), nil
 }
 
 func (s *Server) toolHybridSearch(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -237,6 +316,10 @@ func (s *Server) toolHybridSearch(ctx context.Context, args map[string]interface
 
 	topK := getInt(args, "limit", 5)
 	minScore := getFloat(args, "min_score", 0)
+	filters, err := getFilters(args)
+	if err != nil {
+		return errorContent(err.Error()), nil
+	}
 
 	if s.memoryClient == nil {
 		return errorContent("memory service not connected"), nil
@@ -246,26 +329,33 @@ func (s *Server) toolHybridSearch(ctx context.Context, args map[string]interface
 		Query:    query,
 		TopK:     int32(topK),
 		MinScore: float32(minScore),
+		Filters:  filters,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("hybrid search: %w", err)
 	}
 
-	return formatSearchResults(resp.GetResults(), query), nil
+	return formatSearchResults(resp, query), nil
 }
 
-func (s *Server) toolStatus(ctx context.Context) (interface{}, error) {
+func (s *Server) toolStatus(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	if s.memoryClient == nil {
 		return errorContent("memory service not connected"), nil
 	}
 
-	resp, err := s.memoryClient.GetStats(ctx, &memoryv1.StatsRequest{})
+	collection, _ := args["collection"].(string)
+	resp, err := s.memoryClient.GetStats(ctx, &memoryv1.StatsRequest{Collection: collection})
 	if err != nil {
 		return nil, fmt.Errorf("get stats: %w", err)
 	}
 
+	header := "Second Brain Index Status:"
+	if collection != "" {
+		header = fmt.Sprintf("Second Brain Index Status (collection %q):", collection)
+	}
 	text := fmt.Sprintf(
-		"Second Brain Index Status:\n  Documents: %d\n  Chunks: %d\n  Graph Triples: %d",
+		"%s\n  Documents: %d\n  Chunks: %d\n  Graph Triples: %d",
+		header,
 		resp.GetTotalDocuments(),
 		resp.GetTotalChunks(),
 		resp.GetTotalGraphTriples(),
@@ -273,6 +363,17 @@ func (s *Server) toolStatus(ctx context.Context) (interface{}, error) {
 	if resp.GetLastIndexedAt() != nil {
 		text += fmt.Sprintf("\n  Last Indexed: %s", resp.GetLastIndexedAt().AsTime().Format("2006-01-02 15:04:05"))
 	}
+	if resp.GetEmbeddingModel() != "" {
+		text += fmt.Sprintf("\n  Embedding: %s (%d dimensions)", resp.GetEmbeddingModel(), resp.GetEmbeddingDimension())
+	}
+
+	if collection == "" && len(resp.GetCollections()) > 0 {
+		text += "\n\nCollections:"
+		for _, c := range resp.GetCollections() {
+			text += fmt.Sprintf("\n  %s: %d documents, %d chunks, %d triples",
+				c.GetName(), c.GetDocuments(), c.GetChunks(), c.GetGraphTriples())
+		}
+	}
 
 	return map[string]interface{}{
 		"content": []map[string]interface{}{
@@ -283,7 +384,8 @@ func (s *Server) toolStatus(ctx context.Context) (interface{}, error) {
 
 // --- helpers ---
 
-func formatSearchResults(results []*memoryv1.SearchResult, query string) map[string]interface{} {
+func formatSearchResults(resp *memoryv1.SearchResponse, query string) map[string]interface{} {
+	results := resp.GetResults()
 	if len(results) == 0 {
 		return map[string]interface{}{
 			"content": []map[string]interface{}{
@@ -293,6 +395,9 @@ func formatSearchResults(results []*memoryv1.SearchResult, query string) map[str
 	}
 
 	text := fmt.Sprintf("Found %d result(s) for %q:\n\n", len(results), query)
+	if resp.GetTruncated() {
+		text = fmt.Sprintf("Showing %d of %d result(s) for %q:\n\n", len(results), resp.GetTotalMatches(), query)
+	}
 	for _, r := range results {
 		text += fmt.Sprintf("  [%.0f%%] %s\n", r.GetScore()*100, r.GetDocumentId())
 		content := r.GetContent()
@@ -319,13 +424,22 @@ func errorContent(msg string) map[string]interface{} {
 }
 
 func writeError(w http.ResponseWriter, id interface{}, code int, message string) {
-	resp := jsonRPCResponse{
+	writeJSON(w, jsonRPCResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Error:   &jsonRPCError{Code: code, Message: message},
-	}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	json.NewEncoder(w).Encode(v)
+}
+
+// isBatch reports whether the request body is a JSON array.
+func isBatch(body []byte) bool {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
 }
 
 func getInt(args map[string]interface{}, key string, defaultVal int) int {
@@ -340,6 +454,28 @@ func getInt(args map[string]interface{}, key string, defaultVal int) int {
 	return defaultVal
 }
 
+// getFilters returns the "filters" argument, which must be an object whose
+// values are all strings.
+func getFilters(args map[string]interface{}) (map[string]string, error) {
+	v, ok := args["filters"]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("filters must be an object of string values")
+	}
+	filters := make(map[string]string, len(obj))
+	for k, val := range obj {
+		str, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("filter %q must be a string", k)
+		}
+		filters[k] = str
+	}
+	return filters, nil
+}
+
 func getFloat(args map[string]interface{}, key string, defaultVal float32) float32 {
 	if v, ok := args[key]; ok {
 		if n, ok := v.(float64); ok {
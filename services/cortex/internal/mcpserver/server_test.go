@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"log/slog"
@@ -19,13 +20,16 @@ import (
 // mockMemoryClient implements memoryv1.MemoryServiceClient for testing.
 type mockMemoryClient struct {
 	memoryv1.MemoryServiceClient
-	searchResults   *memoryv1.SearchResponse
-	ftsResults      *memoryv1.SearchResponse
-	hybridResults   *memoryv1.SearchResponse
-	statsResp       *memoryv1.StatsResponse
+	searchResults *memoryv1.SearchResponse
+	ftsResults    *memoryv1.SearchResponse
+	hybridResults *memoryv1.SearchResponse
+	statsResp     *memoryv1.StatsResponse
+	lastStatsReq  *memoryv1.StatsRequest
+	lastSearchReq *memoryv1.SearchRequest
 }
 
 func (m *mockMemoryClient) SemanticSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	m.lastSearchReq = in
 	if m.searchResults != nil {
 		return m.searchResults, nil
 	}
@@ -33,6 +37,7 @@ func (m *mockMemoryClient) SemanticSearch(ctx context.Context, in *memoryv1.Sear
 }
 
 func (m *mockMemoryClient) FullTextSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	m.lastSearchReq = in
 	if m.ftsResults != nil {
 		return m.ftsResults, nil
 	}
@@ -40,6 +45,7 @@ func (m *mockMemoryClient) FullTextSearch(ctx context.Context, in *memoryv1.Sear
 }
 
 func (m *mockMemoryClient) HybridSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	m.lastSearchReq = in
 	if m.hybridResults != nil {
 		return m.hybridResults, nil
 	}
@@ -47,6 +53,7 @@ func (m *mockMemoryClient) HybridSearch(ctx context.Context, in *memoryv1.Search
 }
 
 func (m *mockMemoryClient) GetStats(ctx context.Context, in *memoryv1.StatsRequest, opts ...grpc.CallOption) (*memoryv1.StatsResponse, error) {
+	m.lastStatsReq = in
 	if m.statsResp != nil {
 		return m.statsResp, nil
 	}
@@ -72,10 +79,16 @@ func newTestServer() *Server {
 			},
 		},
 		statsResp: &memoryv1.StatsResponse{
-			TotalDocuments:    10,
-			TotalChunks:       42,
-			TotalGraphTriples: 5,
-			LastIndexedAt:     timestamppb.Now(),
+			TotalDocuments:     10,
+			TotalChunks:        42,
+			TotalGraphTriples:  5,
+			LastIndexedAt:      timestamppb.Now(),
+			EmbeddingDimension: 384,
+			EmbeddingModel:     "mock",
+			Collections: []*memoryv1.CollectionStats{
+				{Name: "notes", Documents: 4, Chunks: 12, GraphTriples: 2},
+				{Name: "second_brain", Documents: 6, Chunks: 30, GraphTriples: 3},
+			},
 		},
 	}
 	return NewServer(logger, mock)
@@ -192,6 +205,57 @@ func TestToolHybrid(t *testing.T) {
 	}
 }
 
+func TestSearchToolsForwardFilters(t *testing.T) {
+	for _, tool := range []string{"search", "fts", "hybrid"} {
+		t.Run(tool, func(t *testing.T) {
+			srv := newTestServer()
+			resp := doRPC(t, srv, "tools/call", map[string]interface{}{
+				"name": tool,
+				"arguments": map[string]interface{}{
+					"query":   "seismic",
+					"filters": map[string]interface{}{"type": "research", "source": "notion"},
+				},
+			})
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error.Message)
+			}
+
+			filters := srv.memoryClient.(*mockMemoryClient).lastSearchReq.GetFilters()
+			if len(filters) != 2 || filters["type"] != "research" || filters["source"] != "notion" {
+				t.Errorf("expected filters forwarded, got %v", filters)
+			}
+		})
+	}
+}
+
+func TestSearchInvalidFilters(t *testing.T) {
+	tests := map[string]interface{}{
+		"not an object":    "type=research",
+		"nested value":     map[string]interface{}{"type": map[string]interface{}{"in": "research"}},
+		"non-string value": map[string]interface{}{"priority": 1.0},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := newTestServer()
+			resp := doRPC(t, srv, "tools/call", map[string]interface{}{
+				"name":      "hybrid",
+				"arguments": map[string]interface{}{"query": "seismic", "filters": filters},
+			})
+			if resp.Error != nil {
+				t.Fatalf("unexpected JSON-RPC error: %s", resp.Error.Message)
+			}
+
+			result := resp.Result.(map[string]interface{})
+			if isErr, _ := result["isError"].(bool); !isErr {
+				t.Error("expected isError=true for invalid filters")
+			}
+			if srv.memoryClient.(*mockMemoryClient).lastSearchReq != nil {
+				t.Error("expected no search with invalid filters")
+			}
+		})
+	}
+}
+
 func TestToolStatus(t *testing.T) {
 	srv := newTestServer()
 	resp := doRPC(t, srv, "tools/call", map[string]interface{}{
@@ -215,6 +279,57 @@ func TestToolStatus(t *testing.T) {
 	}
 }
 
+// statusText calls the status tool and returns the text of its first content item.
+func statusText(t *testing.T, srv *Server, args map[string]interface{}) string {
+	t.Helper()
+	resp := doRPC(t, srv, "tools/call", map[string]interface{}{
+		"name":      "status",
+		"arguments": args,
+	})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error.Message)
+	}
+	result := resp.Result.(map[string]interface{})
+	content := result["content"].([]interface{})
+	return content[0].(map[string]interface{})["text"].(string)
+}
+
+func TestToolStatusListsCollections(t *testing.T) {
+	srv := newTestServer()
+	text := statusText(t, srv, nil)
+
+	for _, want := range []string{"notes: 4 documents, 12 chunks, 2 triples", "second_brain: 6 documents, 30 chunks, 3 triples"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected status to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestToolStatusShowsEmbedding(t *testing.T) {
+	srv := newTestServer()
+	text := statusText(t, srv, nil)
+
+	if !strings.Contains(text, "Embedding: mock (384 dimensions)") {
+		t.Errorf("expected status to show the embedder, got:\n%s", text)
+	}
+}
+
+func TestToolStatusCollectionFilter(t *testing.T) {
+	srv := newTestServer()
+	text := statusText(t, srv, map[string]interface{}{"collection": "notes"})
+
+	mock := srv.memoryClient.(*mockMemoryClient)
+	if mock.lastStatsReq.GetCollection() != "notes" {
+		t.Errorf("expected collection filter forwarded, got %q", mock.lastStatsReq.GetCollection())
+	}
+	if !strings.Contains(text, `collection "notes"`) {
+		t.Errorf("expected scoped header, got:\n%s", text)
+	}
+	if strings.Contains(text, "Collections:") {
+		t.Errorf("expected no per-collection breakdown when filtered, got:\n%s", text)
+	}
+}
+
 func TestUnknownTool(t *testing.T) {
 	srv := newTestServer()
 	resp := doRPC(t, srv, "tools/call", map[string]interface{}{
@@ -266,3 +381,172 @@ func TestGetOnly(t *testing.T) {
 		t.Errorf("expected 405, got %d", w.Code)
 	}
 }
+
+func TestBatchRequest(t *testing.T) {
+	srv := newTestServer()
+	body := `[
+		{"jsonrpc":"2.0","id":1,"method":"initialize"},
+		{"jsonrpc":"2.0","method":"notifications/initialized"},
+		{"jsonrpc":"2.0","id":2,"method":"tools/list"}
+	]`
+	req := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	var resps []jsonRPCResponse
+	if err := json.NewDecoder(w.Body).Decode(&resps); err != nil {
+		t.Fatalf("decode batch response: %v", err)
+	}
+	if len(resps) != 2 {
+		t.Fatalf("expected 2 responses (notification omitted), got %d", len(resps))
+	}
+
+	if id, _ := resps[0].ID.(float64); id != 1 {
+		t.Errorf("expected first response id 1, got %v", resps[0].ID)
+	}
+	result, ok := resps[0].Result.(map[string]interface{})
+	if !ok || result["serverInfo"] == nil {
+		t.Errorf("expected initialize result first, got %v", resps[0].Result)
+	}
+
+	if id, _ := resps[1].ID.(float64); id != 2 {
+		t.Errorf("expected second response id 2, got %v", resps[1].ID)
+	}
+	result, ok = resps[1].Result.(map[string]interface{})
+	if !ok || result["tools"] == nil {
+		t.Errorf("expected tools/list result second, got %v", resps[1].Result)
+	}
+}
+
+func TestEmptyBatch(t *testing.T) {
+	srv := newTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewReader([]byte(`[]`)))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	var resp jsonRPCResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != -32600 {
+		t.Errorf("expected invalid request error, got %+v", resp.Error)
+	}
+}
+
+// parseSSE splits a text/event-stream body into (event, data) pairs.
+func parseSSE(t *testing.T, body string) [][2]string {
+	t.Helper()
+	var events [][2]string
+	for _, block := range strings.Split(strings.TrimSpace(body), "\n\n") {
+		var event, data string
+		for _, line := range strings.Split(block, "\n") {
+			if v, ok := strings.CutPrefix(line, "event: "); ok {
+				event = v
+			}
+			if v, ok := strings.CutPrefix(line, "data: "); ok {
+				data = v
+			}
+		}
+		events = append(events, [2]string{event, data})
+	}
+	return events
+}
+
+func TestToolCallSSE(t *testing.T) {
+	srv := newTestServer()
+	body := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"search","arguments":{"query":"seismic"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+
+	events := parseSSE(t, w.Body.String())
+	if len(events) != 2 {
+		t.Fatalf("expected message + done events, got %d: %v", len(events), events)
+	}
+	if events[0][0] != "message" {
+		t.Errorf("expected first event 'message', got %q", events[0][0])
+	}
+	if events[1][0] != "done" {
+		t.Errorf("expected final event 'done', got %q", events[1][0])
+	}
+
+	var resp jsonRPCResponse
+	if err := json.Unmarshal([]byte(events[0][1]), &resp); err != nil {
+		t.Fatalf("decode message event: %v", err)
+	}
+	if id, _ := resp.ID.(float64); id != 7 {
+		t.Errorf("expected id 7, got %v", resp.ID)
+	}
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error.Message)
+	}
+	if !strings.Contains(events[0][1], "doc-1") {
+		t.Errorf("expected search result in streamed tool result, got %s", events[0][1])
+	}
+}
+
+func TestBatchSSE(t *testing.T) {
+	srv := newTestServer()
+	body := `[{"jsonrpc":"2.0","id":1,"method":"initialize"},{"jsonrpc":"2.0","id":2,"method":"tools/list"}]`
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Accept", "text/event-stream")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	events := parseSSE(t, w.Body.String())
+	if len(events) != 3 {
+		t.Fatalf("expected 2 message events + done, got %d", len(events))
+	}
+	if events[0][0] != "message" || events[1][0] != "message" || events[2][0] != "done" {
+		t.Errorf("unexpected event sequence: %v", events)
+	}
+}
+
+func TestPlainJSONWithoutEventStreamAccept(t *testing.T) {
+	srv := newTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/mcp",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize"}`))
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected application/json, got %q", ct)
+	}
+}
+
+// flushRecorder records the body written before each Flush.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed []string
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushed = append(f.flushed, f.Body.String())
+	f.ResponseRecorder.Flush()
+}
+
+func TestBatchSSEFlushesEachResponse(t *testing.T) {
+	srv := newTestServer()
+	body := `[{"jsonrpc":"2.0","id":1,"method":"initialize"},{"jsonrpc":"2.0","method":"notifications/initialized"},{"jsonrpc":"2.0","id":2,"method":"tools/list"}]`
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Accept", "text/event-stream")
+	w := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	srv.ServeHTTP(w, req)
+
+	if len(w.flushed) != 3 {
+		t.Fatalf("expected a flush per response plus done, got %d", len(w.flushed))
+	}
+	for i, want := range []int{1, 2, 3} {
+		if got := len(parseSSE(t, w.flushed[i])); got != want {
+			t.Errorf("flush %d: expected %d events written, got %d", i, want, got)
+		}
+	}
+}
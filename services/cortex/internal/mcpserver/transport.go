@@ -0,0 +1,83 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// serveEventStream answers a request over Server-Sent Events (the MCP
+// streamable HTTP transport). Each response is written and flushed as soon
+// as it is produced, so a client sees the results of a batch one by one
+// rather than all at once, and a final done event ends the stream.
+func (s *Server) serveEventStream(w http.ResponseWriter, flusher http.Flusher, ctx context.Context, body []byte) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	send := func(event string, data interface{}) {
+		jsonBytes, err := json.Marshal(data)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, jsonBytes)
+		flusher.Flush()
+	}
+	defer send("done", struct{}{})
+
+	if !isBatch(body) {
+		var req jsonRPCRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			send("message", errorResponse(nil, -32700, "parse error"))
+			return
+		}
+		send("message", s.dispatch(ctx, req))
+		return
+	}
+
+	// Batch entries are handled as in serveBatch.
+	var rawReqs []json.RawMessage
+	if err := json.Unmarshal(body, &rawReqs); err != nil {
+		send("message", errorResponse(nil, -32700, "parse error"))
+		return
+	}
+	if len(rawReqs) == 0 {
+		send("message", errorResponse(nil, -32600, "invalid request: empty batch"))
+		return
+	}
+	for _, raw := range rawReqs {
+		var req jsonRPCRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			send("message", errorResponse(nil, -32600, "invalid request"))
+			continue
+		}
+		resp := s.dispatch(ctx, req)
+		if req.ID != nil {
+			send("message", resp)
+		}
+	}
+}
+
+func errorResponse(id interface{}, code int, message string) jsonRPCResponse {
+	return jsonRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &jsonRPCError{Code: code, Message: message},
+	}
+}
+
+// acceptsEventStream reports whether the client listed text/event-stream in
+// its Accept header.
+func acceptsEventStream(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaType := range strings.Split(accept, ",") {
+			mediaType, _, _ = strings.Cut(mediaType, ";")
+			if strings.TrimSpace(mediaType) == "text/event-stream" {
+				return true
+			}
+		}
+	}
+	return false
+}
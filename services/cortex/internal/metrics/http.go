@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RegisterRoutes exposes the store over HTTP:
+//
+//	GET /v1/metrics              summary over all history
+//	GET /v1/metrics?window=24h   summary over records from the last window
+//	GET /v1/metrics?trend=50     quality trend over the last 50 interactions
+//	POST /v1/metrics/reset       clear all metrics, returning the empty summary
+//	GET /metrics                 summary and RPC latency in Prometheus text format
+func (s *Store) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/metrics", s.handleSummary)
+	mux.HandleFunc("POST /v1/metrics/reset", s.handleReset)
+	mux.HandleFunc("GET /metrics", s.handlePrometheus)
+}
+
+func (s *Store) handleSummary(w http.ResponseWriter, r *http.Request) {
+	summary := s.Summary()
+	var since time.Time
+	if window := r.URL.Query().Get("window"); window != "" {
+		d, err := time.ParseDuration(window)
+		if err != nil || d <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "invalid window: must be a positive duration like 24h")
+			return
+		}
+		since = time.Now().Add(-d)
+		summary = s.SummaryWindow(since)
+	}
+	if trend := r.URL.Query().Get("trend"); trend != "" {
+		n, err := strconv.Atoi(trend)
+		if err != nil || n <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "invalid trend: must be a positive number of interactions")
+			return
+		}
+		s.SetQualityTrend(&summary, since, n)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary) //nolint:errcheck
+}
+
+func (s *Store) handleReset(w http.ResponseWriter, r *http.Request) {
+	s.Reset()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.Summary()) //nolint:errcheck
+}
+
+func (s *Store) handlePrometheus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", prometheusContentType)
+	WritePrometheus(w, s.Summary()) //nolint:errcheck
+	s.latency.WritePrometheus(w)    //nolint:errcheck
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message}) //nolint:errcheck
+}
@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMetricsEndpointWindow(t *testing.T) {
+	s := NewStore()
+	s.Record(InteractionRecord{Timestamp: time.Now().Add(-48 * time.Hour), ResponseQuality: 0.2})
+	s.Record(InteractionRecord{Timestamp: time.Now(), ResponseQuality: 0.6})
+
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+
+	tests := []struct {
+		query     string
+		wantCode  int
+		wantTotal int
+	}{
+		{"", http.StatusOK, 2},
+		{"?window=24h", http.StatusOK, 1},
+		{"?window=bogus", http.StatusBadRequest, 0},
+		{"?window=-1h", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/v1/metrics"+tt.query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		if w.Code != tt.wantCode {
+			t.Errorf("%q: expected %d, got %d", tt.query, tt.wantCode, w.Code)
+			continue
+		}
+		if tt.wantCode != http.StatusOK {
+			continue
+		}
+		var summary MetricsSummary
+		if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+			t.Fatalf("%q: decoding: %v", tt.query, err)
+		}
+		if summary.TotalInteractions != tt.wantTotal {
+			t.Errorf("%q: expected %d interactions, got %d", tt.query, tt.wantTotal, summary.TotalInteractions)
+		}
+	}
+}
+
+func TestMetricsEndpointQualityTrend(t *testing.T) {
+	s := NewStore()
+	for _, q := range []float64{0.2, 0.4, 0.6, 0.8, 1.0} {
+		s.Record(InteractionRecord{Timestamp: time.Now(), ResponseQuality: q})
+	}
+
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+
+	tests := []struct {
+		query       string
+		wantCode    int
+		wantRecent  float64
+		wantOverall float64
+	}{
+		{"", http.StatusOK, 0.6, 0}, // default window covers every record
+		{"?trend=2", http.StatusOK, 0.9, 0.3},
+		{"?trend=2&window=24h", http.StatusOK, 0.9, 0.3},
+		{"?trend=0", http.StatusBadRequest, 0, 0},
+		{"?trend=many", http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/v1/metrics"+tt.query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		if w.Code != tt.wantCode {
+			t.Errorf("%q: expected %d, got %d", tt.query, tt.wantCode, w.Code)
+			continue
+		}
+		if tt.wantCode != http.StatusOK {
+			continue
+		}
+		var summary map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+			t.Fatalf("%q: decoding: %v", tt.query, err)
+		}
+		recent, ok := summary["recent_quality_trend"].(float64)
+		if !ok || recent == 0 || math.Abs(recent-tt.wantRecent) > 0.001 {
+			t.Errorf("%q: expected recent_quality_trend ~%v, got %v", tt.query, tt.wantRecent, summary["recent_quality_trend"])
+		}
+		overall, ok := summary["overall_quality_trend"].(float64)
+		if !ok || math.Abs(overall-tt.wantOverall) > 0.001 {
+			t.Errorf("%q: expected overall_quality_trend ~%v, got %v", tt.query, tt.wantOverall, summary["overall_quality_trend"])
+		}
+	}
+}
+
+func TestMetricsResetEndpoint(t *testing.T) {
+	s := NewStore()
+	for i := 0; i < 5; i++ {
+		s.Record(InteractionRecord{Timestamp: time.Now(), ResponseQuality: 0.5, Feedback: FeedbackPositive,
+			TopicDistribution: map[string]float64{"go": 1}})
+	}
+	s.Latency().Observe("/svc/Method", time.Millisecond, false)
+
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/metrics/reset", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var summary MetricsSummary
+	if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if summary.TotalInteractions != 0 || len(summary.FeedbackCounts) != 0 || len(summary.TopicCoverage) != 0 {
+		t.Errorf("expected cleared summary, got %+v", summary)
+	}
+	if len(s.Latency().Snapshot()) != 0 {
+		t.Error("expected latency statistics to be cleared")
+	}
+}
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are histogram upper bounds in seconds, spanning fast unary
+// lookups up to long-running reasoning streams.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// LatencyStore tracks per-method call counts, errors, and latency histograms
+// for RPCs, recorded by the gRPC metrics interceptors.
+type LatencyStore struct {
+	mu      sync.Mutex
+	methods map[string]*methodLatency
+}
+
+type methodLatency struct {
+	count   uint64
+	errors  uint64
+	sum     float64  // seconds
+	buckets []uint64 // non-cumulative counts per latencyBuckets bound
+}
+
+// MethodLatency is a point-in-time view of one method's statistics.
+type MethodLatency struct {
+	Method       string          `json:"method"`
+	Count        uint64          `json:"count"`
+	Errors       uint64          `json:"errors"`
+	TotalSeconds float64         `json:"total_seconds"`
+	Buckets      []LatencyBucket `json:"buckets"`
+}
+
+// LatencyBucket holds the cumulative number of calls at or under UpperBound.
+type LatencyBucket struct {
+	UpperBound float64 `json:"le"`
+	Count      uint64  `json:"count"`
+}
+
+// ErrorRate returns the fraction of calls that failed.
+func (m MethodLatency) ErrorRate() float64 {
+	if m.Count == 0 {
+		return 0
+	}
+	return float64(m.Errors) / float64(m.Count)
+}
+
+// NewLatencyStore creates an empty latency store.
+func NewLatencyStore() *LatencyStore {
+	return &LatencyStore{methods: make(map[string]*methodLatency)}
+}
+
+// Observe records one call to method that took d and failed if failed is set.
+func (l *LatencyStore) Observe(method string, d time.Duration, failed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m, ok := l.methods[method]
+	if !ok {
+		m = &methodLatency{buckets: make([]uint64, len(latencyBuckets))}
+		l.methods[method] = m
+	}
+
+	m.count++
+	if failed {
+		m.errors++
+	}
+	seconds := d.Seconds()
+	m.sum += seconds
+	if i := sort.SearchFloat64s(latencyBuckets, seconds); i < len(latencyBuckets) {
+		m.buckets[i]++
+	}
+}
+
+// Reset discards all recorded statistics.
+func (l *LatencyStore) Reset() {
+	l.mu.Lock()
+	l.methods = make(map[string]*methodLatency)
+	l.mu.Unlock()
+}
+
+// Snapshot returns statistics for every observed method, sorted by name.
+func (l *LatencyStore) Snapshot() []MethodLatency {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make([]MethodLatency, 0, len(l.methods))
+	for name, m := range l.methods {
+		ml := MethodLatency{
+			Method:       name,
+			Count:        m.count,
+			Errors:       m.errors,
+			TotalSeconds: m.sum,
+			Buckets:      make([]LatencyBucket, len(latencyBuckets)),
+		}
+		var cumulative uint64
+		for i, bound := range latencyBuckets {
+			cumulative += m.buckets[i]
+			ml.Buckets[i] = LatencyBucket{UpperBound: bound, Count: cumulative}
+		}
+		out = append(out, ml)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Method < out[j].Method })
+	return out
+}
+
+// WritePrometheus renders the latency histograms and error counters in the
+// Prometheus text exposition format.
+func (l *LatencyStore) WritePrometheus(w io.Writer) error {
+	snapshot := l.Snapshot()
+	var b strings.Builder
+
+	const hist = "secondbrain_grpc_request_duration_seconds"
+	fmt.Fprintf(&b, "# HELP %s Latency of gRPC calls, by method.\n# TYPE %s histogram\n", hist, hist)
+	for _, m := range snapshot {
+		method := escapeLabelValue(m.Method)
+		for _, bucket := range m.Buckets {
+			fmt.Fprintf(&b, "%s_bucket{method=\"%s\",le=\"%g\"} %d\n", hist, method, bucket.UpperBound, bucket.Count)
+		}
+		fmt.Fprintf(&b, "%s_bucket{method=\"%s\",le=\"+Inf\"} %d\n", hist, method, m.Count)
+		fmt.Fprintf(&b, "%s_sum{method=\"%s\"} %g\n", hist, method, m.TotalSeconds)
+		fmt.Fprintf(&b, "%s_count{method=\"%s\"} %d\n", hist, method, m.Count)
+	}
+
+	const errs = "secondbrain_grpc_errors_total"
+	fmt.Fprintf(&b, "# HELP %s Failed gRPC calls, by method.\n# TYPE %s counter\n", errs, errs)
+	for _, m := range snapshot {
+		fmt.Fprintf(&b, "%s{method=\"%s\"} %d\n", errs, escapeLabelValue(m.Method), m.Errors)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import "log/slog"
+
+// LoopWarningPolicy decides when low knowledge coverage is reported as a
+// possible degenerate feedback loop: the system answering an ever narrower
+// set of topics because that is what it has been asked about.
+type LoopWarningPolicy struct {
+	MinCoverage     float64 // warn when coverage falls below this; 0 disables the warning
+	MinInteractions int     // interactions needed before coverage is trusted enough to warn
+}
+
+// SetLoopWarningPolicy sets when summaries raise DegenerateLoopWarning. The
+// warning is also logged once each time it starts to apply to the recorded
+// interactions.
+func (s *Store) SetLoopWarningPolicy(policy LoopWarningPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loopPolicy = policy
+	s.loopWarned = policy.degenerate(s.totalInteractions, s.topicCounts)
+}
+
+// degenerate reports whether the given interaction count and topic counts
+// look like a degenerate feedback loop. Interactions without any topic say
+// nothing about coverage, so they never trip the warning on their own.
+func (p LoopWarningPolicy) degenerate(interactions int, topicCounts map[string]int) bool {
+	if p.MinCoverage <= 0 || len(topicCounts) == 0 || interactions < p.MinInteractions {
+		return false
+	}
+	return normalizedEntropy(topicCounts) < p.MinCoverage
+}
+
+// checkLoopLocked logs a warning when the recorded interactions start to look
+// like a degenerate feedback loop. Callers must hold s.mu for writing.
+func (s *Store) checkLoopLocked() {
+	warn := s.loopPolicy.degenerate(s.totalInteractions, s.topicCounts)
+	if warn && !s.loopWarned {
+		slog.Warn("knowledge coverage is low, possible degenerate feedback loop",
+			"coverage", normalizedEntropy(s.topicCounts),
+			"threshold", s.loopPolicy.MinCoverage,
+			"interactions", s.totalInteractions,
+			"topics", len(s.topicCounts))
+	}
+	s.loopWarned = warn
+}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/atomicfile"
+)
+
+// persistedStore is the on-disk representation of a Store.
+type persistedStore struct {
+	Records           []InteractionRecord  `json:"records"`
+	TopicCounts       map[string]int       `json:"topic_counts"`
+	FeedbackCounts    map[FeedbackType]int `json:"feedback_counts"`
+	TotalInteractions int                  `json:"total_interactions"`
+}
+
+// Save writes the store's records and aggregate counters to path as JSON.
+// The file is replaced atomically, and Record may be called concurrently.
+func (s *Store) Save(path string) error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	data, err := json.Marshal(s.snapshot())
+	if err != nil {
+		return fmt.Errorf("encoding metrics: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(path, data); err != nil {
+		return fmt.Errorf("saving metrics: %w", err)
+	}
+	return nil
+}
+
+// Load replaces the store's contents with those saved at path. A missing
+// file is not an error; the store is left unchanged.
+func (s *Store) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading metrics store: %w", err)
+	}
+
+	var stored persistedStore
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("decoding metrics store: %w", err)
+	}
+	if stored.Records == nil {
+		stored.Records = make([]InteractionRecord, 0)
+	}
+	if stored.TopicCounts == nil {
+		stored.TopicCounts = make(map[string]int)
+	}
+	if stored.FeedbackCounts == nil {
+		stored.FeedbackCounts = make(map[FeedbackType]int)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = stored.Records
+	s.topicCounts = stored.TopicCounts
+	s.feedbackCounts = stored.FeedbackCounts
+	s.totalInteractions = stored.TotalInteractions
+	return nil
+}
+
+// StartAutoSave saves the store to path every interval until the returned
+// function is called. The function waits for a save in progress to finish.
+// It does nothing when interval is not positive.
+func (s *Store) StartAutoSave(path string, interval time.Duration) (stop func()) {
+	if path == "" || interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Save(path); err != nil {
+					slog.Warn("failed to auto-save metrics", "path", path, "error", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-exited
+	}
+}
+
+// snapshot copies the store's state into its persisted form.
+func (s *Store) snapshot() persistedStore {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := persistedStore{
+		Records:           make([]InteractionRecord, len(s.records)),
+		TopicCounts:       make(map[string]int, len(s.topicCounts)),
+		FeedbackCounts:    make(map[FeedbackType]int, len(s.feedbackCounts)),
+		TotalInteractions: s.totalInteractions,
+	}
+	copy(out.Records, s.records)
+	for k, v := range s.topicCounts {
+		out.TopicCounts[k] = v
+	}
+	for k, v := range s.feedbackCounts {
+		out.FeedbackCounts[k] = v
+	}
+	return out
+}
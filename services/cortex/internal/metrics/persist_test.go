@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+
+	s := NewStore()
+	s.Record(InteractionRecord{
+		InteractionID:     "i-1",
+		SessionID:         "sess-1",
+		Timestamp:         time.Now().Add(-time.Hour),
+		Query:             "what is go",
+		ResponseQuality:   0.7,
+		ContextRelevance:  0.6,
+		TopicDistribution: map[string]float64{"go": 1},
+	})
+	s.Record(InteractionRecord{
+		SessionID:         "sess-2",
+		Timestamp:         time.Now(),
+		ResponseQuality:   0.9,
+		ContextRelevance:  0.8,
+		TopicDistribution: map[string]float64{"rust": 1},
+	})
+	s.RecordFeedbackFor("i-1", FeedbackPositive)
+
+	if err := s.Save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded := NewStore()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if got, want := loaded.Summary(), s.Summary(); !reflect.DeepEqual(got, want) {
+		t.Errorf("summary mismatch after reload:\n got  %+v\n want %+v", got, want)
+	}
+	if got, want := loaded.RecentQualityTrend(10), s.RecentQualityTrend(10); got != want {
+		t.Errorf("quality trend mismatch: got %f, want %f", got, want)
+	}
+
+	// Reloaded records still accept updates by interaction ID.
+	if !loaded.UpdateResponseQuality("i-1", 0.1) {
+		t.Error("expected reloaded record to be addressable by interaction ID")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	s := NewStore()
+	if err := s.Load(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Fatalf("expected no error for missing file, got %v", err)
+	}
+	if s.Summary().TotalInteractions != 0 {
+		t.Error("expected empty store")
+	}
+}
+
+func TestSaveConcurrentWithRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	s := NewStore()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.Record(InteractionRecord{Timestamp: time.Now(), ResponseQuality: 0.5})
+		}()
+		go func() {
+			defer wg.Done()
+			if err := s.Save(path); err != nil {
+				t.Errorf("save: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := s.Save(path); err != nil {
+		t.Fatalf("final save: %v", err)
+	}
+	loaded := NewStore()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got := loaded.Summary().TotalInteractions; got != 10 {
+		t.Errorf("expected 10 interactions, got %d", got)
+	}
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// prometheusContentType is the Prometheus text exposition format version 0.0.4.
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WritePrometheus renders a summary in the Prometheus text exposition format.
+func WritePrometheus(w io.Writer, summary MetricsSummary) error {
+	var b strings.Builder
+
+	writeMetric(&b, "secondbrain_interactions_total", "counter",
+		"Total number of recorded interactions.", float64(summary.TotalInteractions))
+	writeMetric(&b, "secondbrain_avg_response_quality", "gauge",
+		"Average estimated response quality in [0,1].", summary.AvgResponseQuality)
+	writeMetric(&b, "secondbrain_avg_context_relevance", "gauge",
+		"Average relevance of retrieved context in [0,1].", summary.AvgContextRelevance)
+	writeMetric(&b, "secondbrain_user_satisfaction_rate", "gauge",
+		"Share of feedback signals that were positive.", summary.UserSatisfactionRate)
+	writeMetric(&b, "secondbrain_knowledge_coverage", "gauge",
+		"Normalized entropy of the topic distribution in [0,1].", summary.KnowledgeCoverage)
+	writeMetric(&b, "secondbrain_degenerate_loop_warning", "gauge",
+		"1 when knowledge coverage is below the warning threshold despite high volume.", boolGauge(summary.DegenerateLoopWarning))
+	writeMetric(&b, "secondbrain_recent_quality_trend", "gauge",
+		"Average response quality of the most recent interactions.", summary.RecentQualityTrend)
+	writeMetric(&b, "secondbrain_overall_quality_trend", "gauge",
+		"Recent response quality minus the overall average; positive means improving.", summary.OverallQualityTrend)
+
+	// Always emit every feedback type so series don't appear and vanish.
+	feedback := map[string]int{
+		string(FeedbackPositive):   0,
+		string(FeedbackNegative):   0,
+		string(FeedbackCorrection): 0,
+	}
+	for k, v := range summary.FeedbackCounts {
+		feedback[string(k)] = v
+	}
+	writeLabeled(&b, "secondbrain_feedback_total", "counter",
+		"Feedback signals received, by type.", "type", feedback)
+	writeLabeled(&b, "secondbrain_topic_interactions_total", "counter",
+		"Interactions involving each topic.", "topic", summary.TopicCoverage)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMetric(b *strings.Builder, name, typ, help string, value float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", name, help, name, typ, name, value)
+}
+
+func writeLabeled(b *strings.Builder, name, typ, help, label string, values map[string]int) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s{%s=\"%s\"} %d\n", name, label, escapeLabelValue(k), values[k])
+	}
+}
+
+// escapeLabelValue escapes backslashes, quotes, and newlines per the
+// exposition format.
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func boolGauge(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
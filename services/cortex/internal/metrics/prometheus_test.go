@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusEndpoint(t *testing.T) {
+	s := NewStore()
+	s.Record(InteractionRecord{ResponseQuality: 0.8, Feedback: FeedbackPositive,
+		TopicDistribution: map[string]float64{`go "lang"`: 1}})
+	s.Record(InteractionRecord{ResponseQuality: 0.4, Feedback: FeedbackNegative})
+
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"secondbrain_user_satisfaction_rate 0.5\n",
+		"secondbrain_interactions_total 2\n",
+		`secondbrain_feedback_total{type="positive"} 1`,
+		`secondbrain_feedback_total{type="correction"} 0`,
+		`secondbrain_topic_interactions_total{topic="go \"lang\""} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected output to contain %q\n%s", want, body)
+		}
+	}
+
+	// Every TYPE line must name a metric and a valid type.
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		if !strings.HasPrefix(line, "# TYPE ") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 || (fields[3] != "counter" && fields[3] != "gauge" && fields[3] != "histogram") {
+			t.Errorf("malformed TYPE line %q", line)
+		}
+	}
+}
+
+func TestPrometheusEndpointIncludesLatency(t *testing.T) {
+	s := NewStore()
+	s.Latency().Observe("/svc/Method", 30*time.Millisecond, false)
+	s.Latency().Observe("/svc/Method", 2*time.Second, true)
+
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"# TYPE secondbrain_grpc_request_duration_seconds histogram",
+		`secondbrain_grpc_request_duration_seconds_bucket{method="/svc/Method",le="0.05"} 1`,
+		`secondbrain_grpc_request_duration_seconds_bucket{method="/svc/Method",le="+Inf"} 2`,
+		`secondbrain_grpc_request_duration_seconds_count{method="/svc/Method"} 2`,
+		`secondbrain_grpc_errors_total{method="/svc/Method"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected output to contain %q\n%s", want, body)
+		}
+	}
+}
@@ -17,22 +17,46 @@ const (
 
 // InteractionRecord captures a single interaction for metrics computation.
 type InteractionRecord struct {
-	SessionID        string
-	Timestamp        time.Time
-	Query            string
-	ResponseQuality  float64      // [0,1] estimated quality based on context relevance
-	ContextRelevance float64      // [0,1] how relevant the retrieved context was
-	Feedback         FeedbackType // user feedback if available
-	TopicDistribution map[string]float64 // topic -> weight, for entropy calculation
+	InteractionID     string             `json:"interaction_id,omitempty"` // optional; lets later signals update this record
+	SessionID         string             `json:"session_id"`
+	Timestamp         time.Time          `json:"timestamp"`
+	Query             string             `json:"query"`
+	ResponseQuality   float64            `json:"response_quality"`             // [0,1] estimated or evaluated quality, before any feedback adjustment
+	ContextRelevance  float64            `json:"context_relevance"`            // [0,1] how relevant the retrieved context was
+	ContextGated      bool               `json:"context_gated,omitempty"`      // retrieved chunks were all below the relevance floor and left out
+	Feedback          FeedbackType       `json:"feedback,omitempty"`           // user feedback if available
+	FeedbackAdjusted  bool               `json:"feedback_adjusted,omitempty"`  // Feedback was attached by RecordFeedbackFor and adjusts Quality
+	TopicDistribution map[string]float64 `json:"topic_distribution,omitempty"` // topic -> weight, for entropy calculation
+}
+
+// Quality returns the record's response quality: ResponseQuality, moved
+// halfway toward 1 (positive) or 0 (negative/correction) when feedback was
+// attached to the interaction. The adjustment is applied on read so that a
+// later evaluation does not overwrite it and repeated feedback does not
+// compound.
+func (r InteractionRecord) Quality() float64 {
+	if !r.FeedbackAdjusted {
+		return r.ResponseQuality
+	}
+	target := 0.0
+	if r.Feedback == FeedbackPositive {
+		target = 1.0
+	}
+	return (r.ResponseQuality + target) / 2
 }
 
 // Store tracks feedback metrics and computes knowledge coverage indicators.
 type Store struct {
-	mu          sync.RWMutex
-	records     []InteractionRecord
-	topicCounts map[string]int
-	feedbackCounts map[FeedbackType]int
+	mu                sync.RWMutex
+	saveMu            sync.Mutex // serializes Save so concurrent writers don't race on the temp file
+	records           []InteractionRecord
+	topicCounts       map[string]int
+	topicAliases      map[string]string // alias -> canonical topic; see SetTopicAliases
+	feedbackCounts    map[FeedbackType]int
 	totalInteractions int
+	latency           *LatencyStore
+	loopPolicy        LoopWarningPolicy // see SetLoopWarningPolicy
+	loopWarned        bool              // the degenerate loop warning has been logged and still applies
 }
 
 // NewStore creates a new metrics store.
@@ -41,9 +65,16 @@ func NewStore() *Store {
 		records:        make([]InteractionRecord, 0),
 		topicCounts:    make(map[string]int),
 		feedbackCounts: make(map[FeedbackType]int),
+		latency:        NewLatencyStore(),
 	}
 }
 
+// Latency returns the per-RPC latency store, fed by the gRPC metrics
+// interceptors and exposed alongside the summary on /metrics.
+func (s *Store) Latency() *LatencyStore {
+	return s.latency
+}
+
 // Record adds a new interaction record.
 func (s *Store) Record(rec InteractionRecord) {
 	s.mu.Lock()
@@ -56,71 +87,154 @@ func (s *Store) Record(rec InteractionRecord) {
 		s.feedbackCounts[rec.Feedback]++
 	}
 
-	for topic, weight := range rec.TopicDistribution {
-		if weight > 0 {
-			s.topicCounts[topic]++
+	for topic := range s.recordTopics(rec) {
+		s.topicCounts[topic]++
+	}
+	s.checkLoopLocked()
+}
+
+// Reset discards all recorded interactions, counters, and RPC latency
+// statistics.
+func (s *Store) Reset() {
+	s.mu.Lock()
+	s.records = make([]InteractionRecord, 0)
+	s.topicCounts = make(map[string]int)
+	s.feedbackCounts = make(map[FeedbackType]int)
+	s.totalInteractions = 0
+	s.loopWarned = false
+	s.mu.Unlock()
+
+	s.latency.Reset()
+}
+
+// UpdateResponseQuality replaces the ResponseQuality of the record with the
+// given interaction ID, e.g. once an asynchronous LLM evaluation completes.
+// Any feedback already attached still adjusts the record's Quality. It reports whether a matching record was found.
+func (s *Store) UpdateResponseQuality(interactionID string, quality float64) bool {
+	if interactionID == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.records) - 1; i >= 0; i-- {
+		if s.records[i].InteractionID == interactionID {
+			s.records[i].ResponseQuality = quality
+			return true
+		}
+	}
+	return false
+}
+
+// RecordFeedbackFor attaches a feedback signal to the record with the given
+// interaction ID, adjusting its Quality. Feedback replaces any earlier
+// feedback on the same interaction, so repeating it changes nothing. It
+// reports whether a matching record was found.
+func (s *Store) RecordFeedbackFor(interactionID string, feedback FeedbackType) bool {
+	if interactionID == "" || feedback == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.records) - 1; i >= 0; i-- {
+		rec := &s.records[i]
+		if rec.InteractionID != interactionID {
+			continue
+		}
+
+		if rec.Feedback != "" {
+			s.feedbackCounts[rec.Feedback]--
 		}
+		rec.Feedback = feedback
+		rec.FeedbackAdjusted = true
+		s.feedbackCounts[feedback]++
+		return true
 	}
+	return false
 }
 
 // Summary returns the current metrics summary.
 func (s *Store) Summary() MetricsSummary {
+	return s.SummaryWindow(time.Time{})
+}
+
+// SummaryWindow returns the metrics summary computed only over records with
+// Timestamp >= since.
+func (s *Store) SummaryWindow(since time.Time) MetricsSummary {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	summary := MetricsSummary{
-		TotalInteractions: s.totalInteractions,
-		FeedbackCounts:    make(map[FeedbackType]int),
-		TopicCoverage:     make(map[string]int),
+		FeedbackCounts: make(map[FeedbackType]int),
+		TopicCoverage:  make(map[string]int),
 	}
 
-	for k, v := range s.feedbackCounts {
-		summary.FeedbackCounts[k] = v
-	}
-	for k, v := range s.topicCounts {
-		summary.TopicCoverage[k] = v
+	var totalQuality, totalRelevance float64
+	for _, rec := range s.records {
+		if rec.Timestamp.Before(since) {
+			continue
+		}
+		summary.TotalInteractions++
+		totalQuality += rec.Quality()
+		totalRelevance += rec.ContextRelevance
+		if rec.ContextGated {
+			summary.ContextGated++
+		}
+		if rec.Feedback != "" {
+			summary.FeedbackCounts[rec.Feedback]++
+		}
+		for topic := range s.recordTopics(rec) {
+			summary.TopicCoverage[topic]++
+		}
 	}
 
-	// Compute aggregate scores
-	if len(s.records) > 0 {
-		var totalQuality, totalRelevance float64
-		for _, rec := range s.records {
-			totalQuality += rec.ResponseQuality
-			totalRelevance += rec.ContextRelevance
-		}
-		n := float64(len(s.records))
+	if summary.TotalInteractions > 0 {
+		n := float64(summary.TotalInteractions)
 		summary.AvgResponseQuality = totalQuality / n
 		summary.AvgContextRelevance = totalRelevance / n
 	}
 
-	// User satisfaction rate: positive / (positive + negative + correction)
-	totalFeedback := s.feedbackCounts[FeedbackPositive] +
-		s.feedbackCounts[FeedbackNegative] +
-		s.feedbackCounts[FeedbackCorrection]
+	totalFeedback := summary.FeedbackCounts[FeedbackPositive] +
+		summary.FeedbackCounts[FeedbackNegative] +
+		summary.FeedbackCounts[FeedbackCorrection]
 	if totalFeedback > 0 {
-		summary.UserSatisfactionRate = float64(s.feedbackCounts[FeedbackPositive]) / float64(totalFeedback)
+		summary.UserSatisfactionRate = float64(summary.FeedbackCounts[FeedbackPositive]) / float64(totalFeedback)
 	}
 
-	// Knowledge coverage score (normalized entropy of topic distribution)
-	summary.KnowledgeCoverage = s.computeKnowledgeCoverage()
+	summary.KnowledgeCoverage = normalizedEntropy(summary.TopicCoverage)
+	summary.DegenerateLoopWarning = s.loopPolicy.degenerate(summary.TotalInteractions, summary.TopicCoverage)
+
+	summary.RecentQualityTrend, summary.OverallQualityTrend = s.qualityTrend(since, DefaultTrendWindow)
 
 	return summary
 }
 
 // MetricsSummary provides aggregated metrics.
 type MetricsSummary struct {
-	TotalInteractions    int                  `json:"total_interactions"`
-	AvgResponseQuality   float64              `json:"avg_response_quality"`
-	AvgContextRelevance  float64              `json:"avg_context_relevance"`
-	UserSatisfactionRate float64              `json:"user_satisfaction_rate"`
-	KnowledgeCoverage    float64              `json:"knowledge_coverage"`
-	FeedbackCounts       map[FeedbackType]int `json:"feedback_counts"`
-	TopicCoverage        map[string]int       `json:"topic_coverage"`
-}
-
-// computeKnowledgeCoverage calculates the normalized Shannon entropy of the
-// topic distribution across all interactions. This is an information-theoretic
-// measure of how evenly the system's knowledge is distributed across topics.
+	TotalInteractions     int                  `json:"total_interactions"`
+	AvgResponseQuality    float64              `json:"avg_response_quality"`
+	AvgContextRelevance   float64              `json:"avg_context_relevance"`
+	UserSatisfactionRate  float64              `json:"user_satisfaction_rate"`
+	KnowledgeCoverage     float64              `json:"knowledge_coverage"`
+	DegenerateLoopWarning bool                 `json:"degenerate_loop_warning"` // coverage is below the LoopWarningPolicy threshold despite high volume
+	ContextGated          int                  `json:"context_gated"`           // interactions whose retrieved context was all below the relevance floor
+	FeedbackCounts        map[FeedbackType]int `json:"feedback_counts"`
+	TopicCoverage         map[string]int       `json:"topic_coverage"`
+	RecentQualityTrend    float64              `json:"recent_quality_trend"`  // average quality of the most recent interactions
+	OverallQualityTrend   float64              `json:"overall_quality_trend"` // RecentQualityTrend minus the average; positive means improving
+}
+
+// DefaultTrendWindow is the number of most recent interactions averaged for
+// RecentQualityTrend.
+const DefaultTrendWindow = 10
+
+// normalizedEntropy calculates the normalized Shannon entropy of the topic
+// distribution, reported as KnowledgeCoverage. This is an
+// information-theoretic measure of how evenly the system's knowledge is
+// distributed across topics.
 //
 // H_norm = -sum(p_i * log2(p_i)) / log2(N)
 //
@@ -129,14 +243,14 @@ type MetricsSummary struct {
 // broad, even coverage; a value close to 0 means it is concentrated on a few
 // topics. This metric helps detect "degenerate feedback loops" (per Chip Huyen)
 // where the system over-specializes.
-func (s *Store) computeKnowledgeCoverage() float64 {
-	n := len(s.topicCounts)
+func normalizedEntropy(topicCounts map[string]int) float64 {
+	n := len(topicCounts)
 	if n <= 1 {
 		return 0
 	}
 
 	total := 0
-	for _, count := range s.topicCounts {
+	for _, count := range topicCounts {
 		total += count
 	}
 	if total == 0 {
@@ -145,7 +259,7 @@ func (s *Store) computeKnowledgeCoverage() float64 {
 
 	var entropy float64
 	totalF := float64(total)
-	for _, count := range s.topicCounts {
+	for _, count := range topicCounts {
 		if count > 0 {
 			p := float64(count) / totalF
 			entropy -= p * math.Log2(p)
@@ -167,24 +281,46 @@ func (s *Store) RecentQualityTrend(n int) float64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if len(s.records) == 0 {
-		return 0
-	}
+	recent, _ := s.qualityTrend(time.Time{}, n)
+	return recent
+}
 
-	start := len(s.records) - n
-	if start < 0 {
-		start = 0
+// SetQualityTrend recomputes a summary's quality trend over its last n
+// interactions, considering only records with Timestamp >= since. Pass the
+// zero time for a summary over all history.
+func (s *Store) SetQualityTrend(summary *MetricsSummary, since time.Time, n int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	summary.RecentQualityTrend, summary.OverallQualityTrend = s.qualityTrend(since, n)
+}
+
+// qualityTrend returns the average quality of the last n records with
+// Timestamp >= since, and how far that average is above the average of all
+// those records. Callers must hold s.mu.
+func (s *Store) qualityTrend(since time.Time, n int) (recent, overall float64) {
+	if n <= 0 {
+		return 0, 0
 	}
 
-	var total float64
-	count := 0
-	for _, rec := range s.records[start:] {
-		total += rec.ResponseQuality
+	var total, recentTotal float64
+	count, recentCount := 0, 0
+	for i := len(s.records) - 1; i >= 0; i-- {
+		rec := s.records[i]
+		if rec.Timestamp.Before(since) {
+			continue
+		}
+		total += rec.Quality()
 		count++
+		if recentCount < n {
+			recentTotal += rec.Quality()
+			recentCount++
+		}
 	}
 
 	if count == 0 {
-		return 0
+		return 0, 0
 	}
-	return total / float64(count)
+	recent = recentTotal / float64(recentCount)
+	return recent, recent - total/float64(count)
 }
@@ -1,7 +1,11 @@
 package metrics
 
 import (
+	"bytes"
+	"log/slog"
 	"math"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -120,6 +124,44 @@ func TestKnowledgeCoverageSingleTopic(t *testing.T) {
 	}
 }
 
+func TestTopicAliasesCollapseSynonyms(t *testing.T) {
+	store := NewStore()
+	store.SetTopicAliases(map[string]string{"ml": "machine_learning"})
+
+	store.Record(InteractionRecord{TopicDistribution: map[string]float64{"ml": 1.0}})
+	store.Record(InteractionRecord{TopicDistribution: map[string]float64{"machine_learning": 1.0}})
+	// Both names on one record still count the interaction once.
+	store.Record(InteractionRecord{TopicDistribution: map[string]float64{"ML": 0.5, "machine_learning": 0.5}})
+
+	summary := store.Summary()
+	if len(summary.TopicCoverage) != 1 || summary.TopicCoverage["machine_learning"] != 3 {
+		t.Errorf("expected a single machine_learning topic with 3 interactions, got %v", summary.TopicCoverage)
+	}
+	if summary.KnowledgeCoverage != 0 {
+		t.Errorf("expected 0 knowledge coverage for a single topic, got %f", summary.KnowledgeCoverage)
+	}
+
+	window := store.SummaryWindow(time.Time{})
+	if len(window.TopicCoverage) != 1 || window.TopicCoverage["machine_learning"] != 3 {
+		t.Errorf("expected windowed coverage to collapse aliases too, got %v", window.TopicCoverage)
+	}
+}
+
+func TestSetTopicAliasesRecountsExistingRecords(t *testing.T) {
+	store := NewStore()
+	store.Record(InteractionRecord{TopicDistribution: map[string]float64{"ml": 1.0}})
+	store.Record(InteractionRecord{TopicDistribution: map[string]float64{"machine_learning": 1.0}})
+	if n := len(store.Summary().TopicCoverage); n != 2 {
+		t.Fatalf("expected 2 distinct topics without aliases, got %d", n)
+	}
+
+	store.SetTopicAliases(map[string]string{"ml": "machine_learning"})
+
+	if got := store.Summary().TopicCoverage; len(got) != 1 || got["machine_learning"] != 2 {
+		t.Errorf("expected existing records recounted under the alias, got %v", got)
+	}
+}
+
 func TestRecentQualityTrend(t *testing.T) {
 	store := NewStore()
 
@@ -196,3 +238,250 @@ func TestConcurrentAccess(t *testing.T) {
 		t.Errorf("expected 500 interactions, got %d", summary.TotalInteractions)
 	}
 }
+
+func TestUpdateResponseQuality(t *testing.T) {
+	store := NewStore()
+	store.Record(InteractionRecord{InteractionID: "i-1", ResponseQuality: 0.2})
+	store.Record(InteractionRecord{InteractionID: "i-2", ResponseQuality: 0.4})
+
+	if !store.UpdateResponseQuality("i-1", 0.8) {
+		t.Fatal("expected record i-1 to be updated")
+	}
+	if store.UpdateResponseQuality("missing", 0.5) {
+		t.Error("expected no update for unknown interaction")
+	}
+	if store.UpdateResponseQuality("", 0.5) {
+		t.Error("expected no update for empty interaction ID")
+	}
+
+	summary := store.Summary()
+	if math.Abs(summary.AvgResponseQuality-0.6) > 1e-9 {
+		t.Errorf("expected avg quality 0.6 after update, got %f", summary.AvgResponseQuality)
+	}
+}
+
+func TestRecordFeedbackFor(t *testing.T) {
+	store := NewStore()
+	store.Record(InteractionRecord{InteractionID: "good", ResponseQuality: 0.6})
+	store.Record(InteractionRecord{InteractionID: "bad", ResponseQuality: 0.6})
+
+	if !store.RecordFeedbackFor("good", FeedbackPositive) {
+		t.Fatal("expected feedback to attach to 'good'")
+	}
+	if !store.RecordFeedbackFor("bad", FeedbackNegative) {
+		t.Fatal("expected feedback to attach to 'bad'")
+	}
+	if store.RecordFeedbackFor("missing", FeedbackPositive) {
+		t.Error("expected no match for unknown interaction")
+	}
+
+	summary := store.Summary()
+	if summary.TotalInteractions != 2 {
+		t.Errorf("expected feedback not to add interactions, got %d", summary.TotalInteractions)
+	}
+	if summary.FeedbackCounts[FeedbackPositive] != 1 || summary.FeedbackCounts[FeedbackNegative] != 1 {
+		t.Errorf("unexpected feedback counts: %v", summary.FeedbackCounts)
+	}
+	// good: (0.6+1)/2 = 0.8, bad: 0.6/2 = 0.3 -> avg 0.55
+	if math.Abs(summary.AvgResponseQuality-0.55) > 1e-9 {
+		t.Errorf("expected avg quality 0.55, got %f", summary.AvgResponseQuality)
+	}
+}
+
+func TestRecordFeedbackForReplacesEarlierFeedback(t *testing.T) {
+	store := NewStore()
+	store.Record(InteractionRecord{InteractionID: "i-1", ResponseQuality: 0.5})
+
+	store.RecordFeedbackFor("i-1", FeedbackNegative)
+	store.RecordFeedbackFor("i-1", FeedbackCorrection)
+
+	summary := store.Summary()
+	if summary.FeedbackCounts[FeedbackNegative] != 0 {
+		t.Errorf("expected negative feedback to be replaced, got %d", summary.FeedbackCounts[FeedbackNegative])
+	}
+	if summary.FeedbackCounts[FeedbackCorrection] != 1 {
+		t.Errorf("expected 1 correction, got %d", summary.FeedbackCounts[FeedbackCorrection])
+	}
+}
+
+func TestRecordFeedbackForIsIdempotent(t *testing.T) {
+	store := NewStore()
+	store.Record(InteractionRecord{InteractionID: "i-1", ResponseQuality: 0.6})
+
+	for i := 0; i < 3; i++ {
+		store.RecordFeedbackFor("i-1", FeedbackPositive)
+	}
+
+	summary := store.Summary()
+	if summary.FeedbackCounts[FeedbackPositive] != 1 {
+		t.Errorf("expected 1 positive feedback, got %d", summary.FeedbackCounts[FeedbackPositive])
+	}
+	// (0.6+1)/2 = 0.8, however often the feedback is repeated
+	if math.Abs(summary.AvgResponseQuality-0.8) > 1e-9 {
+		t.Errorf("expected avg quality 0.8, got %f", summary.AvgResponseQuality)
+	}
+}
+
+func TestUpdateResponseQualityKeepsFeedbackAdjustment(t *testing.T) {
+	store := NewStore()
+	store.Record(InteractionRecord{InteractionID: "i-1", ResponseQuality: 0.9})
+
+	// Feedback arrives before the asynchronous evaluation completes.
+	store.RecordFeedbackFor("i-1", FeedbackPositive)
+	store.UpdateResponseQuality("i-1", 0.4)
+
+	// (0.4+1)/2 = 0.7
+	if got := store.Summary().AvgResponseQuality; math.Abs(got-0.7) > 1e-9 {
+		t.Errorf("expected avg quality 0.7, got %f", got)
+	}
+}
+
+func TestSummaryWindowExcludesOlderRecords(t *testing.T) {
+	s := NewStore()
+	now := time.Now()
+
+	s.Record(InteractionRecord{
+		Timestamp:         now.Add(-72 * time.Hour),
+		ResponseQuality:   0.1,
+		ContextRelevance:  0.1,
+		Feedback:          FeedbackNegative,
+		TopicDistribution: map[string]float64{"old": 1},
+	})
+	s.Record(InteractionRecord{
+		Timestamp:         now.Add(-time.Hour),
+		ResponseQuality:   0.8,
+		ContextRelevance:  0.6,
+		Feedback:          FeedbackPositive,
+		TopicDistribution: map[string]float64{"go": 1},
+	})
+	s.Record(InteractionRecord{
+		Timestamp:         now.Add(-30 * time.Minute),
+		ResponseQuality:   1.0,
+		ContextRelevance:  0.8,
+		TopicDistribution: map[string]float64{"rust": 1},
+	})
+
+	summary := s.SummaryWindow(now.Add(-24 * time.Hour))
+	if summary.TotalInteractions != 2 {
+		t.Errorf("expected 2 interactions in window, got %d", summary.TotalInteractions)
+	}
+	if math.Abs(summary.AvgResponseQuality-0.9) > 1e-9 {
+		t.Errorf("expected avg quality 0.9, got %f", summary.AvgResponseQuality)
+	}
+	if math.Abs(summary.AvgContextRelevance-0.7) > 1e-9 {
+		t.Errorf("expected avg relevance 0.7, got %f", summary.AvgContextRelevance)
+	}
+	if summary.UserSatisfactionRate != 1.0 {
+		t.Errorf("expected satisfaction 1.0 in window, got %f", summary.UserSatisfactionRate)
+	}
+	if _, ok := summary.TopicCoverage["old"]; ok {
+		t.Error("expected topic from an older record to be excluded")
+	}
+	if math.Abs(summary.KnowledgeCoverage-1.0) > 1e-9 {
+		t.Errorf("expected even coverage over 2 topics, got %f", summary.KnowledgeCoverage)
+	}
+
+	if all := s.Summary(); all.TotalInteractions != 3 || all.UserSatisfactionRate != 0.5 {
+		t.Errorf("expected full summary unaffected, got %+v", all)
+	}
+}
+
+func TestResetConcurrentWithReads(t *testing.T) {
+	s := NewStore()
+	for i := 0; i < 20; i++ {
+		s.Record(InteractionRecord{ResponseQuality: 0.5, Feedback: FeedbackNegative})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			s.Summary()
+		}()
+		go func() {
+			defer wg.Done()
+			s.RecentQualityTrend(5)
+		}()
+		go func() {
+			defer wg.Done()
+			s.Reset()
+		}()
+	}
+	wg.Wait()
+
+	s.Reset()
+	summary := s.Summary()
+	if summary.TotalInteractions != 0 {
+		t.Errorf("expected 0 interactions after reset, got %d", summary.TotalInteractions)
+	}
+	if summary.UserSatisfactionRate != 0 || summary.AvgResponseQuality != 0 {
+		t.Errorf("expected zeroed aggregates, got %+v", summary)
+	}
+}
+
+func recordTopics(store *Store, n int, topics ...string) {
+	for i := 0; i < n; i++ {
+		store.Record(InteractionRecord{
+			SessionID:         "s1",
+			Timestamp:         time.Now(),
+			TopicDistribution: map[string]float64{topics[i%len(topics)]: 1},
+		})
+	}
+}
+
+func TestDegenerateLoopWarningOnSkewedTraffic(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	store := NewStore()
+	store.SetLoopWarningPolicy(LoopWarningPolicy{MinCoverage: 0.3, MinInteractions: 20})
+
+	recordTopics(store, 19, "machine_learning")
+	if store.Summary().DegenerateLoopWarning {
+		t.Error("expected no warning below the interaction threshold")
+	}
+
+	recordTopics(store, 30, "machine_learning")
+	recordTopics(store, 1, "databases")
+	summary := store.Summary()
+	if !summary.DegenerateLoopWarning {
+		t.Errorf("expected a warning for single-topic traffic, coverage %f", summary.KnowledgeCoverage)
+	}
+	if n := strings.Count(logs.String(), "degenerate feedback loop"); n != 1 {
+		t.Errorf("expected the warning to be logged once, got %d times:\n%s", n, logs.String())
+	}
+
+	window := store.SummaryWindow(time.Now().Add(-time.Hour))
+	if !window.DegenerateLoopWarning {
+		t.Error("expected the windowed summary to warn as well")
+	}
+}
+
+func TestDegenerateLoopWarningNotOnDiverseTraffic(t *testing.T) {
+	store := NewStore()
+	store.SetLoopWarningPolicy(LoopWarningPolicy{MinCoverage: 0.3, MinInteractions: 20})
+
+	recordTopics(store, 100, "machine_learning", "go_programming", "architecture", "databases")
+	if summary := store.Summary(); summary.DegenerateLoopWarning {
+		t.Errorf("expected no warning for diverse traffic, coverage %f", summary.KnowledgeCoverage)
+	}
+
+	// Interactions without topics say nothing about coverage.
+	untagged := NewStore()
+	untagged.SetLoopWarningPolicy(LoopWarningPolicy{MinCoverage: 0.3, MinInteractions: 20})
+	for i := 0; i < 50; i++ {
+		untagged.Record(InteractionRecord{SessionID: "s1", Timestamp: time.Now()})
+	}
+	if untagged.Summary().DegenerateLoopWarning {
+		t.Error("expected no warning without any topics")
+	}
+
+	// A zero threshold disables the warning.
+	disabled := NewStore()
+	recordTopics(disabled, 50, "machine_learning")
+	if disabled.Summary().DegenerateLoopWarning {
+		t.Error("expected no warning without a policy")
+	}
+}
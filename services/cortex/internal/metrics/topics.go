@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadTopicAliases reads a topic alias map from a JSON file of the form
+// {"alias": "canonical_topic", ...}.
+func LoadTopicAliases(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading topic aliases: %w", err)
+	}
+	var aliases map[string]string
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("decoding topic aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// SetTopicAliases makes the store count each aliased topic under its
+// canonical name, so synonyms such as "ml" and "machine_learning" collapse
+// into one topic for coverage. Aliases match case-insensitively. Topic counts
+// of already recorded interactions are recomputed under the new aliases.
+func (s *Store) SetTopicAliases(aliases map[string]string) {
+	normalized := make(map[string]string, len(aliases))
+	for alias, canonical := range aliases {
+		alias, canonical = normalizeTopic(alias), normalizeTopic(canonical)
+		if alias != "" && canonical != "" && alias != canonical {
+			normalized[alias] = canonical
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.topicAliases = normalized
+	s.topicCounts = make(map[string]int)
+	for _, rec := range s.records {
+		for topic := range s.recordTopics(rec) {
+			s.topicCounts[topic]++
+		}
+	}
+}
+
+// recordTopics returns the canonical topics a record involves, each once even
+// when several of its topics are aliases of the same one. Callers must hold s.mu.
+func (s *Store) recordTopics(rec InteractionRecord) map[string]struct{} {
+	topics := make(map[string]struct{}, len(rec.TopicDistribution))
+	for topic, weight := range rec.TopicDistribution {
+		if weight > 0 {
+			topics[s.canonicalTopic(topic)] = struct{}{}
+		}
+	}
+	return topics
+}
+
+// canonicalTopic maps an aliased topic to its canonical name. Topics without
+// an alias are returned unchanged. Callers must hold s.mu.
+func (s *Store) canonicalTopic(topic string) string {
+	if canonical, ok := s.topicAliases[normalizeTopic(topic)]; ok {
+		return canonical
+	}
+	return topic
+}
+
+func normalizeTopic(topic string) string {
+	return strings.ToLower(strings.TrimSpace(topic))
+}
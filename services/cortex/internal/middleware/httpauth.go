@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// HTTPAuth returns a handler that serves requests from mux, requiring a
+// bearer token matching one of tokens in the Authorization header for the
+// routes registered under patterns. Other routes are served as is. With no
+// tokens, every request is allowed.
+func HTTPAuth(tokens []string, mux *http.ServeMux, patterns ...string) http.Handler {
+	protected := make(map[string]bool, len(patterns))
+	for _, p := range patterns {
+		protected[p] = true
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(tokens) == 0 {
+			mux.ServeHTTP(w, r)
+			return
+		}
+		if _, pattern := mux.Handler(r); !protected[pattern] {
+			mux.ServeHTTP(w, r)
+			return
+		}
+		id, err := matchToken(r.Header.Get("Authorization"), tokens)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()}) //nolint:errcheck
+			return
+		}
+		mux.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityContextKey{}, id)))
+	})
+}
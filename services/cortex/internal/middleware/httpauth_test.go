@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newHTTPAuthMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(Identity(r.Context()))) //nolint:errcheck
+	}
+	mux.HandleFunc("POST /v1/metrics/reset", ok)
+	mux.HandleFunc("POST /v1/classify/reviews/{id}", ok)
+	mux.HandleFunc("GET /v1/metrics", ok)
+	return mux
+}
+
+func TestHTTPAuth(t *testing.T) {
+	handler := HTTPAuth([]string{"old-secret", "new-secret"}, newHTTPAuthMux(),
+		"POST /v1/metrics/reset", "POST /v1/classify/reviews/{id}")
+
+	tests := []struct {
+		name          string
+		method, path  string
+		authorization string
+		wantCode      int
+		wantIdentity  string
+	}{
+		{"missing token", http.MethodPost, "/v1/metrics/reset", "", http.StatusUnauthorized, ""},
+		{"wrong token", http.MethodPost, "/v1/metrics/reset", "Bearer guess", http.StatusUnauthorized, ""},
+		{"not a bearer token", http.MethodPost, "/v1/metrics/reset", "Basic new-secret", http.StatusUnauthorized, ""},
+		{"valid token", http.MethodPost, "/v1/metrics/reset", "Bearer new-secret", http.StatusOK, "token-1"},
+		{"wildcard route", http.MethodPost, "/v1/classify/reviews/review-1", "", http.StatusUnauthorized, ""},
+		{"wildcard route with token", http.MethodPost, "/v1/classify/reviews/review-1", "Bearer old-secret", http.StatusOK, "token-0"},
+		{"unprotected route", http.MethodGet, "/v1/metrics", "", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode == http.StatusOK && w.Body.String() != tt.wantIdentity {
+				t.Errorf("expected identity %q, got %q", tt.wantIdentity, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTPAuthDisabled(t *testing.T) {
+	handler := HTTPAuth(nil, newHTTPAuthMux(), "POST /v1/metrics/reset")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/metrics/reset", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 with auth disabled, got %d", w.Code)
+	}
+}
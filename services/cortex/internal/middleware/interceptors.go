@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"log/slog"
+	"runtime/debug"
 	"time"
 
 	"google.golang.org/grpc"
@@ -29,7 +30,7 @@ func UnaryLogging(logger *slog.Logger) grpc.UnaryServerInterceptor {
 			code = status.Code(err)
 		}
 
-		logger.Info("gRPC request",
+		logger.InfoContext(ctx, "gRPC request",
 			"method", info.FullMethod,
 			"code", code.String(),
 			"duration", duration,
@@ -57,7 +58,7 @@ func StreamLogging(logger *slog.Logger) grpc.StreamServerInterceptor {
 			code = status.Code(err)
 		}
 
-		logger.Info("gRPC stream",
+		logger.InfoContext(ss.Context(), "gRPC stream",
 			"method", info.FullMethod,
 			"code", code.String(),
 			"duration", duration,
@@ -94,9 +95,10 @@ func UnaryRecovery(logger *slog.Logger) grpc.UnaryServerInterceptor {
 	) (resp interface{}, err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.Error("panic recovered in gRPC handler",
+				logger.ErrorContext(ctx, "panic recovered in gRPC handler",
 					"method", info.FullMethod,
 					"panic", r,
+					"stack", string(debug.Stack()),
 				)
 				err = status.Errorf(codes.Internal, "internal server error")
 			}
@@ -105,6 +107,28 @@ func UnaryRecovery(logger *slog.Logger) grpc.UnaryServerInterceptor {
 	}
 }
 
+// StreamRecovery recovers from panics in stream handlers.
+func StreamRecovery(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.ErrorContext(ss.Context(), "panic recovered in gRPC stream handler",
+					"method", info.FullMethod,
+					"panic", r,
+					"stack", string(debug.Stack()),
+				)
+				err = status.Errorf(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
 // ExtractTraceContext extracts trace context from gRPC metadata.
 func ExtractTraceContext(ctx context.Context) string {
 	md, ok := metadata.FromIncomingContext(ctx)
@@ -2,9 +2,19 @@ package middleware
 
 import (
 	"context"
+	"io"
+	"log/slog"
+	"net"
 	"testing"
+	"time"
 
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestExtractTraceContext(t *testing.T) {
@@ -26,3 +36,98 @@ func TestExtractTraceContextMissing(t *testing.T) {
 		t.Errorf("expected empty trace, got %q", trace)
 	}
 }
+
+func TestUnaryRecovery(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	interceptor := UnaryRecovery(logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.memory.v1.MemoryService/IndexDocument"}
+
+	panicking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		var m map[string]int
+		m["boom"]++ // assignment to entry in nil map
+		return nil, nil
+	}
+	_, err := interceptor(context.Background(), nil, info, panicking)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal after a panic, got %v", err)
+	}
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	if resp, err := interceptor(context.Background(), nil, info, ok); err != nil || resp != "ok" {
+		t.Errorf("expected handler result to pass through, got %v (%v)", resp, err)
+	}
+}
+
+func TestStreamRecovery(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	interceptor := StreamRecovery(logger)
+	info := &grpc.StreamServerInfo{FullMethod: streamMethod}
+	stream := &authStream{ctx: context.Background()}
+
+	panicking := func(srv interface{}, ss grpc.ServerStream) error {
+		var items []string
+		_ = items[3] // index out of range
+		return nil
+	}
+	if err := interceptor(nil, stream, info, panicking); status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal after a panic, got %v", err)
+	}
+
+	wantErr := status.Error(codes.NotFound, "missing")
+	failing := func(srv interface{}, ss grpc.ServerStream) error { return wantErr }
+	if err := interceptor(nil, stream, info, failing); err != wantErr {
+		t.Errorf("expected handler error to pass through, got %v", err)
+	}
+}
+
+// panickingEngine is a ReasoningEngine whose StreamThoughtProcess panics.
+type panickingEngine struct {
+	agentv1.UnimplementedReasoningEngineServer
+}
+
+func (panickingEngine) StreamThoughtProcess(stream grpc.BidiStreamingServer[agentv1.AgentInput, agentv1.AgentOutput]) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	var sessions map[string]int
+	sessions["sess-1"]++ // assignment to entry in nil map
+	return nil
+}
+
+func TestStreamRecoveryServerSurvives(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	srv := grpc.NewServer(grpc.ChainStreamInterceptor(StreamRecovery(logger), StreamLogging(logger)))
+	agentv1.RegisterReasoningEngineServer(srv, panickingEngine{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+	client := agentv1.NewReasoningEngineClient(conn)
+
+	// The second call proves the server is still serving after the panic.
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		stream, err := client.StreamThoughtProcess(ctx)
+		if err != nil {
+			cancel()
+			t.Fatalf("call %d: opening stream: %v", i, err)
+		}
+		if err := stream.Send(&agentv1.AgentInput{SessionId: "sess-1"}); err != nil {
+			cancel()
+			t.Fatalf("call %d: send: %v", i, err)
+		}
+		_, err = stream.Recv()
+		cancel()
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("call %d: expected Internal, got %v", i, err)
+		}
+	}
+}
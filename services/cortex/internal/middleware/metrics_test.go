@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/metrics"
+
+	"google.golang.org/grpc"
+)
+
+func TestUnaryMetricsRecordsLatency(t *testing.T) {
+	store := metrics.NewLatencyStore()
+	interceptor := UnaryMetrics(store)
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.memory.v1.MemoryService/SemanticSearch"}
+
+	slow := func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "ok", nil
+	}
+	failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("boom")
+	}
+
+	if _, err := interceptor(context.Background(), nil, info, slow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := interceptor(context.Background(), nil, info, failing); err == nil {
+		t.Fatal("expected handler error to pass through")
+	}
+
+	snapshot := store.Snapshot()
+	if len(snapshot) != 1 {
+		t.Fatalf("expected 1 method, got %d", len(snapshot))
+	}
+	m := snapshot[0]
+	if m.Method != info.FullMethod {
+		t.Errorf("unexpected method %q", m.Method)
+	}
+	if m.Count != 2 || m.Errors != 1 {
+		t.Errorf("expected 2 calls and 1 error, got %d/%d", m.Count, m.Errors)
+	}
+	if m.ErrorRate() != 0.5 {
+		t.Errorf("expected error rate 0.5, got %f", m.ErrorRate())
+	}
+	if m.TotalSeconds < 0.01 {
+		t.Errorf("expected recorded duration of at least 10ms, got %fs", m.TotalSeconds)
+	}
+	if last := m.Buckets[len(m.Buckets)-1]; last.Count != 2 {
+		t.Errorf("expected both calls in the largest bucket, got %d", last.Count)
+	}
+}
+
+func TestStreamMetricsRecordsCall(t *testing.T) {
+	store := metrics.NewLatencyStore()
+	interceptor := StreamMetrics(store)
+	info := &grpc.StreamServerInfo{FullMethod: "/cognitive_os.agent.v1.ReasoningEngine/StreamThoughtProcess"}
+
+	handler := func(srv interface{}, ss grpc.ServerStream) error { return nil }
+	for i := 0; i < 3; i++ {
+		if err := interceptor(nil, nil, info, handler); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	snapshot := store.Snapshot()
+	if len(snapshot) != 1 || snapshot[0].Count != 3 || snapshot[0].Errors != 0 {
+		t.Errorf("expected 3 successful calls, got %+v", snapshot)
+	}
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// maxRateLimitBuckets bounds the number of tracked clients; beyond it, idle
+// clients whose buckets have refilled are forgotten.
+const maxRateLimitBuckets = 10000
+
+// RateLimiter is a token bucket per client: each client may make burst calls
+// at once and then rate calls per second on average.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rate calls per second with the
+// given burst per client. A non-positive rate disables limiting.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Allow reports whether the client identified by key may make a call now,
+// consuming a token if so.
+func (l *RateLimiter) Allow(key string) bool {
+	if l == nil || l.rate <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitBuckets {
+			l.evictIdle(now)
+		}
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// evictIdle drops buckets that would be full by now, since forgetting them
+// does not change any client's allowance.
+func (l *RateLimiter) evictIdle(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// UnaryRateLimit returns a gRPC unary server interceptor that rejects calls
+// with ResourceExhausted once the caller exceeds its rate limit.
+func UnaryRateLimit(l *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !l.Allow(clientKey(ctx)) {
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimit returns a gRPC stream server interceptor that counts each
+// new stream against the caller's rate limit.
+func StreamRateLimit(l *RateLimiter) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if !l.Allow(clientKey(ss.Context())) {
+			return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", info.FullMethod)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// clientKey identifies the caller by its authenticated identity, falling
+// back to the peer's IP address. Caller-supplied metadata is not trusted, so
+// a client cannot claim a fresh bucket per call.
+func clientKey(ctx context.Context) string {
+	if id := Identity(ctx); id != "" {
+		return "id:" + id
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + addr
+	}
+	return "unknown"
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClock is a manually advanced clock for deterministic rate limiting.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(rate float64, burst int) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1700000000, 0)}
+	l := NewRateLimiter(rate, burst)
+	l.now = clock.Now
+	return l, clock
+}
+
+func peerContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000},
+	})
+}
+
+func TestUnaryRateLimitBurst(t *testing.T) {
+	l, clock := newTestLimiter(2, 3)
+	interceptor := UnaryRateLimit(l)
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.agent.v1.ReasoningEngine/ClassifyItem"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	ctx := peerContext("10.0.0.1")
+
+	for i := 0; i < 3; i++ {
+		if _, err := interceptor(ctx, nil, info, handler); err != nil {
+			t.Fatalf("call %d within burst rejected: %v", i, err)
+		}
+	}
+	_, err := interceptor(ctx, nil, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted after burst, got %v", err)
+	}
+
+	// At 2 tokens/s, half a second refills exactly one call.
+	clock.Advance(500 * time.Millisecond)
+	if _, err := interceptor(ctx, nil, info, handler); err != nil {
+		t.Fatalf("expected call after refill to pass, got %v", err)
+	}
+	if _, err := interceptor(ctx, nil, info, handler); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected second call after partial refill to be rejected, got %v", err)
+	}
+
+	// Other clients have their own bucket.
+	if _, err := interceptor(peerContext("10.0.0.2"), nil, info, handler); err != nil {
+		t.Fatalf("expected a different peer to pass, got %v", err)
+	}
+}
+
+func TestStreamRateLimit(t *testing.T) {
+	l, clock := newTestLimiter(1, 2)
+	interceptor := StreamRateLimit(l)
+	info := &grpc.StreamServerInfo{FullMethod: streamMethod}
+	handler := func(srv interface{}, ss grpc.ServerStream) error { return nil }
+
+	stream := &authStream{ctx: context.WithValue(peerContext("10.0.0.1"), identityContextKey{}, "token-0")}
+
+	for i := 0; i < 2; i++ {
+		if err := interceptor(nil, stream, info, handler); err != nil {
+			t.Fatalf("stream %d within burst rejected: %v", i, err)
+		}
+	}
+	if err := interceptor(nil, stream, info, handler); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted after burst, got %v", err)
+	}
+
+	// A long idle period refills only up to the burst.
+	clock.Advance(time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := interceptor(nil, stream, info, handler); err != nil {
+			t.Fatalf("stream %d after refill rejected: %v", i, err)
+		}
+	}
+	if err := interceptor(nil, stream, info, handler); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected refill to be capped at burst, got %v", err)
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	l := NewRateLimiter(0, 1)
+	for i := 0; i < 100; i++ {
+		if !l.Allow("client") {
+			t.Fatal("expected a zero rate to disable limiting")
+		}
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	if got := clientKey(peerContext("192.168.1.5")); got != "ip:192.168.1.5" {
+		t.Errorf("expected peer IP key, got %q", got)
+	}
+	ctx := metadata.NewIncomingContext(peerContext("192.168.1.5"), metadata.Pairs("x-client-id", "svc-a"))
+	if got := clientKey(ctx); got != "ip:192.168.1.5" {
+		t.Errorf("expected caller-supplied client ID to be ignored, got %q", got)
+	}
+	ctx = context.WithValue(ctx, identityContextKey{}, "token-1")
+	if got := clientKey(ctx); got != "id:token-1" {
+		t.Errorf("expected authenticated identity key, got %q", got)
+	}
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey is the gRPC metadata key that carries a request ID between
+// services so their logs can be correlated.
+const RequestIDKey = "x-request-id"
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// UnaryRequestID returns a gRPC unary server interceptor that takes the
+// request ID from the incoming x-request-id metadata, generating one when
+// absent, and stores it in the handler's context.
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		return handler(incomingRequestID(ctx), req)
+	}
+}
+
+// StreamRequestID returns a gRPC stream server interceptor that applies the
+// same request ID handling as UnaryRequestID to the stream's context.
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &requestIDStream{ServerStream: ss, ctx: incomingRequestID(ss.Context())})
+	}
+}
+
+// UnaryClientRequestID returns a gRPC unary client interceptor that forwards
+// the context's request ID to the downstream service.
+func UnaryClientRequestID() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientRequestID returns a gRPC stream client interceptor that
+// forwards the context's request ID to the downstream service.
+func StreamClientRequestID() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+// NewRequestIDHandler wraps h so that records logged with a context carrying
+// a request ID (via the logger's *Context methods) include it as a
+// "request_id" attribute.
+func NewRequestIDHandler(h slog.Handler) slog.Handler {
+	return requestIDHandler{h}
+}
+
+type requestIDHandler struct {
+	slog.Handler
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
+
+// requestIDStream overrides a server stream's context with one carrying the
+// request ID.
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context { return s.ctx }
+
+// incomingRequestID returns ctx carrying the caller's request ID, or a new
+// one if the caller sent none.
+func incomingRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(RequestIDKey); len(values) > 0 && values[0] != "" {
+		return WithRequestID(ctx, values[0])
+	}
+	return WithRequestID(ctx, newRequestID())
+}
+
+// outgoingRequestID returns ctx with its request ID, if any, added to the
+// outgoing metadata.
+func outgoingRequestID(ctx context.Context) context.Context {
+	if id := RequestID(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+	}
+	return ctx
+}
+
+// newRequestID returns a random 128-bit hex request ID.
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:]) //nolint:errcheck
+	return hex.EncodeToString(b[:])
+}
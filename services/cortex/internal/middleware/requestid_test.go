@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryRequestID(t *testing.T) {
+	interceptor := UnaryRequestID()
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.memory.v1.MemoryService/IndexDocument"}
+	var got string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = RequestID(ctx)
+		return nil, nil
+	}
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "req-123"))
+	interceptor(incoming, nil, info, handler)
+	if got != "req-123" {
+		t.Errorf("expected the caller's request ID, got %q", got)
+	}
+
+	interceptor(context.Background(), nil, info, handler)
+	if len(got) != 32 || got == "req-123" {
+		t.Errorf("expected a generated request ID, got %q", got)
+	}
+}
+
+func TestStreamRequestID(t *testing.T) {
+	interceptor := StreamRequestID()
+	info := &grpc.StreamServerInfo{FullMethod: streamMethod}
+	var got string
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got = RequestID(ss.Context())
+		return nil
+	}
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "req-456"))
+	interceptor(nil, &authStream{ctx: incoming}, info, handler)
+	if got != "req-456" {
+		t.Errorf("expected the caller's request ID, got %q", got)
+	}
+}
+
+func TestRequestIDHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewRequestIDHandler(slog.NewJSONHandler(&buf, nil))).With("service", "test")
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-789"), "handled")
+	logger.InfoContext(context.Background(), "no request")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], `"request_id":"req-789"`) || !strings.Contains(lines[0], `"service":"test"`) {
+		t.Errorf("expected request_id and logger attributes, got %s", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("expected no request_id without one in context, got %s", lines[1])
+	}
+}
+
+func TestRequestIDPropagatesDownstream(t *testing.T) {
+	server := UnaryRequestID()
+	client := UnaryClientRequestID()
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.agent.v1.ReasoningEngine/ClassifyItem"}
+
+	var generated string
+	var downstream []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		downstream = md.Get(RequestIDKey)
+		return nil
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		generated = RequestID(ctx)
+		return nil, client(ctx, "/cognitive_os.memory.v1.MemoryService/HybridSearch", nil, nil, nil, invoker)
+	}
+
+	if _, err := server(context.Background(), nil, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if generated == "" {
+		t.Fatal("expected a generated request ID")
+	}
+	if len(downstream) != 1 || downstream[0] != generated {
+		t.Errorf("expected downstream metadata to carry %q, got %v", generated, downstream)
+	}
+}
+
+func TestStreamClientRequestID(t *testing.T) {
+	var downstream []string
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		downstream = md.Get(RequestIDKey)
+		return nil, nil
+	}
+
+	StreamClientRequestID()(WithRequestID(context.Background(), "req-stream"), &grpc.StreamDesc{}, nil, streamMethod, streamer)
+	if len(downstream) != 1 || downstream[0] != "req-stream" {
+		t.Errorf("expected downstream metadata to carry req-stream, got %v", downstream)
+	}
+
+	StreamClientRequestID()(context.Background(), &grpc.StreamDesc{}, nil, streamMethod, streamer)
+	if len(downstream) != 0 {
+		t.Errorf("expected no request ID without one in context, got %v", downstream)
+	}
+}
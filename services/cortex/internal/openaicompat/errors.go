@@ -0,0 +1,64 @@
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultRetryAfter is the Retry-After sent with retryable errors (429 and
+// 503), so clients back off before retrying, unless set with SetRetryAfter.
+const defaultRetryAfter = 5 * time.Second
+
+// apiError is an OpenAI-style error: the HTTP status to respond with and the
+// error type and code that OpenAI clients branch on.
+type apiError struct {
+	status  int
+	errType string
+	code    string
+	message string
+}
+
+// invalidRequest is a 400 for a request the handler rejects itself.
+func invalidRequest(code, message string) apiError {
+	return apiError{http.StatusBadRequest, "invalid_request_error", code, message}
+}
+
+// classifyError maps a failed reasoning engine call to the error returned to
+// the client, so that clients can tell retryable failures (rate limits, an
+// unavailable engine, timeouts) from bad requests. ctx is the call's
+// context, whose deadline is the reasoning timeout.
+func classifyError(ctx context.Context, err error) apiError {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return apiError{http.StatusGatewayTimeout, "timeout_error", "timeout", "Reasoning timed out"}
+	}
+
+	switch status.Code(err) {
+	case codes.DeadlineExceeded:
+		return apiError{http.StatusGatewayTimeout, "timeout_error", "timeout", "Reasoning timed out"}
+	case codes.Unavailable:
+		return apiError{http.StatusServiceUnavailable, "server_error", "service_unavailable", "Reasoning engine unavailable"}
+	case codes.ResourceExhausted:
+		return apiError{http.StatusTooManyRequests, "rate_limit_error", "rate_limit_exceeded", "Rate limit exceeded"}
+	case codes.InvalidArgument:
+		return invalidRequest("invalid_value", status.Convert(err).Message())
+	case codes.OutOfRange:
+		return invalidRequest("context_length_exceeded", status.Convert(err).Message())
+	default:
+		return apiError{http.StatusInternalServerError, "server_error", "internal_error", "Internal server error"}
+	}
+}
+
+// retryable reports whether the client should retry after Retry-After.
+func (e apiError) retryable() bool {
+	return e.status == http.StatusTooManyRequests || e.status == http.StatusServiceUnavailable
+}
+
+// response is the JSON body carrying the error.
+func (e apiError) response() ErrorResponse {
+	return ErrorResponse{Error: ErrorDetail{Message: e.message, Type: e.errType, Code: e.code}}
+}
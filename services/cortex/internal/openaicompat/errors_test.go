@@ -0,0 +1,47 @@
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassifyError(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		err    error
+		status int
+		typ    string
+		code   string
+	}{
+		{"unavailable", context.Background(), fmt.Errorf("opening stream: %w", status.Error(codes.Unavailable, "connection refused")),
+			http.StatusServiceUnavailable, "server_error", "service_unavailable"},
+		{"rate limited", context.Background(), status.Error(codes.ResourceExhausted, "rate limit exceeded"),
+			http.StatusTooManyRequests, "rate_limit_error", "rate_limit_exceeded"},
+		{"engine deadline", context.Background(), status.Error(codes.DeadlineExceeded, "deadline exceeded"),
+			http.StatusGatewayTimeout, "timeout_error", "timeout"},
+		{"reasoning timeout", expired, errors.New("receiving output: context deadline exceeded"),
+			http.StatusGatewayTimeout, "timeout_error", "timeout"},
+		{"too long", context.Background(), status.Error(codes.OutOfRange, "prompt exceeds the context window"),
+			http.StatusBadRequest, "invalid_request_error", "context_length_exceeded"},
+		{"bad argument", context.Background(), status.Error(codes.InvalidArgument, "query is required"),
+			http.StatusBadRequest, "invalid_request_error", "invalid_value"},
+		{"unknown", context.Background(), errors.New("boom"),
+			http.StatusInternalServerError, "server_error", "internal_error"},
+	}
+	for _, tt := range tests {
+		got := classifyError(tt.ctx, tt.err)
+		if got.status != tt.status || got.errType != tt.typ || got.code != tt.code {
+			t.Errorf("%s: got %d %s/%s, want %d %s/%s", tt.name, got.status, got.errType, got.code, tt.status, tt.typ, tt.code)
+		}
+	}
+}
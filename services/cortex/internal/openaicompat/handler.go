@@ -3,38 +3,109 @@ package openaicompat
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/middleware"
 	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Handler serves the OpenAI-compatible HTTP API.
 type Handler struct {
-	logger       *slog.Logger
-	models       []string
-	frontalAddr  string
-	frontalConn  *grpc.ClientConn
+	logger        *slog.Logger
+	staticModels  []string          // configured at startup; always listed
+	modelsMu      sync.RWMutex      // guards models and modelOwners
+	models        []string          // staticModels plus the frontal lobe's, see RefreshModels
+	modelOwners   map[string]string // model -> frontal lobe provider type, reported as owned_by
+	frontalAddr   string
+	frontalConn   *grpc.ClientConn
 	frontalClient agentv1.ReasoningEngineClient
+	creds         credentials.TransportCredentials
+	authToken     string
+	// reasoningTimeout bounds each reasoning engine call; a request can
+	// shorten it with ReasoningTimeoutHeader. 0 disables it.
+	reasoningTimeout time.Duration
+	// heartbeatInterval is how often a streamed completion sends a keep-alive
+	// comment while waiting for the first output; 0 disables heartbeats.
+	heartbeatInterval time.Duration
+	// retryAfter is sent as Retry-After with retryable errors.
+	retryAfter time.Duration
 }
 
+// defaultReasoningTimeout is the reasoning timeout used unless overridden.
+const defaultReasoningTimeout = 5 * time.Minute
+
+// defaultHeartbeatInterval is the streaming keep-alive interval used unless
+// overridden.
+const defaultHeartbeatInterval = 15 * time.Second
+
+// ReasoningTimeoutHeader lets a request shorten the reasoning timeout with
+// a Go duration such as "30s". Longer values are capped at the configured
+// timeout.
+const ReasoningTimeoutHeader = "X-Reasoning-Timeout"
+
 // NewHandler creates a new OpenAI-compatible API handler.
 func NewHandler(logger *slog.Logger, models []string) *Handler {
 	return &Handler{
-		logger: logger,
-		models: models,
+		logger:            logger,
+		staticModels:      models,
+		models:            models,
+		reasoningTimeout:  defaultReasoningTimeout,
+		heartbeatInterval: defaultHeartbeatInterval,
+		retryAfter:        defaultRetryAfter,
 	}
 }
 
+// SetTransportCredentials sets the credentials used to dial the frontal lobe.
+// Connections are plaintext when unset.
+func (h *Handler) SetTransportCredentials(creds credentials.TransportCredentials) {
+	h.creds = creds
+}
+
+// SetAuthToken sets the bearer token sent to the frontal lobe.
+func (h *Handler) SetAuthToken(token string) {
+	h.authToken = token
+}
+
+// SetReasoningTimeout sets how long a chat completion may wait on the
+// reasoning engine before the call is canceled. Zero disables the timeout.
+func (h *Handler) SetReasoningTimeout(d time.Duration) {
+	h.reasoningTimeout = d
+}
+
+// SetHeartbeatInterval sets how often a streamed chat completion sends an
+// SSE keep-alive comment while the reasoning engine has yet to produce
+// output. Zero disables heartbeats.
+func (h *Handler) SetHeartbeatInterval(d time.Duration) {
+	h.heartbeatInterval = d
+}
+
+// SetRetryAfter sets the Retry-After sent with retryable errors, rounded
+// down to whole seconds and at least one second.
+func (h *Handler) SetRetryAfter(d time.Duration) {
+	h.retryAfter = d
+}
+
 // ConnectFrontalLobe sets up the gRPC connection to the frontal lobe.
 func (h *Handler) ConnectFrontalLobe(addr string) error {
+	creds := h.creds
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
 	conn, err := grpc.NewClient(addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(middleware.BearerToken(h.authToken)),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return fmt.Errorf("connecting to frontal lobe: %w", err)
@@ -59,13 +130,17 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 }
 
 func (h *Handler) handleListModels(w http.ResponseWriter, r *http.Request) {
-	models := make([]Model, 0, len(h.models))
-	for _, m := range h.models {
+	h.modelsMu.RLock()
+	ids, owners := h.models, h.modelOwners
+	h.modelsMu.RUnlock()
+
+	models := make([]Model, 0, len(ids))
+	for _, m := range ids {
 		models = append(models, Model{
 			ID:      m,
 			Object:  "model",
 			Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
-			OwnedBy: "secondbrain",
+			OwnedBy: modelOwner(owners, m),
 		})
 	}
 
@@ -81,68 +156,99 @@ func (h *Handler) handleListModels(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
 	var req ChatCompletionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "invalid_request_error", "Invalid JSON: "+err.Error())
+		h.writeError(w, invalidRequest("invalid_json", "Invalid JSON: "+err.Error()))
 		return
 	}
 
 	if len(req.Messages) == 0 {
-		h.writeError(w, http.StatusBadRequest, "invalid_request_error", "messages is required")
+		h.writeError(w, invalidRequest("missing_required_parameter", "messages is required"))
+		return
+	}
+	for _, m := range req.Messages {
+		if m.Role == "tool" && m.ToolCallID == "" {
+			h.writeError(w, invalidRequest("missing_required_parameter", "tool messages require tool_call_id"))
+			return
+		}
+	}
+	if err := req.Stop.validate(); err != nil {
+		h.writeError(w, invalidRequest("invalid_value", err.Error()))
 		return
 	}
 
+	timeout := h.reasoningTimeout
+	if v := r.Header.Get(ReasoningTimeoutHeader); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			h.writeError(w, invalidRequest("invalid_value", "Invalid "+ReasoningTimeoutHeader+" header: "+v))
+			return
+		}
+		// The header can only shorten the configured timeout.
+		if timeout <= 0 || d < timeout {
+			timeout = d
+		}
+	}
+
+	// The reasoning stream is opened with this context, so the timeout
+	// cancels it downstream as well.
+	ctx, cancel := r.Context(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
 	if req.Stream {
-		h.handleStreamingCompletion(w, r, &req)
+		h.handleStreamingCompletion(ctx, w, &req)
 		return
 	}
 
-	h.handleNonStreamingCompletion(w, r, &req)
+	h.handleNonStreamingCompletion(ctx, w, &req)
 }
 
-func (h *Handler) handleNonStreamingCompletion(w http.ResponseWriter, r *http.Request, req *ChatCompletionRequest) {
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
-	defer cancel()
-
+func (h *Handler) handleNonStreamingCompletion(ctx context.Context, w http.ResponseWriter, req *ChatCompletionRequest) {
 	// Build session and query from messages
 	sessionID := req.User
 	if sessionID == "" {
 		sessionID = fmt.Sprintf("openai-compat-%d", time.Now().UnixNano())
 	}
 
-	query, systemPrompt := extractQueryAndSystem(req.Messages)
-
 	// Call the reasoning engine via gRPC streaming
-	response, err := h.callReasoningEngine(ctx, sessionID, query, systemPrompt, req.Model)
+	response, toolCalls, err := h.callReasoningEngine(ctx, sessionID, req.Messages, req.Model, req.Stop)
 	if err != nil {
-		h.logger.Error("reasoning engine call failed", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "server_error", "Internal server error")
+		apiErr := classifyError(ctx, err)
+		h.logger.Warn("reasoning engine call failed", "session_id", sessionID, "code", apiErr.code, "error", err)
+		h.writeError(w, apiErr)
 		return
 	}
 
+	// Providers that support stop sequences already stopped at them; the
+	// response is cut here for those that don't.
+	response, _ = truncateAtStop(response, req.Stop)
+
 	chatResp := NewChatCompletionResponse(
 		fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano()),
 		req.Model,
 		response,
 	)
+	if len(toolCalls) > 0 {
+		chatResp.Choices[0].Message.ToolCalls = toolCalls
+		chatResp.Choices[0].FinishReason = "tool_calls"
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(chatResp)
 }
 
-func (h *Handler) handleStreamingCompletion(w http.ResponseWriter, r *http.Request, req *ChatCompletionRequest) {
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
-	defer cancel()
-
+func (h *Handler) handleStreamingCompletion(ctx context.Context, w http.ResponseWriter, req *ChatCompletionRequest) {
 	sessionID := req.User
 	if sessionID == "" {
 		sessionID = fmt.Sprintf("openai-compat-%d", time.Now().UnixNano())
 	}
 
-	query, systemPrompt := extractQueryAndSystem(req.Messages)
 	completionID := fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano())
 
 	flusher, ok := w.(http.Flusher)
 	if !ok {
-		h.writeError(w, http.StatusInternalServerError, "server_error", "Streaming not supported")
+		h.writeError(w, apiError{http.StatusInternalServerError, "server_error", "streaming_unsupported", "Streaming not supported"})
 		return
 	}
 
@@ -164,92 +270,200 @@ func (h *Handler) handleStreamingCompletion(w http.ResponseWriter, r *http.Reque
 	flusher.Flush()
 
 	// Stream from reasoning engine
-	chunks, err := h.streamReasoningEngine(ctx, sessionID, query, systemPrompt, req.Model)
+	events, err := h.streamReasoningEngine(ctx, sessionID, req.Messages, req.Model, req.Stop)
 	if err != nil {
-		h.logger.Error("streaming reasoning engine failed", "error", err)
+		apiErr := classifyError(ctx, err)
+		h.logger.Warn("streaming reasoning engine failed", "session_id", sessionID, "code", apiErr.code, "error", err)
+		h.writeSSE(w, apiErr.response())
+		flusher.Flush()
 		return
 	}
 
-	for content := range chunks {
-		chunk := NewStreamChunk(completionID, req.Model, content, false)
-		h.writeSSE(w, chunk)
-		flusher.Flush()
+	// Until the reasoning engine produces output, send SSE comments so that
+	// clients and proxies don't close the idle connection.
+	var heartbeats <-chan time.Time
+	if h.heartbeatInterval > 0 {
+		ticker := time.NewTicker(h.heartbeatInterval)
+		defer ticker.Stop()
+		heartbeats = ticker.C
+	}
+
+	// Cut streamed content at stop sequences the provider did not honor.
+	stops := &stopScanner{stops: req.Stop}
+	stopped := false
+	toolCalls := 0
+	for !stopped {
+		var ev streamEvent
+		var ok bool
+		select {
+		case <-heartbeats:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+			continue
+		case ev, ok = <-events:
+		}
+		if !ok {
+			break
+		}
+		heartbeats = nil
+
+		if ev.err != nil {
+			apiErr := classifyError(ctx, ev.err)
+			h.logger.Warn("streaming reasoning engine failed", "session_id", sessionID, "code", apiErr.code, "error", ev.err)
+			h.writeSSE(w, apiErr.response())
+			flusher.Flush()
+			return
+		}
+		if ev.toolCall != nil {
+			h.writeSSE(w, NewToolCallChunk(completionID, req.Model, toolCallFromProto(toolCalls, ev.toolCall)))
+			flusher.Flush()
+			toolCalls++
+			continue
+		}
+		var content string
+		content, stopped = stops.push(ev.content)
+		if content != "" {
+			h.writeSSE(w, NewStreamChunk(completionID, req.Model, content, false))
+			flusher.Flush()
+		}
+	}
+	if ctx.Err() != nil {
+		h.logger.Info("streaming completion canceled", "session_id", sessionID, "error", ctx.Err())
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			h.writeSSE(w, classifyError(ctx, ctx.Err()).response())
+			flusher.Flush()
+		}
+		return
+	}
+
+	if !stopped {
+		if rest := stops.flush(); rest != "" {
+			h.writeSSE(w, NewStreamChunk(completionID, req.Model, rest, false))
+		}
 	}
 
 	// Send final chunk
 	finishChunk := NewStreamChunk(completionID, req.Model, "", true)
+	if toolCalls > 0 {
+		reason := "tool_calls"
+		finishChunk.Choices[0].FinishReason = &reason
+	}
 	h.writeSSE(w, finishChunk)
 	fmt.Fprintf(w, "data: [DONE]\n\n")
 	flusher.Flush()
 }
 
 // openReasoningStream opens a bidirectional gRPC stream to the reasoning
-// engine and sends the initial query. Returns the stream or an echo fallback
-// channel if no reasoning engine is connected.
-func (h *Handler) openReasoningStream(ctx context.Context, sessionID, query, systemPrompt string) (agentv1.ReasoningEngine_StreamThoughtProcessClient, error) {
+// engine and sends the inputs built from the conversation and stop
+// sequences.
+func (h *Handler) openReasoningStream(ctx context.Context, sessionID string, messages []ChatMessage, stop []string) (agentv1.ReasoningEngine_StreamThoughtProcessClient, error) {
 	stream, err := h.frontalClient.StreamThoughtProcess(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("opening stream: %w", err)
 	}
 
-	input := &agentv1.AgentInput{
-		SessionId: sessionID,
-		InputType: &agentv1.AgentInput_UserQuery{UserQuery: query},
-		Context: &agentv1.ContextSnapshot{
-			SystemPrompt: systemPrompt,
-		},
-	}
-
-	if err := stream.Send(input); err != nil {
-		return nil, fmt.Errorf("sending input: %w", err)
+	for _, input := range buildAgentInputs(sessionID, messages, stop) {
+		if err := stream.Send(input); err != nil {
+			return nil, fmt.Errorf("sending input: %w", err)
+		}
 	}
 	stream.CloseSend()
 
 	return stream, nil
 }
 
-func (h *Handler) callReasoningEngine(ctx context.Context, sessionID, query, systemPrompt, model string) (string, error) {
+// buildAgentInputs maps a conversation to reasoning engine inputs. When the
+// conversation ends with tool messages, each becomes a ToolResult answering
+// an earlier tool call; otherwise the last user message is sent as the query.
+func buildAgentInputs(sessionID string, messages []ChatMessage, stop []string) []*agentv1.AgentInput {
+	query, systemPrompt := extractQueryAndSystem(messages)
+	snapshot := &agentv1.ContextSnapshot{SystemPrompt: systemPrompt, StopSequences: stop}
+
+	start := len(messages)
+	for start > 0 && messages[start-1].Role == "tool" {
+		start--
+	}
+	if start == len(messages) {
+		return []*agentv1.AgentInput{{
+			SessionId: sessionID,
+			InputType: &agentv1.AgentInput_UserQuery{UserQuery: query},
+			Context:   snapshot,
+		}}
+	}
+
+	inputs := make([]*agentv1.AgentInput, 0, len(messages)-start)
+	for _, m := range messages[start:] {
+		inputs = append(inputs, &agentv1.AgentInput{
+			SessionId: sessionID,
+			InputType: &agentv1.AgentInput_ToolResult{ToolResult: &agentv1.ToolResult{
+				CallId:        m.ToolCallID,
+				ResultPayload: m.Content,
+			}},
+			Context: snapshot,
+		})
+	}
+	return inputs
+}
+
+func (h *Handler) callReasoningEngine(ctx context.Context, sessionID string, messages []ChatMessage, model string, stop []string) (string, []ToolCall, error) {
 	if h.frontalClient == nil {
-		return fmt.Sprintf("Echo: %s (model: %s, no reasoning engine connected)", query, model), nil
+		query, _ := extractQueryAndSystem(messages)
+		return fmt.Sprintf("Echo: %s (model: %s, no reasoning engine connected)", query, model), nil, nil
 	}
 
-	stream, err := h.openReasoningStream(ctx, sessionID, query, systemPrompt)
+	stream, err := h.openReasoningStream(ctx, sessionID, messages, stop)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	var finalResponse string
+	var toolCalls []ToolCall
 	for {
+		if err := ctx.Err(); err != nil {
+			return "", nil, fmt.Errorf("request canceled: %w", err)
+		}
 		output, err := stream.Recv()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return "", fmt.Errorf("receiving output: %w", err)
+			return "", nil, fmt.Errorf("receiving output: %w", err)
 		}
 		if resp := output.GetFinalResponse(); resp != "" {
 			finalResponse = resp
 		}
+		if call := output.GetToolCall(); call != nil {
+			toolCalls = append(toolCalls, toolCallFromProto(len(toolCalls), call))
+		}
 	}
 
-	if finalResponse == "" {
+	if finalResponse == "" && len(toolCalls) == 0 {
 		finalResponse = "No response generated."
 	}
-	return finalResponse, nil
+	return finalResponse, toolCalls, nil
 }
 
-func (h *Handler) streamReasoningEngine(ctx context.Context, sessionID, query, systemPrompt, model string) (<-chan string, error) {
-	ch := make(chan string, 10)
+// streamEvent is one piece of a streamed completion: text content, a tool
+// call, or the error that ended the stream.
+type streamEvent struct {
+	content  string
+	toolCall *agentv1.ToolCall
+	err      error
+}
+
+func (h *Handler) streamReasoningEngine(ctx context.Context, sessionID string, messages []ChatMessage, model string, stop []string) (<-chan streamEvent, error) {
+	ch := make(chan streamEvent, 10)
 
 	if h.frontalClient == nil {
+		query, _ := extractQueryAndSystem(messages)
 		go func() {
 			defer close(ch)
-			ch <- fmt.Sprintf("Echo: %s (model: %s, no reasoning engine connected)", query, model)
+			ch <- streamEvent{content: fmt.Sprintf("Echo: %s (model: %s, no reasoning engine connected)", query, model)}
 		}()
 		return ch, nil
 	}
 
-	stream, err := h.openReasoningStream(ctx, sessionID, query, systemPrompt)
+	stream, err := h.openReasoningStream(ctx, sessionID, messages, stop)
 	if err != nil {
 		close(ch)
 		return nil, err
@@ -263,14 +477,23 @@ func (h *Handler) streamReasoningEngine(ctx context.Context, sessionID, query, s
 				return
 			}
 			if err != nil {
-				h.logger.Error("stream recv error", "error", err)
+				sendChunk(ctx, ch, streamEvent{err: err})
 				return
 			}
 			if thought := output.GetThoughtChain(); thought != "" {
-				ch <- thought + "\n"
+				if !sendChunk(ctx, ch, streamEvent{content: thought + "\n"}) {
+					return
+				}
+			}
+			if call := output.GetToolCall(); call != nil {
+				if !sendChunk(ctx, ch, streamEvent{toolCall: call}) {
+					return
+				}
 			}
 			if resp := output.GetFinalResponse(); resp != "" {
-				ch <- resp
+				if !sendChunk(ctx, ch, streamEvent{content: resp}) {
+					return
+				}
 			}
 		}
 	}()
@@ -278,6 +501,38 @@ func (h *Handler) streamReasoningEngine(ctx context.Context, sessionID, query, s
 	return ch, nil
 }
 
+// toolCallFromProto converts a reasoning engine tool call to the OpenAI
+// format, encoding its arguments as a JSON object string.
+func toolCallFromProto(index int, call *agentv1.ToolCall) ToolCall {
+	args := []byte("{}")
+	if call.GetArguments() != nil {
+		if b, err := json.Marshal(call.GetArguments().AsMap()); err == nil {
+			args = b
+		}
+	}
+	return ToolCall{
+		Index: index,
+		ID:    call.GetCallId(),
+		Type:  "function",
+		Function: ToolCallFunction{
+			Name:      call.GetToolName(),
+			Arguments: string(args),
+		},
+		RequiresConfirmation: call.GetRequiresConfirmation(),
+	}
+}
+
+// sendChunk delivers ev to ch unless ctx is canceled first, in which case it
+// reports false so the producer can stop reading from the reasoning engine.
+func sendChunk(ctx context.Context, ch chan<- streamEvent, ev streamEvent) bool {
+	select {
+	case ch <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func (h *Handler) writeSSE(w http.ResponseWriter, data interface{}) {
 	jsonBytes, err := json.Marshal(data)
 	if err != nil {
@@ -286,16 +541,13 @@ func (h *Handler) writeSSE(w http.ResponseWriter, data interface{}) {
 	fmt.Fprintf(w, "data: %s\n\n", jsonBytes)
 }
 
-func (h *Handler) writeError(w http.ResponseWriter, status int, errType, message string) {
+func (h *Handler) writeError(w http.ResponseWriter, e apiError) {
+	if e.retryable() {
+		w.Header().Set("Retry-After", strconv.Itoa(int(max(h.retryAfter, time.Second).Seconds())))
+	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(ErrorResponse{
-		Error: ErrorDetail{
-			Message: message,
-			Type:    errType,
-			Code:    fmt.Sprintf("%d", status),
-		},
-	})
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(e.response())
 }
 
 // extractQueryAndSystem extracts the last user message as the query and the
@@ -5,10 +5,20 @@ import (
 	"encoding/json"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestHandleListModels(t *testing.T) {
@@ -175,6 +185,9 @@ func TestHandleChatCompletionsEmptyMessages(t *testing.T) {
 	if errResp.Error.Type != "invalid_request_error" {
 		t.Errorf("expected error type 'invalid_request_error', got %q", errResp.Error.Type)
 	}
+	if errResp.Error.Code != "missing_required_parameter" {
+		t.Errorf("expected code 'missing_required_parameter', got %q", errResp.Error.Code)
+	}
 }
 
 func TestHandleChatCompletionsInvalidJSON(t *testing.T) {
@@ -244,3 +257,551 @@ func TestNewStreamChunk(t *testing.T) {
 		t.Error("expected finish_reason 'stop' for final chunk")
 	}
 }
+
+// slowEngine is a frontal lobe whose StreamThoughtProcess never replies. It
+// closes canceled once the caller cancels the stream.
+type slowEngine struct {
+	agentv1.UnimplementedReasoningEngineServer
+	canceled chan struct{}
+}
+
+func (e slowEngine) StreamThoughtProcess(stream grpc.BidiStreamingServer[agentv1.AgentInput, agentv1.AgentOutput]) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	close(e.canceled)
+	return stream.Context().Err()
+}
+
+// newSlowFrontalHandler returns a handler connected to a slowEngine served
+// on a local port.
+func newSlowFrontalHandler(t *testing.T) (*Handler, slowEngine) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	engine := slowEngine{canceled: make(chan struct{})}
+	srv := grpc.NewServer()
+	agentv1.RegisterReasoningEngineServer(srv, engine)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	if err := handler.ConnectFrontalLobe(lis.Addr().String()); err != nil {
+		t.Fatalf("ConnectFrontalLobe: %v", err)
+	}
+	t.Cleanup(handler.Close)
+	return handler, engine
+}
+
+// newUnavailableFrontalHandler returns a handler pointed at a port nothing
+// listens on.
+func newUnavailableFrontalHandler(t *testing.T) *Handler {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	if err := handler.ConnectFrontalLobe(addr); err != nil {
+		t.Fatalf("ConnectFrontalLobe: %v", err)
+	}
+	t.Cleanup(handler.Close)
+	return handler
+}
+
+func TestUnavailableFrontalLobeReturns503(t *testing.T) {
+	handler := newUnavailableFrontalHandler(t)
+
+	w := postChatCompletion(handler, nil)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.Error.Type != "server_error" || resp.Error.Code != "service_unavailable" {
+		t.Errorf("expected server_error/service_unavailable, got %s/%s", resp.Error.Type, resp.Error.Code)
+	}
+}
+
+// unavailableEngine is a frontal lobe that reports itself unavailable, as
+// when it is shutting down or overloaded.
+type unavailableEngine struct {
+	agentv1.UnimplementedReasoningEngineServer
+}
+
+func (unavailableEngine) StreamThoughtProcess(stream grpc.BidiStreamingServer[agentv1.AgentInput, agentv1.AgentOutput]) error {
+	return status.Error(codes.Unavailable, "frontal lobe is shutting down")
+}
+
+func TestFrontalLobeUnavailableStatusReturns503(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	agentv1.RegisterReasoningEngineServer(srv, unavailableEngine{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	handler.SetRetryAfter(12 * time.Second)
+	if err := handler.ConnectFrontalLobe(lis.Addr().String()); err != nil {
+		t.Fatalf("ConnectFrontalLobe: %v", err)
+	}
+	t.Cleanup(handler.Close)
+
+	w := postChatCompletion(handler, nil)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Retry-After"); got != "12" {
+		t.Errorf("expected the configured Retry-After 12 on 503, got %q", got)
+	}
+	var resp ErrorResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Error.Type != "server_error" || resp.Error.Code != "service_unavailable" {
+		t.Errorf("expected server_error/service_unavailable, got %s/%s", resp.Error.Type, resp.Error.Code)
+	}
+}
+
+func TestUnavailableFrontalLobeStreamsError(t *testing.T) {
+	handler := newUnavailableFrontalHandler(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(ChatCompletionRequest{
+		Model:    "mock",
+		Stream:   true,
+		Messages: []ChatMessage{{Role: "user", Content: "Hello"}},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if !strings.Contains(w.Body.String(), `"code":"service_unavailable"`) {
+		t.Errorf("expected a service_unavailable error event, got:\n%s", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "[DONE]") {
+		t.Error("expected the failed stream not to end with [DONE]")
+	}
+}
+
+func postChatCompletion(handler *Handler, header http.Header) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(ChatCompletionRequest{
+		Model:    "mock",
+		Messages: []ChatMessage{{Role: "user", Content: "Think slowly"}},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestReasoningTimeoutCutsOffFrontalLobe(t *testing.T) {
+	handler, engine := newSlowFrontalHandler(t)
+	handler.SetReasoningTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	w := postChatCompletion(handler, nil)
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d: %s", w.Code, w.Body.String())
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the call to be cut off near the 50ms timeout, took %v", elapsed)
+	}
+	var resp ErrorResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Error.Type != "timeout_error" {
+		t.Errorf("expected timeout_error, got %q", resp.Error.Type)
+	}
+
+	select {
+	case <-engine.canceled:
+	case <-time.After(2 * time.Second):
+		t.Error("expected the frontal lobe stream to be canceled")
+	}
+}
+
+func TestReasoningTimeoutHeaderOverride(t *testing.T) {
+	handler, _ := newSlowFrontalHandler(t)
+
+	w := postChatCompletion(handler, http.Header{ReasoningTimeoutHeader: {"50ms"}})
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504 with a 50ms override, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = postChatCompletion(handler, http.Header{ReasoningTimeoutHeader: {"soon"}})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid override, got %d", w.Code)
+	}
+}
+
+func TestReasoningTimeoutHeaderCappedAtConfigured(t *testing.T) {
+	handler, _ := newSlowFrontalHandler(t)
+	handler.SetReasoningTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	w := postChatCompletion(handler, http.Header{ReasoningTimeoutHeader: {"1h"}})
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504 with the configured 50ms timeout, got %d: %s", w.Code, w.Body.String())
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected a 1h header to be capped at 50ms, took %v", elapsed)
+	}
+}
+
+// delayedEngine is a frontal lobe that reasons for delay before its first
+// output, then pauses for delay again before the final response.
+type delayedEngine struct {
+	agentv1.UnimplementedReasoningEngineServer
+	delay time.Duration
+}
+
+func (e delayedEngine) StreamThoughtProcess(stream grpc.BidiStreamingServer[agentv1.AgentInput, agentv1.AgentOutput]) error {
+	input, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	time.Sleep(e.delay)
+	if err := stream.Send(&agentv1.AgentOutput{
+		SessionId:  input.GetSessionId(),
+		OutputType: &agentv1.AgentOutput_ThoughtChain{ThoughtChain: "Thinking it over"},
+	}); err != nil {
+		return err
+	}
+	time.Sleep(e.delay)
+	return stream.Send(&agentv1.AgentOutput{
+		SessionId:  input.GetSessionId(),
+		OutputType: &agentv1.AgentOutput_FinalResponse{FinalResponse: "Done."},
+	})
+}
+
+func TestStreamingHeartbeatsUntilFirstContent(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	agentv1.RegisterReasoningEngineServer(srv, delayedEngine{delay: 200 * time.Millisecond})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	handler.SetHeartbeatInterval(20 * time.Millisecond)
+	if err := handler.ConnectFrontalLobe(lis.Addr().String()); err != nil {
+		t.Fatalf("ConnectFrontalLobe: %v", err)
+	}
+	t.Cleanup(handler.Close)
+
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	body, _ := json.Marshal(ChatCompletionRequest{
+		Model:    "mock",
+		Stream:   true,
+		Messages: []ChatMessage{{Role: "user", Content: "Think slowly"}},
+	})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body)))
+
+	out := w.Body.String()
+	first := strings.Index(out, "Thinking it over")
+	if first < 0 || !strings.Contains(out, "Done.") {
+		t.Fatalf("expected the streamed content, got:\n%s", out)
+	}
+	if !strings.Contains(out[:first], ": keep-alive\n\n") {
+		t.Errorf("expected heartbeats before the first content, got:\n%s", out[:first])
+	}
+	if strings.Contains(out[first:], "keep-alive") {
+		t.Errorf("expected heartbeats to stop once content streams, got:\n%s", out[first:])
+	}
+}
+
+// toolEngine is a frontal lobe that answers a query with a tool call and a
+// tool result with a final response. It records the inputs it receives.
+type toolEngine struct {
+	agentv1.UnimplementedReasoningEngineServer
+	inputs chan *agentv1.AgentInput
+}
+
+func (e toolEngine) StreamThoughtProcess(stream grpc.BidiStreamingServer[agentv1.AgentInput, agentv1.AgentOutput]) error {
+	for {
+		input, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		e.inputs <- input
+
+		out := &agentv1.AgentOutput{SessionId: input.GetSessionId()}
+		if input.GetToolResult() != nil {
+			out.OutputType = &agentv1.AgentOutput_FinalResponse{FinalResponse: "Task created."}
+		} else {
+			args, _ := structpb.NewStruct(map[string]any{"title": "Buy milk"})
+			out.OutputType = &agentv1.AgentOutput_ToolCall{ToolCall: &agentv1.ToolCall{
+				ToolName:             "create_task",
+				CallId:               "call-1",
+				Arguments:            args,
+				RequiresConfirmation: true,
+			}}
+		}
+		if err := stream.Send(out); err != nil {
+			return err
+		}
+	}
+}
+
+// newToolFrontalHandler returns a handler connected to a toolEngine served on
+// a local port.
+func newToolFrontalHandler(t *testing.T) (*Handler, toolEngine) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	engine := toolEngine{inputs: make(chan *agentv1.AgentInput, 10)}
+	srv := grpc.NewServer()
+	agentv1.RegisterReasoningEngineServer(srv, engine)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	if err := handler.ConnectFrontalLobe(lis.Addr().String()); err != nil {
+		t.Fatalf("ConnectFrontalLobe: %v", err)
+	}
+	t.Cleanup(handler.Close)
+	return handler, engine
+}
+
+func TestStreamingRelaysToolCalls(t *testing.T) {
+	handler, _ := newToolFrontalHandler(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(ChatCompletionRequest{
+		Model:    "mock",
+		Messages: []ChatMessage{{Role: "user", Content: "Remind me to buy milk"}},
+		Stream:   true,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	var calls []ToolCall
+	var finish string
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+		var chunk ChatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatalf("decoding chunk %q: %v", data, err)
+		}
+		calls = append(calls, chunk.Choices[0].Delta.ToolCalls...)
+		if reason := chunk.Choices[0].FinishReason; reason != nil {
+			finish = *reason
+		}
+	}
+
+	if len(calls) != 1 {
+		t.Fatalf("expected one tool_calls delta, got %d in:\n%s", len(calls), w.Body.String())
+	}
+	call := calls[0]
+	if call.ID != "call-1" || call.Type != "function" || call.Function.Name != "create_task" || !call.RequiresConfirmation {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+	if call.Function.Arguments != `{"title":"Buy milk"}` {
+		t.Errorf("unexpected arguments: %s", call.Function.Arguments)
+	}
+	if finish != "tool_calls" {
+		t.Errorf("expected finish_reason tool_calls, got %q", finish)
+	}
+}
+
+func TestToolMessageMapsToToolResult(t *testing.T) {
+	handler, engine := newToolFrontalHandler(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(ChatCompletionRequest{
+		Model: "mock",
+		User:  "sess-tools",
+		Messages: []ChatMessage{
+			{Role: "user", Content: "Remind me to buy milk"},
+			{Role: "assistant", ToolCalls: []ToolCall{{ID: "call-1", Type: "function", Function: ToolCallFunction{Name: "create_task"}}}},
+			{Role: "tool", ToolCallID: "call-1", Content: `{"task_id": "t-42"}`},
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ChatCompletionResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.Choices[0].Message.Content != "Task created." {
+		t.Errorf("unexpected response: %q", resp.Choices[0].Message.Content)
+	}
+
+	input := <-engine.inputs
+	result := input.GetToolResult()
+	if result == nil || result.GetCallId() != "call-1" || result.GetResultPayload() != `{"task_id": "t-42"}` {
+		t.Errorf("expected a ToolResult for call-1, got %v", input)
+	}
+	if input.GetSessionId() != "sess-tools" {
+		t.Errorf("expected session sess-tools, got %q", input.GetSessionId())
+	}
+}
+
+func TestToolMessageRequiresToolCallID(t *testing.T) {
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(ChatCompletionRequest{
+		Model:    "mock",
+		Messages: []ChatMessage{{Role: "tool", Content: "done"}},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestNonStreamingTruncatesAtStopSequence(t *testing.T) {
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body := `{"model":"mock","stop":" (model","messages":[{"role":"user","content":"Hello!"}]}`
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp ChatCompletionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := resp.Choices[0].Message.Content; got != "Echo: Hello!" {
+		t.Errorf("expected content cut at the stop sequence, got %q", got)
+	}
+	if resp.Choices[0].FinishReason != "stop" {
+		t.Errorf("expected finish_reason stop, got %q", resp.Choices[0].FinishReason)
+	}
+}
+
+func TestStopSequencesSentToFrontalLobe(t *testing.T) {
+	handler, engine := newToolFrontalHandler(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body := `{"model":"mock","stop":["\n\n","END"],"messages":[{"role":"user","content":"Remind me to buy milk"}]}`
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	input := <-engine.inputs
+	if got := input.GetContext().GetStopSequences(); !slices.Equal(got, []string{"\n\n", "END"}) {
+		t.Errorf("expected stop sequences in the context snapshot, got %q", got)
+	}
+}
+
+func TestStreamingTruncatesAtStopSequence(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	agentv1.RegisterReasoningEngineServer(srv, delayedEngine{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	if err := handler.ConnectFrontalLobe(lis.Addr().String()); err != nil {
+		t.Fatalf("ConnectFrontalLobe: %v", err)
+	}
+	t.Cleanup(handler.Close)
+
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	// The stop sequence straddles the thought chain and the final response.
+	body, _ := json.Marshal(ChatCompletionRequest{
+		Model:    "mock",
+		Stream:   true,
+		Stop:     StopSequences{"over\nDo"},
+		Messages: []ChatMessage{{Role: "user", Content: "Think"}},
+	})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body)))
+
+	var content strings.Builder
+	var finish string
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+		var chunk ChatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatalf("decode chunk %q: %v", data, err)
+		}
+		content.WriteString(chunk.Choices[0].Delta.Content)
+		if r := chunk.Choices[0].FinishReason; r != nil {
+			finish = *r
+		}
+	}
+	if got := content.String(); got != "Thinking it " {
+		t.Errorf("expected streamed content cut at the stop sequence, got %q", got)
+	}
+	if finish != "stop" {
+		t.Errorf("expected finish_reason stop, got %q", finish)
+	}
+	if !strings.Contains(w.Body.String(), "data: [DONE]") {
+		t.Error("expected [DONE] marker after the stop sequence")
+	}
+}
+
+func TestInvalidStopSequencesRejected(t *testing.T) {
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"mock"})
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	for _, stop := range []string{`["a","b","c","d","e"]`, `[""]`, `42`} {
+		body := `{"model":"mock","stop":` + stop + `,"messages":[{"role":"user","content":"Hi"}]}`
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("stop %s: expected 400, got %d", stop, w.Code)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+// modelsTimeout bounds each ListModels call to the frontal lobe.
+const modelsTimeout = 5 * time.Second
+
+// RefreshModels asks the frontal lobe which models it routes and lists them
+// on /v1/models after the models the handler was created with, owned by the
+// type of provider serving them. On error the previous list is kept.
+func (h *Handler) RefreshModels(ctx context.Context) error {
+	if h.frontalClient == nil {
+		return errors.New("frontal lobe not connected")
+	}
+	ctx, cancel := context.WithTimeout(ctx, modelsTimeout)
+	defer cancel()
+
+	resp, err := h.frontalClient.ListModels(ctx, &agentv1.ListModelsRequest{})
+	if err != nil {
+		return err
+	}
+	models := mergeModels(h.staticModels, resp.GetModels())
+	owners := make(map[string]string, len(resp.GetDetails()))
+	for _, d := range resp.GetDetails() {
+		if d.GetProvider() != "" {
+			owners[d.GetName()] = d.GetProvider()
+		}
+	}
+
+	h.modelsMu.Lock()
+	h.models, h.modelOwners = models, owners
+	h.modelsMu.Unlock()
+	return nil
+}
+
+// StartModelRefresher refreshes the model list right away and then every
+// interval in a background goroutine, logging failures. It does nothing when
+// the interval is 0. The returned function stops the refresher.
+func (h *Handler) StartModelRefresher(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := h.RefreshModels(context.Background()); err != nil {
+				h.logger.Warn("failed to refresh models from frontal lobe", "error", err)
+			}
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// modelOwner returns the owned_by of a listed model: the provider type the
+// frontal lobe reported for it, or "secondbrain".
+func modelOwner(owners map[string]string, model string) string {
+	if owner, ok := owners[model]; ok {
+		return owner
+	}
+	return "secondbrain"
+}
+
+// mergeModels returns static followed by the live models it does not
+// already contain.
+func mergeModels(static, live []string) []string {
+	models := make([]string, 0, len(static)+len(live))
+	seen := make(map[string]bool, len(static)+len(live))
+	for _, m := range append(static[:len(static):len(static)], live...) {
+		if m != "" && !seen[m] {
+			seen[m] = true
+			models = append(models, m)
+		}
+	}
+	return models
+}
@@ -0,0 +1,163 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+// modelsEngine reports the models registered with its router.
+type modelsEngine struct {
+	agentv1.UnimplementedReasoningEngineServer
+	mu        sync.Mutex
+	models    []string
+	providers map[string]string
+}
+
+func (e *modelsEngine) ListModels(ctx context.Context, req *agentv1.ListModelsRequest) (*agentv1.ListModelsResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	resp := &agentv1.ListModelsResponse{Models: e.models}
+	for _, m := range e.models {
+		resp.Details = append(resp.Details, &agentv1.ModelInfo{Name: m, Provider: e.providers[m]})
+	}
+	return resp, nil
+}
+
+func (e *modelsEngine) register(model string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.models = append(e.models, model)
+}
+
+func newModelsHandler(t *testing.T, engine *modelsEngine) *Handler {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	agentv1.RegisterReasoningEngineServer(srv, engine)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	handler := NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), []string{"secondbrain", "mock"})
+	if err := handler.ConnectFrontalLobe(lis.Addr().String()); err != nil {
+		t.Fatalf("ConnectFrontalLobe: %v", err)
+	}
+	t.Cleanup(handler.Close)
+	return handler
+}
+
+func listModels(t *testing.T, handler *Handler) []Model {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+
+	var resp ModelList
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	return resp.Data
+}
+
+func listModelIDs(t *testing.T, handler *Handler) []string {
+	t.Helper()
+
+	models := listModels(t, handler)
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func TestRefreshModelsSurfacesRouterModels(t *testing.T) {
+	engine := &modelsEngine{models: []string{"gemini-pro", "gpt-4o", "mock"}}
+	handler := newModelsHandler(t, engine)
+
+	if got := listModelIDs(t, handler); len(got) != 2 {
+		t.Fatalf("expected only the static models before a refresh, got %v", got)
+	}
+	if err := handler.RefreshModels(context.Background()); err != nil {
+		t.Fatalf("RefreshModels: %v", err)
+	}
+
+	got := listModelIDs(t, handler)
+	want := []string{"secondbrain", "mock", "gemini-pro", "gpt-4o"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestRefreshModelsReportsProviderAsOwner(t *testing.T) {
+	engine := &modelsEngine{
+		models:    []string{"gemini-pro", "gpt-4o", "mock"},
+		providers: map[string]string{"gemini-pro": "google", "gpt-4o": "openai", "mock": "mock"},
+	}
+	handler := newModelsHandler(t, engine)
+	if err := handler.RefreshModels(context.Background()); err != nil {
+		t.Fatalf("RefreshModels: %v", err)
+	}
+
+	want := map[string]string{"secondbrain": "secondbrain", "mock": "mock", "gemini-pro": "google", "gpt-4o": "openai"}
+	for _, m := range listModels(t, handler) {
+		if m.OwnedBy != want[m.ID] {
+			t.Errorf("model %q: expected owned_by %q, got %q", m.ID, want[m.ID], m.OwnedBy)
+		}
+	}
+}
+
+func TestModelRefresherPicksUpNewModels(t *testing.T) {
+	engine := &modelsEngine{}
+	handler := newModelsHandler(t, engine)
+
+	stop := handler.StartModelRefresher(10 * time.Millisecond)
+	defer stop()
+
+	engine.register("claude-3")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got := listModelIDs(t, handler)
+		if len(got) == 3 && got[2] == "claude-3" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the newly registered model to be listed, got %v", got)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stop()
+	stop() // stopping twice is safe
+}
+
+func TestRefreshModelsKeepsListOnError(t *testing.T) {
+	handler := newUnavailableFrontalHandler(t)
+	if err := handler.RefreshModels(context.Background()); err == nil {
+		t.Fatal("expected an error from an unavailable frontal lobe")
+	}
+	if got := listModelIDs(t, handler); len(got) != 1 || got[0] != "mock" {
+		t.Errorf("expected the static models to be kept, got %v", got)
+	}
+}
@@ -0,0 +1,99 @@
+package openaicompat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxStopSequences is the most stop sequences OpenAI accepts per request.
+const maxStopSequences = 4
+
+// StopSequences is the request's stop parameter, which OpenAI accepts as
+// either a single string or an array of up to four strings.
+type StopSequences []string
+
+// UnmarshalJSON accepts a string, an array of strings, or null.
+func (s *StopSequences) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = nil
+		return nil
+	}
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*s = StopSequences{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("stop must be a string or an array of strings")
+	}
+	*s = many
+	return nil
+}
+
+// validate rejects stop parameters OpenAI would reject.
+func (s StopSequences) validate() error {
+	if len(s) > maxStopSequences {
+		return fmt.Errorf("stop accepts at most %d sequences, got %d", maxStopSequences, len(s))
+	}
+	for _, seq := range s {
+		if seq == "" {
+			return fmt.Errorf("stop sequences must not be empty")
+		}
+	}
+	return nil
+}
+
+// truncateAtStop cuts text before the earliest occurrence of any stop
+// sequence, reporting whether one was found.
+func truncateAtStop(text string, stops []string) (string, bool) {
+	cut := -1
+	for _, seq := range stops {
+		if i := strings.Index(text, seq); i >= 0 && (cut < 0 || i < cut) {
+			cut = i
+		}
+	}
+	if cut < 0 {
+		return text, false
+	}
+	return text[:cut], true
+}
+
+// stopScanner applies stop sequences to streamed content. A stop sequence
+// may straddle two chunks, so any trailing text that could begin one is held
+// back until the next chunk settles it.
+type stopScanner struct {
+	stops   []string
+	pending string
+}
+
+// push appends a chunk and returns the text that is safe to emit. Once a
+// stop sequence is found it returns the text before it and true; the caller
+// must not push again.
+func (s *stopScanner) push(chunk string) (string, bool) {
+	text := s.pending + chunk
+	s.pending = ""
+	if out, stopped := truncateAtStop(text, s.stops); stopped {
+		return out, true
+	}
+	hold := 0
+	for _, seq := range s.stops {
+		for n := len(seq) - 1; n > hold; n-- {
+			if strings.HasSuffix(text, seq[:n]) {
+				hold = n
+				break
+			}
+		}
+	}
+	s.pending = text[len(text)-hold:]
+	return text[:len(text)-hold], false
+}
+
+// flush returns the text still held back when the stream ends without
+// reaching a stop sequence.
+func (s *stopScanner) flush() string {
+	out := s.pending
+	s.pending = ""
+	return out
+}
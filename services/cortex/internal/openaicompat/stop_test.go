@@ -0,0 +1,71 @@
+package openaicompat
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStopSequencesUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{`{"stop":"END"}`, []string{"END"}},
+		{`{"stop":["a","b"]}`, []string{"a", "b"}},
+		{`{"stop":null}`, nil},
+		{`{}`, nil},
+	}
+	for _, tt := range tests {
+		var req ChatCompletionRequest
+		if err := json.Unmarshal([]byte(tt.in), &req); err != nil {
+			t.Fatalf("%s: %v", tt.in, err)
+		}
+		if len(req.Stop) != len(tt.want) {
+			t.Fatalf("%s: got %q, want %q", tt.in, req.Stop, tt.want)
+		}
+		for i := range tt.want {
+			if req.Stop[i] != tt.want[i] {
+				t.Errorf("%s: got %q, want %q", tt.in, req.Stop, tt.want)
+			}
+		}
+	}
+}
+
+func TestTruncateAtStopPicksEarliest(t *testing.T) {
+	got, stopped := truncateAtStop("one two three", []string{"three", "two"})
+	if !stopped || got != "one " {
+		t.Errorf("got %q, %v; want %q, true", got, stopped, "one ")
+	}
+	got, stopped = truncateAtStop("one two", []string{"four"})
+	if stopped || got != "one two" {
+		t.Errorf("got %q, %v; want the text unchanged", got, stopped)
+	}
+}
+
+func TestStopScannerAcrossChunks(t *testing.T) {
+	s := &stopScanner{stops: []string{"STOP"}}
+	var out string
+	for _, chunk := range []string{"hello S", "T", "ill going"} {
+		text, stopped := s.push(chunk)
+		out += text
+		if stopped {
+			t.Fatalf("unexpected stop after %q", chunk)
+		}
+	}
+	if out += s.flush(); out != "hello STill going" {
+		t.Errorf("expected held-back text to be released, got %q", out)
+	}
+
+	s = &stopScanner{stops: []string{"STOP"}}
+	out = ""
+	for _, chunk := range []string{"hello ST", "OP and more"} {
+		text, stopped := s.push(chunk)
+		out += text
+		if stopped {
+			break
+		}
+	}
+	if out != "hello " {
+		t.Errorf("expected output cut before the split stop sequence, got %q", out)
+	}
+}
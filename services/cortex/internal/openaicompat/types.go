@@ -11,13 +11,33 @@ type ChatCompletionRequest struct {
 	Temperature *float64        `json:"temperature,omitempty"`
 	MaxTokens   *int            `json:"max_tokens,omitempty"`
 	Stream      bool            `json:"stream,omitempty"`
+	Stop        StopSequences   `json:"stop,omitempty"`
 	User        string          `json:"user,omitempty"`
 }
 
 // ChatMessage represents a single message in the conversation.
 type ChatMessage struct {
-	Role    string `json:"role"`    // "system", "user", "assistant"
-	Content string `json:"content"`
+	Role       string     `json:"role"` // "system", "user", "assistant", "tool"
+	Content    string     `json:"content"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`   // tools the assistant asked to call
+	ToolCallID string     `json:"tool_call_id,omitempty"` // for "tool" messages, the call being answered
+}
+
+// ToolCall is a tool invocation requested by the assistant, in OpenAI's
+// tool_calls format. RequiresConfirmation is a SecondBrain extension telling
+// the client to ask the user before running the tool.
+type ToolCall struct {
+	Index                int              `json:"index"`
+	ID                   string           `json:"id"`
+	Type                 string           `json:"type"` // always "function"
+	Function             ToolCallFunction `json:"function"`
+	RequiresConfirmation bool             `json:"requires_confirmation,omitempty"`
+}
+
+// ToolCallFunction names the function to call and its arguments.
+type ToolCallFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"` // JSON-encoded object
 }
 
 // ChatCompletionResponse mirrors the OpenAI chat completion response.
@@ -62,8 +82,9 @@ type ChatChunkChoice struct {
 
 // ChatDelta is the incremental message content in a stream chunk.
 type ChatDelta struct {
-	Role    string `json:"role,omitempty"`
-	Content string `json:"content,omitempty"`
+	Role      string     `json:"role,omitempty"`
+	Content   string     `json:"content,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
 }
 
 // Model represents a model in the /v1/models response.
@@ -131,3 +152,16 @@ func NewStreamChunk(id, model, content string, finish bool) *ChatCompletionChunk
 		Choices: []ChatChunkChoice{choice},
 	}
 }
+
+// NewToolCallChunk builds a streaming chunk carrying a single tool call.
+func NewToolCallChunk(id, model string, call ToolCall) *ChatCompletionChunk {
+	return &ChatCompletionChunk{
+		ID:      id,
+		Object:  "chat.completion.chunk",
+		Created: time.Now().Unix(),
+		Model:   model,
+		Choices: []ChatChunkChoice{
+			{Index: 0, Delta: ChatDelta{ToolCalls: []ToolCall{call}}},
+		},
+	}
+}
@@ -0,0 +1,276 @@
+// Package search exposes Hippocampus memory search and document retrieval
+// over plain HTTP for clients that speak neither gRPC nor MCP.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	memoryv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/memory/v1"
+)
+
+const (
+	defaultLimit = 5
+	maxLimit     = 100
+)
+
+// Handler serves search requests against the memory service.
+type Handler struct {
+	logger       *slog.Logger
+	memoryClient memoryv1.MemoryServiceClient
+}
+
+// NewHandler creates a search handler. memoryClient may be nil, in which
+// case searches fail with 503.
+func NewHandler(logger *slog.Logger, memoryClient memoryv1.MemoryServiceClient) *Handler {
+	return &Handler{logger: logger, memoryClient: memoryClient}
+}
+
+// RegisterRoutes exposes search over HTTP:
+//
+//	GET /v1/search?q=...                     hybrid search
+//	GET /v1/search?q=...&mode=semantic       mode is semantic, fts or hybrid
+//	GET /v1/search?q=...&limit=5&min_score=0.3
+//	GET /v1/search?q=...&filter=source:email  filter is key:value, repeatable
+//	GET /v1/search?filter=type:research      no q lists matches, newest first
+//	GET /v1/search?filter=...&page_token=... next page of a listing
+//	GET /v1/documents/{id}                   full document by ID
+//	GET /v1/documents/{id}?collection=notes
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/search", h.handleSearch)
+	mux.HandleFunc("GET /v1/documents/{id}", h.handleGetDocument)
+}
+
+// Response is the JSON body returned by GET /v1/search.
+type Response struct {
+	Query        string   `json:"query"`
+	Mode         string   `json:"mode"`
+	Results      []Result `json:"results"`
+	TotalMatches int32    `json:"total_matches"` // matches before truncation to limit
+	Truncated    bool     `json:"truncated"`
+	// NextPageToken fetches the next page of a filter-only listing.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// Result is one matching chunk.
+type Result struct {
+	ChunkID    string            `json:"chunk_id"`
+	DocumentID string            `json:"document_id"`
+	Content    string            `json:"content"`
+	Score      float32           `json:"score"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	IndexedAt  *time.Time        `json:"indexed_at,omitempty"` // set on filter-only listings
+}
+
+func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	filters, err := parseFilters(params["filter"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := params.Get("q")
+	if query == "" && len(filters) == 0 {
+		writeJSONError(w, http.StatusBadRequest, "q or filter is required")
+		return
+	}
+
+	mode := params.Get("mode")
+	if mode == "" {
+		mode = "hybrid"
+	}
+
+	limit := defaultLimit
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxLimit {
+			writeJSONError(w, http.StatusBadRequest, "invalid limit: must be an integer from 1 to 100")
+			return
+		}
+		limit = n
+	}
+
+	if query == "" {
+		h.listByFilter(w, r, filters, limit)
+		return
+	}
+
+	var minScore float64
+	if v := params.Get("min_score"); v != "" {
+		f, err := strconv.ParseFloat(v, 32)
+		if err != nil || f < 0 {
+			writeJSONError(w, http.StatusBadRequest, "invalid min_score: must be a non-negative number")
+			return
+		}
+		minScore = f
+	}
+
+	if h.memoryClient == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "memory service not connected")
+		return
+	}
+
+	req := &memoryv1.SearchRequest{
+		Query:    query,
+		TopK:     int32(limit),
+		Filters:  filters,
+		MinScore: float32(minScore),
+	}
+
+	var resp *memoryv1.SearchResponse
+	switch mode {
+	case "semantic":
+		resp, err = h.memoryClient.SemanticSearch(r.Context(), req)
+	case "fts":
+		resp, err = h.memoryClient.FullTextSearch(r.Context(), req)
+	case "hybrid":
+		resp, err = h.memoryClient.HybridSearch(r.Context(), req)
+	default:
+		writeJSONError(w, http.StatusBadRequest, "invalid mode: must be semantic, fts or hybrid")
+		return
+	}
+	if err != nil {
+		h.logger.Warn("search failed", "mode", mode, "error", err)
+		writeJSONError(w, http.StatusBadGateway, "search failed")
+		return
+	}
+
+	out := Response{
+		Query:        query,
+		Mode:         mode,
+		Results:      make([]Result, 0, len(resp.GetResults())),
+		TotalMatches: resp.GetTotalMatches(),
+		Truncated:    resp.GetTruncated(),
+	}
+	for _, res := range resp.GetResults() {
+		out.Results = append(out.Results, Result{
+			ChunkID:    res.GetChunkId(),
+			DocumentID: res.GetDocumentId(),
+			Content:    res.GetContent(),
+			Score:      res.GetScore(),
+			Metadata:   res.GetMetadata(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out) //nolint:errcheck
+}
+
+// listByFilter answers a search with filters but no query by listing the
+// matching documents, newest first.
+func (h *Handler) listByFilter(w http.ResponseWriter, r *http.Request, filters map[string]string, limit int) {
+	if h.memoryClient == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "memory service not connected")
+		return
+	}
+
+	resp, err := h.memoryClient.ListByFilter(r.Context(), &memoryv1.ListByFilterRequest{
+		Filters:   filters,
+		PageSize:  int32(limit),
+		PageToken: r.URL.Query().Get("page_token"),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		writeJSONError(w, http.StatusBadRequest, status.Convert(err).Message())
+		return
+	default:
+		h.logger.Warn("list by filter failed", "filters", filters, "error", err)
+		writeJSONError(w, http.StatusBadGateway, "search failed")
+		return
+	}
+
+	out := Response{
+		Mode:          "filter",
+		Results:       make([]Result, 0, len(resp.GetDocuments())),
+		TotalMatches:  resp.GetTotalMatches(),
+		Truncated:     resp.GetNextPageToken() != "",
+		NextPageToken: resp.GetNextPageToken(),
+	}
+	for _, doc := range resp.GetDocuments() {
+		res := Result{
+			DocumentID: doc.GetDocumentId(),
+			Content:    doc.GetContent(),
+			Metadata:   doc.GetMetadata(),
+		}
+		if doc.GetIndexedAt() != nil {
+			t := doc.GetIndexedAt().AsTime()
+			res.IndexedAt = &t
+		}
+		out.Results = append(out.Results, res)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out) //nolint:errcheck
+}
+
+// parseFilters parses repeated key:value filter parameters.
+func parseFilters(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	filters := make(map[string]string, len(values))
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, ":")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid filter %q: must be key:value", v)
+		}
+		filters[key] = value
+	}
+	return filters, nil
+}
+
+// Document is the JSON body returned by GET /v1/documents/{id}.
+type Document struct {
+	DocumentID string            `json:"document_id"`
+	Content    string            `json:"content"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	ChunkCount int32             `json:"chunk_count"`
+}
+
+func (h *Handler) handleGetDocument(w http.ResponseWriter, r *http.Request) {
+	if h.memoryClient == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "memory service not connected")
+		return
+	}
+
+	id := r.PathValue("id")
+	resp, err := h.memoryClient.GetDocument(r.Context(), &memoryv1.GetDocumentRequest{
+		DocumentId: id,
+		Collection: r.URL.Query().Get("collection"),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		writeJSONError(w, http.StatusNotFound, "document not found: "+id)
+		return
+	default:
+		h.logger.Warn("get document failed", "document_id", id, "error", err)
+		writeJSONError(w, http.StatusBadGateway, "get document failed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Document{ //nolint:errcheck
+		DocumentID: resp.GetDocumentId(),
+		Content:    resp.GetContent(),
+		Metadata:   resp.GetMetadata(),
+		ChunkCount: resp.GetChunkCount(),
+	})
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message}) //nolint:errcheck
+}
@@ -0,0 +1,232 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	memoryv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/memory/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeMemoryClient answers each search RPC with a result naming the RPC,
+// records the last request, and serves documents from docs.
+type fakeMemoryClient struct {
+	memoryv1.MemoryServiceClient
+	lastReq  *memoryv1.SearchRequest
+	lastList *memoryv1.ListByFilterRequest
+	docs     map[string]string
+}
+
+func (f *fakeMemoryClient) respond(rpc string, in *memoryv1.SearchRequest) (*memoryv1.SearchResponse, error) {
+	f.lastReq = in
+	return &memoryv1.SearchResponse{
+		Results:      []*memoryv1.SearchResult{{ChunkId: "c1", DocumentId: "d1", Content: rpc, Score: 0.9}},
+		TotalMatches: 37,
+		Truncated:    true,
+	}, nil
+}
+
+func (f *fakeMemoryClient) SemanticSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	return f.respond("semantic", in)
+}
+
+func (f *fakeMemoryClient) FullTextSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	return f.respond("fts", in)
+}
+
+func (f *fakeMemoryClient) HybridSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	return f.respond("hybrid", in)
+}
+
+func (f *fakeMemoryClient) GetDocument(ctx context.Context, in *memoryv1.GetDocumentRequest, opts ...grpc.CallOption) (*memoryv1.GetDocumentResponse, error) {
+	content, ok := f.docs[in.GetDocumentId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &memoryv1.GetDocumentResponse{
+		DocumentId: in.GetDocumentId(),
+		Content:    content,
+		Metadata:   map[string]string{"source": "notion"},
+		ChunkCount: 2,
+	}, nil
+}
+
+func (f *fakeMemoryClient) ListByFilter(ctx context.Context, in *memoryv1.ListByFilterRequest, opts ...grpc.CallOption) (*memoryv1.ListByFilterResponse, error) {
+	f.lastList = in
+	if in.GetPageToken() == "bad" {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	return &memoryv1.ListByFilterResponse{
+		Documents: []*memoryv1.ListedDocument{{
+			DocumentId: "d2",
+			Content:    "A research email.",
+			Metadata:   in.GetFilters(),
+			IndexedAt:  timestamppb.New(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)),
+		}},
+		NextPageToken: "1",
+		TotalMatches:  2,
+	}, nil
+}
+
+func newTestMux(client memoryv1.MemoryServiceClient) *http.ServeMux {
+	mux := http.NewServeMux()
+	NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), client).RegisterRoutes(mux)
+	return mux
+}
+
+func TestSearchModes(t *testing.T) {
+	client := &fakeMemoryClient{}
+	mux := newTestMux(client)
+
+	tests := []struct {
+		query    string
+		wantMode string
+	}{
+		{"?q=tracing", "hybrid"},
+		{"?q=tracing&mode=semantic", "semantic"},
+		{"?q=tracing&mode=fts", "fts"},
+		{"?q=tracing&mode=hybrid&limit=3&min_score=0.3", "hybrid"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/v1/search"+tt.query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: expected 200, got %d: %s", tt.query, w.Code, w.Body)
+		}
+		var resp Response
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("%q: decoding: %v", tt.query, err)
+		}
+		if resp.Mode != tt.wantMode || resp.Query != "tracing" {
+			t.Errorf("%q: unexpected response %+v", tt.query, resp)
+		}
+		if len(resp.Results) != 1 || resp.Results[0].Content != tt.wantMode {
+			t.Errorf("%q: expected a result from the %s RPC, got %+v", tt.query, tt.wantMode, resp.Results)
+		}
+		if resp.TotalMatches != 37 || !resp.Truncated {
+			t.Errorf("%q: expected total matches to be passed through, got %d (truncated=%v)", tt.query, resp.TotalMatches, resp.Truncated)
+		}
+	}
+
+	if client.lastReq.GetTopK() != 3 || client.lastReq.GetMinScore() != 0.3 {
+		t.Errorf("expected limit and min_score to be forwarded, got %+v", client.lastReq)
+	}
+}
+
+func TestSearchByFilterOnly(t *testing.T) {
+	client := &fakeMemoryClient{}
+	mux := newTestMux(client)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?filter=source:email&filter=type:research&limit=1&page_token=0", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var resp Response
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if resp.Mode != "filter" || len(resp.Results) != 1 || resp.Results[0].DocumentID != "d2" {
+		t.Fatalf("expected the filtered document, got %+v", resp)
+	}
+	if resp.Results[0].IndexedAt == nil || resp.Results[0].IndexedAt.Year() != 2026 {
+		t.Errorf("expected the index time, got %v", resp.Results[0].IndexedAt)
+	}
+	if resp.NextPageToken != "1" || !resp.Truncated || resp.TotalMatches != 2 {
+		t.Errorf("expected pagination to be passed through, got %+v", resp)
+	}
+
+	got := client.lastList
+	if got.GetFilters()["source"] != "email" || got.GetFilters()["type"] != "research" || got.GetPageSize() != 1 || got.GetPageToken() != "0" {
+		t.Errorf("expected filters, limit and page token to be forwarded, got %+v", got)
+	}
+	if client.lastReq != nil {
+		t.Error("expected no text search without q")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/search?filter=source:email&page_token=bad", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a bad page token, got %d", w.Code)
+	}
+}
+
+func TestSearchForwardsFilters(t *testing.T) {
+	client := &fakeMemoryClient{}
+	mux := newTestMux(client)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=tracing&filter=source:email", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if client.lastReq.GetFilters()["source"] != "email" || client.lastList != nil {
+		t.Errorf("expected a filtered hybrid search, got %+v", client.lastReq)
+	}
+}
+
+func TestSearchValidation(t *testing.T) {
+	mux := newTestMux(&fakeMemoryClient{})
+
+	for _, query := range []string{"", "?q=", "?q=x&mode=vector", "?q=x&limit=0", "?q=x&limit=abc", "?q=x&min_score=-1", "?filter=source", "?q=x&filter=:email"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/search"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestSearchWithoutMemoryService(t *testing.T) {
+	mux := newTestMux(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=tracing", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestGetDocument(t *testing.T) {
+	mux := newTestMux(&fakeMemoryClient{docs: map[string]string{"doc-1": "The full note."}})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/documents/doc-1", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var doc Document
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if doc.DocumentID != "doc-1" || doc.Content != "The full note." || doc.Metadata["source"] != "notion" || doc.ChunkCount != 2 {
+		t.Errorf("unexpected document %+v", doc)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/documents/doc-missing", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing document, got %d", w.Code)
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"strings"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+// dedupeChunks removes duplicate semantic chunks, keeping the first position
+// of each chunk but the highest relevance score seen for it. Two chunks are
+// duplicates when they share a chunk ID, or when their content is identical
+// after normalizing case and whitespace (near-duplicates returned by
+// different search paths, which may not carry chunk IDs).
+func dedupeChunks(chunks []*agentv1.SemanticChunk) []*agentv1.SemanticChunk {
+	out := make([]*agentv1.SemanticChunk, 0, len(chunks))
+	byID := make(map[string]int)
+	byContent := make(map[string]int)
+
+	for _, c := range chunks {
+		key := normalizeContent(c.GetContent())
+
+		idx, dup := byID[c.GetChunkId()]
+		if !dup || c.GetChunkId() == "" {
+			idx, dup = byContent[key]
+		}
+		if dup {
+			if c.GetRelevanceScore() > out[idx].GetRelevanceScore() {
+				out[idx] = c
+			}
+			continue
+		}
+
+		if c.GetChunkId() != "" {
+			byID[c.GetChunkId()] = len(out)
+		}
+		if key != "" {
+			byContent[key] = len(out)
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// normalizeContent lowercases text and collapses runs of whitespace.
+func normalizeContent(content string) string {
+	return strings.Join(strings.Fields(strings.ToLower(content)), " ")
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ClassifyRequest is the JSON body accepted by POST /v1/classify.
+type ClassifyRequest struct {
+	Content  string            `json:"content"`
+	Source   string            `json:"source"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// ClassifyResponse is the JSON body returned by POST /v1/classify.
+// Classification is ACTIONABLE, REFERENCE, TRASH or NEEDS_REVIEW.
+type ClassifyResponse struct {
+	Classification    string            `json:"classification"`
+	SuggestedProject  string            `json:"suggested_project"`
+	SuggestedArea     string            `json:"suggested_area"`
+	Priority          string            `json:"priority"`
+	Confidence        float32           `json:"confidence"`
+	ExtractedMetadata map[string]string `json:"extracted_metadata,omitempty"`
+	ReviewReason      string            `json:"review_reason,omitempty"`
+	ReviewID          string            `json:"review_id,omitempty"`     // pass to POST /v1/classify/reviews/{id}
+	ThoughtChain      []string          `json:"thought_chain,omitempty"` // the Clarify agent's reasoning steps
+}
+
+// PendingReview is an item held for manual confirmation, as returned by
+// GET /v1/classify/reviews.
+type PendingReview struct {
+	ReviewID                string            `json:"review_id"`
+	Content                 string            `json:"content"`
+	Source                  string            `json:"source"`
+	Metadata                map[string]string `json:"metadata,omitempty"`
+	SuggestedClassification string            `json:"suggested_classification"`
+	Confidence              float32           `json:"confidence"`
+	ReviewReason            string            `json:"review_reason"`
+	QueuedAt                time.Time         `json:"queued_at"`
+}
+
+// ResolveReviewRequest is the JSON body accepted by
+// POST /v1/classify/reviews/{id}. Classification is ACTIONABLE, REFERENCE
+// or TRASH.
+type ResolveReviewRequest struct {
+	Classification string `json:"classification"`
+}
+
+// ResolveReviewResponse is the JSON body returned by
+// POST /v1/classify/reviews/{id}.
+type ResolveReviewResponse struct {
+	Review         PendingReview `json:"review"`
+	Classification string        `json:"classification"`
+}
+
+// RegisterClassifyRoutes exposes item classification over HTTP so ad-hoc
+// text can be classified from scripts, and the queue of low-confidence
+// classifications can be worked through:
+//
+//	POST /v1/classify                 classify an item
+//	GET  /v1/classify/reviews         list items awaiting review
+//	POST /v1/classify/reviews/{id}    confirm a queued item's classification
+func (s *CortexServer) RegisterClassifyRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/classify", s.handleClassify)
+	mux.HandleFunc("GET /v1/classify/reviews", s.handleListReviews)
+	mux.HandleFunc("POST /v1/classify/reviews/{id}", s.handleResolveReview)
+}
+
+// ListPendingReviews relays the Frontal Lobe's queue of classifications
+// awaiting manual confirmation.
+func (s *CortexServer) ListPendingReviews(ctx context.Context, req *agentv1.ListPendingReviewsRequest) (*agentv1.ListPendingReviewsResponse, error) {
+	if s.frontalClient != nil {
+		return s.frontalClient.ListPendingReviews(ctx, req)
+	}
+	return &agentv1.ListPendingReviewsResponse{}, nil
+}
+
+// ResolveReview confirms the classification of an item in the Frontal Lobe's
+// review queue.
+func (s *CortexServer) ResolveReview(ctx context.Context, req *agentv1.ResolveReviewRequest) (*agentv1.ResolveReviewResponse, error) {
+	if s.frontalClient != nil {
+		return s.frontalClient.ResolveReview(ctx, req)
+	}
+	return nil, status.Errorf(codes.NotFound, "no pending review %q", req.GetReviewId())
+}
+
+func (s *CortexServer) handleClassify(w http.ResponseWriter, r *http.Request) {
+	var body ClassifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if body.Content == "" {
+		writeJSONError(w, http.StatusBadRequest, "content is required")
+		return
+	}
+
+	ctx, cancel := s.reasoningContext(r.Context())
+	defer cancel()
+
+	resp, err := s.ClassifyItem(ctx, &agentv1.ClassifyRequest{
+		Content:  body.Content,
+		Source:   body.Source,
+		Metadata: body.Metadata,
+	})
+	if err != nil {
+		s.logger.WarnContext(r.Context(), "classification failed", "source", body.Source, "error", err)
+		s.writeDownstreamError(w, "classification", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ClassifyResponse{ //nolint:errcheck
+		Classification:    resp.GetClassification().String(),
+		SuggestedProject:  resp.GetSuggestedProject(),
+		SuggestedArea:     resp.GetSuggestedArea(),
+		Priority:          resp.GetPriority(),
+		Confidence:        resp.GetConfidence(),
+		ExtractedMetadata: resp.GetExtractedMetadata(),
+		ReviewReason:      resp.GetReviewReason(),
+		ReviewID:          resp.GetReviewId(),
+		ThoughtChain:      resp.GetThoughtChain(),
+	})
+}
+
+func (s *CortexServer) handleListReviews(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.ListPendingReviews(r.Context(), &agentv1.ListPendingReviewsRequest{})
+	if err != nil {
+		s.logger.WarnContext(r.Context(), "listing pending reviews failed", "error", err)
+		s.writeDownstreamError(w, "listing reviews", err)
+		return
+	}
+
+	reviews := make([]PendingReview, 0, len(resp.GetReviews()))
+	for _, pr := range resp.GetReviews() {
+		reviews = append(reviews, pendingReviewJSON(pr))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]PendingReview{"reviews": reviews}) //nolint:errcheck
+}
+
+func (s *CortexServer) handleResolveReview(w http.ResponseWriter, r *http.Request) {
+	var body ResolveReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	classification, ok := agentv1.ClassifyResponse_Classification_value[body.Classification]
+	if !ok || classification == int32(agentv1.ClassifyResponse_NEEDS_REVIEW) {
+		writeJSONError(w, http.StatusBadRequest, "classification must be ACTIONABLE, REFERENCE or TRASH")
+		return
+	}
+
+	resp, err := s.ResolveReview(r.Context(), &agentv1.ResolveReviewRequest{
+		ReviewId:       r.PathValue("id"),
+		Classification: agentv1.ClassifyResponse_Classification(classification),
+	})
+	switch {
+	case status.Code(err) == codes.NotFound:
+		writeJSONError(w, http.StatusNotFound, "no pending review "+r.PathValue("id"))
+		return
+	case err != nil:
+		s.logger.WarnContext(r.Context(), "resolving review failed", "review_id", r.PathValue("id"), "error", err)
+		s.writeDownstreamError(w, "resolving review", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ResolveReviewResponse{ //nolint:errcheck
+		Review:         pendingReviewJSON(resp.GetReview()),
+		Classification: resp.GetClassification().String(),
+	})
+}
+
+func pendingReviewJSON(pr *agentv1.PendingReview) PendingReview {
+	return PendingReview{
+		ReviewID:                pr.GetReviewId(),
+		Content:                 pr.GetContent(),
+		Source:                  pr.GetSource(),
+		Metadata:                pr.GetMetadata(),
+		SuggestedClassification: pr.GetSuggestedClassification().String(),
+		Confidence:              pr.GetConfidence(),
+		ReviewReason:            pr.GetReviewReason(),
+		QueuedAt:                pr.GetQueuedAt().AsTime(),
+	}
+}
@@ -0,0 +1,218 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+// classifyFrontalClient fakes the Frontal Lobe's classification RPC,
+// recording the request it receives.
+type classifyFrontalClient struct {
+	agentv1.ReasoningEngineClient
+	req *agentv1.ClassifyRequest
+	err error // returned instead of a classification when set
+}
+
+func (f *classifyFrontalClient) ClassifyItem(ctx context.Context, req *agentv1.ClassifyRequest, opts ...grpc.CallOption) (*agentv1.ClassifyResponse, error) {
+	f.req = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &agentv1.ClassifyResponse{
+		Classification:   agentv1.ClassifyResponse_ACTIONABLE,
+		SuggestedProject: "Home",
+		SuggestedArea:    "Personal",
+		Priority:         "high",
+		Confidence:       0.9,
+		ThoughtChain:     []string{"Analyzing content for classification...", "Classified as ACTIONABLE with confidence 0.90"},
+	}, nil
+}
+
+func postClassify(s *CortexServer, body string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	s.RegisterClassifyRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/classify", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestClassifyEndpoint(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	frontal := &classifyFrontalClient{}
+	s.frontalClient = frontal
+
+	w := postClassify(s, `{"content": "Fix the leaking sink", "source": "cli", "metadata": {"tag": "home"}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	want := map[string]any{
+		"classification":    "ACTIONABLE",
+		"suggested_project": "Home",
+		"suggested_area":    "Personal",
+		"priority":          "high",
+	}
+	for k, v := range want {
+		if resp[k] != v {
+			t.Errorf("expected %s=%v, got %v", k, v, resp[k])
+		}
+	}
+	if conf, ok := resp["confidence"].(float64); !ok || conf < 0.89 || conf > 0.91 {
+		t.Errorf("expected confidence ~0.9, got %v", resp["confidence"])
+	}
+	if chain, ok := resp["thought_chain"].([]any); !ok || len(chain) != 2 || chain[1] != "Classified as ACTIONABLE with confidence 0.90" {
+		t.Errorf("expected the thought chain to be relayed, got %v", resp["thought_chain"])
+	}
+
+	if frontal.req.GetContent() != "Fix the leaking sink" || frontal.req.GetSource() != "cli" || frontal.req.GetMetadata()["tag"] != "home" {
+		t.Errorf("request not mapped to the RPC: %v", frontal.req)
+	}
+}
+
+func TestClassifyEndpointWithoutFrontalLobe(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+
+	w := postClassify(s, `{"content": "Some note"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ClassifyResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if resp.Classification != "REFERENCE" || resp.Confidence != 0 {
+		t.Errorf("expected the REFERENCE default with no confidence, got %+v", resp)
+	}
+}
+
+func TestClassifyEndpointRejectsBadInput(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+
+	for _, body := range []string{`not json`, `{"source": "cli"}`} {
+		if w := postClassify(s, body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestClassifyEndpointDownstreamErrors(t *testing.T) {
+	tests := []struct {
+		err        error
+		code       int
+		retryAfter bool
+	}{
+		{status.Error(codes.Unavailable, "connection refused"), http.StatusServiceUnavailable, true},
+		{status.Error(codes.DeadlineExceeded, "deadline exceeded"), http.StatusGatewayTimeout, false},
+		{status.Error(codes.Internal, "boom"), http.StatusBadGateway, false},
+	}
+	for _, tt := range tests {
+		cfg := newTestConfig()
+		cfg.RetryAfter = 7 * time.Second
+		s := NewCortexServer(newTestLogger(), cfg)
+		s.frontalClient = &classifyFrontalClient{err: tt.err}
+
+		w := postClassify(s, `{"content": "Some note"}`)
+		if w.Code != tt.code {
+			t.Errorf("%v: expected %d, got %d", tt.err, tt.code, w.Code)
+		}
+		if got := w.Header().Get("Retry-After") != ""; got != tt.retryAfter {
+			t.Errorf("%v: expected Retry-After present=%v, got %v", tt.err, tt.retryAfter, got)
+		}
+		if got := w.Header().Get("Retry-After"); tt.retryAfter && got != "7" {
+			t.Errorf("%v: expected the configured Retry-After 7, got %q", tt.err, got)
+		}
+	}
+}
+
+// pendingReviewFrontalClient fakes the Frontal Lobe's review queue RPCs.
+type pendingReviewFrontalClient struct {
+	agentv1.ReasoningEngineClient
+	pending  []*agentv1.PendingReview
+	resolved *agentv1.ResolveReviewRequest
+}
+
+func (f *pendingReviewFrontalClient) ListPendingReviews(ctx context.Context, req *agentv1.ListPendingReviewsRequest, opts ...grpc.CallOption) (*agentv1.ListPendingReviewsResponse, error) {
+	return &agentv1.ListPendingReviewsResponse{Reviews: f.pending}, nil
+}
+
+func (f *pendingReviewFrontalClient) ResolveReview(ctx context.Context, req *agentv1.ResolveReviewRequest, opts ...grpc.CallOption) (*agentv1.ResolveReviewResponse, error) {
+	for _, pr := range f.pending {
+		if pr.ReviewId == req.ReviewId {
+			f.resolved = req
+			return &agentv1.ResolveReviewResponse{Review: pr, Classification: req.Classification}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no pending review %q", req.ReviewId)
+}
+
+func TestReviewEndpoints(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	queuedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	frontal := &pendingReviewFrontalClient{pending: []*agentv1.PendingReview{{
+		ReviewId:                "review-1",
+		Content:                 "Maybe look into the thing",
+		Source:                  "slack",
+		SuggestedClassification: agentv1.ClassifyResponse_ACTIONABLE,
+		Confidence:              0.4,
+		ReviewReason:            "low confidence",
+		QueuedAt:                timestamppb.New(queuedAt),
+	}}}
+	s.frontalClient = frontal
+	mux := http.NewServeMux()
+	s.RegisterClassifyRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/classify/reviews", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var list struct {
+		Reviews []PendingReview `json:"reviews"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if len(list.Reviews) != 1 {
+		t.Fatalf("expected 1 review, got %d", len(list.Reviews))
+	}
+	if got := list.Reviews[0]; got.ReviewID != "review-1" || got.SuggestedClassification != "ACTIONABLE" || !got.QueuedAt.Equal(queuedAt) {
+		t.Errorf("unexpected review %+v", got)
+	}
+
+	for _, tc := range []struct {
+		path, body string
+		code       int
+	}{
+		{"/v1/classify/reviews/review-1", `{"classification": "NEEDS_REVIEW"}`, http.StatusBadRequest},
+		{"/v1/classify/reviews/review-1", `{"classification": "MAYBE"}`, http.StatusBadRequest},
+		{"/v1/classify/reviews/review-9", `{"classification": "TRASH"}`, http.StatusNotFound},
+		{"/v1/classify/reviews/review-1", `{"classification": "REFERENCE"}`, http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body)))
+		if w.Code != tc.code {
+			t.Errorf("%s %s: expected %d, got %d: %s", tc.path, tc.body, tc.code, w.Code, w.Body.String())
+		}
+	}
+	if frontal.resolved.GetReviewId() != "review-1" || frontal.resolved.GetClassification() != agentv1.ClassifyResponse_REFERENCE {
+		t.Errorf("resolution not relayed: %v", frontal.resolved)
+	}
+}
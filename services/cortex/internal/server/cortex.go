@@ -5,20 +5,39 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
 	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
 	ingestionv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/ingestion/v1"
 	memoryv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/memory/v1"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/config"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/metrics"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/middleware"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/session"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/tlsconfig"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	// maxGraphEntities caps how many query entities are looked up in the graph.
+	maxGraphEntities = 3
+	// maxGraphTriples caps how many graph triples are added to the context.
+	maxGraphTriples = 10
+	// defaultContextTopK is used when the configured topK is not positive.
+	defaultContextTopK = 5
+)
+
+var tracer = otel.Tracer("github.com/ziyixi/SecondBrain/services/cortex/internal/server")
+
 // CortexServer implements the orchestration logic for the Cognitive OS.
 // It coordinates between the Frontal Lobe, Hippocampus, and Sensory Gateway.
 type CortexServer struct {
@@ -27,25 +46,125 @@ type CortexServer struct {
 	ingestionv1.UnimplementedIngestionServiceServer
 
 	logger         *slog.Logger
+	cfg            *config.Config
 	sessionMgr     *session.Manager
+	stopSweeper    func()
 	metricsStore   *metrics.Store
+	stopAutoSave   func()
+	topics         *TopicClassifier
 	frontalConn    *grpc.ClientConn
 	hippocampusConn *grpc.ClientConn
 	frontalClient  agentv1.ReasoningEngineClient
 	memoryClient   memoryv1.MemoryServiceClient
+	healthClients  map[string]commonv1.HealthServiceClient
+	connected      atomic.Bool // set once ConnectDownstream succeeds; see Ready
+	evaluator      QualityEvaluator
+	evalWG         sync.WaitGroup
+	summarizer     TurnSummarizer
+	summaryMu      sync.Mutex // guards summaryQueue
+	summaryQueue   map[string][][]string // session ID -> evicted batches awaiting summarization, oldest first
+	summaryWG      sync.WaitGroup
+	idempotency    *idempotencyCache // remembers IngestItem responses by idempotency key
 	version        string
 }
 
 // NewCortexServer creates a new CortexServer instance.
-func NewCortexServer(logger *slog.Logger) *CortexServer {
+func NewCortexServer(logger *slog.Logger, cfg *config.Config) *CortexServer {
+	sessionMgr := newSessionManager(logger, cfg.SessionStorePath)
+	sessionMgr.SetPolicy(session.Policy{
+		TTL:         cfg.SessionTTL,
+		MaxSessions: cfg.MaxSessions,
+		Episodic: session.EpisodicLimits{
+			MaxTurns:  cfg.SessionMaxTurns,
+			MaxTokens: cfg.SessionMaxTokens,
+		},
+	})
+
+	metricsStore := newMetricsStore(logger, cfg.MetricsStorePath)
+	if cfg.TopicAliasesPath != "" {
+		if aliases, err := metrics.LoadTopicAliases(cfg.TopicAliasesPath); err != nil {
+			logger.Warn("failed to load topic aliases, topics will not be merged", "path", cfg.TopicAliasesPath, "error", err)
+		} else {
+			metricsStore.SetTopicAliases(aliases)
+		}
+	}
+	metricsStore.SetLoopWarningPolicy(metrics.LoopWarningPolicy{
+		MinCoverage:     cfg.LoopWarnCoverage,
+		MinInteractions: cfg.LoopWarnMinQueries,
+	})
+
 	return &CortexServer{
-		logger:       logger,
-		sessionMgr:   session.NewManager(),
-		metricsStore: metrics.NewStore(),
-		version:      "0.1.0",
+		logger:        logger,
+		cfg:           cfg,
+		sessionMgr:    sessionMgr,
+		stopSweeper:   func() {},
+		metricsStore:  metricsStore,
+		stopAutoSave:  func() {},
+		topics:        newTopicClassifier(logger, cfg.TopicTaxonomyPath),
+		healthClients: make(map[string]commonv1.HealthServiceClient),
+		idempotency:   newIdempotencyCache(cfg.IdempotencyTTL, cfg.IdempotencyMaxKeys),
+		version:       "0.1.0",
 	}
 }
 
+// StartSessionSweeper evicts expired sessions every cfg.SessionSweepInterval
+// until Close, which also stops it. It does nothing when the interval or the
+// session TTL is unset.
+func (s *CortexServer) StartSessionSweeper() {
+	s.stopSweeper = s.sessionMgr.StartSweeper(s.cfg.SessionSweepInterval)
+}
+
+// StartMetricsAutoSave saves metrics to cfg.MetricsStorePath every
+// cfg.MetricsSaveInterval until Close, which also stops it. It does nothing
+// when either is unset.
+func (s *CortexServer) StartMetricsAutoSave() {
+	s.stopAutoSave = s.metricsStore.StartAutoSave(s.cfg.MetricsStorePath, s.cfg.MetricsSaveInterval)
+}
+
+// newSessionManager returns a file-backed session manager when a store path
+// is configured, falling back to an in-memory manager if it cannot be loaded.
+func newSessionManager(logger *slog.Logger, storePath string) *session.Manager {
+	if storePath == "" {
+		return session.NewManager()
+	}
+	mgr, err := session.NewManagerWithStore(storePath)
+	if err != nil {
+		logger.Warn("failed to load session store, sessions will not persist", "path", storePath, "error", err)
+		return session.NewManager()
+	}
+	logger.Info("session store loaded", "path", storePath, "sessions", len(mgr.ListSessions()))
+	return mgr
+}
+
+// newMetricsStore returns a metrics store restored from storePath when one is
+// configured. A store that fails to load starts empty.
+func newMetricsStore(logger *slog.Logger, storePath string) *metrics.Store {
+	store := metrics.NewStore()
+	if storePath == "" {
+		return store
+	}
+	if err := store.Load(storePath); err != nil {
+		logger.Warn("failed to load metrics store, starting empty", "path", storePath, "error", err)
+		return metrics.NewStore()
+	}
+	logger.Info("metrics store loaded", "path", storePath, "interactions", store.Summary().TotalInteractions)
+	return store
+}
+
+// newTopicClassifier builds a classifier from the taxonomy at path, falling
+// back to the built-in taxonomy when none is configured or it fails to load.
+func newTopicClassifier(logger *slog.Logger, path string) *TopicClassifier {
+	if path == "" {
+		return NewTopicClassifier(nil)
+	}
+	taxonomy, err := LoadTopicTaxonomy(path)
+	if err != nil {
+		logger.Warn("failed to load topic taxonomy, using built-in taxonomy", "path", path, "error", err)
+		return NewTopicClassifier(nil)
+	}
+	return NewTopicClassifier(taxonomy)
+}
+
 // MetricsStore returns the metrics store for external access (e.g., HTTP API).
 func (s *CortexServer) MetricsStore() *metrics.Store {
 	return s.metricsStore
@@ -56,26 +175,59 @@ func (s *CortexServer) MemoryClient() memoryv1.MemoryServiceClient {
 	return s.memoryClient
 }
 
+// SetQualityEvaluator sets the evaluator used to score responses after they
+// are produced. Scoring only runs when QualityEvalEnabled is set in config.
+func (s *CortexServer) SetQualityEvaluator(e QualityEvaluator) {
+	s.evaluator = e
+}
+
+// SetTurnSummarizer sets the summarizer used to condense turns evicted from
+// session episodic memory. It only runs when SessionSummarize is set in config.
+func (s *CortexServer) SetTurnSummarizer(t TurnSummarizer) {
+	s.summarizer = t
+}
+
 // ConnectDownstream establishes connections to downstream services.
 func (s *CortexServer) ConnectDownstream(frontalAddr, hippocampusAddr string) error {
-	var err error
+	creds, err := tlsconfig.ClientCredentials(s.cfg.DownstreamTLS, s.cfg.TLSCAFile)
+	if err != nil {
+		return fmt.Errorf("loading downstream credentials: %w", err)
+	}
 
-	s.frontalConn, err = grpc.NewClient(frontalAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	token := middleware.BearerToken(s.cfg.DownstreamAuthToken)
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(token),
+		grpc.WithConnectParams(s.connectParams()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithUnaryInterceptor(middleware.UnaryClientRequestID()),
+		grpc.WithStreamInterceptor(middleware.StreamClientRequestID()),
+	}
+
+	s.frontalConn, err = grpc.NewClient(frontalAddr, opts...)
 	if err != nil {
 		return fmt.Errorf("connecting to frontal lobe: %w", err)
 	}
 	s.frontalClient = agentv1.NewReasoningEngineClient(s.frontalConn)
+	s.healthClients[dependencyFrontalLobe] = commonv1.NewHealthServiceClient(s.frontalConn)
+	if s.cfg.QualityEvalEnabled && s.evaluator == nil {
+		s.evaluator = NewFrontalLobeEvaluator(s.frontalClient)
+	}
+	if s.cfg.SessionSummarize && s.summarizer == nil {
+		s.summarizer = NewFrontalLobeSummarizer(s.frontalClient)
+	}
 
-	s.hippocampusConn, err = grpc.NewClient(hippocampusAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	s.hippocampusConn, err = grpc.NewClient(hippocampusAddr, opts...)
 	if err != nil {
 		return fmt.Errorf("connecting to hippocampus: %w", err)
 	}
 	s.memoryClient = memoryv1.NewMemoryServiceClient(s.hippocampusConn)
+	s.healthClients[dependencyHippocampus] = commonv1.NewHealthServiceClient(s.hippocampusConn)
+
+	s.watchConn(dependencyFrontalLobe, s.frontalConn)
+	s.watchConn(dependencyHippocampus, s.hippocampusConn)
 
+	s.connected.Store(true)
 	s.logger.Info("connected to downstream services",
 		"frontal_lobe", frontalAddr,
 		"hippocampus", hippocampusAddr,
@@ -84,8 +236,21 @@ func (s *CortexServer) ConnectDownstream(frontalAddr, hippocampusAddr string) er
 	return nil
 }
 
-// Close cleanly shuts down connections.
+// Close waits for pending quality evaluations and session summaries, flushes
+// sessions and metrics, and cleanly shuts down connections.
 func (s *CortexServer) Close() {
+	s.stopSweeper()
+	s.evalWG.Wait()
+	s.summaryWG.Wait()
+	if err := s.sessionMgr.Close(); err != nil {
+		s.logger.Warn("failed to flush sessions", "error", err)
+	}
+	s.stopAutoSave()
+	if path := s.cfg.MetricsStorePath; path != "" {
+		if err := s.metricsStore.Save(path); err != nil {
+			s.logger.Warn("failed to save metrics", "path", path, "error", err)
+		}
+	}
 	if s.frontalConn != nil {
 		s.frontalConn.Close()
 	}
@@ -94,15 +259,6 @@ func (s *CortexServer) Close() {
 	}
 }
 
-// Check implements the HealthService Check RPC.
-func (s *CortexServer) Check(ctx context.Context, req *commonv1.HealthCheckRequest) (*commonv1.HealthCheckResponse, error) {
-	return &commonv1.HealthCheckResponse{
-		Status:    commonv1.HealthCheckResponse_SERVING,
-		Version:   s.version,
-		Timestamp: timestamppb.Now(),
-	}, nil
-}
-
 // StreamThoughtProcess implements the bidirectional streaming RPC
 // between the client and the Frontal Lobe reasoning engine.
 func (s *CortexServer) StreamThoughtProcess(stream agentv1.ReasoningEngine_StreamThoughtProcessServer) error {
@@ -113,7 +269,7 @@ func (s *CortexServer) StreamThoughtProcess(stream agentv1.ReasoningEngine_Strea
 	}
 
 	sessionID := firstMsg.GetSessionId()
-	s.logger.Info("starting thought process stream", "session_id", sessionID)
+	s.logger.InfoContext(stream.Context(), "starting thought process stream", "session_id", sessionID)
 
 	// Ensure session exists
 	sess, exists := s.sessionMgr.Get(sessionID)
@@ -130,7 +286,7 @@ func (s *CortexServer) StreamThoughtProcess(stream agentv1.ReasoningEngine_Strea
 	for {
 		msg, err := stream.Recv()
 		if err == io.EOF {
-			s.logger.Info("stream ended", "session_id", sessionID)
+			s.logger.InfoContext(stream.Context(), "stream ended", "session_id", sessionID)
 			return nil
 		}
 		if err != nil {
@@ -173,80 +329,273 @@ func (s *CortexServer) handleUserQuery(
 	input *agentv1.AgentInput,
 	sessionID, query string,
 ) error {
-	sess.AddEpisodicMemory("User: " + query)
+	s.summarizeEvicted(sess, sess.AddEpisodicMemory("User: "+query))
 
 	ctx := input.GetContext()
 	if ctx == nil {
 		ctx = &agentv1.ContextSnapshot{}
 	}
 
-	contextRelevance := s.enrichContextFromMemory(stream.Context(), ctx, query)
+	contextRelevance, contextGated := s.enrichContextFromMemory(stream.Context(), ctx, query)
+	s.enrichContextFromGraph(stream.Context(), ctx, query)
 	ctx.EpisodicMemory = sess.GetEpisodicMemory()
+	if summary := sess.GetEpisodicSummary(); summary != "" {
+		ctx.EpisodicMemory = append([]string{"Summary of earlier turns: " + summary}, ctx.EpisodicMemory...)
+	}
 	input.Context = ctx
 
+	interactionID := fmt.Sprintf("%s-%d", sessionID, time.Now().UnixNano())
 	s.metricsStore.Record(metrics.InteractionRecord{
-		SessionID:        sessionID,
-		Timestamp:        time.Now(),
-		Query:            query,
-		ContextRelevance: contextRelevance,
-		ResponseQuality:  contextRelevance, // initial estimate from context quality
+		InteractionID:     interactionID,
+		SessionID:         sessionID,
+		Timestamp:         time.Now(),
+		Query:             query,
+		ContextRelevance:  contextRelevance,
+		ContextGated:      contextGated,
+		ResponseQuality:   contextRelevance, // initial estimate, refined by evaluateQuality when enabled
+		TopicDistribution: s.topics.Classify(query),
 	})
 
 	if s.frontalClient != nil {
-		return s.forwardToFrontalLobe(stream, input)
+		response, err := s.forwardToFrontalLobe(stream, input, interactionID)
+		if err != nil {
+			return err
+		}
+		s.evaluateQuality(interactionID, query, ctx.GetSemanticMemory(), response)
+		return nil
 	}
 
-	return sendFinalResponse(stream, sessionID,
+	return sendFinalResponse(stream, sessionID, interactionID,
 		fmt.Sprintf("Received query: %s (Frontal Lobe not connected)", query))
 }
 
 // enrichContextFromMemory searches Hippocampus for relevant content using
 // hybrid search (BM25 + vector with RRF) and appends matches to the context
-// snapshot. Falls back to semantic-only search when hybrid is unavailable.
-// Returns the average relevance score across results (0 if no results).
+// snapshot, dropping duplicate chunks. Falls back to semantic-only search when
+// hybrid is unavailable, then to full-text search. MinContextRelevance is
+// sent as min_score, so Hippocampus leaves out chunks scoring below it on an
+// absolute scale; when results were found but none clear the floor, the
+// snapshot is marked ContextGated and gated is true. Returns
+// the average relevance score across the distinct chunks kept (0 if none).
 func (s *CortexServer) enrichContextFromMemory(
 	reqCtx context.Context,
 	snapshot *agentv1.ContextSnapshot,
 	query string,
-) float64 {
+) (relevance float64, gated bool) {
 	if s.memoryClient == nil {
-		return 0
+		return 0, false
 	}
 
+	reqCtx, span := tracer.Start(reqCtx, "cortex.enrichContextFromMemory")
+	defer span.End()
+
 	searchReq := &memoryv1.SearchRequest{
-		Query: query,
-		TopK:  5,
+		Query:    query,
+		TopK:     int32(s.contextTopK(snapshot.GetRetrievalTopK())),
+		MinScore: float32(max(s.cfg.MinContextRelevance, 0)),
 	}
+	span.SetAttributes(attribute.Int("memory.top_k", int(searchReq.TopK)))
 
-	// Try hybrid search first, fall back to semantic-only
+	// Try hybrid search first, then semantic-only, then full-text, which
+	// works even when embeddings are unavailable
 	searchResp, err := s.memoryClient.HybridSearch(reqCtx, searchReq)
 	if err != nil {
-		s.logger.Debug("hybrid search unavailable, falling back to semantic", "error", err)
+		s.logger.DebugContext(reqCtx, "hybrid search unavailable, falling back to semantic", "error", err)
 		searchResp, err = s.memoryClient.SemanticSearch(reqCtx, searchReq)
-		if err != nil {
-			s.logger.Warn("failed to search memory", "error", err)
-			return 0
-		}
 	}
+	if err != nil {
+		s.logger.DebugContext(reqCtx, "semantic search unavailable, falling back to full-text", "error", err)
+		searchResp, err = s.memoryClient.FullTextSearch(reqCtx, searchReq)
+	}
+	if err != nil {
+		s.logger.WarnContext(reqCtx, "failed to search memory", "error", err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, false
+	}
+	span.SetAttributes(attribute.Int("memory.results", len(searchResp.GetResults())))
 
-	var totalScore float64
+	retrieved := make([]*agentv1.SemanticChunk, 0, len(searchResp.GetResults()))
 	for _, result := range searchResp.GetResults() {
-		snapshot.SemanticMemory = append(snapshot.SemanticMemory, &agentv1.SemanticChunk{
+		retrieved = append(retrieved, &agentv1.SemanticChunk{
 			ChunkId:        result.GetChunkId(),
 			Content:        result.GetContent(),
 			RelevanceScore: result.GetScore(),
 			Metadata:       result.GetMetadata(),
 		})
-		totalScore += float64(result.GetScore())
 	}
+	relevant := dedupeChunks(retrieved)
+	belowFloor := int(searchResp.GetBelowMinScore())
+	span.SetAttributes(attribute.Int("memory.below_relevance_floor", belowFloor))
+	if belowFloor > 0 && len(relevant) == 0 {
+		s.logger.DebugContext(reqCtx, "no memory results cleared the relevance floor",
+			"results", belowFloor, "min_relevance", s.cfg.MinContextRelevance)
+		snapshot.ContextGated = true
+		return 0, true
+	}
+	snapshot.SemanticMemory = dedupeChunks(append(snapshot.SemanticMemory, relevant...))
 
-	if n := len(searchResp.GetResults()); n > 0 {
-		return totalScore / float64(n)
+	var totalScore float64
+	for _, chunk := range relevant {
+		totalScore += float64(chunk.GetRelevanceScore())
+	}
+	if n := len(relevant); n > 0 {
+		return totalScore / float64(n), false
+	}
+	return 0, false
+}
+
+// contextTopK resolves how many chunks to retrieve for a query: a positive
+// per-request override wins over the configured default, and the result is
+// capped at the configured maximum.
+func (s *CortexServer) contextTopK(override int32) int {
+	topK := s.cfg.ContextTopK
+	if override > 0 {
+		topK = int(override)
+	}
+	if topK <= 0 {
+		topK = defaultContextTopK
+	}
+	if s.cfg.MaxContextTopK > 0 && topK > s.cfg.MaxContextTopK {
+		topK = s.cfg.MaxContextTopK
+	}
+	return topK
+}
+
+// enrichContextFromGraph looks up candidate entities from the query in the
+// Hippocampus knowledge graph and appends the neighbouring triples to the
+// context snapshot, capped at maxGraphTriples.
+func (s *CortexServer) enrichContextFromGraph(
+	reqCtx context.Context,
+	snapshot *agentv1.ContextSnapshot,
+	query string,
+) {
+	if s.memoryClient == nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, entity := range extractEntities(query, maxGraphEntities) {
+		resp, err := s.memoryClient.QueryGraph(reqCtx, &memoryv1.GraphQueryRequest{
+			Entity:  entity,
+			MaxHops: 1,
+		})
+		if err != nil {
+			s.logger.DebugContext(reqCtx, "graph query failed", "entity", entity, "error", err)
+			continue
+		}
+
+		for _, edge := range resp.GetEdges() {
+			key := edge.GetSource() + "\x00" + edge.GetRelationship() + "\x00" + edge.GetTarget()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			snapshot.GraphContext = append(snapshot.GraphContext, &agentv1.GraphTriple{
+				Subject:   edge.GetSource(),
+				Predicate: edge.GetRelationship(),
+				Object:    edge.GetTarget(),
+			})
+			if len(snapshot.GraphContext) >= maxGraphTriples {
+				return
+			}
+		}
 	}
-	return 0
 }
 
-// handleFeedback records a user feedback signal in the metrics store.
+// evaluateQuality asynchronously asks the quality evaluator to score a
+// response and, on success, replaces the interaction's initial quality
+// estimate in the metrics store. It is a no-op unless evaluation is enabled.
+func (s *CortexServer) evaluateQuality(interactionID, query string, chunks []*agentv1.SemanticChunk, response string) {
+	if !s.cfg.QualityEvalEnabled || s.evaluator == nil || response == "" {
+		return
+	}
+
+	contextChunks := make([]string, len(chunks))
+	for i, c := range chunks {
+		contextChunks[i] = c.GetContent()
+	}
+
+	s.evalWG.Add(1)
+	go func() {
+		defer s.evalWG.Done()
+
+		ctx := context.Background()
+		if s.cfg.QualityEvalTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.cfg.QualityEvalTimeout)
+			defer cancel()
+		}
+
+		score, err := s.evaluator.Evaluate(ctx, query, contextChunks, response)
+		if err != nil {
+			s.logger.Warn("response quality evaluation failed", "interaction_id", interactionID, "error", err)
+			return
+		}
+		s.metricsStore.UpdateResponseQuality(interactionID, score)
+	}()
+}
+
+// summarizeEvicted asynchronously folds turns evicted from a session's
+// episodic memory into its running summary. It is a no-op unless
+// summarization is enabled. Each session's batches are summarized one at a
+// time in eviction order, so no update is lost or applied out of order,
+// while different sessions are summarized concurrently.
+func (s *CortexServer) summarizeEvicted(sess *session.Session, evicted []string) {
+	if !s.cfg.SessionSummarize || s.summarizer == nil || len(evicted) == 0 {
+		return
+	}
+
+	s.summaryMu.Lock()
+	defer s.summaryMu.Unlock()
+	if s.summaryQueue == nil {
+		s.summaryQueue = make(map[string][][]string)
+	}
+	// A session has a worker draining its queue while it has an entry.
+	batches, running := s.summaryQueue[sess.ID]
+	s.summaryQueue[sess.ID] = append(batches, evicted)
+	if running {
+		return
+	}
+
+	s.summaryWG.Add(1)
+	go func() {
+		defer s.summaryWG.Done()
+		for {
+			s.summaryMu.Lock()
+			batches := s.summaryQueue[sess.ID]
+			if len(batches) == 0 {
+				delete(s.summaryQueue, sess.ID)
+				s.summaryMu.Unlock()
+				return
+			}
+			s.summaryQueue[sess.ID] = batches[1:]
+			s.summaryMu.Unlock()
+
+			s.summarize(sess, batches[0])
+		}
+	}()
+}
+
+// summarize folds one batch of evicted turns into sess's running summary.
+func (s *CortexServer) summarize(sess *session.Session, evicted []string) {
+	ctx := context.Background()
+	if s.cfg.DefaultTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.DefaultTimeout)
+		defer cancel()
+	}
+
+	summary, err := s.summarizer.Summarize(ctx, sess.GetEpisodicSummary(), evicted)
+	if err != nil {
+		s.logger.Warn("episodic memory summarization failed", "session_id", sess.ID, "error", err)
+		return
+	}
+	sess.SetEpisodicSummary(summary)
+}
+
+// handleFeedback records a user feedback signal in the metrics store. Feedback
+// that names a known interaction is attached to that interaction's record;
+// otherwise it is recorded as a standalone session-level signal.
 func (s *CortexServer) handleFeedback(sessionID string, feedback *agentv1.FeedbackSignal) {
 	var feedbackType metrics.FeedbackType
 	switch feedback.GetSentiment() {
@@ -257,6 +606,14 @@ func (s *CortexServer) handleFeedback(sessionID string, feedback *agentv1.Feedba
 	case agentv1.FeedbackSignal_CORRECTION:
 		feedbackType = metrics.FeedbackCorrection
 	}
+
+	if id := feedback.GetInteractionId(); id != "" {
+		if s.metricsStore.RecordFeedbackFor(id, feedbackType) {
+			return
+		}
+		s.logger.Debug("feedback for unknown interaction", "interaction_id", id)
+	}
+
 	s.metricsStore.Record(metrics.InteractionRecord{
 		SessionID: sessionID,
 		Timestamp: time.Now(),
@@ -281,46 +638,78 @@ func sendStatus(stream agentv1.ReasoningEngine_StreamThoughtProcessServer, sessi
 }
 
 // sendFinalResponse sends a final response to the client stream.
-func sendFinalResponse(stream agentv1.ReasoningEngine_StreamThoughtProcessServer, sessionID, response string) error {
+func sendFinalResponse(stream agentv1.ReasoningEngine_StreamThoughtProcessServer, sessionID, interactionID, response string) error {
 	return stream.Send(&agentv1.AgentOutput{
-		SessionId: sessionID,
-		Timestamp: timestamppb.Now(),
+		SessionId:     sessionID,
+		InteractionId: interactionID,
+		Timestamp:     timestamppb.Now(),
 		OutputType: &agentv1.AgentOutput_FinalResponse{
 			FinalResponse: response,
 		},
 	})
 }
 
+// reasoningContext derives the context for a Frontal Lobe stream from the
+// client's, bounded by the configured ReasoningTimeout. A deadline the client
+// set on its own call applies only when it is earlier, so callers can shorten
+// the timeout per request but not extend it. Canceling the returned context
+// tears down the downstream stream.
+func (s *CortexServer) reasoningContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.cfg.ReasoningTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	// WithTimeout keeps the parent's deadline when it is the earlier one.
+	return context.WithTimeout(ctx, s.cfg.ReasoningTimeout)
+}
+
+// forwardToFrontalLobe relays the input to the Frontal Lobe and streams its
+// outputs back to the client, tagged with the interaction ID. It returns the
+// final response text, if any.
 func (s *CortexServer) forwardToFrontalLobe(
 	clientStream agentv1.ReasoningEngine_StreamThoughtProcessServer,
 	input *agentv1.AgentInput,
-) error {
-	ctx, cancel := context.WithTimeout(clientStream.Context(), 5*time.Minute)
+	interactionID string,
+) (string, error) {
+	ctx, cancel := s.reasoningContext(clientStream.Context())
 	defer cancel()
 
 	frontalStream, err := s.frontalClient.StreamThoughtProcess(ctx)
 	if err != nil {
-		return fmt.Errorf("connecting to frontal lobe stream: %w", err)
+		return "", fmt.Errorf("connecting to frontal lobe stream: %w", err)
 	}
 
 	// Send input to frontal lobe
 	if err := frontalStream.Send(input); err != nil {
-		return fmt.Errorf("sending to frontal lobe: %w", err)
+		return "", fmt.Errorf("sending to frontal lobe: %w", err)
 	}
 	frontalStream.CloseSend()
 
-	// Relay responses back to client
+	// Relay responses back to client. The downstream context is derived from
+	// the client's, so returning on client cancellation (and the deferred
+	// cancel) tears down the frontal lobe stream instead of letting it keep
+	// generating for nobody.
+	var finalResponse string
 	for {
+		if err := clientStream.Context().Err(); err != nil {
+			s.logger.InfoContext(clientStream.Context(), "client canceled, aborting frontal lobe stream", "session_id", input.GetSessionId())
+			return "", fmt.Errorf("client stream closed: %w", err)
+		}
+
 		output, err := frontalStream.Recv()
 		if err == io.EOF {
-			return nil
+			return finalResponse, nil
 		}
 		if err != nil {
-			return fmt.Errorf("receiving from frontal lobe: %w", err)
+			return "", fmt.Errorf("receiving from frontal lobe: %w", err)
 		}
 
+		if resp := output.GetFinalResponse(); resp != "" {
+			finalResponse = resp
+		}
+		output.InteractionId = interactionID
+
 		if err := clientStream.Send(output); err != nil {
-			return fmt.Errorf("relaying to client: %w", err)
+			return "", fmt.Errorf("relaying to client: %w", err)
 		}
 	}
 }
@@ -346,31 +735,112 @@ func (s *CortexServer) GenerateWeeklyReview(ctx context.Context, req *agentv1.We
 	}, nil
 }
 
-// IngestItem implements the IngestionService IngestItem RPC (proxy).
+// GenerateMonthlyReview implements the monthly review generation RPC.
+func (s *CortexServer) GenerateMonthlyReview(ctx context.Context, req *agentv1.MonthlyReviewRequest) (*agentv1.MonthlyReviewResponse, error) {
+	if s.frontalClient != nil {
+		return s.frontalClient.GenerateMonthlyReview(ctx, req)
+	}
+	return &agentv1.MonthlyReviewResponse{
+		ReportMarkdown: "Monthly review generation requires the Frontal Lobe service.",
+	}, nil
+}
+
+// StreamWeeklyReview relays the Frontal Lobe's streamed weekly review to the
+// client chunk by chunk.
+func (s *CortexServer) StreamWeeklyReview(req *agentv1.WeeklyReviewRequest, stream agentv1.ReasoningEngine_StreamWeeklyReviewServer) error {
+	if s.frontalClient == nil {
+		return stream.Send(&agentv1.WeeklyReviewChunk{
+			Section:  "report",
+			Markdown: "Weekly review generation requires the Frontal Lobe service.",
+		})
+	}
+
+	ctx, cancel := s.reasoningContext(stream.Context())
+	defer cancel()
+
+	frontalStream, err := s.frontalClient.StreamWeeklyReview(ctx, req)
+	if err != nil {
+		return fmt.Errorf("connecting to frontal lobe stream: %w", err)
+	}
+	for {
+		if err := stream.Context().Err(); err != nil {
+			return fmt.Errorf("client stream closed: %w", err)
+		}
+
+		chunk, err := frontalStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("receiving from frontal lobe: %w", err)
+		}
+		if err := stream.Send(chunk); err != nil {
+			return fmt.Errorf("relaying to client: %w", err)
+		}
+	}
+}
+
+// IngestItem implements the IngestionService IngestItem RPC (proxy). A
+// repeated idempotency key returns the first response without indexing the
+// item again.
 func (s *CortexServer) IngestItem(ctx context.Context, req *ingestionv1.IngestRequest) (*ingestionv1.IngestResponse, error) {
-	item := req.GetItem()
-	s.logger.Info("ingesting item", "id", item.GetId(), "source", item.GetSource())
+	key := ingestKey(ctx, req)
+	resp, replayed, err := s.idempotency.do(ctx, key, func() (*ingestionv1.IngestResponse, bool) {
+		return s.ingestItem(ctx, req.GetItem())
+	})
+	if err != nil {
+		return nil, err
+	}
+	if replayed {
+		s.logger.InfoContext(ctx, "replaying ingest response for repeated idempotency key", "id", resp.GetItemId(), "idempotency_key", key)
+	}
+	return resp, nil
+}
+
+// ingestItem indexes an item in Hippocampus, reporting whether it was fully
+// processed so that a failed delivery is not remembered as done. An item
+// that could not be indexed is answered with Accepted=false and an ERROR
+// status, so the sender keeps it for a retry.
+func (s *CortexServer) ingestItem(ctx context.Context, item *ingestionv1.InboxItem) (*ingestionv1.IngestResponse, bool) {
+	s.logger.InfoContext(ctx, "ingesting item", "id", item.GetId(), "source", item.GetSource())
 
+	indexed := true
 	// Index in Hippocampus for semantic search
 	if s.memoryClient != nil && item.GetContent() != "" {
-		_, err := s.memoryClient.IndexDocument(ctx, &memoryv1.IndexRequest{
+		metadata := map[string]string{}
+		for k, v := range item.GetRawMetadata() {
+			metadata[k] = v
+		}
+		metadata["source"] = item.GetSource()
+		metadata["source_id"] = item.GetSourceId()
+		metadata["content_type"] = item.GetContentType()
+		resp, err := s.memoryClient.IndexDocument(ctx, &memoryv1.IndexRequest{
 			DocumentId: item.GetId(),
 			Content:    item.GetContent(),
-			Metadata: map[string]string{
-				"source":     item.GetSource(),
-				"source_id":  item.GetSourceId(),
-				"content_type": item.GetContentType(),
-			},
+			Metadata:   metadata,
 		})
-		if err != nil {
-			s.logger.Warn("failed to index document", "error", err)
+		switch {
+		case err != nil:
+			s.logger.WarnContext(ctx, "failed to index document", "error", err)
+			indexed = false
+		case !resp.GetSuccess():
+			// Hippocampus reports indexing failures in the response.
+			s.logger.WarnContext(ctx, "hippocampus rejected document", "id", item.GetId(), "error", resp.GetErrorMessage())
+			indexed = false
 		}
 	}
 
+	if !indexed {
+		return &ingestionv1.IngestResponse{
+			ItemId:  item.GetId(),
+			Message: "Item could not be indexed",
+			Status:  commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR,
+		}, false
+	}
 	return &ingestionv1.IngestResponse{
 		ItemId:   item.GetId(),
 		Accepted: true,
 		Message:  "Item accepted for processing",
 		Status:   commonv1.ProcessingStatus_PROCESSING_STATUS_ANALYZING,
-	}, nil
+	}, true
 }
@@ -2,14 +2,31 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"log/slog"
 	"os"
+	"path/filepath"
 
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/config"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/metrics"
 	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
 	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
 	ingestionv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/ingestion/v1"
+	memoryv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/memory/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -17,8 +34,15 @@ func newTestLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
 }
 
+func newTestConfig() *config.Config {
+	return &config.Config{
+		ContextTopK:    5,
+		MaxContextTopK: 20,
+	}
+}
+
 func TestHealthCheck(t *testing.T) {
-	s := NewCortexServer(newTestLogger())
+	s := NewCortexServer(newTestLogger(), newTestConfig())
 
 	resp, err := s.Check(context.Background(), &commonv1.HealthCheckRequest{
 		Service: "cortex",
@@ -41,7 +65,7 @@ func TestHealthCheck(t *testing.T) {
 }
 
 func TestClassifyItemWithoutFrontalLobe(t *testing.T) {
-	s := NewCortexServer(newTestLogger())
+	s := NewCortexServer(newTestLogger(), newTestConfig())
 
 	resp, err := s.ClassifyItem(context.Background(), &agentv1.ClassifyRequest{
 		Content: "test content",
@@ -57,7 +81,7 @@ func TestClassifyItemWithoutFrontalLobe(t *testing.T) {
 }
 
 func TestGenerateWeeklyReviewWithoutFrontalLobe(t *testing.T) {
-	s := NewCortexServer(newTestLogger())
+	s := NewCortexServer(newTestLogger(), newTestConfig())
 
 	resp, err := s.GenerateWeeklyReview(context.Background(), &agentv1.WeeklyReviewRequest{
 		UserId: "test-user",
@@ -71,8 +95,23 @@ func TestGenerateWeeklyReviewWithoutFrontalLobe(t *testing.T) {
 	}
 }
 
+func TestGenerateMonthlyReviewWithoutFrontalLobe(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+
+	resp, err := s.GenerateMonthlyReview(context.Background(), &agentv1.MonthlyReviewRequest{
+		UserId: "test-user",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.ReportMarkdown == "" {
+		t.Error("expected non-empty report")
+	}
+}
+
 func TestIngestItemWithoutHippocampus(t *testing.T) {
-	s := NewCortexServer(newTestLogger())
+	s := NewCortexServer(newTestLogger(), newTestConfig())
 
 	resp, err := s.IngestItem(context.Background(), &ingestionv1.IngestRequest{
 		Item: &ingestionv1.InboxItem{
@@ -94,3 +133,1028 @@ func TestIngestItemWithoutHippocampus(t *testing.T) {
 		t.Errorf("expected item ID 'item-1', got %q", resp.ItemId)
 	}
 }
+
+// --- fakes for downstream services ---
+
+// fakeMemoryClient implements memoryv1.MemoryServiceClient for testing.
+type fakeMemoryClient struct {
+	memoryv1.MemoryServiceClient
+	searchResults []*memoryv1.SearchResult
+	graphEdges    map[string][]*memoryv1.GraphEdge // entity -> edges
+	searchReqs    []*memoryv1.SearchRequest
+	graphReqs     []*memoryv1.GraphQueryRequest
+	embeddingErr  error // fails the searches that need embeddings
+	searchModes   []string
+}
+
+func (f *fakeMemoryClient) HybridSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	return f.search("hybrid", in, f.embeddingErr)
+}
+
+func (f *fakeMemoryClient) SemanticSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	return f.search("semantic", in, f.embeddingErr)
+}
+
+func (f *fakeMemoryClient) FullTextSearch(ctx context.Context, in *memoryv1.SearchRequest, opts ...grpc.CallOption) (*memoryv1.SearchResponse, error) {
+	return f.search("fts", in, nil)
+}
+
+func (f *fakeMemoryClient) search(mode string, in *memoryv1.SearchRequest, err error) (*memoryv1.SearchResponse, error) {
+	f.searchModes = append(f.searchModes, mode)
+	if err != nil {
+		return nil, err
+	}
+	f.searchReqs = append(f.searchReqs, in)
+	// Like Hippocampus, leave out results below min_score.
+	resp := &memoryv1.SearchResponse{}
+	for _, r := range f.searchResults {
+		if in.GetMinScore() > 0 && r.GetScore() < in.GetMinScore() {
+			resp.BelowMinScore++
+			continue
+		}
+		resp.Results = append(resp.Results, r)
+	}
+	return resp, nil
+}
+
+func (f *fakeMemoryClient) QueryGraph(ctx context.Context, in *memoryv1.GraphQueryRequest, opts ...grpc.CallOption) (*memoryv1.GraphQueryResponse, error) {
+	f.graphReqs = append(f.graphReqs, in)
+	return &memoryv1.GraphQueryResponse{Edges: f.graphEdges[in.GetEntity()]}, nil
+}
+
+// fakeFrontalClient implements agentv1.ReasoningEngineClient, capturing the
+// inputs forwarded on StreamThoughtProcess and replying with a final response.
+type fakeFrontalClient struct {
+	agentv1.ReasoningEngineClient
+	inputs []*agentv1.AgentInput
+}
+
+func (f *fakeFrontalClient) StreamThoughtProcess(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[agentv1.AgentInput, agentv1.AgentOutput], error) {
+	return &fakeFrontalStream{ctx: ctx, client: f}, nil
+}
+
+type fakeFrontalStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	client *fakeFrontalClient
+	sent   bool
+}
+
+func (f *fakeFrontalStream) Send(in *agentv1.AgentInput) error {
+	f.client.inputs = append(f.client.inputs, in)
+	return nil
+}
+
+func (f *fakeFrontalStream) CloseSend() error { return nil }
+
+func (f *fakeFrontalStream) Context() context.Context { return f.ctx }
+
+func (f *fakeFrontalStream) Recv() (*agentv1.AgentOutput, error) {
+	if f.sent {
+		return nil, io.EOF
+	}
+	f.sent = true
+	return &agentv1.AgentOutput{
+		OutputType: &agentv1.AgentOutput_FinalResponse{FinalResponse: "fake answer"},
+	}, nil
+}
+
+// fakeClientStream implements the server side of StreamThoughtProcess,
+// feeding scripted inputs and collecting outputs.
+type fakeClientStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	inputs  []*agentv1.AgentInput
+	outputs []*agentv1.AgentOutput
+	onSend  func(*agentv1.AgentOutput)
+}
+
+func (f *fakeClientStream) Context() context.Context { return f.ctx }
+
+func (f *fakeClientStream) Recv() (*agentv1.AgentInput, error) {
+	if len(f.inputs) == 0 {
+		return nil, io.EOF
+	}
+	in := f.inputs[0]
+	f.inputs = f.inputs[1:]
+	return in, nil
+}
+
+func (f *fakeClientStream) Send(out *agentv1.AgentOutput) error {
+	f.outputs = append(f.outputs, out)
+	if f.onSend != nil {
+		f.onSend(out)
+	}
+	return nil
+}
+
+// endlessFrontalClient streams thoughts until its context is canceled,
+// counting how many times Recv is called.
+type endlessFrontalClient struct {
+	agentv1.ReasoningEngineClient
+	recvs int
+}
+
+func (f *endlessFrontalClient) StreamThoughtProcess(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[agentv1.AgentInput, agentv1.AgentOutput], error) {
+	return &endlessFrontalStream{ctx: ctx, client: f}, nil
+}
+
+type endlessFrontalStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	client *endlessFrontalClient
+}
+
+func (f *endlessFrontalStream) Send(*agentv1.AgentInput) error { return nil }
+
+func (f *endlessFrontalStream) CloseSend() error { return nil }
+
+func (f *endlessFrontalStream) Context() context.Context { return f.ctx }
+
+func (f *endlessFrontalStream) Recv() (*agentv1.AgentOutput, error) {
+	f.client.recvs++
+	if f.client.recvs > 100 {
+		return nil, io.EOF
+	}
+	return &agentv1.AgentOutput{
+		OutputType: &agentv1.AgentOutput_ThoughtChain{ThoughtChain: "still thinking"},
+	}, nil
+}
+
+func queryInput(sessionID, query string) *agentv1.AgentInput {
+	return &agentv1.AgentInput{
+		SessionId: sessionID,
+		InputType: &agentv1.AgentInput_UserQuery{UserQuery: query},
+	}
+}
+
+func TestGraphContextReachesFrontalLobe(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	memory := &fakeMemoryClient{
+		graphEdges: map[string][]*memoryv1.GraphEdge{
+			"PhaseNet-TF": {
+				{Source: "PhaseNet-TF", Target: "PhaseNet", Relationship: "extends"},
+				{Source: "Ziyi", Target: "PhaseNet-TF", Relationship: "authored"},
+			},
+		},
+	}
+	frontal := &fakeFrontalClient{}
+	s.memoryClient = memory
+	s.frontalClient = frontal
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-graph", "What does PhaseNet-TF extend?")},
+	}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	if len(frontal.inputs) != 1 {
+		t.Fatalf("expected 1 forwarded input, got %d", len(frontal.inputs))
+	}
+	graph := frontal.inputs[0].GetContext().GetGraphContext()
+	if len(graph) != 2 {
+		t.Fatalf("expected 2 graph triples in context, got %d", len(graph))
+	}
+	if graph[0].GetSubject() != "PhaseNet-TF" || graph[0].GetPredicate() != "extends" || graph[0].GetObject() != "PhaseNet" {
+		t.Errorf("unexpected first triple: %v", graph[0])
+	}
+	if len(memory.graphReqs) == 0 || memory.graphReqs[0].GetEntity() != "PhaseNet-TF" {
+		t.Errorf("expected PhaseNet-TF to be queried first, got %v", memory.graphReqs)
+	}
+}
+
+func TestGraphContextIsCapped(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	var edges []*memoryv1.GraphEdge
+	for i := 0; i < maxGraphTriples+5; i++ {
+		edges = append(edges, &memoryv1.GraphEdge{
+			Source: "Kubernetes", Target: fmt.Sprintf("node-%d", i), Relationship: "runs",
+		})
+	}
+	s.memoryClient = &fakeMemoryClient{graphEdges: map[string][]*memoryv1.GraphEdge{"Kubernetes": edges}}
+
+	snapshot := &agentv1.ContextSnapshot{}
+	s.enrichContextFromGraph(context.Background(), snapshot, "Kubernetes deployment")
+
+	if len(snapshot.GraphContext) != maxGraphTriples {
+		t.Errorf("expected %d triples, got %d", maxGraphTriples, len(snapshot.GraphContext))
+	}
+}
+
+func TestExtractEntities(t *testing.T) {
+	got := extractEntities("What do my notes say about PhaseNet-TF and seismic detection?", 3)
+	want := []string{"PhaseNet-TF", "seismic", "detection"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entity %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestMemoryContextFallsBackToFullText(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	memory := &fakeMemoryClient{
+		searchResults: []*memoryv1.SearchResult{{ChunkId: "c1", Content: "BM25 hit", Score: 0.5}},
+		embeddingErr:  errors.New("embedding API down"),
+	}
+	s.memoryClient = memory
+
+	snapshot := &agentv1.ContextSnapshot{}
+	relevance, _ := s.enrichContextFromMemory(context.Background(), snapshot, "bm25")
+
+	if got := fmt.Sprint(memory.searchModes); got != "[hybrid semantic fts]" {
+		t.Errorf("expected hybrid, semantic then fts searches, got %s", got)
+	}
+	if len(snapshot.SemanticMemory) != 1 || snapshot.SemanticMemory[0].GetContent() != "BM25 hit" {
+		t.Errorf("expected the full-text hit in context, got %v", snapshot.SemanticMemory)
+	}
+	if relevance != 0.5 {
+		t.Errorf("expected relevance 0.5, got %v", relevance)
+	}
+}
+
+func TestMinContextRelevanceGatesWeakChunks(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.MinContextRelevance = 0.5
+	s := NewCortexServer(newTestLogger(), cfg)
+	s.memoryClient = &fakeMemoryClient{
+		searchResults: []*memoryv1.SearchResult{
+			{ChunkId: "c1", Content: "barely related", Score: 0.2},
+			{ChunkId: "c2", Content: "also weak", Score: 0.45},
+		},
+	}
+	frontal := &fakeFrontalClient{}
+	s.frontalClient = frontal
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-gate", "what did I decide about the roadmap")},
+	}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	if len(frontal.inputs) != 1 {
+		t.Fatalf("expected 1 forwarded input, got %d", len(frontal.inputs))
+	}
+	snapshot := frontal.inputs[0].GetContext()
+	if len(snapshot.GetSemanticMemory()) != 0 {
+		t.Errorf("expected no chunks below the floor in context, got %v", snapshot.GetSemanticMemory())
+	}
+	if !snapshot.GetContextGated() {
+		t.Error("expected the snapshot to be marked as gated")
+	}
+	if summary := s.metricsStore.Summary(); summary.ContextGated != 1 || summary.AvgContextRelevance != 0 {
+		t.Errorf("expected one gated interaction with no relevance, got gated=%d relevance=%v",
+			summary.ContextGated, summary.AvgContextRelevance)
+	}
+}
+
+func TestMinContextRelevanceKeepsStrongChunks(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.MinContextRelevance = 0.5
+	s := NewCortexServer(newTestLogger(), cfg)
+	memory := &fakeMemoryClient{
+		searchResults: []*memoryv1.SearchResult{
+			{ChunkId: "c1", Content: "the roadmap decision", Score: 0.9},
+			{ChunkId: "c2", Content: "barely related", Score: 0.2},
+		},
+	}
+	s.memoryClient = memory
+
+	snapshot := &agentv1.ContextSnapshot{}
+	relevance, gated := s.enrichContextFromMemory(context.Background(), snapshot, "roadmap")
+
+	if got := memory.searchReqs[0].GetMinScore(); got != 0.5 {
+		t.Errorf("expected the relevance floor to be sent as min_score, got %v", got)
+	}
+
+	if gated || snapshot.GetContextGated() {
+		t.Error("expected no gating when a chunk clears the floor")
+	}
+	if len(snapshot.SemanticMemory) != 1 || snapshot.SemanticMemory[0].GetChunkId() != "c1" {
+		t.Errorf("expected only the strong chunk, got %v", snapshot.SemanticMemory)
+	}
+	if math.Abs(relevance-0.9) > 1e-6 {
+		t.Errorf("expected relevance of the kept chunk, got %v", relevance)
+	}
+}
+
+func TestContextTopKFromConfig(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.ContextTopK = 8
+	s := NewCortexServer(newTestLogger(), cfg)
+	memory := &fakeMemoryClient{}
+	s.memoryClient = memory
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-topk", "machine learning")},
+	}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	if len(memory.searchReqs) != 1 {
+		t.Fatalf("expected 1 search request, got %d", len(memory.searchReqs))
+	}
+	if got := memory.searchReqs[0].GetTopK(); got != 8 {
+		t.Errorf("expected configured topK 8, got %d", got)
+	}
+}
+
+func TestContextTopKOverride(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+
+	tests := []struct {
+		name     string
+		override int32
+		want     int
+	}{
+		{"no override uses config", 0, 5},
+		{"negative override ignored", -3, 5},
+		{"override within bounds", 12, 12},
+		{"override capped at max", 500, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.contextTopK(tt.override); got != tt.want {
+				t.Errorf("contextTopK(%d) = %d, want %d", tt.override, got, tt.want)
+			}
+		})
+	}
+
+	s.cfg.ContextTopK = 0
+	if got := s.contextTopK(0); got != defaultContextTopK {
+		t.Errorf("expected fallback to %d for non-positive config, got %d", defaultContextTopK, got)
+	}
+}
+
+// fakeEvaluator implements QualityEvaluator with a fixed score.
+type fakeEvaluator struct {
+	score    float64
+	query    string
+	chunks   []string
+	response string
+}
+
+func (f *fakeEvaluator) Evaluate(ctx context.Context, query string, contextChunks []string, response string) (float64, error) {
+	f.query = query
+	f.chunks = contextChunks
+	f.response = response
+	return f.score, nil
+}
+
+func TestQualityEvaluationRecordsScore(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.QualityEvalEnabled = true
+	s := NewCortexServer(newTestLogger(), cfg)
+	s.memoryClient = &fakeMemoryClient{
+		searchResults: []*memoryv1.SearchResult{{ChunkId: "c1", Content: "ML is a subset of AI", Score: 0.4}},
+	}
+	s.frontalClient = &fakeFrontalClient{}
+	evaluator := &fakeEvaluator{score: 0.9}
+	s.SetQualityEvaluator(evaluator)
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-eval", "what is machine learning")},
+	}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	s.evalWG.Wait()
+
+	if evaluator.query != "what is machine learning" {
+		t.Errorf("expected evaluator to receive the query, got %q", evaluator.query)
+	}
+	if evaluator.response != "fake answer" {
+		t.Errorf("expected evaluator to receive the final response, got %q", evaluator.response)
+	}
+	if len(evaluator.chunks) != 1 || evaluator.chunks[0] != "ML is a subset of AI" {
+		t.Errorf("expected retrieved context to be passed, got %v", evaluator.chunks)
+	}
+
+	summary := s.MetricsStore().Summary()
+	if summary.AvgResponseQuality != 0.9 {
+		t.Errorf("expected evaluated quality 0.9, got %f", summary.AvgResponseQuality)
+	}
+	if math.Abs(summary.AvgContextRelevance-0.4) > 1e-6 {
+		t.Errorf("expected context relevance 0.4 to be preserved, got %f", summary.AvgContextRelevance)
+	}
+}
+
+func TestQualityEvaluationDisabledByDefault(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	s.frontalClient = &fakeFrontalClient{}
+	evaluator := &fakeEvaluator{score: 0.9}
+	s.SetQualityEvaluator(evaluator)
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-noeval", "hello")},
+	}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	s.evalWG.Wait()
+
+	if evaluator.response != "" {
+		t.Error("expected evaluator not to be called when disabled")
+	}
+}
+
+// fakeSummarizer implements TurnSummarizer by recording its input.
+type fakeSummarizer struct {
+	previous string
+	evicted  []string
+}
+
+func (f *fakeSummarizer) Summarize(ctx context.Context, previous string, evicted []string) (string, error) {
+	f.previous = previous
+	f.evicted = append(f.evicted, evicted...)
+	return "the user asked about q1", nil
+}
+
+func TestEvictedTurnsAreSummarized(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.SessionMaxTurns = 2
+	cfg.SessionSummarize = true
+	s := NewCortexServer(newTestLogger(), cfg)
+	frontal := &fakeFrontalClient{}
+	s.frontalClient = frontal
+	summarizer := &fakeSummarizer{}
+	s.SetTurnSummarizer(summarizer)
+
+	for _, q := range []string{"q1", "q2", "q3"} {
+		stream := &fakeClientStream{
+			ctx:    context.Background(),
+			inputs: []*agentv1.AgentInput{queryInput("sess-sum", q)},
+		}
+		if err := s.StreamThoughtProcess(stream); err != nil {
+			t.Fatalf("stream error: %v", err)
+		}
+		s.summaryWG.Wait()
+	}
+
+	if len(summarizer.evicted) != 1 || summarizer.evicted[0] != "User: q1" {
+		t.Fatalf("expected only the oldest turn to be summarized, got %v", summarizer.evicted)
+	}
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-sum", "q4")},
+	}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	s.summaryWG.Wait()
+
+	mem := frontal.inputs[len(frontal.inputs)-1].GetContext().GetEpisodicMemory()
+	want := []string{"Summary of earlier turns: the user asked about q1", "User: q3", "User: q4"}
+	if strings.Join(mem, "|") != strings.Join(want, "|") {
+		t.Errorf("expected episodic memory %v, got %v", want, mem)
+	}
+	if summarizer.previous != "the user asked about q1" {
+		t.Errorf("expected the earlier summary to be passed on, got %q", summarizer.previous)
+	}
+}
+
+// chainSummarizer appends each batch to the previous summary, holding the
+// first call until release is closed.
+type chainSummarizer struct {
+	started chan struct{}
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (f *chainSummarizer) Summarize(ctx context.Context, previous string, evicted []string) (string, error) {
+	if f.calls.Add(1) == 1 {
+		close(f.started)
+		<-f.release
+	}
+	return strings.Join(append([]string{previous}, evicted...), "|"), nil
+}
+
+func TestEvictedBatchesAreSummarizedInOrder(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.SessionSummarize = true
+	s := NewCortexServer(newTestLogger(), cfg)
+	summarizer := &chainSummarizer{started: make(chan struct{}), release: make(chan struct{})}
+	s.SetTurnSummarizer(summarizer)
+	sess := s.sessionMgr.Create("sess-order", "u1")
+
+	s.summarizeEvicted(sess, []string{"a"})
+	<-summarizer.started
+	// Queued while the first batch is still being summarized.
+	s.summarizeEvicted(sess, []string{"b"})
+	s.summarizeEvicted(sess, []string{"c"})
+	close(summarizer.release)
+	s.summaryWG.Wait()
+
+	if got := sess.GetEpisodicSummary(); got != "|a|b|c" {
+		t.Errorf("expected batches folded in eviction order, got %q", got)
+	}
+}
+
+func TestParseQualityScore(t *testing.T) {
+	tests := []struct {
+		verdict string
+		want    float64
+		wantErr bool
+	}{
+		{"0.75", 0.75, false},
+		{"Score: 0.3 because the answer is vague", 0.3, false},
+		{"1", 1, false},
+		{"7.5", 1, false},
+		{"no idea", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseQualityScore(tt.verdict)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseQualityScore(%q) error = %v, wantErr %v", tt.verdict, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseQualityScore(%q) = %f, want %f", tt.verdict, got, tt.want)
+		}
+	}
+}
+
+func TestFeedbackTargetsInteraction(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	s.memoryClient = &fakeMemoryClient{
+		searchResults: []*memoryv1.SearchResult{{ChunkId: "c1", Content: "context", Score: 0.6}},
+	}
+	s.frontalClient = &fakeFrontalClient{}
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-fb", "what is a second brain")},
+	}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	var interactionID string
+	for _, out := range stream.outputs {
+		if out.GetFinalResponse() != "" {
+			interactionID = out.GetInteractionId()
+		}
+	}
+	if interactionID == "" {
+		t.Fatal("expected final response to carry an interaction ID")
+	}
+
+	feedback := &fakeClientStream{
+		ctx: context.Background(),
+		inputs: []*agentv1.AgentInput{{
+			SessionId: "sess-fb",
+			InputType: &agentv1.AgentInput_UserFeedback{UserFeedback: &agentv1.FeedbackSignal{
+				Sentiment:     agentv1.FeedbackSignal_NEGATIVE,
+				InteractionId: interactionID,
+			}},
+		}},
+	}
+	if err := s.StreamThoughtProcess(feedback); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	summary := s.MetricsStore().Summary()
+	if summary.TotalInteractions != 1 {
+		t.Errorf("expected targeted feedback not to add a record, got %d interactions", summary.TotalInteractions)
+	}
+	if summary.FeedbackCounts["negative"] != 1 {
+		t.Errorf("expected 1 negative feedback, got %v", summary.FeedbackCounts)
+	}
+	if math.Abs(summary.AvgResponseQuality-0.3) > 1e-6 {
+		t.Errorf("expected quality lowered to 0.3, got %f", summary.AvgResponseQuality)
+	}
+}
+
+func TestSemanticMemoryDeduplicated(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	s.memoryClient = &fakeMemoryClient{
+		searchResults: []*memoryv1.SearchResult{
+			{ChunkId: "c1", Content: "Go channels enable CSP-style concurrency", Score: 0.5},
+			{ChunkId: "c2", Content: "Kubernetes schedules pods", Score: 0.7},
+			{ChunkId: "c1", Content: "Go channels enable CSP-style concurrency", Score: 0.9},
+			{ChunkId: "", Content: "  kubernetes   schedules PODS ", Score: 0.6},
+		},
+	}
+	frontal := &fakeFrontalClient{}
+	s.frontalClient = frontal
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-dedup", "go concurrency")},
+	}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	chunks := frontal.inputs[0].GetContext().GetSemanticMemory()
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 distinct chunks, got %d: %v", len(chunks), chunks)
+	}
+	if chunks[0].GetChunkId() != "c1" || chunks[0].GetRelevanceScore() != 0.9 {
+		t.Errorf("expected c1 with the highest score 0.9, got %s/%f", chunks[0].GetChunkId(), chunks[0].GetRelevanceScore())
+	}
+	if chunks[1].GetChunkId() != "c2" || chunks[1].GetRelevanceScore() != 0.7 {
+		t.Errorf("expected c2 with score 0.7, got %s/%f", chunks[1].GetChunkId(), chunks[1].GetRelevanceScore())
+	}
+}
+
+func TestClientCancellationStopsDownstreamRecv(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	frontal := &endlessFrontalClient{}
+	s.frontalClient = frontal
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &fakeClientStream{
+		ctx:    ctx,
+		inputs: []*agentv1.AgentInput{queryInput("sess-cancel", "long question")},
+	}
+	stream.onSend = func(out *agentv1.AgentOutput) {
+		if out.GetThoughtChain() != "" {
+			cancel()
+		}
+	}
+
+	if err := s.StreamThoughtProcess(stream); err == nil {
+		t.Fatal("expected an error after the client canceled")
+	}
+	if frontal.recvs != 1 {
+		t.Errorf("expected downstream Recv to stop after cancellation, got %d calls", frontal.recvs)
+	}
+}
+
+// fakeHealthClient implements commonv1.HealthServiceClient with a fixed
+// status, or an error when err is set.
+type fakeHealthClient struct {
+	status commonv1.HealthCheckResponse_ServingStatus
+	err    error
+}
+
+func (f *fakeHealthClient) Check(ctx context.Context, in *commonv1.HealthCheckRequest, opts ...grpc.CallOption) (*commonv1.HealthCheckResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &commonv1.HealthCheckResponse{Status: f.status}, nil
+}
+
+func TestHealthCheckRequiredDependencyDown(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RequiredDependencies = []string{dependencyFrontalLobe}
+	s := NewCortexServer(newTestLogger(), cfg)
+	s.healthClients[dependencyFrontalLobe] = &fakeHealthClient{err: errors.New("connection refused")}
+	s.healthClients[dependencyHippocampus] = &fakeHealthClient{status: commonv1.HealthCheckResponse_SERVING}
+
+	resp, err := s.Check(context.Background(), &commonv1.HealthCheckRequest{Service: "cortex"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetStatus() != commonv1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected NOT_SERVING, got %v", resp.GetStatus())
+	}
+	deps := resp.GetDependencies()
+	if deps[dependencyFrontalLobe] != dependencyUnreachable {
+		t.Errorf("expected frontal_lobe UNREACHABLE, got %q", deps[dependencyFrontalLobe])
+	}
+	if deps[dependencyHippocampus] != "SERVING" {
+		t.Errorf("expected hippocampus SERVING, got %q", deps[dependencyHippocampus])
+	}
+}
+
+func TestHealthCheckOptionalDependencyDown(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RequiredDependencies = []string{dependencyFrontalLobe}
+	s := NewCortexServer(newTestLogger(), cfg)
+	s.healthClients[dependencyFrontalLobe] = &fakeHealthClient{status: commonv1.HealthCheckResponse_SERVING}
+	s.healthClients[dependencyHippocampus] = &fakeHealthClient{status: commonv1.HealthCheckResponse_NOT_SERVING}
+
+	resp, err := s.Check(context.Background(), &commonv1.HealthCheckRequest{Service: "cortex"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetStatus() != commonv1.HealthCheckResponse_SERVING {
+		t.Errorf("expected SERVING with only an optional dependency down, got %v", resp.GetStatus())
+	}
+	if got := resp.GetDependencies()[dependencyHippocampus]; got != "NOT_SERVING" {
+		t.Errorf("expected hippocampus NOT_SERVING, got %q", got)
+	}
+}
+
+func TestMetricsPersistAcrossRestart(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.MetricsStorePath = filepath.Join(t.TempDir(), "metrics.json")
+
+	s := NewCortexServer(newTestLogger(), cfg)
+	s.MetricsStore().Record(metrics.InteractionRecord{SessionID: "sess-1", ResponseQuality: 0.6})
+	s.Close()
+
+	restarted := NewCortexServer(newTestLogger(), cfg)
+	defer restarted.Close()
+	if got := restarted.MetricsStore().Summary().TotalInteractions; got != 1 {
+		t.Errorf("expected 1 restored interaction, got %d", got)
+	}
+}
+
+func TestSessionSweeperStartsExplicitly(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.SessionTTL = 10 * time.Millisecond
+	cfg.SessionSweepInterval = 5 * time.Millisecond
+
+	s := NewCortexServer(newTestLogger(), cfg)
+	defer s.Close()
+	s.sessionMgr.Create("stale", "u1")
+	time.Sleep(50 * time.Millisecond)
+	if len(s.sessionMgr.ListSessions()) != 1 {
+		t.Fatal("expected no sweep before StartSessionSweeper")
+	}
+
+	s.StartSessionSweeper()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(s.sessionMgr.ListSessions()) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the sweeper to evict the stale session")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMetricsAutoSaveStartsExplicitly(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.MetricsStorePath = filepath.Join(t.TempDir(), "metrics.json")
+	cfg.MetricsSaveInterval = 5 * time.Millisecond
+
+	s := NewCortexServer(newTestLogger(), cfg)
+	time.Sleep(25 * time.Millisecond)
+	if _, err := os.Stat(cfg.MetricsStorePath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no auto-save before StartMetricsAutoSave, got %v", err)
+	}
+
+	s.StartMetricsAutoSave()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(cfg.MetricsStorePath); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected metrics to be auto-saved")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	s.Close()
+}
+
+// chunkedReviewClient streams a weekly review as scripted chunks.
+type chunkedReviewClient struct {
+	agentv1.ReasoningEngineClient
+	chunks []*agentv1.WeeklyReviewChunk
+}
+
+func (f *chunkedReviewClient) StreamWeeklyReview(ctx context.Context, req *agentv1.WeeklyReviewRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[agentv1.WeeklyReviewChunk], error) {
+	return &chunkedReviewStream{ctx: ctx, chunks: f.chunks}, nil
+}
+
+type chunkedReviewStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	chunks []*agentv1.WeeklyReviewChunk
+}
+
+func (f *chunkedReviewStream) Context() context.Context { return f.ctx }
+
+func (f *chunkedReviewStream) Recv() (*agentv1.WeeklyReviewChunk, error) {
+	if len(f.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := f.chunks[0]
+	f.chunks = f.chunks[1:]
+	return chunk, nil
+}
+
+// reviewServerStream collects the chunks cortex relays to its client.
+type reviewServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*agentv1.WeeklyReviewChunk
+}
+
+func (f *reviewServerStream) Context() context.Context { return f.ctx }
+
+func (f *reviewServerStream) Send(chunk *agentv1.WeeklyReviewChunk) error {
+	f.chunks = append(f.chunks, chunk)
+	return nil
+}
+
+func TestStreamWeeklyReviewRelaysChunks(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	s.frontalClient = &chunkedReviewClient{chunks: []*agentv1.WeeklyReviewChunk{
+		{Section: "report", Markdown: "# Weekly Review\n\n"},
+		{Section: "report", Markdown: "Steady progress."},
+		{Section: "stalled_projects", Markdown: "\n\n## Stalled Projects\n- Task D\n"},
+		{Section: "suggested_next_actions", Markdown: "\n\n## Suggested Next Actions\n- Unblock Task D\n"},
+		{Section: "dormant_ideas", Markdown: "\n\n## Dormant Ideas\n- Revisit old research\n"},
+	}}
+
+	stream := &reviewServerStream{ctx: context.Background()}
+	if err := s.StreamWeeklyReview(&agentv1.WeeklyReviewRequest{UserId: "test-user"}, stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(stream.chunks) != 5 {
+		t.Fatalf("expected 5 relayed chunks, got %d", len(stream.chunks))
+	}
+	var report string
+	for _, chunk := range stream.chunks {
+		report += chunk.GetMarkdown()
+	}
+	want := "# Weekly Review\n\nSteady progress.\n\n## Stalled Projects\n- Task D\n" +
+		"\n\n## Suggested Next Actions\n- Unblock Task D\n\n\n## Dormant Ideas\n- Revisit old research\n"
+	if report != want {
+		t.Errorf("unexpected concatenated report:\n%s", report)
+	}
+}
+
+func TestStreamWeeklyReviewWithoutFrontalLobe(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+
+	stream := &reviewServerStream{ctx: context.Background()}
+	if err := s.StreamWeeklyReview(&agentv1.WeeklyReviewRequest{UserId: "test-user"}, stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stream.chunks) != 1 || stream.chunks[0].GetMarkdown() == "" {
+		t.Errorf("expected a single fallback chunk, got %v", stream.chunks)
+	}
+}
+
+// slowFrontalClient never replies on StreamThoughtProcess, returning the
+// gRPC status for its context's error once the context is done, as a real
+// stream does. canceled is closed when that happens.
+type slowFrontalClient struct {
+	agentv1.ReasoningEngineClient
+	deadline time.Time
+	canceled chan struct{}
+}
+
+func (f *slowFrontalClient) StreamThoughtProcess(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[agentv1.AgentInput, agentv1.AgentOutput], error) {
+	f.deadline, _ = ctx.Deadline()
+	return &slowFrontalStream{ctx: ctx, client: f}, nil
+}
+
+type slowFrontalStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	client *slowFrontalClient
+}
+
+func (f *slowFrontalStream) Send(*agentv1.AgentInput) error { return nil }
+
+func (f *slowFrontalStream) CloseSend() error { return nil }
+
+func (f *slowFrontalStream) Context() context.Context { return f.ctx }
+
+func (f *slowFrontalStream) Recv() (*agentv1.AgentOutput, error) {
+	<-f.ctx.Done()
+	close(f.client.canceled)
+	return nil, status.FromContextError(f.ctx.Err()).Err()
+}
+
+func TestReasoningTimeoutCutsOffFrontalLobe(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.ReasoningTimeout = 50 * time.Millisecond
+	s := NewCortexServer(newTestLogger(), cfg)
+	frontal := &slowFrontalClient{canceled: make(chan struct{})}
+	s.frontalClient = frontal
+
+	stream := &fakeClientStream{
+		ctx:    context.Background(),
+		inputs: []*agentv1.AgentInput{queryInput("sess-slow", "slow question")},
+	}
+	start := time.Now()
+	err := s.StreamThoughtProcess(stream)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the call to be cut off near the 50ms timeout, took %v", elapsed)
+	}
+	select {
+	case <-frontal.canceled:
+	default:
+		t.Error("expected the downstream stream to be canceled")
+	}
+}
+
+func TestEarlierClientDeadlineShortensReasoningTimeout(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.ReasoningTimeout = time.Hour
+	s := NewCortexServer(newTestLogger(), cfg)
+	frontal := &slowFrontalClient{canceled: make(chan struct{})}
+	s.frontalClient = frontal
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	stream := &fakeClientStream{
+		ctx:    ctx,
+		inputs: []*agentv1.AgentInput{queryInput("sess-deadline", "slow question")},
+	}
+	if err := s.StreamThoughtProcess(stream); err == nil {
+		t.Fatal("expected an error once the client deadline passed")
+	}
+	if !frontal.deadline.Equal(want) {
+		t.Errorf("expected the downstream deadline to be the client's %v, got %v", want, frontal.deadline)
+	}
+}
+
+func TestLaterClientDeadlineKeepsReasoningTimeout(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.ReasoningTimeout = 50 * time.Millisecond
+	s := NewCortexServer(newTestLogger(), cfg)
+	frontal := &slowFrontalClient{canceled: make(chan struct{})}
+	s.frontalClient = frontal
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	stream := &fakeClientStream{
+		ctx:    ctx,
+		inputs: []*agentv1.AgentInput{queryInput("sess-long", "slow question")},
+	}
+	start := time.Now()
+	if err := s.StreamThoughtProcess(stream); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the reasoning timeout to cap the client's longer deadline, took %v", elapsed)
+	}
+}
+
+// servingHealth is a HealthService that always reports SERVING.
+type servingHealth struct {
+	commonv1.UnimplementedHealthServiceServer
+}
+
+func (servingHealth) Check(ctx context.Context, req *commonv1.HealthCheckRequest) (*commonv1.HealthCheckResponse, error) {
+	return &commonv1.HealthCheckResponse{Status: commonv1.HealthCheckResponse_SERVING}, nil
+}
+
+func getProbe(s *CortexServer, path string) int {
+	mux := http.NewServeMux()
+	s.RegisterProbeRoutes(mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w.Code
+}
+
+func TestReadyzAfterConnectDownstream(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	downstream := grpc.NewServer()
+	commonv1.RegisterHealthServiceServer(downstream, servingHealth{})
+	go downstream.Serve(lis)
+	t.Cleanup(downstream.Stop)
+
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	defer s.Close()
+
+	if code := getProbe(s, "/healthz"); code != http.StatusOK {
+		t.Errorf("expected /healthz 200 before connecting, got %d", code)
+	}
+	if code := getProbe(s, "/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("expected /readyz 503 before ConnectDownstream, got %d", code)
+	}
+
+	// One server stands in for both the frontal lobe and hippocampus.
+	if err := s.ConnectDownstream(lis.Addr().String(), lis.Addr().String()); err != nil {
+		t.Fatalf("ConnectDownstream: %v", err)
+	}
+	if code := getProbe(s, "/readyz"); code != http.StatusOK {
+		t.Errorf("expected /readyz 200 after ConnectDownstream, got %d", code)
+	}
+}
+
+func TestReadyzDependencyUnreachable(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	s.connected.Store(true)
+	s.healthClients[dependencyFrontalLobe] = &fakeHealthClient{status: commonv1.HealthCheckResponse_SERVING}
+	s.healthClients[dependencyHippocampus] = &fakeHealthClient{err: errors.New("connection refused")}
+
+	if code := getProbe(s, "/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("expected /readyz 503 with hippocampus unreachable, got %d", code)
+	}
+	if code := getProbe(s, "/healthz"); code != http.StatusOK {
+		t.Errorf("expected /healthz 200 regardless of dependencies, got %d", code)
+	}
+}
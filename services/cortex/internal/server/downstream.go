@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+)
+
+// Fallback reconnect delays used when the configured values are not positive.
+const (
+	defaultBackoffBaseDelay = time.Second
+	defaultBackoffMaxDelay  = 30 * time.Second
+)
+
+// minConnectTimeout bounds a single downstream dial attempt.
+const minConnectTimeout = 5 * time.Second
+
+// connectParams returns the reconnect backoff used for downstream
+// connections, so a downstream that starts after cortex is retried at a
+// bounded pace instead of gRPC's 120s default ceiling.
+func (s *CortexServer) connectParams() grpc.ConnectParams {
+	base := s.cfg.BackoffBaseDelay
+	if base <= 0 {
+		base = defaultBackoffBaseDelay
+	}
+	maxDelay := s.cfg.BackoffMaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultBackoffMaxDelay
+	}
+	if maxDelay < base {
+		maxDelay = base
+	}
+	return grpc.ConnectParams{
+		Backoff: backoff.Config{
+			BaseDelay:  base,
+			Multiplier: backoff.DefaultConfig.Multiplier,
+			Jitter:     backoff.DefaultConfig.Jitter,
+			MaxDelay:   maxDelay,
+		},
+		MinConnectTimeout: minConnectTimeout,
+	}
+}
+
+// watchConn starts connecting conn and logs its connectivity state
+// transitions until the connection is closed.
+func (s *CortexServer) watchConn(name string, conn *grpc.ClientConn) {
+	conn.Connect()
+	go func() {
+		state := conn.GetState()
+		for state != connectivity.Shutdown {
+			if !conn.WaitForStateChange(context.Background(), state) {
+				return
+			}
+			prev := state
+			state = conn.GetState()
+			switch state {
+			case connectivity.Ready:
+				s.logger.Info("downstream connection ready", "service", name, "from", prev.String())
+			case connectivity.TransientFailure:
+				s.logger.Warn("downstream connection failed, retrying with backoff", "service", name, "from", prev.String())
+			default:
+				s.logger.Debug("downstream connection state changed", "service", name, "from", prev.String(), "to", state.String())
+			}
+		}
+	}()
+}
+
+// WaitForDownstream blocks until every downstream connection is READY or ctx
+// is done. It returns an error naming the first connection that did not
+// become ready.
+func (s *CortexServer) WaitForDownstream(ctx context.Context) error {
+	conns := []struct {
+		name string
+		conn *grpc.ClientConn
+	}{
+		{dependencyFrontalLobe, s.frontalConn},
+		{dependencyHippocampus, s.hippocampusConn},
+	}
+	for _, c := range conns {
+		if c.conn == nil {
+			return fmt.Errorf("%s: not connected", c.name)
+		}
+		for state := c.conn.GetState(); state != connectivity.Ready; state = c.conn.GetState() {
+			if state == connectivity.Shutdown {
+				return fmt.Errorf("%s: connection closed", c.name)
+			}
+			if !c.conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("%s: waiting for ready (last state %s): %w", c.name, state, ctx.Err())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
+
+	"google.golang.org/grpc"
+)
+
+func TestDownstreamStartingLateIsEventuallyUsed(t *testing.T) {
+	// Reserve an address, then free it so the first dials are refused.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	cfg := newTestConfig()
+	cfg.BackoffBaseDelay = 20 * time.Millisecond
+	cfg.BackoffMaxDelay = 100 * time.Millisecond
+	s := NewCortexServer(newTestLogger(), cfg)
+	defer s.Close()
+
+	if err := s.ConnectDownstream(addr, addr); err != nil {
+		t.Fatalf("ConnectDownstream: %v", err)
+	}
+	if code := getProbe(s, "/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("expected /readyz 503 while downstream is down, got %d", code)
+	}
+
+	downstream := grpc.NewServer()
+	commonv1.RegisterHealthServiceServer(downstream, servingHealth{})
+	t.Cleanup(downstream.Stop)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Errorf("listen on reserved address: %v", err)
+			return
+		}
+		downstream.Serve(lis)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.WaitForDownstream(ctx); err != nil {
+		t.Fatalf("WaitForDownstream: %v", err)
+	}
+	if code := getProbe(s, "/readyz"); code != http.StatusOK {
+		t.Errorf("expected /readyz 200 once downstream is up, got %d", code)
+	}
+}
+
+func TestWaitForDownstreamTimesOut(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	defer s.Close()
+	if err := s.ConnectDownstream(addr, addr); err != nil {
+		t.Fatalf("ConnectDownstream: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := s.WaitForDownstream(ctx); err == nil {
+		t.Fatal("expected WaitForDownstream to fail with no downstream listening")
+	}
+}
+
+func TestWaitForDownstreamNotConnected(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	defer s.Close()
+	if err := s.WaitForDownstream(context.Background()); err == nil {
+		t.Fatal("expected error before ConnectDownstream")
+	}
+}
+
+func TestConnectParamsDefaults(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.BackoffBaseDelay = 0
+	cfg.BackoffMaxDelay = 0
+	s := NewCortexServer(newTestLogger(), cfg)
+	defer s.Close()
+
+	p := s.connectParams()
+	if p.Backoff.BaseDelay != defaultBackoffBaseDelay || p.Backoff.MaxDelay != defaultBackoffMaxDelay {
+		t.Errorf("expected default backoff %v/%v, got %v/%v",
+			defaultBackoffBaseDelay, defaultBackoffMaxDelay, p.Backoff.BaseDelay, p.Backoff.MaxDelay)
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are common English words that are never treated as entities.
+var stopWords = map[string]bool{
+	"a": true, "about": true, "all": true, "an": true, "and": true, "are": true,
+	"as": true, "at": true, "be": true, "by": true, "can": true, "did": true,
+	"do": true, "does": true, "for": true, "from": true, "has": true, "have": true,
+	"how": true, "i": true, "in": true, "is": true, "it": true, "me": true,
+	"my": true, "of": true, "on": true, "or": true, "say": true, "show": true,
+	"tell": true, "that": true, "the": true, "their": true, "there": true,
+	"this": true, "to": true, "was": true, "what": true, "when": true,
+	"where": true, "which": true, "who": true, "why": true, "with": true,
+	"you": true, "your": true, "notes": true,
+}
+
+// extractEntities picks up to limit candidate entity names from a query using
+// a simple keyword heuristic: capitalized or hyphenated terms come first (they
+// are most likely proper nouns such as project or model names), followed by
+// the remaining non-stopword terms in query order. Terms shorter than three
+// characters are ignored.
+func extractEntities(query string, limit int) []string {
+	var proper, others []string
+	seen := make(map[string]bool)
+
+	for _, field := range strings.Fields(query) {
+		term := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len([]rune(term)) < 3 || seen[term] || stopWords[strings.ToLower(term)] {
+			continue
+		}
+		seen[term] = true
+
+		if unicode.IsUpper([]rune(term)[0]) || strings.Contains(term, "-") {
+			proper = append(proper, term)
+		} else {
+			others = append(others, term)
+		}
+	}
+
+	entities := append(proper, others...)
+	if len(entities) > limit {
+		entities = entities[:limit]
+	}
+	return entities
+}
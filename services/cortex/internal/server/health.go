@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Names under which downstream dependencies are reported in health checks.
+const (
+	dependencyFrontalLobe = "frontal_lobe"
+	dependencyHippocampus = "hippocampus"
+)
+
+// dependencyUnreachable is reported for a dependency whose health probe failed.
+const dependencyUnreachable = "UNREACHABLE"
+
+// defaultHealthCheckTimeout bounds each probe when no timeout is configured.
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// Check implements the HealthService Check RPC. It probes every connected
+// downstream in parallel and reports NOT_SERVING when a required dependency
+// is unreachable or not serving; optional dependencies only show up in the
+// per-dependency status map. Before ConnectDownstream there is nothing to
+// probe, so a standalone cortex reports SERVING.
+func (s *CortexServer) Check(ctx context.Context, req *commonv1.HealthCheckRequest) (*commonv1.HealthCheckResponse, error) {
+	deps := s.probeDependencies(ctx)
+
+	status := commonv1.HealthCheckResponse_SERVING
+	for name, depStatus := range deps {
+		if depStatus != commonv1.HealthCheckResponse_SERVING.String() && slices.Contains(s.cfg.RequiredDependencies, name) {
+			s.logger.Warn("required dependency unhealthy", "dependency", name, "status", depStatus)
+			status = commonv1.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	return &commonv1.HealthCheckResponse{
+		Status:       status,
+		Version:      s.version,
+		Timestamp:    timestamppb.Now(),
+		Dependencies: deps,
+	}, nil
+}
+
+// probeDependencies pings each downstream HealthService with a short timeout
+// and returns its status by dependency name.
+func (s *CortexServer) probeDependencies(ctx context.Context) map[string]string {
+	timeout := s.cfg.HealthCheckTimeout
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		deps = make(map[string]string, len(s.healthClients))
+	)
+	for name, client := range s.healthClients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			depStatus := dependencyUnreachable
+			resp, err := client.Check(probeCtx, &commonv1.HealthCheckRequest{Service: name})
+			if err == nil {
+				depStatus = resp.GetStatus().String()
+			}
+
+			mu.Lock()
+			deps[name] = depStatus
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return deps
+}
+
+// Ready reports whether cortex can serve reasoning requests: ConnectDownstream
+// has succeeded, every downstream is reachable and every required one is
+// SERVING. It also returns the per-dependency statuses it probed.
+func (s *CortexServer) Ready(ctx context.Context) (bool, map[string]string) {
+	if !s.connected.Load() {
+		return false, nil
+	}
+
+	deps := s.probeDependencies(ctx)
+	for name, depStatus := range deps {
+		if depStatus == dependencyUnreachable {
+			return false, deps
+		}
+		if depStatus != commonv1.HealthCheckResponse_SERVING.String() && slices.Contains(s.cfg.RequiredDependencies, name) {
+			return false, deps
+		}
+	}
+	return true, deps
+}
+
+// RegisterProbeRoutes registers the HTTP probes for Kubernetes. GET /healthz
+// is the liveness probe and returns 200 whenever the process is serving HTTP;
+// GET /readyz is the readiness probe and returns 200 only while Ready, and
+// 503 otherwise.
+func (s *CortexServer) RegisterProbeRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", s.handleHealthz)
+	mux.HandleFunc("GET /readyz", s.handleReadyz)
+}
+
+func (s *CortexServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"}) //nolint:errcheck
+}
+
+func (s *CortexServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	ready, deps := s.Ready(r.Context())
+
+	code, status := http.StatusOK, "ready"
+	if !ready {
+		code, status = http.StatusServiceUnavailable, "not ready"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{ //nolint:errcheck
+		"status":       status,
+		"dependencies": deps,
+	})
+}
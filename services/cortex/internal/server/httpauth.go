@@ -0,0 +1,11 @@
+package server
+
+// AuthenticatedRoutes are the HTTP routes that change state or expose
+// queued items. They require one of the configured bearer tokens when auth
+// is enabled; see middleware.HTTPAuth.
+var AuthenticatedRoutes = []string{
+	"POST /v1/metrics/reset",
+	"POST /v1/ingest",
+	"GET /v1/classify/reviews",
+	"POST /v1/classify/reviews/{id}",
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/middleware"
+)
+
+func TestAuthenticatedRoutesRequireToken(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	s.memoryClient = &recordingMemoryClient{}
+
+	mux := http.NewServeMux()
+	s.MetricsStore().RegisterRoutes(mux)
+	s.RegisterClassifyRoutes(mux)
+	s.RegisterIngestRoutes(mux)
+	handler := middleware.HTTPAuth([]string{"secret"}, mux, AuthenticatedRoutes...)
+
+	routes := []struct {
+		method, path, body string
+	}{
+		{http.MethodPost, "/v1/metrics/reset", ""},
+		{http.MethodPost, "/v1/ingest", `{"content": "Buy milk"}`},
+		{http.MethodGet, "/v1/classify/reviews", ""},
+		{http.MethodPost, "/v1/classify/reviews/review-1", `{}`},
+	}
+	for _, rt := range routes {
+		t.Run(rt.method+" "+rt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(rt.method, rt.path, strings.NewReader(rt.body)))
+			if w.Code != http.StatusUnauthorized {
+				t.Fatalf("expected 401 without a token, got %d: %s", w.Code, w.Body.String())
+			}
+
+			req := httptest.NewRequest(rt.method, rt.path, strings.NewReader(rt.body))
+			req.Header.Set("Authorization", "Bearer secret")
+			w = httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code == http.StatusUnauthorized {
+				t.Fatalf("expected the token to be accepted, got 401: %s", w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected the metrics summary to stay open, got %d", w.Code)
+	}
+}
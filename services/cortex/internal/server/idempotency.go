@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/ingestion/v1"
+)
+
+// IdempotencyKeyHeader is the gRPC metadata key IngestItem reads an
+// idempotency key from when the request does not carry one.
+const IdempotencyKeyHeader = "idempotency-key"
+
+// ingestKey returns the idempotency key of an IngestItem call, preferring
+// the request field over the metadata header.
+func ingestKey(ctx context.Context, req *ingestionv1.IngestRequest) string {
+	if key := req.GetIdempotencyKey(); key != "" {
+		return key
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(IdempotencyKeyHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// idempotencyCache remembers IngestItem responses by idempotency key for a
+// bounded time, so that a retried delivery gets the first response back
+// instead of indexing the item again.
+type idempotencyCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	maxKeys int
+	entries map[string]*idempotencyEntry
+	now     func() time.Time
+}
+
+// idempotencyEntry is one remembered key. done is closed once the first
+// call finishes; resp is nil until then.
+type idempotencyEntry struct {
+	done    chan struct{}
+	resp    *ingestionv1.IngestResponse
+	expires time.Time
+}
+
+// newIdempotencyCache creates a cache remembering keys for ttl, holding at
+// most maxKeys of them. A ttl of zero or less disables the cache.
+func newIdempotencyCache(ttl time.Duration, maxKeys int) *idempotencyCache {
+	return &idempotencyCache{
+		ttl:     ttl,
+		maxKeys: maxKeys,
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// do returns the remembered response for key, or runs ingest and remembers
+// its response. Concurrent calls with the same key wait for the first one
+// rather than ingesting in parallel. When ingest reports that its response
+// should not be remembered, the key is forgotten so that a retry ingests
+// again. An empty key bypasses the cache.
+func (c *idempotencyCache) do(ctx context.Context, key string, ingest func() (resp *ingestionv1.IngestResponse, remember bool)) (resp *ingestionv1.IngestResponse, replayed bool, err error) {
+	if key == "" || c.ttl <= 0 {
+		resp, _ = ingest()
+		return resp, false, nil
+	}
+
+	for {
+		c.mu.Lock()
+		now := c.now()
+		entry, ok := c.entries[key]
+		if ok && entry.resp != nil && !now.Before(entry.expires) {
+			delete(c.entries, key)
+			ok = false
+		}
+		if !ok {
+			break // c.mu stays held for the insert below
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if entry.resp != nil {
+			return proto.Clone(entry.resp).(*ingestionv1.IngestResponse), true, nil
+		}
+		// The first call was forgotten; try to become the owner.
+	}
+
+	entry := &idempotencyEntry{done: make(chan struct{})}
+	c.evictLocked()
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	resp, remember := ingest()
+
+	c.mu.Lock()
+	if remember {
+		entry.resp = proto.Clone(resp).(*ingestionv1.IngestResponse)
+		entry.expires = c.now().Add(c.ttl)
+	} else {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	close(entry.done)
+	return resp, false, nil
+}
+
+// evictLocked makes room for a new key by dropping expired keys and then,
+// if still at capacity, the ones closest to expiring. Keys still being
+// ingested are never evicted. c.mu must be held.
+func (c *idempotencyCache) evictLocked() {
+	if c.maxKeys <= 0 || len(c.entries) < c.maxKeys {
+		return
+	}
+	now := c.now()
+	for key, entry := range c.entries {
+		if entry.resp != nil && !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	for len(c.entries) >= c.maxKeys {
+		oldest := ""
+		for key, entry := range c.entries {
+			if entry.resp != nil && (oldest == "" || entry.expires.Before(c.entries[oldest].expires)) {
+				oldest = key
+			}
+		}
+		if oldest == "" {
+			return
+		}
+		delete(c.entries, oldest)
+	}
+}
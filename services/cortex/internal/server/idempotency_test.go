@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/ingestion/v1"
+	memoryv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/memory/v1"
+)
+
+// indexingMemoryClient records IndexDocument calls, failing them while err
+// is set and rejecting them with Success=false while reject is set.
+type indexingMemoryClient struct {
+	memoryv1.MemoryServiceClient
+	mu      sync.Mutex
+	indexed []string
+	err     error
+	reject  string
+}
+
+func (f *indexingMemoryClient) IndexDocument(ctx context.Context, in *memoryv1.IndexRequest, opts ...grpc.CallOption) (*memoryv1.IndexResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.reject != "" {
+		return &memoryv1.IndexResponse{DocumentId: in.GetDocumentId(), ErrorMessage: f.reject}, nil
+	}
+	f.indexed = append(f.indexed, in.GetDocumentId())
+	return &memoryv1.IndexResponse{DocumentId: in.GetDocumentId(), Success: true}, nil
+}
+
+func newIdempotentServer(t *testing.T) (*CortexServer, *indexingMemoryClient) {
+	t.Helper()
+	cfg := newTestConfig()
+	cfg.IdempotencyTTL = time.Hour
+	cfg.IdempotencyMaxKeys = 100
+	s := NewCortexServer(newTestLogger(), cfg)
+	memory := &indexingMemoryClient{}
+	s.memoryClient = memory
+	return s, memory
+}
+
+func ingestRequest(id, key string) *ingestionv1.IngestRequest {
+	return &ingestionv1.IngestRequest{
+		Item:           &ingestionv1.InboxItem{Id: id, Content: "Webhook payload " + id, Source: "webhook"},
+		IdempotencyKey: key,
+	}
+}
+
+func TestIngestItemSameIdempotencyKeyIndexesOnce(t *testing.T) {
+	s, memory := newIdempotentServer(t)
+	ctx := context.Background()
+
+	first, err := s.IngestItem(ctx, ingestRequest("item-1", "delivery-1"))
+	if err != nil {
+		t.Fatalf("first ingest: %v", err)
+	}
+	second, err := s.IngestItem(ctx, ingestRequest("item-1", "delivery-1"))
+	if err != nil {
+		t.Fatalf("repeated ingest: %v", err)
+	}
+
+	if len(memory.indexed) != 1 {
+		t.Errorf("expected the document to be indexed once, got %v", memory.indexed)
+	}
+	if second.GetItemId() != first.GetItemId() || second.GetMessage() != first.GetMessage() || !second.GetAccepted() {
+		t.Errorf("expected the first response to be replayed, got %v (first %v)", second, first)
+	}
+
+	// Without a key, or with a new one, the item is indexed again.
+	s.IngestItem(ctx, ingestRequest("item-1", ""))
+	s.IngestItem(ctx, ingestRequest("item-1", "delivery-2"))
+	if len(memory.indexed) != 3 {
+		t.Errorf("expected unkeyed and newly keyed ingests to index, got %v", memory.indexed)
+	}
+}
+
+func TestIngestItemIdempotencyKeyFromMetadata(t *testing.T) {
+	s, memory := newIdempotentServer(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "delivery-1"))
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.IngestItem(ctx, ingestRequest("item-1", "")); err != nil {
+			t.Fatalf("ingest %d: %v", i, err)
+		}
+	}
+	if len(memory.indexed) != 1 {
+		t.Errorf("expected the header key to deduplicate, got %v", memory.indexed)
+	}
+}
+
+func TestIngestItemConcurrentSameKeyIndexesOnce(t *testing.T) {
+	s, memory := newIdempotentServer(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.IngestItem(context.Background(), ingestRequest("item-1", "delivery-1")); err != nil {
+				t.Errorf("ingest: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(memory.indexed) != 1 {
+		t.Errorf("expected concurrent deliveries to index once, got %v", memory.indexed)
+	}
+}
+
+func TestIngestItemFailedIndexIsRetried(t *testing.T) {
+	s, memory := newIdempotentServer(t)
+	ctx := context.Background()
+
+	memory.err = errors.New("hippocampus unavailable")
+	s.IngestItem(ctx, ingestRequest("item-1", "delivery-1"))
+	memory.err = nil
+	s.IngestItem(ctx, ingestRequest("item-1", "delivery-1"))
+	s.IngestItem(ctx, ingestRequest("item-1", "delivery-1"))
+
+	if len(memory.indexed) != 1 {
+		t.Errorf("expected the retry after a failed index to index once, got %v", memory.indexed)
+	}
+}
+
+func TestIdempotencyCacheExpiryAndCapacity(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	c := newIdempotencyCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	calls := 0
+	ingest := func() (*ingestionv1.IngestResponse, bool) {
+		calls++
+		return &ingestionv1.IngestResponse{Accepted: true}, true
+	}
+	ctx := context.Background()
+
+	c.do(ctx, "a", ingest)
+	if _, replayed, _ := c.do(ctx, "a", ingest); !replayed || calls != 1 {
+		t.Fatalf("expected a replay within the TTL, got replayed=%v after %d calls", replayed, calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, replayed, _ := c.do(ctx, "a", ingest); replayed || calls != 2 {
+		t.Fatalf("expected an expired key to ingest again, got replayed=%v after %d calls", replayed, calls)
+	}
+
+	now = now.Add(time.Second)
+	c.do(ctx, "b", ingest)
+	now = now.Add(time.Second)
+	c.do(ctx, "c", ingest) // evicts "a", the closest to expiring
+	if len(c.entries) != 2 {
+		t.Errorf("expected at most 2 keys, got %d", len(c.entries))
+	}
+	if _, ok := c.entries["a"]; ok {
+		t.Error("expected the oldest key to be evicted")
+	}
+}
+
+func TestIngestItemRejectedIndexIsRetried(t *testing.T) {
+	s, memory := newIdempotentServer(t)
+	ctx := context.Background()
+
+	memory.reject = "embedding dimension mismatch"
+	s.IngestItem(ctx, ingestRequest("item-1", "delivery-1"))
+	memory.reject = ""
+	s.IngestItem(ctx, ingestRequest("item-1", "delivery-1"))
+	s.IngestItem(ctx, ingestRequest("item-1", "delivery-1"))
+
+	if len(memory.indexed) != 1 {
+		t.Errorf("expected the retry after a rejected index to index once, got %v", memory.indexed)
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/ingestion/v1"
+)
+
+// IngestItemRequest is the JSON body accepted by POST /v1/ingest. ID is
+// generated when empty.
+type IngestItemRequest struct {
+	ID          string            `json:"id"`
+	Content     string            `json:"content"`
+	Source      string            `json:"source"`
+	ContentType string            `json:"content_type"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+// IngestItemResponse is the JSON body returned by POST /v1/ingest. Status is
+// a ProcessingStatus name such as PROCESSING_STATUS_ANALYZING.
+type IngestItemResponse struct {
+	ItemID   string `json:"item_id"`
+	Accepted bool   `json:"accepted"`
+	Message  string `json:"message"`
+	Status   string `json:"status"`
+}
+
+// IdempotencyKeyHTTPHeader is the HTTP header POST /v1/ingest reads an
+// idempotency key from; see IdempotencyKeyHeader.
+const IdempotencyKeyHTTPHeader = "Idempotency-Key"
+
+// RegisterIngestRoutes exposes item ingestion over HTTP so documents can be
+// indexed from scripts without a gRPC client:
+//
+//	POST /v1/ingest   ingest an item
+func (s *CortexServer) RegisterIngestRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/ingest", s.handleIngest)
+}
+
+func (s *CortexServer) handleIngest(w http.ResponseWriter, r *http.Request) {
+	var body IngestItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if body.Content == "" {
+		writeJSONError(w, http.StatusBadRequest, "content is required")
+		return
+	}
+	if body.ID == "" {
+		body.ID = fmt.Sprintf("http-%d", time.Now().UnixNano())
+	}
+	if body.Source == "" {
+		body.Source = "http"
+	}
+
+	resp, err := s.IngestItem(r.Context(), &ingestionv1.IngestRequest{
+		Item: &ingestionv1.InboxItem{
+			Id:          body.ID,
+			Content:     body.Content,
+			Source:      body.Source,
+			ContentType: body.ContentType,
+			RawMetadata: body.Metadata,
+			ReceivedAt:  timestamppb.Now(),
+		},
+		IdempotencyKey: r.Header.Get(IdempotencyKeyHTTPHeader),
+	})
+	if err != nil {
+		s.logger.WarnContext(r.Context(), "ingestion failed", "id", body.ID, "source", body.Source, "error", err)
+		s.writeDownstreamError(w, "ingestion", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.GetAccepted() {
+		// Hippocampus could not index the item; the caller may retry.
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	json.NewEncoder(w).Encode(IngestItemResponse{ //nolint:errcheck
+		ItemID:   resp.GetItemId(),
+		Accepted: resp.GetAccepted(),
+		Message:  resp.GetMessage(),
+		Status:   resp.GetStatus().String(),
+	})
+}
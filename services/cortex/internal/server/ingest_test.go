@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/ingestion/v1"
+	memoryv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/memory/v1"
+)
+
+// recordingMemoryClient records the IndexDocument requests it receives.
+type recordingMemoryClient struct {
+	memoryv1.MemoryServiceClient
+	reqs []*memoryv1.IndexRequest
+}
+
+func (f *recordingMemoryClient) IndexDocument(ctx context.Context, in *memoryv1.IndexRequest, opts ...grpc.CallOption) (*memoryv1.IndexResponse, error) {
+	f.reqs = append(f.reqs, in)
+	return &memoryv1.IndexResponse{DocumentId: in.GetDocumentId(), Success: true}, nil
+}
+
+func postIngest(s *CortexServer, body string, header http.Header) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	s.RegisterIngestRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ingest", strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestIngestEndpointIndexesDocument(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	memory := &recordingMemoryClient{}
+	s.memoryClient = memory
+
+	w := postIngest(s, `{"id": "note-1", "content": "PhaseNet picks P and S arrivals.", "source": "script", "content_type": "text/markdown", "metadata": {"tag": "seismology"}}`, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp IngestItemResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if !resp.Accepted || resp.ItemID != "note-1" || resp.Status != "PROCESSING_STATUS_ANALYZING" {
+		t.Errorf("expected the item to be accepted, got %+v", resp)
+	}
+
+	if len(memory.reqs) != 1 {
+		t.Fatalf("expected one indexed document, got %d", len(memory.reqs))
+	}
+	got := memory.reqs[0]
+	if got.GetDocumentId() != "note-1" || got.GetContent() != "PhaseNet picks P and S arrivals." {
+		t.Errorf("unexpected index request: %v", got)
+	}
+	md := got.GetMetadata()
+	if md["source"] != "script" || md["content_type"] != "text/markdown" || md["tag"] != "seismology" {
+		t.Errorf("expected the source, content type and metadata to be indexed, got %v", md)
+	}
+}
+
+func TestIngestEndpointIdempotencyKeyHeader(t *testing.T) {
+	s, memory := newIdempotentServer(t)
+
+	header := http.Header{IdempotencyKeyHTTPHeader: []string{"delivery-1"}}
+	for i := 0; i < 2; i++ {
+		if w := postIngest(s, `{"id": "note-1", "content": "hello"}`, header); w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	if len(memory.indexed) != 1 {
+		t.Errorf("expected the repeated delivery to index once, got %v", memory.indexed)
+	}
+}
+
+func TestIngestEndpointValidation(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	memory := &recordingMemoryClient{}
+	s.memoryClient = memory
+
+	for _, body := range []string{`{"id": "note-1"}`, `{"content": ""}`, `not json`} {
+		if w := postIngest(s, body, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+	if len(memory.reqs) != 0 {
+		t.Errorf("expected nothing indexed, got %d documents", len(memory.reqs))
+	}
+
+	// An ID is generated when none is given.
+	w := postIngest(s, `{"content": "untitled note"}`, nil)
+	var resp IngestItemResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.ItemID == "" || len(memory.reqs) != 1 || memory.reqs[0].GetDocumentId() != resp.ItemID {
+		t.Errorf("expected a generated ID to be used, got %+v", resp)
+	}
+}
+
+// rejectingMemoryServer is a Hippocampus that fails every IndexDocument the
+// way the real one reports indexing errors: Success=false and a nil error.
+type rejectingMemoryServer struct {
+	memoryv1.UnimplementedMemoryServiceServer
+}
+
+func (rejectingMemoryServer) IndexDocument(ctx context.Context, req *memoryv1.IndexRequest) (*memoryv1.IndexResponse, error) {
+	return &memoryv1.IndexResponse{DocumentId: req.GetDocumentId(), ErrorMessage: "embedding dimension mismatch"}, nil
+}
+
+// serveGRPC serves srv on a loopback port and returns its address.
+func serveGRPC(t *testing.T, srv *grpc.Server) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func TestIngestItemRejectedByHippocampusReportsError(t *testing.T) {
+	hippocampus := grpc.NewServer()
+	memoryv1.RegisterMemoryServiceServer(hippocampus, rejectingMemoryServer{})
+	hippocampusAddr := serveGRPC(t, hippocampus)
+
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	defer s.Close()
+	if err := s.ConnectDownstream(hippocampusAddr, hippocampusAddr); err != nil {
+		t.Fatalf("ConnectDownstream: %v", err)
+	}
+	cortex := grpc.NewServer()
+	ingestionv1.RegisterIngestionServiceServer(cortex, s)
+	conn, err := grpc.NewClient(serveGRPC(t, cortex), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial cortex: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := ingestionv1.NewIngestionServiceClient(conn).IngestItem(context.Background(), &ingestionv1.IngestRequest{
+		Item: &ingestionv1.InboxItem{Id: "item-1", Content: "Webhook payload", Source: "webhook"},
+	})
+	if err != nil {
+		t.Fatalf("IngestItem: %v", err)
+	}
+	if resp.GetAccepted() || resp.GetStatus() != commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR {
+		t.Errorf("expected a rejected index to be reported as not accepted with ERROR, got %v", resp)
+	}
+
+	w := postIngest(s, `{"id": "note-1", "content": "Some note"}`, nil)
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("expected 502 from /v1/ingest for a rejected index, got %d: %s", w.Code, w.Body.String())
+	}
+}
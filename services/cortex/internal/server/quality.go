@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+// QualityEvaluator rates how well a response answers a query given the
+// context that was retrieved for it. Scores are in [0,1].
+type QualityEvaluator interface {
+	Evaluate(ctx context.Context, query string, contextChunks []string, response string) (float64, error)
+}
+
+const qualityEvalPrompt = `You are a strict evaluator of answer quality for a personal knowledge assistant.
+Rate how well the assistant's answer addresses the user's question, using the retrieved context as ground truth.
+Reply with a single number between 0 and 1 (for example 0.75) and nothing else.`
+
+// scorePattern matches the first decimal number in an evaluator reply.
+var scorePattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// FrontalLobeEvaluator asks the Frontal Lobe reasoning engine to act as a
+// judge and score a response (LLM-as-judge).
+type FrontalLobeEvaluator struct {
+	client agentv1.ReasoningEngineClient
+}
+
+// NewFrontalLobeEvaluator creates an evaluator backed by the given reasoning engine.
+func NewFrontalLobeEvaluator(client agentv1.ReasoningEngineClient) *FrontalLobeEvaluator {
+	return &FrontalLobeEvaluator{client: client}
+}
+
+// Evaluate implements QualityEvaluator.
+func (e *FrontalLobeEvaluator) Evaluate(ctx context.Context, query string, contextChunks []string, response string) (float64, error) {
+	verdict, err := askFrontalLobe(ctx, e.client, "quality-eval", qualityEvalPrompt,
+		buildEvaluationQuery(query, contextChunks, response))
+	if err != nil {
+		return 0, fmt.Errorf("evaluating response: %w", err)
+	}
+
+	return parseQualityScore(verdict)
+}
+
+// buildEvaluationQuery renders the query, retrieved context, and answer for the judge.
+func buildEvaluationQuery(query string, contextChunks []string, response string) string {
+	var b strings.Builder
+	b.WriteString("Question:\n" + query + "\n\n")
+	if len(contextChunks) > 0 {
+		b.WriteString("Retrieved context:\n")
+		for _, chunk := range contextChunks {
+			b.WriteString("- " + chunk + "\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("Answer:\n" + response)
+	return b.String()
+}
+
+// parseQualityScore extracts the first number from the judge's reply and
+// clamps it to [0,1].
+func parseQualityScore(verdict string) (float64, error) {
+	match := scorePattern.FindString(verdict)
+	if match == "" {
+		return 0, fmt.Errorf("no score in evaluator reply %q", verdict)
+	}
+	score, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing score %q: %w", match, err)
+	}
+	return min(max(score, 0), 1), nil
+}
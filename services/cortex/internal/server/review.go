@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+// weeklyReviewPeriod is the review period used when the request gives no
+// start date.
+const weeklyReviewPeriod = 7 * 24 * time.Hour
+
+// WeeklyReviewRequest is the JSON body accepted by POST /v1/weekly-review.
+// Dates are RFC 3339 timestamps or plain YYYY-MM-DD days; the end date
+// defaults to now and the start date to a week before the end.
+type WeeklyReviewRequest struct {
+	UserID         string   `json:"user_id"`
+	CompletedTasks []string `json:"completed_tasks"`
+	ActiveTasks    []string `json:"active_tasks"`
+	BlockedTasks   []string `json:"blocked_tasks"`
+	StartDate      string   `json:"start_date"`
+	EndDate        string   `json:"end_date"`
+}
+
+// WeeklyReviewResponse is the JSON body returned by POST /v1/weekly-review.
+type WeeklyReviewResponse struct {
+	ReportMarkdown       string   `json:"report_markdown"`
+	StalledProjects      []string `json:"stalled_projects"`
+	SuggestedNextActions []string `json:"suggested_next_actions"`
+	DormantIdeas         []string `json:"dormant_ideas"`
+}
+
+// RegisterReviewRoutes exposes review generation over HTTP so it can be
+// triggered from cron or curl:
+//
+//	POST /v1/weekly-review   generate a weekly review report
+func (s *CortexServer) RegisterReviewRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/weekly-review", s.handleWeeklyReview)
+}
+
+func (s *CortexServer) handleWeeklyReview(w http.ResponseWriter, r *http.Request) {
+	var body WeeklyReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	end := time.Now()
+	if body.EndDate != "" {
+		t, err := parseReviewDate(body.EndDate)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid end_date: "+err.Error())
+			return
+		}
+		end = t
+	}
+	start := end.Add(-weeklyReviewPeriod)
+	if body.StartDate != "" {
+		t, err := parseReviewDate(body.StartDate)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid start_date: "+err.Error())
+			return
+		}
+		start = t
+	}
+	if start.After(end) {
+		writeJSONError(w, http.StatusBadRequest, "start_date must not be after end_date")
+		return
+	}
+
+	ctx, cancel := s.reasoningContext(r.Context())
+	defer cancel()
+
+	resp, err := s.GenerateWeeklyReview(ctx, &agentv1.WeeklyReviewRequest{
+		UserId:         body.UserID,
+		StartDate:      timestamppb.New(start),
+		EndDate:        timestamppb.New(end),
+		CompletedTasks: body.CompletedTasks,
+		ActiveTasks:    body.ActiveTasks,
+		BlockedTasks:   body.BlockedTasks,
+	})
+	if err != nil {
+		s.logger.WarnContext(r.Context(), "weekly review failed", "user_id", body.UserID, "error", err)
+		s.writeDownstreamError(w, "weekly review", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(WeeklyReviewResponse{ //nolint:errcheck
+		ReportMarkdown:       resp.GetReportMarkdown(),
+		StalledProjects:      resp.GetStalledProjects(),
+		SuggestedNextActions: resp.GetSuggestedNextActions(),
+		DormantIdeas:         resp.GetDormantIdeas(),
+	})
+}
+
+// parseReviewDate accepts an RFC 3339 timestamp or a YYYY-MM-DD day.
+func parseReviewDate(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is neither RFC 3339 nor YYYY-MM-DD", v)
+}
+
+// writeDownstreamError reports a failed Frontal Lobe call for action: 504
+// when it timed out, 503 with a Retry-After of cfg.RetryAfter when the
+// Frontal Lobe is unavailable, both of which clients may retry, and 502
+// otherwise.
+func (s *CortexServer) writeDownstreamError(w http.ResponseWriter, action string, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded:
+		writeJSONError(w, http.StatusGatewayTimeout, action+" timed out")
+	case status.Code(err) == codes.Unavailable:
+		w.Header().Set("Retry-After", strconv.Itoa(int(max(s.cfg.RetryAfter, time.Second).Seconds())))
+		writeJSONError(w, http.StatusServiceUnavailable, "reasoning engine unavailable")
+	default:
+		writeJSONError(w, http.StatusBadGateway, action+" failed")
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message}) //nolint:errcheck
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+// reviewFrontalClient fakes the Frontal Lobe's weekly review RPC, recording
+// the request it receives.
+type reviewFrontalClient struct {
+	agentv1.ReasoningEngineClient
+	req *agentv1.WeeklyReviewRequest
+}
+
+func (f *reviewFrontalClient) GenerateWeeklyReview(ctx context.Context, req *agentv1.WeeklyReviewRequest, opts ...grpc.CallOption) (*agentv1.WeeklyReviewResponse, error) {
+	f.req = req
+	return &agentv1.WeeklyReviewResponse{
+		ReportMarkdown:       "# Weekly Review\n\n- Shipped the release",
+		SuggestedNextActions: []string{"Plan next sprint"},
+	}, nil
+}
+
+func TestWeeklyReviewEndpoint(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	frontal := &reviewFrontalClient{}
+	s.frontalClient = frontal
+
+	mux := http.NewServeMux()
+	s.RegisterReviewRoutes(mux)
+
+	body := `{"user_id": "u1", "completed_tasks": ["Ship the release"], "active_tasks": ["Plan sprint"],
+		"blocked_tasks": ["Wait on review"], "start_date": "2026-10-05", "end_date": "2026-10-12T00:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/weekly-review", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp WeeklyReviewResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if resp.ReportMarkdown != "# Weekly Review\n\n- Shipped the release" {
+		t.Errorf("unexpected report: %q", resp.ReportMarkdown)
+	}
+	if len(resp.SuggestedNextActions) != 1 || resp.SuggestedNextActions[0] != "Plan next sprint" {
+		t.Errorf("unexpected next actions: %v", resp.SuggestedNextActions)
+	}
+
+	got := frontal.req
+	if got.GetUserId() != "u1" || len(got.GetCompletedTasks()) != 1 || len(got.GetActiveTasks()) != 1 || len(got.GetBlockedTasks()) != 1 {
+		t.Errorf("request not mapped to the RPC: %v", got)
+	}
+	if want := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC); !got.GetStartDate().AsTime().Equal(want) {
+		t.Errorf("expected start date %v, got %v", want, got.GetStartDate().AsTime())
+	}
+	if want := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC); !got.GetEndDate().AsTime().Equal(want) {
+		t.Errorf("expected end date %v, got %v", want, got.GetEndDate().AsTime())
+	}
+}
+
+func TestWeeklyReviewEndpointRejectsBadInput(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+	s.frontalClient = &reviewFrontalClient{}
+
+	mux := http.NewServeMux()
+	s.RegisterReviewRoutes(mux)
+
+	for _, body := range []string{
+		`not json`,
+		`{"start_date": "last week"}`,
+		`{"start_date": "2026-10-12", "end_date": "2026-10-05"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/weekly-review", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+// TurnSummarizer condenses conversation turns evicted from a session's
+// episodic memory into a running summary.
+type TurnSummarizer interface {
+	Summarize(ctx context.Context, previous string, evicted []string) (string, error)
+}
+
+const turnSummaryPrompt = `You maintain a running summary of a conversation between a user and a personal knowledge assistant.
+Merge the earlier summary with the turns that follow it into a single updated summary of at most a few sentences.
+Keep facts, decisions, and open questions the user may refer back to. Reply with the summary only.`
+
+// FrontalLobeSummarizer asks the Frontal Lobe reasoning engine to summarize
+// evicted turns.
+type FrontalLobeSummarizer struct {
+	client agentv1.ReasoningEngineClient
+}
+
+// NewFrontalLobeSummarizer creates a summarizer backed by the given reasoning engine.
+func NewFrontalLobeSummarizer(client agentv1.ReasoningEngineClient) *FrontalLobeSummarizer {
+	return &FrontalLobeSummarizer{client: client}
+}
+
+// Summarize implements TurnSummarizer.
+func (f *FrontalLobeSummarizer) Summarize(ctx context.Context, previous string, evicted []string) (string, error) {
+	summary, err := askFrontalLobe(ctx, f.client, "session-summary", turnSummaryPrompt, buildSummaryQuery(previous, evicted))
+	if err != nil {
+		return "", fmt.Errorf("summarizing turns: %w", err)
+	}
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return "", fmt.Errorf("empty summary from frontal lobe")
+	}
+	return summary, nil
+}
+
+// buildSummaryQuery renders the earlier summary and evicted turns for the summarizer.
+func buildSummaryQuery(previous string, evicted []string) string {
+	var b strings.Builder
+	if previous != "" {
+		b.WriteString("Earlier summary:\n" + previous + "\n\n")
+	}
+	b.WriteString("Turns:\n")
+	for _, turn := range evicted {
+		b.WriteString(turn + "\n")
+	}
+	return b.String()
+}
+
+// askFrontalLobe sends a single query with the given system prompt over a
+// one-off reasoning stream and returns the final response.
+func askFrontalLobe(ctx context.Context, client agentv1.ReasoningEngineClient, sessionID, systemPrompt, query string) (string, error) {
+	stream, err := client.StreamThoughtProcess(ctx)
+	if err != nil {
+		return "", fmt.Errorf("opening stream: %w", err)
+	}
+
+	if err := stream.Send(&agentv1.AgentInput{
+		SessionId: sessionID,
+		InputType: &agentv1.AgentInput_UserQuery{UserQuery: query},
+		Context:   &agentv1.ContextSnapshot{SystemPrompt: systemPrompt},
+	}); err != nil {
+		return "", fmt.Errorf("sending request: %w", err)
+	}
+	stream.CloseSend()
+
+	var response string
+	for {
+		output, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("receiving response: %w", err)
+		}
+		if resp := output.GetFinalResponse(); resp != "" {
+			response = resp
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// defaultTopicTaxonomy maps each topic to keywords that signal it. Keywords
+// may span several words; matching is case-insensitive on whole words.
+var defaultTopicTaxonomy = map[string][]string{
+	"programming":      {"go", "golang", "python", "rust", "code", "function", "bug", "compiler", "api", "refactor", "debug"},
+	"machine_learning": {"machine learning", "neural", "model", "training", "embedding", "llm", "transformer", "dataset"},
+	"infrastructure":   {"kubernetes", "docker", "deploy", "deployment", "server", "cloud", "terraform", "database", "grpc"},
+	"productivity":     {"task", "project", "deadline", "todo", "inbox", "gtd", "review", "plan", "schedule"},
+	"research":         {"paper", "study", "experiment", "hypothesis", "citation", "research", "seismology", "physics"},
+	"personal":         {"health", "family", "travel", "finance", "budget", "book", "habit", "exercise"},
+}
+
+// TopicClassifier assigns queries to topics from a keyword taxonomy so that
+// interactions carry a TopicDistribution for knowledge coverage metrics.
+type TopicClassifier struct {
+	taxonomy map[string][]string
+}
+
+// NewTopicClassifier creates a classifier over the given taxonomy, or the
+// built-in taxonomy when it is empty.
+func NewTopicClassifier(taxonomy map[string][]string) *TopicClassifier {
+	if len(taxonomy) == 0 {
+		taxonomy = defaultTopicTaxonomy
+	}
+
+	normalized := make(map[string][]string, len(taxonomy))
+	for topic, keywords := range taxonomy {
+		for _, kw := range keywords {
+			if kw = normalizeWords(kw); kw != "" {
+				normalized[topic] = append(normalized[topic], kw)
+			}
+		}
+	}
+	return &TopicClassifier{taxonomy: normalized}
+}
+
+// LoadTopicTaxonomy reads a taxonomy from a JSON file of the form
+// {"topic": ["keyword", "multi word keyword", ...]}.
+func LoadTopicTaxonomy(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading topic taxonomy: %w", err)
+	}
+	var taxonomy map[string][]string
+	if err := json.Unmarshal(data, &taxonomy); err != nil {
+		return nil, fmt.Errorf("decoding topic taxonomy: %w", err)
+	}
+	return taxonomy, nil
+}
+
+// Classify returns the weight of each matching topic, proportional to its
+// keyword hits and summing to 1. Queries matching no topic yield nil.
+func (c *TopicClassifier) Classify(query string) map[string]float64 {
+	// Pad with spaces so keywords only match on word boundaries.
+	text := " " + normalizeWords(query) + " "
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	hits := make(map[string]int)
+	total := 0
+	for topic, keywords := range c.taxonomy {
+		for _, kw := range keywords {
+			if n := strings.Count(text, " "+kw+" "); n > 0 {
+				hits[topic] += n
+				total += n
+			}
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	dist := make(map[string]float64, len(hits))
+	for topic, n := range hits {
+		dist[topic] = float64(n) / float64(total)
+	}
+	return dist
+}
+
+// normalizeWords lowercases text and reduces it to space-separated words.
+func normalizeWords(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+)
+
+func TestTopicClassifierClassify(t *testing.T) {
+	c := NewTopicClassifier(map[string][]string{
+		"ml":    {"Machine Learning", "model"},
+		"infra": {"kubernetes"},
+	})
+
+	dist := c.Classify("Deploy the machine-learning model on Kubernetes")
+	if math.Abs(dist["ml"]-2.0/3) > 1e-9 || math.Abs(dist["infra"]-1.0/3) > 1e-9 {
+		t.Errorf("unexpected distribution %v", dist)
+	}
+
+	if dist := c.Classify("models are not a whole-word match"); dist != nil {
+		t.Errorf("expected no topics for partial word matches, got %v", dist)
+	}
+}
+
+func TestLoadTopicTaxonomy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taxonomy.json")
+	if err := os.WriteFile(path, []byte(`{"cooking": ["recipe", "bake"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := newTestConfig()
+	cfg.TopicTaxonomyPath = path
+	s := NewCortexServer(newTestLogger(), cfg)
+
+	if dist := s.topics.Classify("a bread recipe"); dist["cooking"] != 1 {
+		t.Errorf("expected configured taxonomy to be used, got %v", dist)
+	}
+}
+
+func TestTopicAliasesFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	aliases := `{"programming": "engineering", "infrastructure": "engineering"}`
+	if err := os.WriteFile(path, []byte(aliases), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := newTestConfig()
+	cfg.TopicAliasesPath = path
+	s := NewCortexServer(newTestLogger(), cfg)
+
+	for _, q := range []string{"debug this golang function", "deploy it to kubernetes"} {
+		stream := &fakeClientStream{
+			ctx:    context.Background(),
+			inputs: []*agentv1.AgentInput{queryInput("sess-aliases", q)},
+		}
+		if err := s.StreamThoughtProcess(stream); err != nil {
+			t.Fatalf("stream error: %v", err)
+		}
+	}
+
+	if got := s.metricsStore.Summary().TopicCoverage; len(got) != 1 || got["engineering"] != 2 {
+		t.Errorf("expected both queries counted under engineering, got %v", got)
+	}
+}
+
+func TestQueryTopicsAccumulateInMetrics(t *testing.T) {
+	s := NewCortexServer(newTestLogger(), newTestConfig())
+
+	queries := []string{
+		"How do I debug this golang function?",
+		"Which embedding model should I use for training?",
+		"Plan the project deadline for next week",
+		"Deploy the service to kubernetes",
+	}
+	for i, q := range queries {
+		stream := &fakeClientStream{
+			ctx:    context.Background(),
+			inputs: []*agentv1.AgentInput{queryInput(fmt.Sprintf("sess-topic-%d", i), q)},
+		}
+		if err := s.StreamThoughtProcess(stream); err != nil {
+			t.Fatalf("stream error: %v", err)
+		}
+	}
+
+	summary := s.MetricsStore().Summary()
+	for _, topic := range []string{"programming", "machine_learning", "productivity", "infrastructure"} {
+		if summary.TopicCoverage[topic] == 0 {
+			t.Errorf("expected topic %q to be recorded, got %v", topic, summary.TopicCoverage)
+		}
+	}
+	if summary.KnowledgeCoverage <= 0.9 {
+		t.Errorf("expected near-even knowledge coverage, got %f", summary.KnowledgeCoverage)
+	}
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,68 +14,277 @@ type Session struct {
 	LastActivityAt  time.Time
 	EpisodicMemory  []string
 	ActiveContext   map[string]string
+	EpisodicSummary string // condensed form of turns evicted from EpisodicMemory
 	mu             sync.RWMutex
+	onChange       func() // called after mutations, outside the lock
+	now            func() time.Time
+	limits         EpisodicLimits
 }
 
 // Manager handles session lifecycle.
 type Manager struct {
 	sessions map[string]*Session
 	mu       sync.RWMutex
+
+	// Persistence (optional): when path is set, changes are batched and
+	// written to it. See NewManagerWithStore.
+	path         string
+	saveMu       sync.Mutex // serializes writes and guards closed
+	closed       bool
+	flushPending atomic.Bool   // a delayed flush is scheduled
+	flushDelay   time.Duration // how long persist waits to batch changes
+
+	policy Policy
+	now    func() time.Time // injectable clock for tests
+}
+
+// Policy bounds how long and how many sessions the manager keeps.
+type Policy struct {
+	// TTL is the idle time after which a session expires. Zero disables expiry.
+	TTL time.Duration
+	// MaxSessions caps the number of live sessions; creating a session beyond
+	// the cap evicts the least recently active one. Zero means unlimited.
+	MaxSessions int
+	// Episodic bounds each session's episodic memory.
+	Episodic EpisodicLimits
+}
+
+// DefaultMaxEpisodicTurns is the number of turns a session keeps when no
+// turn cap is configured.
+const DefaultMaxEpisodicTurns = 50
+
+// EpisodicLimits bounds a session's episodic memory. When a new turn pushes
+// the memory past either limit, the oldest turns are evicted.
+type EpisodicLimits struct {
+	// MaxTurns caps the number of turns kept. Zero uses DefaultMaxEpisodicTurns.
+	MaxTurns int
+	// MaxTokens caps the estimated token count of the kept turns. Zero means
+	// no token budget. The newest turn is always kept, even if it alone
+	// exceeds the budget.
+	MaxTokens int
 }
 
 // NewManager creates a new session manager.
 func NewManager() *Manager {
 	return &Manager{
 		sessions: make(map[string]*Session),
+		now:      time.Now,
+	}
+}
+
+// SetPolicy configures session expiry, the session cap, and the episodic
+// memory limits of new and existing sessions.
+func (m *Manager) SetPolicy(p Policy) {
+	m.mu.Lock()
+	m.policy = p
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		sessions = append(sessions, s)
+	}
+	m.mu.Unlock()
+
+	// Apply outside m.mu: trimming a session persists, which takes m.mu.
+	for _, s := range sessions {
+		s.SetEpisodicLimits(p.Episodic)
+	}
+}
+
+// StartSweeper launches a background goroutine that evicts expired sessions
+// every interval. It does nothing when the policy has no TTL. The returned
+// function stops the sweeper.
+func (m *Manager) StartSweeper(interval time.Duration) (stop func()) {
+	m.mu.RLock()
+	ttl := m.policy.TTL
+	m.mu.RUnlock()
+
+	if ttl <= 0 || interval <= 0 {
+		return func() {}
 	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.CleanupExpired(ttl)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
 }
 
 // Create starts a new session.
 func (m *Manager) Create(sessionID, userID string) *Session {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
+	now := m.now()
 	s := &Session{
 		ID:             sessionID,
 		UserID:         userID,
-		CreatedAt:      time.Now(),
-		LastActivityAt: time.Now(),
+		CreatedAt:      now,
+		LastActivityAt: now,
 		EpisodicMemory: make([]string, 0),
 		ActiveContext:  make(map[string]string),
+		onChange:       m.persist,
+		now:            m.now,
+		limits:         m.policy.Episodic,
+	}
+	if _, exists := m.sessions[sessionID]; !exists {
+		m.evictForCapacity()
 	}
 	m.sessions[sessionID] = s
+	m.mu.Unlock()
+
+	m.persist()
 	return s
 }
 
-// Get retrieves a session by ID.
+// Get retrieves a session by ID. Sessions idle for longer than the policy
+// TTL are treated as missing and removed.
 func (m *Manager) Get(sessionID string) (*Session, bool) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	s, ok := m.sessions[sessionID]
+	expired := ok && m.isExpired(s)
+	m.mu.RUnlock()
+
+	if expired {
+		m.mu.Lock()
+		if cur, ok := m.sessions[sessionID]; ok && cur == s && m.isExpired(s) {
+			delete(m.sessions, sessionID)
+		}
+		m.mu.Unlock()
+		m.persist()
+		return nil, false
+	}
 	return s, ok
 }
 
+// isExpired reports whether a session has been idle longer than the TTL.
+// Callers must hold m.mu.
+func (m *Manager) isExpired(s *Session) bool {
+	if m.policy.TTL <= 0 {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return m.now().Sub(s.LastActivityAt) > m.policy.TTL
+}
+
+// evictForCapacity removes the least recently active session when the
+// manager is at its session cap. Callers must hold m.mu for writing.
+func (m *Manager) evictForCapacity() {
+	if m.policy.MaxSessions <= 0 || len(m.sessions) < m.policy.MaxSessions {
+		return
+	}
+
+	var oldestID string
+	var oldest time.Time
+	for id, s := range m.sessions {
+		s.mu.RLock()
+		last := s.LastActivityAt
+		s.mu.RUnlock()
+		if oldestID == "" || last.Before(oldest) {
+			oldestID, oldest = id, last
+		}
+	}
+	delete(m.sessions, oldestID)
+}
+
 // Delete removes a session.
 func (m *Manager) Delete(sessionID string) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	delete(m.sessions, sessionID)
+	m.mu.Unlock()
+
+	m.persist()
 }
 
-// AddEpisodicMemory adds a turn to the session's episodic memory.
-func (s *Session) AddEpisodicMemory(entry string) {
+// AddEpisodicMemory adds a turn to the session's episodic memory, evicting
+// the oldest turns that no longer fit the session's limits. The evicted turns
+// are returned, oldest first, so callers can summarize them.
+func (s *Session) AddEpisodicMemory(entry string) (evicted []string) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	s.EpisodicMemory = append(s.EpisodicMemory, entry)
-	s.LastActivityAt = time.Now()
+	s.LastActivityAt = s.clock()
+	evicted = s.trimEpisodicMemory()
+	s.mu.Unlock()
+
+	s.changed()
+	return evicted
+}
+
+// SetEpisodicLimits changes the session's episodic memory limits. Turns that
+// no longer fit are evicted immediately and returned, oldest first.
+func (s *Session) SetEpisodicLimits(limits EpisodicLimits) (evicted []string) {
+	s.mu.Lock()
+	s.limits = limits
+	evicted = s.trimEpisodicMemory()
+	s.mu.Unlock()
+
+	if len(evicted) > 0 {
+		s.changed()
+	}
+	return evicted
+}
 
-	// Keep only last 50 entries
-	if len(s.EpisodicMemory) > 50 {
-		s.EpisodicMemory = s.EpisodicMemory[len(s.EpisodicMemory)-50:]
+// trimEpisodicMemory drops the oldest turns until the memory fits the turn
+// cap and token budget, returning them. Callers must hold s.mu for writing.
+func (s *Session) trimEpisodicMemory() []string {
+	maxTurns := s.limits.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = DefaultMaxEpisodicTurns
+	}
+
+	drop := 0
+	if len(s.EpisodicMemory) > maxTurns {
+		drop = len(s.EpisodicMemory) - maxTurns
+	}
+	if s.limits.MaxTokens > 0 {
+		tokens := 0
+		for _, turn := range s.EpisodicMemory[drop:] {
+			tokens += estimateTokens(turn)
+		}
+		for drop < len(s.EpisodicMemory)-1 && tokens > s.limits.MaxTokens {
+			tokens -= estimateTokens(s.EpisodicMemory[drop])
+			drop++
+		}
+	}
+	if drop == 0 {
+		return nil
 	}
+
+	evicted := append([]string(nil), s.EpisodicMemory[:drop]...)
+	s.EpisodicMemory = append(make([]string, 0, len(s.EpisodicMemory)-drop), s.EpisodicMemory[drop:]...)
+	return evicted
+}
+
+// estimateTokens approximates the number of model tokens in text, using the
+// common rule of thumb of four characters per token.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// SetEpisodicSummary replaces the summary of turns evicted from episodic memory.
+func (s *Session) SetEpisodicSummary(summary string) {
+	s.mu.Lock()
+	s.EpisodicSummary = summary
+	s.mu.Unlock()
+
+	s.changed()
+}
+
+// GetEpisodicSummary returns the summary of turns evicted from episodic
+// memory, or "" if none has been recorded.
+func (s *Session) GetEpisodicSummary() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.EpisodicSummary
 }
 
 // GetEpisodicMemory returns a copy of the episodic memory.
@@ -90,10 +300,26 @@ func (s *Session) GetEpisodicMemory() []string {
 // SetContext sets a key-value pair in the session context.
 func (s *Session) SetContext(key, value string) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	s.ActiveContext[key] = value
-	s.LastActivityAt = time.Now()
+	s.LastActivityAt = s.clock()
+	s.mu.Unlock()
+
+	s.changed()
+}
+
+// clock returns the current time from the manager's clock.
+func (s *Session) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+// changed notifies the owning manager that the session was modified.
+func (s *Session) changed() {
+	if s.onChange != nil {
+		s.onChange()
+	}
 }
 
 // GetContext returns the active context map.
@@ -123,9 +349,7 @@ func (m *Manager) ListSessions() []string {
 // CleanupExpired removes sessions older than the given duration.
 func (m *Manager) CleanupExpired(maxAge time.Duration) int {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	cutoff := time.Now().Add(-maxAge)
+	cutoff := m.now().Add(-maxAge)
 	removed := 0
 	for id, s := range m.sessions {
 		s.mu.RLock()
@@ -135,5 +359,10 @@ func (m *Manager) CleanupExpired(maxAge time.Duration) int {
 		}
 		s.mu.RUnlock()
 	}
+	m.mu.Unlock()
+
+	if removed > 0 {
+		m.persist()
+	}
 	return removed
 }
@@ -1,6 +1,8 @@
 package session
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -75,6 +77,62 @@ func TestSessionEpisodicMemoryOverflow(t *testing.T) {
 	}
 }
 
+func TestSessionEpisodicMemoryTurnCap(t *testing.T) {
+	mgr := NewManager()
+	mgr.SetPolicy(Policy{Episodic: EpisodicLimits{MaxTurns: 3}})
+	s := mgr.Create("sess-1", "user-1")
+
+	var evicted []string
+	for i := 1; i <= 5; i++ {
+		evicted = append(evicted, s.AddEpisodicMemory(fmt.Sprintf("turn %d", i))...)
+	}
+
+	mem := s.GetEpisodicMemory()
+	if len(mem) != 3 || mem[0] != "turn 3" || mem[2] != "turn 5" {
+		t.Errorf("expected the 3 most recent turns, got %v", mem)
+	}
+	if len(evicted) != 2 || evicted[0] != "turn 1" || evicted[1] != "turn 2" {
+		t.Errorf("expected turns 1 and 2 to be evicted in order, got %v", evicted)
+	}
+}
+
+func TestSessionEpisodicMemoryTokenBudget(t *testing.T) {
+	mgr := NewManager()
+	// Each 40-character turn estimates to 10 tokens, so 25 tokens fit two.
+	mgr.SetPolicy(Policy{Episodic: EpisodicLimits{MaxTokens: 25}})
+	s := mgr.Create("sess-1", "user-1")
+
+	for i := 1; i <= 4; i++ {
+		s.AddEpisodicMemory(fmt.Sprintf("turn %d %s", i, strings.Repeat("x", 33)))
+	}
+
+	mem := s.GetEpisodicMemory()
+	if len(mem) != 2 || !strings.HasPrefix(mem[0], "turn 3") || !strings.HasPrefix(mem[1], "turn 4") {
+		t.Errorf("expected the 2 most recent turns, got %v", mem)
+	}
+
+	// A single turn over budget is still kept.
+	s.AddEpisodicMemory(strings.Repeat("y", 400))
+	if mem := s.GetEpisodicMemory(); len(mem) != 1 {
+		t.Errorf("expected only the oversized newest turn, got %d turns", len(mem))
+	}
+}
+
+func TestSetPolicyTrimsExistingSessions(t *testing.T) {
+	mgr := NewManager()
+	s := mgr.Create("sess-1", "user-1")
+	for i := 0; i < 10; i++ {
+		s.AddEpisodicMemory(fmt.Sprintf("turn %d", i))
+	}
+
+	mgr.SetPolicy(Policy{Episodic: EpisodicLimits{MaxTurns: 4}})
+
+	mem := s.GetEpisodicMemory()
+	if len(mem) != 4 || mem[0] != "turn 6" {
+		t.Errorf("expected existing session trimmed to the last 4 turns, got %v", mem)
+	}
+}
+
 func TestSessionContext(t *testing.T) {
 	mgr := NewManager()
 	s := mgr.Create("sess-1", "user-1")
@@ -126,3 +184,94 @@ func TestManagerCleanupExpired(t *testing.T) {
 		t.Error("expected new session to still exist")
 	}
 }
+
+// fakeClock is an injectable, manually advanced clock.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newManagerWithClock(p Policy) (*Manager, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	mgr := NewManager()
+	mgr.now = clock.Now
+	mgr.SetPolicy(p)
+	return mgr, clock
+}
+
+func TestManagerTTLExpiry(t *testing.T) {
+	mgr, clock := newManagerWithClock(Policy{TTL: 30 * time.Minute})
+
+	mgr.Create("idle", "u1")
+	active := mgr.Create("active", "u2")
+
+	clock.Advance(20 * time.Minute)
+	active.AddEpisodicMemory("still talking")
+	clock.Advance(15 * time.Minute)
+
+	if _, ok := mgr.Get("idle"); ok {
+		t.Error("expected idle session to be expired")
+	}
+	if _, ok := mgr.Get("active"); !ok {
+		t.Error("expected active session to be retained")
+	}
+	if len(mgr.ListSessions()) != 1 {
+		t.Errorf("expected expired session to be removed, got %v", mgr.ListSessions())
+	}
+}
+
+func TestManagerCleanupUsesClock(t *testing.T) {
+	mgr, clock := newManagerWithClock(Policy{TTL: time.Hour})
+
+	mgr.Create("old", "u1")
+	clock.Advance(2 * time.Hour)
+	mgr.Create("new", "u2")
+
+	if removed := mgr.CleanupExpired(time.Hour); removed != 1 {
+		t.Errorf("expected 1 removed, got %d", removed)
+	}
+	if _, ok := mgr.Get("new"); !ok {
+		t.Error("expected new session to be retained")
+	}
+}
+
+func TestManagerMaxSessionsEvictsLRU(t *testing.T) {
+	mgr, clock := newManagerWithClock(Policy{MaxSessions: 2})
+
+	a := mgr.Create("a", "u1")
+	clock.Advance(time.Minute)
+	mgr.Create("b", "u2")
+	clock.Advance(time.Minute)
+	a.AddEpisodicMemory("a is used again") // b is now least recently active
+	clock.Advance(time.Minute)
+	mgr.Create("c", "u3")
+
+	if _, ok := mgr.Get("b"); ok {
+		t.Error("expected least recently active session b to be evicted")
+	}
+	for _, id := range []string{"a", "c"} {
+		if _, ok := mgr.Get(id); !ok {
+			t.Errorf("expected session %s to be retained", id)
+		}
+	}
+}
+
+func TestManagerSweeper(t *testing.T) {
+	mgr, clock := newManagerWithClock(Policy{TTL: time.Minute})
+	mgr.Create("stale", "u1")
+	clock.Advance(time.Hour)
+
+	stop := mgr.StartSweeper(5 * time.Millisecond)
+	defer stop()
+
+	deadline := time.Now().Add(time.Second)
+	for len(mgr.ListSessions()) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected sweeper to evict the stale session")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
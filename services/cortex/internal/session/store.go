@@ -0,0 +1,169 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/atomicfile"
+)
+
+// persistedSession is the on-disk representation of a Session.
+type persistedSession struct {
+	ID              string            `json:"id"`
+	UserID          string            `json:"user_id"`
+	CreatedAt       time.Time         `json:"created_at"`
+	LastActivityAt  time.Time         `json:"last_activity_at"`
+	EpisodicMemory  []string          `json:"episodic_memory"`
+	ActiveContext   map[string]string `json:"active_context"`
+	EpisodicSummary string            `json:"episodic_summary,omitempty"`
+}
+
+// defaultFlushDelay is how long changes are batched before a file-backed
+// manager writes them to disk.
+const defaultFlushDelay = 500 * time.Millisecond
+
+// NewManagerWithStore creates a session manager backed by a JSON file at
+// path. Existing sessions in the file are restored, and subsequent changes
+// (session created/deleted, episodic memory or context updated) are written
+// back to disk shortly after they happen so conversations survive restarts.
+// Call Close on shutdown to write the last changes.
+func NewManagerWithStore(path string) (*Manager, error) {
+	m := NewManager()
+	m.path = path
+	m.flushDelay = defaultFlushDelay
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading session store: %w", err)
+	}
+
+	var stored []persistedSession
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("decoding session store: %w", err)
+	}
+
+	for _, ps := range stored {
+		s := &Session{
+			ID:              ps.ID,
+			UserID:          ps.UserID,
+			CreatedAt:       ps.CreatedAt,
+			LastActivityAt:  ps.LastActivityAt,
+			EpisodicMemory:  ps.EpisodicMemory,
+			ActiveContext:   ps.ActiveContext,
+			EpisodicSummary: ps.EpisodicSummary,
+			onChange:        m.persist,
+			now:             m.now,
+		}
+		if s.EpisodicMemory == nil {
+			s.EpisodicMemory = make([]string, 0)
+		}
+		if s.ActiveContext == nil {
+			s.ActiveContext = make(map[string]string)
+		}
+		m.sessions[s.ID] = s
+	}
+
+	return m, nil
+}
+
+// Flush writes all sessions to the backing store. It is a no-op for
+// in-memory managers.
+func (m *Manager) Flush() error {
+	if m.path == "" {
+		return nil
+	}
+
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+	return m.write()
+}
+
+// Close writes any changes not yet flushed and stops later delayed flushes
+// from touching the backing store.
+func (m *Manager) Close() error {
+	if m.path == "" {
+		return nil
+	}
+
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+	m.closed = true
+	return m.write()
+}
+
+// write encodes every session and replaces the backing store. The caller
+// must hold saveMu.
+func (m *Manager) write() error {
+	data, err := json.Marshal(m.snapshot())
+	if err != nil {
+		return fmt.Errorf("encoding sessions: %w", err)
+	}
+	if err := atomicfile.WriteFile(m.path, data); err != nil {
+		return fmt.Errorf("saving sessions: %w", err)
+	}
+	return nil
+}
+
+// persist schedules a flush after a change. Changes made within flushDelay
+// of each other are written together, so a conversation turn, which adds
+// episodic memory and updates context, rewrites the file once.
+func (m *Manager) persist() {
+	if m.path == "" || !m.flushPending.CompareAndSwap(false, true) {
+		return
+	}
+	time.AfterFunc(m.flushDelay, m.flushPendingChanges)
+}
+
+// flushPendingChanges writes the changes scheduled by persist, logging
+// rather than returning errors since it runs on a timer.
+func (m *Manager) flushPendingChanges() {
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+
+	// Clear the flag before taking the snapshot so a change made during the
+	// write schedules another flush.
+	m.flushPending.Store(false)
+	if m.closed {
+		return
+	}
+	if err := m.write(); err != nil {
+		slog.Warn("failed to persist sessions", "path", m.path, "error", err)
+	}
+}
+
+// snapshot copies every session into its persisted form.
+func (m *Manager) snapshot() []persistedSession {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]persistedSession, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		s.mu.RLock()
+		out = append(out, persistedSession{
+			ID:              s.ID,
+			UserID:          s.UserID,
+			CreatedAt:       s.CreatedAt,
+			LastActivityAt:  s.LastActivityAt,
+			EpisodicMemory:  append([]string(nil), s.EpisodicMemory...),
+			ActiveContext:   copyContext(s.ActiveContext),
+			EpisodicSummary: s.EpisodicSummary,
+		})
+		s.mu.RUnlock()
+	}
+	return out
+}
+
+func copyContext(ctx map[string]string) map[string]string {
+	out := make(map[string]string, len(ctx))
+	for k, v := range ctx {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManagerPersistAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+
+	mgr, err := NewManagerWithStore(path)
+	if err != nil {
+		t.Fatalf("creating manager: %v", err)
+	}
+	s := mgr.Create("sess-1", "user-1")
+	s.AddEpisodicMemory("User: hello")
+	s.AddEpisodicMemory("Assistant: hi there")
+	s.SetContext("project", "second-brain")
+	s.SetEpisodicSummary("User greeted the assistant.")
+	mgr.Create("sess-2", "user-2")
+
+	// Closing and opening a fresh manager on the same path simulates a
+	// restart.
+	if err := mgr.Close(); err != nil {
+		t.Fatalf("closing manager: %v", err)
+	}
+	reloaded, err := NewManagerWithStore(path)
+	if err != nil {
+		t.Fatalf("reloading manager: %v", err)
+	}
+
+	got, ok := reloaded.Get("sess-1")
+	if !ok {
+		t.Fatal("expected sess-1 to be restored")
+	}
+	if got.UserID != "user-1" {
+		t.Errorf("expected user-1, got %q", got.UserID)
+	}
+	mem := got.GetEpisodicMemory()
+	if len(mem) != 2 || mem[0] != "User: hello" || mem[1] != "Assistant: hi there" {
+		t.Errorf("unexpected episodic memory after reload: %v", mem)
+	}
+	if got.GetContext()["project"] != "second-brain" {
+		t.Errorf("expected context to be restored, got %v", got.GetContext())
+	}
+	if got.GetEpisodicSummary() != "User greeted the assistant." {
+		t.Errorf("expected episodic summary to be restored, got %q", got.GetEpisodicSummary())
+	}
+	if !got.CreatedAt.Equal(s.CreatedAt) {
+		t.Errorf("expected CreatedAt %v, got %v", s.CreatedAt, got.CreatedAt)
+	}
+	if len(reloaded.ListSessions()) != 2 {
+		t.Errorf("expected 2 sessions after reload, got %d", len(reloaded.ListSessions()))
+	}
+
+	// Changes after reload keep flushing to the same file.
+	got.AddEpisodicMemory("User: still here")
+	reloaded.Delete("sess-2")
+	if err := reloaded.Close(); err != nil {
+		t.Fatalf("closing manager: %v", err)
+	}
+
+	again, err := NewManagerWithStore(path)
+	if err != nil {
+		t.Fatalf("reloading manager: %v", err)
+	}
+	if _, ok := again.Get("sess-2"); ok {
+		t.Error("expected deleted session to stay deleted")
+	}
+	restored, _ := again.Get("sess-1")
+	if n := len(restored.GetEpisodicMemory()); n != 3 {
+		t.Errorf("expected 3 episodic entries, got %d", n)
+	}
+}
+
+func TestManagerPersistBatchesChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+
+	mgr, err := NewManagerWithStore(path)
+	if err != nil {
+		t.Fatalf("creating manager: %v", err)
+	}
+	mgr.flushDelay = 50 * time.Millisecond
+	s := mgr.Create("sess-1", "user-1")
+	for i := range 100 {
+		s.AddEpisodicMemory(fmt.Sprintf("User: message %d", i))
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no write before the flush delay, got %v", err)
+	}
+
+	// The batch is written once the delay passes, without an explicit flush.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		reloaded, err := NewManagerWithStore(path)
+		if err != nil {
+			t.Fatalf("reloading manager: %v", err)
+		}
+		if got, ok := reloaded.Get("sess-1"); ok && len(got.GetEpisodicMemory()) == DefaultMaxEpisodicTurns {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the batched session to be persisted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := mgr.Close(); err != nil {
+		t.Fatalf("closing manager: %v", err)
+	}
+}
+
+func TestNewManagerWithStoreMissingFile(t *testing.T) {
+	mgr, err := NewManagerWithStore(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("expected missing file to start empty, got %v", err)
+	}
+	if len(mgr.ListSessions()) != 0 {
+		t.Error("expected no sessions")
+	}
+}
+
+func TestNewManagerWithStoreCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewManagerWithStore(path); err == nil {
+		t.Error("expected error for corrupt store")
+	}
+}
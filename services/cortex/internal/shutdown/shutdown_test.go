@@ -0,0 +1,191 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// blockingEngine is a ReasoningEngine whose StreamThoughtProcess replies only
+// once release is closed.
+type blockingEngine struct {
+	agentv1.UnimplementedReasoningEngineServer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e blockingEngine) StreamThoughtProcess(stream grpc.BidiStreamingServer[agentv1.AgentInput, agentv1.AgentOutput]) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	close(e.started)
+	select {
+	case <-e.release:
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	}
+	return stream.Send(&agentv1.AgentOutput{SessionId: in.SessionId})
+}
+
+// testServers is a gRPC server running a blockingEngine and an HTTP server
+// with an SSE endpoint, both of which hold their request open until release
+// is closed.
+type testServers struct {
+	grpcServer  *grpc.Server
+	grpcAddr    string
+	httpServer  *http.Server
+	grpcStarted chan struct{}
+	sseStarted  chan struct{}
+	release     chan struct{}
+}
+
+func startServers(t *testing.T) *testServers {
+	t.Helper()
+
+	ts := &testServers{
+		grpcStarted: make(chan struct{}),
+		sseStarted:  make(chan struct{}),
+		release:     make(chan struct{}),
+	}
+
+	grpcLis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ts.grpcAddr = grpcLis.Addr().String()
+	ts.grpcServer = grpc.NewServer()
+	agentv1.RegisterReasoningEngineServer(ts.grpcServer, blockingEngine{started: ts.grpcStarted, release: ts.release})
+	go ts.grpcServer.Serve(grpcLis)
+	t.Cleanup(ts.grpcServer.Stop)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: first\n\n")
+		w.(http.Flusher).Flush()
+		close(ts.sseStarted)
+		select {
+		case <-ts.release:
+		case <-r.Context().Done():
+			return
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+	httpLis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ts.httpServer = &http.Server{Addr: httpLis.Addr().String(), Handler: mux}
+	go ts.httpServer.Serve(httpLis)
+	t.Cleanup(func() { ts.httpServer.Close() })
+
+	return ts
+}
+
+// openRequests starts a StreamThoughtProcess call and an SSE request and
+// waits until both handlers are running. The returned channels deliver each
+// request's outcome.
+func (ts *testServers) openRequests(t *testing.T) (grpcResult <-chan error, sseResult <-chan string) {
+	t.Helper()
+
+	conn, err := grpc.NewClient(ts.grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	stream, err := agentv1.NewReasoningEngineClient(conn).StreamThoughtProcess(context.Background())
+	if err != nil {
+		t.Fatalf("opening stream: %v", err)
+	}
+	if err := stream.Send(&agentv1.AgentInput{SessionId: "sess-1"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	grpcCh := make(chan error, 1)
+	go func() {
+		_, err := stream.Recv()
+		grpcCh <- err
+	}()
+
+	sseCh := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + ts.httpServer.Addr + "/events")
+		if err != nil {
+			sseCh <- "error: " + err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		sseCh <- string(body)
+	}()
+
+	for _, started := range []chan struct{}{ts.grpcStarted, ts.sseStarted} {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatal("request did not reach its handler")
+		}
+	}
+	return grpcCh, sseCh
+}
+
+func TestDrainWaitsForInFlightRequests(t *testing.T) {
+	ts := startServers(t)
+	grpcResult, sseResult := ts.openRequests(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	drained := make(chan error, 1)
+	go func() { drained <- Drain(ctx, ts.grpcServer, ts.httpServer) }()
+
+	select {
+	case err := <-drained:
+		t.Fatalf("Drain returned before in-flight requests finished: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(ts.release)
+
+	if err := <-grpcResult; err != nil {
+		t.Errorf("expected the in-flight stream to complete, got %v", err)
+	}
+	if body := <-sseResult; body != "data: first\n\ndata: [DONE]\n\n" {
+		t.Errorf("expected the in-flight SSE response to complete, got %q", body)
+	}
+	if err := <-drained; err != nil {
+		t.Errorf("expected a clean drain, got %v", err)
+	}
+
+	if _, err := http.Get("http://" + ts.httpServer.Addr + "/events"); err == nil {
+		t.Error("expected new requests to be refused after draining")
+	}
+}
+
+func TestDrainForcesCloseAfterDeadline(t *testing.T) {
+	ts := startServers(t)
+	defer close(ts.release)
+	grpcResult, sseResult := ts.openRequests(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := Drain(ctx, ts.grpcServer, ts.httpServer); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+
+	if err := <-grpcResult; err == nil {
+		t.Error("expected the stream to be cut off")
+	}
+	if body := <-sseResult; body == "data: first\n\ndata: [DONE]\n\n" {
+		t.Error("expected the SSE response to be cut off")
+	}
+}
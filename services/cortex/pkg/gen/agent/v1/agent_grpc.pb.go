@@ -19,9 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ReasoningEngine_StreamThoughtProcess_FullMethodName = "/cognitive_os.agent.v1.ReasoningEngine/StreamThoughtProcess"
-	ReasoningEngine_ClassifyItem_FullMethodName         = "/cognitive_os.agent.v1.ReasoningEngine/ClassifyItem"
-	ReasoningEngine_GenerateWeeklyReview_FullMethodName = "/cognitive_os.agent.v1.ReasoningEngine/GenerateWeeklyReview"
+	ReasoningEngine_StreamThoughtProcess_FullMethodName  = "/cognitive_os.agent.v1.ReasoningEngine/StreamThoughtProcess"
+	ReasoningEngine_ClassifyItem_FullMethodName          = "/cognitive_os.agent.v1.ReasoningEngine/ClassifyItem"
+	ReasoningEngine_GenerateWeeklyReview_FullMethodName  = "/cognitive_os.agent.v1.ReasoningEngine/GenerateWeeklyReview"
+	ReasoningEngine_StreamWeeklyReview_FullMethodName    = "/cognitive_os.agent.v1.ReasoningEngine/StreamWeeklyReview"
+	ReasoningEngine_GenerateMonthlyReview_FullMethodName = "/cognitive_os.agent.v1.ReasoningEngine/GenerateMonthlyReview"
+	ReasoningEngine_ListModels_FullMethodName            = "/cognitive_os.agent.v1.ReasoningEngine/ListModels"
+	ReasoningEngine_ListPendingReviews_FullMethodName    = "/cognitive_os.agent.v1.ReasoningEngine/ListPendingReviews"
+	ReasoningEngine_ResolveReview_FullMethodName         = "/cognitive_os.agent.v1.ReasoningEngine/ResolveReview"
 )
 
 // ReasoningEngineClient is the client API for ReasoningEngine service.
@@ -38,6 +43,16 @@ type ReasoningEngineClient interface {
 	ClassifyItem(ctx context.Context, in *ClassifyRequest, opts ...grpc.CallOption) (*ClassifyResponse, error)
 	// Generate a weekly review report
 	GenerateWeeklyReview(ctx context.Context, in *WeeklyReviewRequest, opts ...grpc.CallOption) (*WeeklyReviewResponse, error)
+	// Stream a weekly review report section by section as it is generated
+	StreamWeeklyReview(ctx context.Context, in *WeeklyReviewRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WeeklyReviewChunk], error)
+	// Generate a monthly review report from several weeks of task activity
+	GenerateMonthlyReview(ctx context.Context, in *MonthlyReviewRequest, opts ...grpc.CallOption) (*MonthlyReviewResponse, error)
+	// List the model names the reasoning engine routes to a dedicated provider
+	ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error)
+	// List the items ClassifyItem queued for manual confirmation, oldest first
+	ListPendingReviews(ctx context.Context, in *ListPendingReviewsRequest, opts ...grpc.CallOption) (*ListPendingReviewsResponse, error)
+	// Confirm a queued item's classification and remove it from the queue
+	ResolveReview(ctx context.Context, in *ResolveReviewRequest, opts ...grpc.CallOption) (*ResolveReviewResponse, error)
 }
 
 type reasoningEngineClient struct {
@@ -81,6 +96,65 @@ func (c *reasoningEngineClient) GenerateWeeklyReview(ctx context.Context, in *We
 	return out, nil
 }
 
+func (c *reasoningEngineClient) StreamWeeklyReview(ctx context.Context, in *WeeklyReviewRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WeeklyReviewChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReasoningEngine_ServiceDesc.Streams[1], ReasoningEngine_StreamWeeklyReview_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WeeklyReviewRequest, WeeklyReviewChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReasoningEngine_StreamWeeklyReviewClient = grpc.ServerStreamingClient[WeeklyReviewChunk]
+
+func (c *reasoningEngineClient) GenerateMonthlyReview(ctx context.Context, in *MonthlyReviewRequest, opts ...grpc.CallOption) (*MonthlyReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonthlyReviewResponse)
+	err := c.cc.Invoke(ctx, ReasoningEngine_GenerateMonthlyReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reasoningEngineClient) ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModelsResponse)
+	err := c.cc.Invoke(ctx, ReasoningEngine_ListModels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reasoningEngineClient) ListPendingReviews(ctx context.Context, in *ListPendingReviewsRequest, opts ...grpc.CallOption) (*ListPendingReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingReviewsResponse)
+	err := c.cc.Invoke(ctx, ReasoningEngine_ListPendingReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reasoningEngineClient) ResolveReview(ctx context.Context, in *ResolveReviewRequest, opts ...grpc.CallOption) (*ResolveReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveReviewResponse)
+	err := c.cc.Invoke(ctx, ReasoningEngine_ResolveReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReasoningEngineServer is the server API for ReasoningEngine service.
 // All implementations must embed UnimplementedReasoningEngineServer
 // for forward compatibility.
@@ -95,6 +169,16 @@ type ReasoningEngineServer interface {
 	ClassifyItem(context.Context, *ClassifyRequest) (*ClassifyResponse, error)
 	// Generate a weekly review report
 	GenerateWeeklyReview(context.Context, *WeeklyReviewRequest) (*WeeklyReviewResponse, error)
+	// Stream a weekly review report section by section as it is generated
+	StreamWeeklyReview(*WeeklyReviewRequest, grpc.ServerStreamingServer[WeeklyReviewChunk]) error
+	// Generate a monthly review report from several weeks of task activity
+	GenerateMonthlyReview(context.Context, *MonthlyReviewRequest) (*MonthlyReviewResponse, error)
+	// List the model names the reasoning engine routes to a dedicated provider
+	ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error)
+	// List the items ClassifyItem queued for manual confirmation, oldest first
+	ListPendingReviews(context.Context, *ListPendingReviewsRequest) (*ListPendingReviewsResponse, error)
+	// Confirm a queued item's classification and remove it from the queue
+	ResolveReview(context.Context, *ResolveReviewRequest) (*ResolveReviewResponse, error)
 	mustEmbedUnimplementedReasoningEngineServer()
 }
 
@@ -114,6 +198,21 @@ func (UnimplementedReasoningEngineServer) ClassifyItem(context.Context, *Classif
 func (UnimplementedReasoningEngineServer) GenerateWeeklyReview(context.Context, *WeeklyReviewRequest) (*WeeklyReviewResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GenerateWeeklyReview not implemented")
 }
+func (UnimplementedReasoningEngineServer) StreamWeeklyReview(*WeeklyReviewRequest, grpc.ServerStreamingServer[WeeklyReviewChunk]) error {
+	return status.Error(codes.Unimplemented, "method StreamWeeklyReview not implemented")
+}
+func (UnimplementedReasoningEngineServer) GenerateMonthlyReview(context.Context, *MonthlyReviewRequest) (*MonthlyReviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateMonthlyReview not implemented")
+}
+func (UnimplementedReasoningEngineServer) ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListModels not implemented")
+}
+func (UnimplementedReasoningEngineServer) ListPendingReviews(context.Context, *ListPendingReviewsRequest) (*ListPendingReviewsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPendingReviews not implemented")
+}
+func (UnimplementedReasoningEngineServer) ResolveReview(context.Context, *ResolveReviewRequest) (*ResolveReviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveReview not implemented")
+}
 func (UnimplementedReasoningEngineServer) mustEmbedUnimplementedReasoningEngineServer() {}
 func (UnimplementedReasoningEngineServer) testEmbeddedByValue()                         {}
 
@@ -178,6 +277,89 @@ func _ReasoningEngine_GenerateWeeklyReview_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReasoningEngine_StreamWeeklyReview_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WeeklyReviewRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReasoningEngineServer).StreamWeeklyReview(m, &grpc.GenericServerStream[WeeklyReviewRequest, WeeklyReviewChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReasoningEngine_StreamWeeklyReviewServer = grpc.ServerStreamingServer[WeeklyReviewChunk]
+
+func _ReasoningEngine_GenerateMonthlyReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MonthlyReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReasoningEngineServer).GenerateMonthlyReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReasoningEngine_GenerateMonthlyReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReasoningEngineServer).GenerateMonthlyReview(ctx, req.(*MonthlyReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReasoningEngine_ListModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReasoningEngineServer).ListModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReasoningEngine_ListModels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReasoningEngineServer).ListModels(ctx, req.(*ListModelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReasoningEngine_ListPendingReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReasoningEngineServer).ListPendingReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReasoningEngine_ListPendingReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReasoningEngineServer).ListPendingReviews(ctx, req.(*ListPendingReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReasoningEngine_ResolveReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReasoningEngineServer).ResolveReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReasoningEngine_ResolveReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReasoningEngineServer).ResolveReview(ctx, req.(*ResolveReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReasoningEngine_ServiceDesc is the grpc.ServiceDesc for ReasoningEngine service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -193,6 +375,22 @@ var ReasoningEngine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateWeeklyReview",
 			Handler:    _ReasoningEngine_GenerateWeeklyReview_Handler,
 		},
+		{
+			MethodName: "GenerateMonthlyReview",
+			Handler:    _ReasoningEngine_GenerateMonthlyReview_Handler,
+		},
+		{
+			MethodName: "ListModels",
+			Handler:    _ReasoningEngine_ListModels_Handler,
+		},
+		{
+			MethodName: "ListPendingReviews",
+			Handler:    _ReasoningEngine_ListPendingReviews_Handler,
+		},
+		{
+			MethodName: "ResolveReview",
+			Handler:    _ReasoningEngine_ResolveReview_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -201,6 +399,11 @@ var ReasoningEngine_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamWeeklyReview",
+			Handler:       _ReasoningEngine_StreamWeeklyReview_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "agent/v1/agent.proto",
 }
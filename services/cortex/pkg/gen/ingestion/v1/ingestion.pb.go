@@ -24,10 +24,14 @@ const (
 )
 
 type IngestRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *InboxItem             `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Item  *InboxItem             `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	// Client-supplied key identifying this delivery. A repeat of a recently
+	// seen key returns the first response without re-indexing the item. The
+	// "idempotency-key" gRPC metadata header is used when this is empty.
+	IdempotencyKey string `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *IngestRequest) Reset() {
@@ -67,6 +71,13 @@ func (x *IngestRequest) GetItem() *InboxItem {
 	return nil
 }
 
+func (x *IngestRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type InboxItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -424,10 +435,13 @@ func (x *ItemStatusResponse) GetLastUpdated() *timestamppb.Timestamp {
 }
 
 type ListItemsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	StatusFilter  v1.ProcessingStatus    `protobuf:"varint,3,opt,name=status_filter,json=statusFilter,proto3,enum=cognitive_os.common.v1.ProcessingStatus" json:"status_filter,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only list items in this status; UNSPECIFIED lists every status.
+	StatusFilter v1.ProcessingStatus `protobuf:"varint,3,opt,name=status_filter,json=statusFilter,proto3,enum=cognitive_os.common.v1.ProcessingStatus" json:"status_filter,omitempty"`
+	// Only list items from this source, e.g. "email"; empty lists every source.
+	Source        string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -483,6 +497,13 @@ func (x *ListItemsRequest) GetStatusFilter() v1.ProcessingStatus {
 	return v1.ProcessingStatus(0)
 }
 
+func (x *ListItemsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type ListItemsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*InboxItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -547,9 +568,10 @@ var File_ingestion_v1_ingestion_proto protoreflect.FileDescriptor
 
 const file_ingestion_v1_ingestion_proto_rawDesc = "" +
 	"\n" +
-	"\x1cingestion/v1/ingestion.proto\x12\x19cognitive_os.ingestion.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16common/v1/common.proto\"I\n" +
+	"\x1cingestion/v1/ingestion.proto\x12\x19cognitive_os.ingestion.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16common/v1/common.proto\"r\n" +
 	"\rIngestRequest\x128\n" +
-	"\x04item\x18\x01 \x01(\v2$.cognitive_os.ingestion.v1.InboxItemR\x04item\"\xa2\x03\n" +
+	"\x04item\x18\x01 \x01(\v2$.cognitive_os.ingestion.v1.InboxItemR\x04item\x12'\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\"\xa2\x03\n" +
 	"\tInboxItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x16\n" +
@@ -580,12 +602,13 @@ const file_ingestion_v1_ingestion_proto_rawDesc = "" +
 	"\x06status\x18\x02 \x01(\x0e2(.cognitive_os.common.v1.ProcessingStatusR\x06status\x12&\n" +
 	"\x0eclassification\x18\x03 \x01(\tR\x0eclassification\x12 \n" +
 	"\vdestination\x18\x04 \x01(\tR\vdestination\x12=\n" +
-	"\flast_updated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\"\x9d\x01\n" +
+	"\flast_updated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\"\xb5\x01\n" +
 	"\x10ListItemsRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x02 \x01(\tR\tpageToken\x12M\n" +
-	"\rstatus_filter\x18\x03 \x01(\x0e2(.cognitive_os.common.v1.ProcessingStatusR\fstatusFilter\"\x98\x01\n" +
+	"\rstatus_filter\x18\x03 \x01(\x0e2(.cognitive_os.common.v1.ProcessingStatusR\fstatusFilter\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\"\x98\x01\n" +
 	"\x11ListItemsResponse\x12:\n" +
 	"\x05items\x18\x01 \x03(\v2$.cognitive_os.ingestion.v1.InboxItemR\x05items\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
@@ -19,14 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MemoryService_IndexDocument_FullMethodName  = "/cognitive_os.memory.v1.MemoryService/IndexDocument"
-	MemoryService_SemanticSearch_FullMethodName = "/cognitive_os.memory.v1.MemoryService/SemanticSearch"
-	MemoryService_FullTextSearch_FullMethodName = "/cognitive_os.memory.v1.MemoryService/FullTextSearch"
-	MemoryService_HybridSearch_FullMethodName   = "/cognitive_os.memory.v1.MemoryService/HybridSearch"
-	MemoryService_AddGraphTriple_FullMethodName = "/cognitive_os.memory.v1.MemoryService/AddGraphTriple"
-	MemoryService_QueryGraph_FullMethodName     = "/cognitive_os.memory.v1.MemoryService/QueryGraph"
-	MemoryService_DeleteDocument_FullMethodName = "/cognitive_os.memory.v1.MemoryService/DeleteDocument"
-	MemoryService_GetStats_FullMethodName       = "/cognitive_os.memory.v1.MemoryService/GetStats"
+	MemoryService_IndexDocument_FullMethodName       = "/cognitive_os.memory.v1.MemoryService/IndexDocument"
+	MemoryService_StreamIndexDocument_FullMethodName = "/cognitive_os.memory.v1.MemoryService/StreamIndexDocument"
+	MemoryService_BatchIndexDocuments_FullMethodName = "/cognitive_os.memory.v1.MemoryService/BatchIndexDocuments"
+	MemoryService_SemanticSearch_FullMethodName      = "/cognitive_os.memory.v1.MemoryService/SemanticSearch"
+	MemoryService_FullTextSearch_FullMethodName      = "/cognitive_os.memory.v1.MemoryService/FullTextSearch"
+	MemoryService_HybridSearch_FullMethodName        = "/cognitive_os.memory.v1.MemoryService/HybridSearch"
+	MemoryService_AddGraphTriple_FullMethodName      = "/cognitive_os.memory.v1.MemoryService/AddGraphTriple"
+	MemoryService_QueryGraph_FullMethodName          = "/cognitive_os.memory.v1.MemoryService/QueryGraph"
+	MemoryService_DeleteDocument_FullMethodName      = "/cognitive_os.memory.v1.MemoryService/DeleteDocument"
+	MemoryService_UndeleteDocument_FullMethodName    = "/cognitive_os.memory.v1.MemoryService/UndeleteDocument"
+	MemoryService_DeleteByFilter_FullMethodName      = "/cognitive_os.memory.v1.MemoryService/DeleteByFilter"
+	MemoryService_GetDocument_FullMethodName         = "/cognitive_os.memory.v1.MemoryService/GetDocument"
+	MemoryService_ListByFilter_FullMethodName        = "/cognitive_os.memory.v1.MemoryService/ListByFilter"
+	MemoryService_GetStats_FullMethodName            = "/cognitive_os.memory.v1.MemoryService/GetStats"
+	MemoryService_GetStatsHistory_FullMethodName     = "/cognitive_os.memory.v1.MemoryService/GetStatsHistory"
+	MemoryService_Reindex_FullMethodName             = "/cognitive_os.memory.v1.MemoryService/Reindex"
 )
 
 // MemoryServiceClient is the client API for MemoryService service.
@@ -38,6 +46,10 @@ const (
 type MemoryServiceClient interface {
 	// Index a document into the vector store
 	IndexDocument(ctx context.Context, in *IndexRequest, opts ...grpc.CallOption) (*IndexResponse, error)
+	// Index a document, streaming progress as its chunks are embedded
+	StreamIndexDocument(ctx context.Context, in *IndexRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[IndexProgress], error)
+	// Index several documents in one call, embedding their chunks together
+	BatchIndexDocuments(ctx context.Context, in *BatchIndexRequest, opts ...grpc.CallOption) (*BatchIndexResponse, error)
 	// Search for semantically similar content
 	SemanticSearch(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	// Full-text keyword search using BM25 ranking
@@ -50,8 +62,22 @@ type MemoryServiceClient interface {
 	QueryGraph(ctx context.Context, in *GraphQueryRequest, opts ...grpc.CallOption) (*GraphQueryResponse, error)
 	// Delete a document from the vector store
 	DeleteDocument(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Restore a soft-deleted document
+	UndeleteDocument(ctx context.Context, in *UndeleteRequest, opts ...grpc.CallOption) (*UndeleteResponse, error)
+	// Delete every document whose metadata matches a filter
+	DeleteByFilter(ctx context.Context, in *DeleteByFilterRequest, opts ...grpc.CallOption) (*DeleteByFilterResponse, error)
+	// Get a stored document's full content and metadata by ID
+	GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*GetDocumentResponse, error)
+	// List the documents whose metadata matches a filter, newest first,
+	// without a text query
+	ListByFilter(ctx context.Context, in *ListByFilterRequest, opts ...grpc.CallOption) (*ListByFilterResponse, error)
 	// Get indexing statistics
 	GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// Get snapshots of the indexing statistics over time, for charting growth
+	GetStatsHistory(ctx context.Context, in *StatsHistoryRequest, opts ...grpc.CallOption) (*StatsHistoryResponse, error)
+	// Re-chunk and re-embed every stored document with the current embedder,
+	// streaming progress
+	Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReindexProgress], error)
 }
 
 type memoryServiceClient struct {
@@ -72,6 +98,35 @@ func (c *memoryServiceClient) IndexDocument(ctx context.Context, in *IndexReques
 	return out, nil
 }
 
+func (c *memoryServiceClient) StreamIndexDocument(ctx context.Context, in *IndexRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[IndexProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MemoryService_ServiceDesc.Streams[0], MemoryService_StreamIndexDocument_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IndexRequest, IndexProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MemoryService_StreamIndexDocumentClient = grpc.ServerStreamingClient[IndexProgress]
+
+func (c *memoryServiceClient) BatchIndexDocuments(ctx context.Context, in *BatchIndexRequest, opts ...grpc.CallOption) (*BatchIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchIndexResponse)
+	err := c.cc.Invoke(ctx, MemoryService_BatchIndexDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoryServiceClient) SemanticSearch(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SearchResponse)
@@ -132,6 +187,46 @@ func (c *memoryServiceClient) DeleteDocument(ctx context.Context, in *DeleteRequ
 	return out, nil
 }
 
+func (c *memoryServiceClient) UndeleteDocument(ctx context.Context, in *UndeleteRequest, opts ...grpc.CallOption) (*UndeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndeleteResponse)
+	err := c.cc.Invoke(ctx, MemoryService_UndeleteDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) DeleteByFilter(ctx context.Context, in *DeleteByFilterRequest, opts ...grpc.CallOption) (*DeleteByFilterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteByFilterResponse)
+	err := c.cc.Invoke(ctx, MemoryService_DeleteByFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*GetDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDocumentResponse)
+	err := c.cc.Invoke(ctx, MemoryService_GetDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) ListByFilter(ctx context.Context, in *ListByFilterRequest, opts ...grpc.CallOption) (*ListByFilterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListByFilterResponse)
+	err := c.cc.Invoke(ctx, MemoryService_ListByFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoryServiceClient) GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StatsResponse)
@@ -142,6 +237,35 @@ func (c *memoryServiceClient) GetStats(ctx context.Context, in *StatsRequest, op
 	return out, nil
 }
 
+func (c *memoryServiceClient) GetStatsHistory(ctx context.Context, in *StatsHistoryRequest, opts ...grpc.CallOption) (*StatsHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsHistoryResponse)
+	err := c.cc.Invoke(ctx, MemoryService_GetStatsHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReindexProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MemoryService_ServiceDesc.Streams[1], MemoryService_Reindex_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReindexRequest, ReindexProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MemoryService_ReindexClient = grpc.ServerStreamingClient[ReindexProgress]
+
 // MemoryServiceServer is the server API for MemoryService service.
 // All implementations must embed UnimplementedMemoryServiceServer
 // for forward compatibility.
@@ -151,6 +275,10 @@ func (c *memoryServiceClient) GetStats(ctx context.Context, in *StatsRequest, op
 type MemoryServiceServer interface {
 	// Index a document into the vector store
 	IndexDocument(context.Context, *IndexRequest) (*IndexResponse, error)
+	// Index a document, streaming progress as its chunks are embedded
+	StreamIndexDocument(*IndexRequest, grpc.ServerStreamingServer[IndexProgress]) error
+	// Index several documents in one call, embedding their chunks together
+	BatchIndexDocuments(context.Context, *BatchIndexRequest) (*BatchIndexResponse, error)
 	// Search for semantically similar content
 	SemanticSearch(context.Context, *SearchRequest) (*SearchResponse, error)
 	// Full-text keyword search using BM25 ranking
@@ -163,8 +291,22 @@ type MemoryServiceServer interface {
 	QueryGraph(context.Context, *GraphQueryRequest) (*GraphQueryResponse, error)
 	// Delete a document from the vector store
 	DeleteDocument(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Restore a soft-deleted document
+	UndeleteDocument(context.Context, *UndeleteRequest) (*UndeleteResponse, error)
+	// Delete every document whose metadata matches a filter
+	DeleteByFilter(context.Context, *DeleteByFilterRequest) (*DeleteByFilterResponse, error)
+	// Get a stored document's full content and metadata by ID
+	GetDocument(context.Context, *GetDocumentRequest) (*GetDocumentResponse, error)
+	// List the documents whose metadata matches a filter, newest first,
+	// without a text query
+	ListByFilter(context.Context, *ListByFilterRequest) (*ListByFilterResponse, error)
 	// Get indexing statistics
 	GetStats(context.Context, *StatsRequest) (*StatsResponse, error)
+	// Get snapshots of the indexing statistics over time, for charting growth
+	GetStatsHistory(context.Context, *StatsHistoryRequest) (*StatsHistoryResponse, error)
+	// Re-chunk and re-embed every stored document with the current embedder,
+	// streaming progress
+	Reindex(*ReindexRequest, grpc.ServerStreamingServer[ReindexProgress]) error
 	mustEmbedUnimplementedMemoryServiceServer()
 }
 
@@ -178,6 +320,12 @@ type UnimplementedMemoryServiceServer struct{}
 func (UnimplementedMemoryServiceServer) IndexDocument(context.Context, *IndexRequest) (*IndexResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method IndexDocument not implemented")
 }
+func (UnimplementedMemoryServiceServer) StreamIndexDocument(*IndexRequest, grpc.ServerStreamingServer[IndexProgress]) error {
+	return status.Error(codes.Unimplemented, "method StreamIndexDocument not implemented")
+}
+func (UnimplementedMemoryServiceServer) BatchIndexDocuments(context.Context, *BatchIndexRequest) (*BatchIndexResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchIndexDocuments not implemented")
+}
 func (UnimplementedMemoryServiceServer) SemanticSearch(context.Context, *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SemanticSearch not implemented")
 }
@@ -196,9 +344,27 @@ func (UnimplementedMemoryServiceServer) QueryGraph(context.Context, *GraphQueryR
 func (UnimplementedMemoryServiceServer) DeleteDocument(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteDocument not implemented")
 }
+func (UnimplementedMemoryServiceServer) UndeleteDocument(context.Context, *UndeleteRequest) (*UndeleteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UndeleteDocument not implemented")
+}
+func (UnimplementedMemoryServiceServer) DeleteByFilter(context.Context, *DeleteByFilterRequest) (*DeleteByFilterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteByFilter not implemented")
+}
+func (UnimplementedMemoryServiceServer) GetDocument(context.Context, *GetDocumentRequest) (*GetDocumentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDocument not implemented")
+}
+func (UnimplementedMemoryServiceServer) ListByFilter(context.Context, *ListByFilterRequest) (*ListByFilterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListByFilter not implemented")
+}
 func (UnimplementedMemoryServiceServer) GetStats(context.Context, *StatsRequest) (*StatsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStats not implemented")
 }
+func (UnimplementedMemoryServiceServer) GetStatsHistory(context.Context, *StatsHistoryRequest) (*StatsHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStatsHistory not implemented")
+}
+func (UnimplementedMemoryServiceServer) Reindex(*ReindexRequest, grpc.ServerStreamingServer[ReindexProgress]) error {
+	return status.Error(codes.Unimplemented, "method Reindex not implemented")
+}
 func (UnimplementedMemoryServiceServer) mustEmbedUnimplementedMemoryServiceServer() {}
 func (UnimplementedMemoryServiceServer) testEmbeddedByValue()                       {}
 
@@ -238,6 +404,35 @@ func _MemoryService_IndexDocument_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoryService_StreamIndexDocument_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IndexRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MemoryServiceServer).StreamIndexDocument(m, &grpc.GenericServerStream[IndexRequest, IndexProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MemoryService_StreamIndexDocumentServer = grpc.ServerStreamingServer[IndexProgress]
+
+func _MemoryService_BatchIndexDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).BatchIndexDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_BatchIndexDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).BatchIndexDocuments(ctx, req.(*BatchIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoryService_SemanticSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchRequest)
 	if err := dec(in); err != nil {
@@ -346,6 +541,78 @@ func _MemoryService_DeleteDocument_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoryService_UndeleteDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).UndeleteDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_UndeleteDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).UndeleteDocument(ctx, req.(*UndeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_DeleteByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteByFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).DeleteByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_DeleteByFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).DeleteByFilter(ctx, req.(*DeleteByFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_GetDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).GetDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_GetDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).GetDocument(ctx, req.(*GetDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_ListByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListByFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).ListByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_ListByFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).ListByFilter(ctx, req.(*ListByFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoryService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatsRequest)
 	if err := dec(in); err != nil {
@@ -364,6 +631,35 @@ func _MemoryService_GetStats_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoryService_GetStatsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).GetStatsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_GetStatsHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).GetStatsHistory(ctx, req.(*StatsHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_Reindex_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReindexRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MemoryServiceServer).Reindex(m, &grpc.GenericServerStream[ReindexRequest, ReindexProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MemoryService_ReindexServer = grpc.ServerStreamingServer[ReindexProgress]
+
 // MemoryService_ServiceDesc is the grpc.ServiceDesc for MemoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -375,6 +671,10 @@ var MemoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IndexDocument",
 			Handler:    _MemoryService_IndexDocument_Handler,
 		},
+		{
+			MethodName: "BatchIndexDocuments",
+			Handler:    _MemoryService_BatchIndexDocuments_Handler,
+		},
 		{
 			MethodName: "SemanticSearch",
 			Handler:    _MemoryService_SemanticSearch_Handler,
@@ -399,11 +699,42 @@ var MemoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDocument",
 			Handler:    _MemoryService_DeleteDocument_Handler,
 		},
+		{
+			MethodName: "UndeleteDocument",
+			Handler:    _MemoryService_UndeleteDocument_Handler,
+		},
+		{
+			MethodName: "DeleteByFilter",
+			Handler:    _MemoryService_DeleteByFilter_Handler,
+		},
+		{
+			MethodName: "GetDocument",
+			Handler:    _MemoryService_GetDocument_Handler,
+		},
+		{
+			MethodName: "ListByFilter",
+			Handler:    _MemoryService_ListByFilter_Handler,
+		},
 		{
 			MethodName: "GetStats",
 			Handler:    _MemoryService_GetStats_Handler,
 		},
+		{
+			MethodName: "GetStatsHistory",
+			Handler:    _MemoryService_GetStatsHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamIndexDocument",
+			Handler:       _MemoryService_StreamIndexDocument_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Reindex",
+			Handler:       _MemoryService_Reindex_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "memory/v1/memory.proto",
 }
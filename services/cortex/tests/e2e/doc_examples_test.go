@@ -16,6 +16,7 @@ import (
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/mcpserver"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/metrics"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/openaicompat"
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/search"
 	cortexserver "github.com/ziyixi/SecondBrain/services/cortex/internal/server"
 	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
 	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
@@ -50,7 +51,7 @@ func TestDocExamples(t *testing.T) {
 	})
 	defer frontalStop()
 
-	cortex := cortexserver.NewCortexServer(logger)
+	cortex := cortexserver.NewCortexServer(logger, mustLoadConfig(t))
 	if err := cortex.ConnectDownstream(frontalAddr, hippoAddr); err != nil {
 		t.Fatalf("connecting downstream: %v", err)
 	}
@@ -80,6 +81,8 @@ func TestDocExamples(t *testing.T) {
 		json.NewEncoder(w).Encode(metricsStore.Summary())
 	})
 
+	search.NewHandler(logger, cortex.MemoryClient()).RegisterRoutes(httpMux)
+
 	srv := httptest.NewServer(httpMux)
 	defer srv.Close()
 
@@ -287,6 +290,51 @@ func TestDocExamples(t *testing.T) {
 		}
 	})
 
+	// ===================================================================
+	// README Example: GET /v1/search
+	// ===================================================================
+	t.Run("SearchEndpoint", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/v1/search?q=machine+learning&mode=semantic&limit=5")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+
+		var result search.Response
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatalf("decoding: %v", err)
+		}
+		if result.Mode != "semantic" || result.Query != "machine learning" {
+			t.Errorf("unexpected response: %+v", result)
+		}
+		if len(result.Results) == 0 {
+			t.Fatal("expected search results")
+		}
+		found := false
+		for _, r := range result.Results {
+			if r.DocumentID == "doc-1" && r.Content != "" && r.Score > 0 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected the machine learning document in results, got %+v", result.Results)
+		}
+
+		// An empty query is rejected
+		resp2, err := http.Get(srv.URL + "/v1/search?q=")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp2.Body.Close()
+		if resp2.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected 400 for an empty query, got %d", resp2.StatusCode)
+		}
+	})
+
 	// ===================================================================
 	// README Example: MCP tools/list
 	// ===================================================================
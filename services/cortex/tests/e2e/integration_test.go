@@ -22,6 +22,7 @@ import (
 	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
 	memoryv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/memory/v1"
 
+	"github.com/ziyixi/SecondBrain/services/cortex/internal/config"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/metrics"
 	"github.com/ziyixi/SecondBrain/services/cortex/internal/openaicompat"
 	cortexserver "github.com/ziyixi/SecondBrain/services/cortex/internal/server"
@@ -298,18 +299,29 @@ func (f *fakeMemoryService) SemanticSearch(ctx context.Context, req *memoryv1.Se
 
 // --- Helper to start a gRPC server on a random port ---
 
-func startGRPCServer(t *testing.T, register func(s *grpc.Server)) (addr string, stop func()) {
+func startGRPCServer(t *testing.T, register func(s *grpc.Server), opts ...grpc.ServerOption) (addr string, stop func()) {
 	t.Helper()
 	lis, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
 		t.Fatalf("listen: %v", err)
 	}
-	s := grpc.NewServer()
+	s := grpc.NewServer(opts...)
 	register(s)
 	go s.Serve(lis)
 	return lis.Addr().String(), s.GracefulStop
 }
 
+// --- Helper to load the cortex config ---
+
+func mustLoadConfig(t *testing.T) *config.Config {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	return cfg
+}
+
 // --- Helper to call the OpenAI-compatible chat completions API ---
 
 func chatCompletion(t *testing.T, baseURL, model, userMsg string) string {
@@ -410,7 +422,7 @@ func TestIntegrationFeedbackLoop(t *testing.T) {
 	defer frontalStop()
 
 	// --- Step 4: Start real Cortex gRPC server ---
-	cortex := cortexserver.NewCortexServer(logger)
+	cortex := cortexserver.NewCortexServer(logger, mustLoadConfig(t))
 	if err := cortex.ConnectDownstream(frontalAddr, hippoAddr); err != nil {
 		t.Fatalf("connecting downstream: %v", err)
 	}
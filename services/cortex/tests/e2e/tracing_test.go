@@ -0,0 +1,158 @@
+package e2e
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	agentv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/agent/v1"
+	commonv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/common/v1"
+	memoryv1 "github.com/ziyixi/SecondBrain/services/cortex/pkg/gen/memory/v1"
+
+	cortexserver "github.com/ziyixi/SecondBrain/services/cortex/internal/server"
+)
+
+// installTestTracer records spans in memory for the duration of the test.
+func installTestTracer(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		provider.Shutdown(context.Background())
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return exporter
+}
+
+func TestTracingSpansRequestPath(t *testing.T) {
+	exporter := installTestTracer(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	traced := grpc.StatsHandler(otelgrpc.NewServerHandler())
+
+	memService := newFakeMemoryService()
+	memService.docs["doc-1"] = "Tracing connects spans across services."
+	hippoAddr, hippoStop := startGRPCServer(t, func(s *grpc.Server) {
+		memoryv1.RegisterMemoryServiceServer(s, memService)
+		commonv1.RegisterHealthServiceServer(s, memService)
+	}, traced)
+	defer hippoStop()
+
+	frontalSvc := &fakeFrontalLobe{model: "test"}
+	frontalAddr, frontalStop := startGRPCServer(t, func(s *grpc.Server) {
+		agentv1.RegisterReasoningEngineServer(s, frontalSvc)
+		commonv1.RegisterHealthServiceServer(s, frontalSvc)
+	}, traced)
+	defer frontalStop()
+
+	cortex := cortexserver.NewCortexServer(logger, mustLoadConfig(t))
+	if err := cortex.ConnectDownstream(frontalAddr, hippoAddr); err != nil {
+		t.Fatalf("connecting downstream: %v", err)
+	}
+	defer cortex.Close()
+
+	cortexAddr, cortexStop := startGRPCServer(t, func(s *grpc.Server) {
+		agentv1.RegisterReasoningEngineServer(s, cortex)
+	}, traced)
+	defer cortexStop()
+
+	conn, err := grpc.NewClient(cortexAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial cortex: %v", err)
+	}
+	defer conn.Close()
+
+	stream, err := agentv1.NewReasoningEngineClient(conn).StreamThoughtProcess(context.Background())
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	if err := stream.Send(&agentv1.AgentInput{
+		SessionId: "trace-session",
+		InputType: &agentv1.AgentInput_UserQuery{UserQuery: "How does tracing work?"},
+	}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	stream.CloseSend()
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("recv: %v", err)
+		}
+	}
+	cortexStop()
+
+	spans := exporter.GetSpans()
+	byName := func(name string) []tracetest.SpanStub {
+		var out []tracetest.SpanStub
+		for _, s := range spans {
+			if s.Name == name {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	childrenOf := func(parent tracetest.SpanStub) map[string]bool {
+		names := make(map[string]bool)
+		for _, s := range spans {
+			if s.Parent.SpanID() == parent.SpanContext.SpanID() {
+				names[s.Name] = true
+			}
+		}
+		return names
+	}
+
+	roots := byName("cognitive_os.agent.v1.ReasoningEngine/StreamThoughtProcess")
+	var root tracetest.SpanStub
+	for _, s := range roots {
+		if !s.Parent.IsValid() {
+			root = s
+		}
+	}
+	if !root.SpanContext.IsValid() {
+		t.Fatalf("expected a root cortex server span, got %d StreamThoughtProcess spans", len(roots))
+	}
+
+	enrich := byName("cortex.enrichContextFromMemory")
+	if len(enrich) != 1 {
+		t.Fatalf("expected one memory enrichment span, got %d", len(enrich))
+	}
+	if enrich[0].Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Error("expected memory enrichment to be a child of the cortex server span")
+	}
+
+	// The fake hippocampus only implements SemanticSearch, so cortex tries
+	// HybridSearch first and falls back.
+	children := childrenOf(enrich[0])
+	for _, name := range []string{
+		"cognitive_os.memory.v1.MemoryService/HybridSearch",
+		"cognitive_os.memory.v1.MemoryService/SemanticSearch",
+	} {
+		if !children[name] {
+			t.Errorf("expected client span %s under memory enrichment, got %v", name, children)
+		}
+	}
+
+	// Every span, including the downstream servers', belongs to one trace.
+	for _, s := range spans {
+		if s.SpanContext.TraceID() != root.SpanContext.TraceID() {
+			t.Errorf("span %s is in a different trace", s.Name)
+		}
+	}
+	if len(byName("cognitive_os.memory.v1.MemoryService/SemanticSearch")) != 2 {
+		t.Error("expected both client and server spans for SemanticSearch")
+	}
+}
@@ -5,69 +5,142 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/config"
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/metrics"
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/middleware"
 	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/reasoning"
 	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/server"
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/tracing"
 	agentv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/agent/v1"
 	commonv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/common/v1"
 )
 
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(middleware.NewRequestIDHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 	slog.SetDefault(logger)
 
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.ServiceName, cfg.OTelEndpoint)
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
 
-	// Create LLM provider router
+	// Create LLM provider router. Providers whose key comes from a file are
+	// collected per file so a rotated key reaches every one of them.
 	var defaultLLM reasoning.LLMProvider
+	var defaultKeyed, openAIKeyed, googleKeyed []keyedProvider
 	switch cfg.LLMProvider {
 	case "openai":
-		defaultLLM = reasoning.NewOpenAIProvider(cfg.LLMAPIKey, cfg.LLMBaseURL, cfg.LLMModel, cfg.ReasoningTimeout)
+		p := reasoning.NewOpenAIProvider(cfg.LLMAPIKey, cfg.LLMBaseURL, cfg.LLMModel, cfg.ReasoningTimeout)
+		defaultLLM, defaultKeyed = p, append(defaultKeyed, p)
 	case "google":
-		defaultLLM = reasoning.NewGoogleProvider(cfg.LLMAPIKey, cfg.LLMModel, cfg.ReasoningTimeout)
+		p := reasoning.NewGoogleProvider(cfg.LLMAPIKey, cfg.LLMModel, cfg.ReasoningTimeout)
+		defaultLLM, defaultKeyed = p, append(defaultKeyed, p)
 	default:
 		defaultLLM = reasoning.NewMockLLM()
 	}
 
 	router := reasoning.NewRouter(defaultLLM)
 
-	// Register additional OpenAI models
-	if cfg.OpenAIAPIKey != "" && cfg.OpenAIModels != "" {
+	// Register additional OpenAI models, rotating between keys per call when
+	// several are configured
+	if (len(cfg.OpenAIAPIKeys) > 0 || cfg.OpenAIAPIKey != "" || cfg.OpenAIAPIKeyFile != "") && cfg.OpenAIModels != "" {
 		for _, model := range strings.Split(cfg.OpenAIModels, ",") {
 			model = strings.TrimSpace(model)
-			if model != "" {
-				router.Register(model, reasoning.NewOpenAIProvider(cfg.OpenAIAPIKey, cfg.OpenAIBaseURL, model, cfg.ReasoningTimeout))
+			if model == "" {
+				continue
 			}
+			if len(cfg.OpenAIAPIKeys) > 0 {
+				keys := make([]reasoning.LLMProvider, len(cfg.OpenAIAPIKeys))
+				for i, key := range cfg.OpenAIAPIKeys {
+					keys[i] = reasoning.NewOpenAIProvider(key, cfg.OpenAIBaseURL, model, cfg.ReasoningTimeout)
+				}
+				router.Register(model, reasoning.NewKeyRotatingProvider(keys, cfg.APIKeyCooldown))
+				continue
+			}
+			p := reasoning.NewOpenAIProvider(cfg.OpenAIAPIKey, cfg.OpenAIBaseURL, model, cfg.ReasoningTimeout)
+			router.Register(model, p)
+			openAIKeyed = append(openAIKeyed, p)
 		}
 	}
 
 	// Register additional Google models
-	if cfg.GoogleAPIKey != "" && cfg.GoogleModels != "" {
+	if (cfg.GoogleAPIKey != "" || cfg.GoogleAPIKeyFile != "") && cfg.GoogleModels != "" {
 		for _, model := range strings.Split(cfg.GoogleModels, ",") {
 			model = strings.TrimSpace(model)
 			if model != "" {
-				router.Register(model, reasoning.NewGoogleProvider(cfg.GoogleAPIKey, model, cfg.ReasoningTimeout))
+				p := reasoning.NewGoogleProvider(cfg.GoogleAPIKey, model, cfg.ReasoningTimeout)
+				router.Register(model, p)
+				googleKeyed = append(googleKeyed, p)
+			}
+		}
+	}
+
+	// Load keys from files, when configured, and keep them up to date
+	for _, kf := range []struct {
+		path      string
+		providers []keyedProvider
+	}{
+		{cfg.LLMAPIKeyFile, defaultKeyed},
+		{cfg.OpenAIAPIKeyFile, openAIKeyed},
+		{cfg.GoogleAPIKeyFile, googleKeyed},
+	} {
+		if kf.path == "" || len(kf.providers) == 0 {
+			continue
+		}
+		stop, err := reasoning.WatchKeyFile(kf.path, cfg.APIKeyReloadInterval, func(key string) {
+			for _, p := range kf.providers {
+				p.SetAPIKey(key)
 			}
+		})
+		if err != nil {
+			logger.Error("failed to load API key file", "path", kf.path, "error", err)
+			os.Exit(1)
 		}
+		defer stop()
 	}
 
 	// Create server (router implements LLMProvider)
 	frontalServer := server.NewFrontalLobeServer(logger, cfg, router)
 
+	// Serve TLS when a certificate is configured
+	var serverOpts []grpc.ServerOption
+	if cfg.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			logger.Error("failed to load TLS credentials", "error", err)
+			os.Exit(1)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
+
 	// Configure gRPC server
-	grpcServer := grpc.NewServer(
+	latency := metrics.NewLatencyStore()
+	limiter := middleware.NewRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst)
+	grpcServer := grpc.NewServer(append(serverOpts,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle:     15 * time.Minute,
 			MaxConnectionAge:      30 * time.Minute,
@@ -75,7 +148,23 @@ func main() {
 			Time:                  5 * time.Minute,
 			Timeout:               1 * time.Second,
 		}),
-	)
+		grpc.ChainUnaryInterceptor(
+			middleware.UnaryRequestID(),
+			middleware.UnaryRecovery(logger),
+			middleware.UnaryLogging(logger),
+			middleware.UnaryMetrics(latency),
+			middleware.UnaryAuth(cfg.AuthTokens),
+			middleware.UnaryRateLimit(limiter),
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.StreamRequestID(),
+			middleware.StreamRecovery(logger),
+			middleware.StreamLogging(logger),
+			middleware.StreamMetrics(latency),
+			middleware.StreamAuth(cfg.AuthTokens),
+			middleware.StreamRateLimit(limiter),
+		),
+	)...)
 
 	agentv1.RegisterReasoningEngineServer(grpcServer, frontalServer)
 	commonv1.RegisterHealthServiceServer(grpcServer, frontalServer)
@@ -101,8 +190,41 @@ func main() {
 		}
 	}()
 
+	metricsServer := serveMetrics(logger, cfg.MetricsPort, latency)
+
 	<-ctx.Done()
 	logger.Info("shutting down frontal lobe service...")
 	grpcServer.GracefulStop()
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
 	logger.Info("frontal lobe service stopped")
 }
+
+// serveMetrics serves RPC latency on GET /metrics at port in the
+// background. It returns nil, serving nothing, when port is 0.
+func serveMetrics(logger *slog.Logger, port int, latency *metrics.LatencyStore) *http.Server {
+	if port == 0 {
+		return nil
+	}
+	mux := http.NewServeMux()
+	latency.RegisterRoutes(mux)
+	srv := &http.Server{
+		Addr:        fmt.Sprintf(":%d", port),
+		Handler:     mux,
+		ReadTimeout: 10 * time.Second,
+	}
+	go func() {
+		logger.Info("metrics server starting", "address", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("metrics server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+	return srv
+}
+
+// keyedProvider is an LLM provider whose API key can be replaced at runtime.
+type keyedProvider interface {
+	SetAPIKey(key string)
+}
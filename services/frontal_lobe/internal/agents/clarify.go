@@ -12,6 +12,7 @@ import (
 type State string
 
 const (
+	StateDedup     State = "DEDUP"
 	StateClassify  State = "CLASSIFY"
 	StateExtract   State = "EXTRACT"
 	StateSummarize State = "SUMMARIZE"
@@ -19,9 +20,14 @@ const (
 	StateExecute   State = "EXECUTE"
 	StateRepair    State = "REPAIR"
 	StateDelete    State = "DELETE"
+	StateReview    State = "REVIEW"
 	StateEnd       State = "END"
 )
 
+// ClassificationNeedsReview marks an item whose classification confidence
+// was too low to act on without manual confirmation.
+const ClassificationNeedsReview = "NEEDS_REVIEW"
+
 // ClarifyResult holds the output of the Clarify agent.
 type ClarifyResult struct {
 	Classification    string
@@ -30,18 +36,56 @@ type ClarifyResult struct {
 	Priority          string
 	ExtractedMetadata map[string]string
 	Confidence        float64
+	ReviewReason      string // set when Classification is NEEDS_REVIEW
+	ReviewID          string // the item's review queue ID, when queued
 	ThoughtChain      []string
 }
 
+// Taxonomy lists the user's areas and projects that items can be routed to.
+// When a list is empty, routing for it uses keyword heuristics only.
+type Taxonomy struct {
+	Areas    []string
+	Projects []string
+}
+
+// minRouteConfidence is the LLM confidence below which routing falls back to
+// keyword heuristics.
+const minRouteConfidence = 0.6
+
 // ClarifyAgent implements the "Clarify" agent state machine from PRD §6.1.
-// It processes inbox items through: CLASSIFY → EXTRACT/SUMMARIZE/DELETE → ROUTE → EXECUTE.
+// It processes inbox items through: CLASSIFY → EXTRACT/SUMMARIZE/DELETE → ROUTE → EXECUTE,
+// preceded by DEDUP when a recent-items store is set.
 type ClarifyAgent struct {
-	llm reasoning.LLMProvider
+	llm             reasoning.LLMProvider
+	taxonomy        Taxonomy
+	reviewThreshold float64
+	reviews         *ReviewQueue
+	recent          *RecentItems
+}
+
+// NewClarifyAgent creates a new ClarifyAgent that routes items within the
+// given taxonomy.
+func NewClarifyAgent(llm reasoning.LLMProvider, taxonomy Taxonomy) *ClarifyAgent {
+	return &ClarifyAgent{llm: llm, taxonomy: taxonomy}
+}
+
+// SetReviewThreshold sets the classification confidence below which items
+// are queued for manual confirmation instead of being routed. Zero disables
+// review, which is the default.
+func (a *ClarifyAgent) SetReviewThreshold(threshold float64) {
+	a.reviewThreshold = threshold
 }
 
-// NewClarifyAgent creates a new ClarifyAgent.
-func NewClarifyAgent(llm reasoning.LLMProvider) *ClarifyAgent {
-	return &ClarifyAgent{llm: llm}
+// SetReviewQueue sets the queue that items needing review are added to
+// until they are confirmed. Without one, NEEDS_REVIEW is only reported.
+func (a *ClarifyAgent) SetReviewQueue(reviews *ReviewQueue) {
+	a.reviews = reviews
+}
+
+// SetRecentItems enables deduplication: content matching an item in recent
+// reuses that item's result instead of being classified again.
+func (a *ClarifyAgent) SetRecentItems(recent *RecentItems) {
+	a.recent = recent
 }
 
 // Process runs the state machine on the given content.
@@ -52,9 +96,20 @@ func (a *ClarifyAgent) Process(ctx context.Context, content, source string, meta
 	}
 
 	state := StateClassify
+	if a.recent != nil {
+		state = StateDedup
+	}
 
 	for state != StateEnd {
 		switch state {
+		case StateDedup:
+			original, hash, ok := a.recent.Lookup(content)
+			if !ok {
+				state = StateClassify
+				break
+			}
+			return duplicateResult(original, hash), nil
+
 		case StateClassify:
 			result.ThoughtChain = append(result.ThoughtChain, "Analyzing content for classification...")
 
@@ -68,6 +123,13 @@ func (a *ClarifyAgent) Process(ctx context.Context, content, source string, meta
 			result.ThoughtChain = append(result.ThoughtChain,
 				fmt.Sprintf("Classified as %s with confidence %.2f", classification, confidence))
 
+			if confidence < a.reviewThreshold {
+				result.ReviewReason = fmt.Sprintf("classified as %s with confidence %.2f, below the review threshold %.2f",
+					classification, confidence, a.reviewThreshold)
+				state = StateReview
+				break
+			}
+
 			switch classification {
 			case "ACTIONABLE":
 				state = StateExtract
@@ -108,8 +170,12 @@ func (a *ClarifyAgent) Process(ctx context.Context, content, source string, meta
 		case StateRoute:
 			result.ThoughtChain = append(result.ThoughtChain, "Determining destination area...")
 
-			result.SuggestedArea = determineArea(content, source)
-			result.SuggestedProject = determineProject(content)
+			result.SuggestedArea = a.routeTo(ctx, content, a.taxonomy.Areas, func() string {
+				return determineArea(content, source)
+			})
+			result.SuggestedProject = a.routeTo(ctx, content, a.taxonomy.Projects, func() string {
+				return determineProject(content)
+			})
 			result.ThoughtChain = append(result.ThoughtChain,
 				fmt.Sprintf("Routing to area: %s, project: %s", result.SuggestedArea, result.SuggestedProject))
 			state = StateExecute
@@ -123,6 +189,21 @@ func (a *ClarifyAgent) Process(ctx context.Context, content, source string, meta
 			result.Priority = "LOW"
 			state = StateEnd
 
+		case StateReview:
+			result.ThoughtChain = append(result.ThoughtChain, "Queueing item for manual confirmation...")
+			if a.reviews != nil {
+				result.ReviewID = a.reviews.Add(PendingReview{
+					Content:                 content,
+					Source:                  source,
+					Metadata:                metadata,
+					SuggestedClassification: result.Classification,
+					Confidence:              result.Confidence,
+					Reason:                  result.ReviewReason,
+				})
+			}
+			result.Classification = ClassificationNeedsReview
+			state = StateEnd
+
 		case StateRepair:
 			result.ThoughtChain = append(result.ThoughtChain, "Attempting repair after error...")
 			state = StateEnd
@@ -132,9 +213,50 @@ func (a *ClarifyAgent) Process(ctx context.Context, content, source string, meta
 		}
 	}
 
+	// Items awaiting review are not remembered, so a duplicate is queued in
+	// its own right rather than pointing at a review that may be resolved.
+	if a.recent != nil && result.Classification != ClassificationNeedsReview {
+		a.recent.Add(content, result)
+	}
 	return result, nil
 }
 
+// duplicateResult copies the result of the item that content duplicates,
+// tagging it with the original's content hash.
+func duplicateResult(original *ClarifyResult, hash string) *ClarifyResult {
+	result := *original
+	result.ExtractedMetadata = make(map[string]string, len(original.ExtractedMetadata)+2)
+	for k, v := range original.ExtractedMetadata {
+		result.ExtractedMetadata[k] = v
+	}
+	result.ExtractedMetadata["duplicate"] = "true"
+	result.ExtractedMetadata["duplicate_of"] = hash
+	result.ThoughtChain = []string{
+		"Checking for recently processed duplicates...",
+		fmt.Sprintf("Matches recent item %s; reusing its classification", hash),
+	}
+	return &result
+}
+
+// routeTo asks the LLM to pick one of choices for the content, falling back
+// to the heuristic when there are no choices, the LLM fails, or it is not
+// confident in an answer from the list.
+func (a *ClarifyAgent) routeTo(ctx context.Context, content string, choices []string, heuristic func() string) string {
+	if len(choices) == 0 {
+		return heuristic()
+	}
+	choice, confidence, err := a.llm.Classify(ctx, content, choices)
+	if err != nil || confidence < minRouteConfidence {
+		return heuristic()
+	}
+	for _, c := range choices {
+		if strings.EqualFold(strings.TrimSpace(choice), c) {
+			return c
+		}
+	}
+	return heuristic()
+}
+
 func determinePriority(content string) string {
 	lower := strings.ToLower(content)
 	if strings.Contains(lower, "urgent") || strings.Contains(lower, "asap") {
@@ -2,6 +2,7 @@ package agents
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/reasoning"
@@ -9,7 +10,7 @@ import (
 
 func TestClarifyAgentActionable(t *testing.T) {
 	llm := reasoning.NewMockLLM()
-	agent := NewClarifyAgent(llm)
+	agent := NewClarifyAgent(llm, Taxonomy{})
 
 	result, err := agent.Process(context.Background(), "This is an urgent task with a deadline", "email", nil)
 	if err != nil {
@@ -31,7 +32,7 @@ func TestClarifyAgentActionable(t *testing.T) {
 
 func TestClarifyAgentReference(t *testing.T) {
 	llm := reasoning.NewMockLLM()
-	agent := NewClarifyAgent(llm)
+	agent := NewClarifyAgent(llm, Taxonomy{})
 
 	result, err := agent.Process(context.Background(), "Here is a research paper about machine learning", "browser", nil)
 	if err != nil {
@@ -49,7 +50,7 @@ func TestClarifyAgentReference(t *testing.T) {
 
 func TestClarifyAgentTrash(t *testing.T) {
 	llm := reasoning.NewMockLLM()
-	agent := NewClarifyAgent(llm)
+	agent := NewClarifyAgent(llm, Taxonomy{})
 
 	result, err := agent.Process(context.Background(), "Unsubscribe from promotional emails", "email", nil)
 	if err != nil {
@@ -78,7 +79,7 @@ func TestClarifyAgentAreaRouting(t *testing.T) {
 	}
 
 	llm := reasoning.NewMockLLM()
-	agent := NewClarifyAgent(llm)
+	agent := NewClarifyAgent(llm, Taxonomy{})
 
 	for _, tc := range tests {
 		t.Run(tc.expected, func(t *testing.T) {
@@ -95,7 +96,7 @@ func TestClarifyAgentAreaRouting(t *testing.T) {
 
 func TestClarifyAgentProjectDetection(t *testing.T) {
 	llm := reasoning.NewMockLLM()
-	agent := NewClarifyAgent(llm)
+	agent := NewClarifyAgent(llm, Taxonomy{})
 
 	result, err := agent.Process(context.Background(), "Update on PhaseNet seismic model training", "email", nil)
 	if err != nil {
@@ -106,3 +107,71 @@ func TestClarifyAgentProjectDetection(t *testing.T) {
 		t.Error("expected project to be detected")
 	}
 }
+
+// routingLLM answers routing classifications with fixed choices and defers
+// everything else to the mock LLM.
+type routingLLM struct {
+	*reasoning.MockLLM
+	area, project string
+	confidence    float64
+	err           error
+}
+
+func (r *routingLLM) Classify(ctx context.Context, content string, categories []string) (string, float64, error) {
+	if len(categories) > 0 && categories[0] == "ACTIONABLE" {
+		return r.MockLLM.Classify(ctx, content, categories)
+	}
+	if r.err != nil {
+		return "", 0, r.err
+	}
+	for _, c := range categories {
+		if c == r.area || c == r.project {
+			return c, r.confidence, nil
+		}
+	}
+	return categories[0], 0.5, nil
+}
+
+func TestClarifyAgentLLMRouting(t *testing.T) {
+	taxonomy := Taxonomy{
+		Areas:    []string{"Financial Health", "Health & Fitness", "Career"},
+		Projects: []string{"Marathon Training", "Home Renovation"},
+	}
+	llm := &routingLLM{MockLLM: reasoning.NewMockLLM(), area: "Health & Fitness", project: "Marathon Training", confidence: 0.9}
+	agent := NewClarifyAgent(llm, taxonomy)
+
+	result, err := agent.Process(context.Background(), "Notes on my long run pacing and recovery", "notes", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.SuggestedArea != "Health & Fitness" {
+		t.Errorf("expected LLM-chosen area, got %q", result.SuggestedArea)
+	}
+	if result.SuggestedProject != "Marathon Training" {
+		t.Errorf("expected LLM-chosen project, got %q", result.SuggestedProject)
+	}
+}
+
+func TestClarifyAgentRoutingFallsBackToKeywords(t *testing.T) {
+	taxonomy := Taxonomy{Areas: []string{"Career", "Health & Fitness"}}
+	content := "Your bank payment is scheduled"
+
+	tests := []struct {
+		name string
+		llm  *routingLLM
+	}{
+		{"low confidence", &routingLLM{MockLLM: reasoning.NewMockLLM(), area: "Career", confidence: 0.3}},
+		{"LLM unavailable", &routingLLM{MockLLM: reasoning.NewMockLLM(), err: errors.New("connection refused")}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := NewClarifyAgent(tc.llm, taxonomy).Process(context.Background(), content, "email", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.SuggestedArea != "Financial Health" {
+				t.Errorf("expected keyword fallback area, got %q", result.SuggestedArea)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package agents
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// RecentItems remembers the Clarify results for recently processed content
+// so that near-duplicate items can reuse them instead of being classified
+// again. It holds at most capacity items, evicting the oldest.
+type RecentItems struct {
+	mu        sync.Mutex
+	capacity  int
+	threshold float64
+	items     []recentItem // oldest first
+}
+
+type recentItem struct {
+	hash   string
+	words  map[string]struct{}
+	result *ClarifyResult
+}
+
+// NewRecentItems creates a store of up to capacity items. Content matches a
+// stored item when their normalized text is identical or, for a positive
+// threshold, when the Jaccard similarity of their word sets is at least
+// threshold.
+func NewRecentItems(capacity int, threshold float64) *RecentItems {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RecentItems{capacity: capacity, threshold: threshold}
+}
+
+// Lookup returns the result stored for content matching the given content,
+// along with the matching item's content hash.
+func (r *RecentItems) Lookup(content string) (*ClarifyResult, string, bool) {
+	hash, words := fingerprint(content)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Prefer the most recent match.
+	for i := len(r.items) - 1; i >= 0; i-- {
+		item := r.items[i]
+		if item.hash == hash || (r.threshold > 0 && jaccard(item.words, words) >= r.threshold) {
+			return item.result, item.hash, true
+		}
+	}
+	return nil, "", false
+}
+
+// Add records the result for content, evicting the oldest item when full.
+func (r *RecentItems) Add(content string, result *ClarifyResult) {
+	hash, words := fingerprint(content)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.items) >= r.capacity {
+		r.items = append(r.items[:0], r.items[len(r.items)-r.capacity+1:]...)
+	}
+	r.items = append(r.items, recentItem{hash: hash, words: words, result: result})
+}
+
+// Len returns the number of stored items.
+func (r *RecentItems) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.items)
+}
+
+// fingerprint normalizes content to lowercase words without punctuation and
+// returns a hash of the normalized text and its word set.
+func fingerprint(content string) (string, map[string]struct{}) {
+	fields := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	words := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		words[f] = struct{}{}
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, " ")))
+	return hex.EncodeToString(sum[:8]), words
+}
+
+// jaccard returns the size of the intersection of a and b over the size of
+// their union.
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for w := range a {
+		if _, ok := b[w]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
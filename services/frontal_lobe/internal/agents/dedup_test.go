@@ -0,0 +1,75 @@
+package agents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/reasoning"
+)
+
+// countingLLM counts classification calls made to the mock LLM.
+type countingLLM struct {
+	*reasoning.MockLLM
+	classifications int
+}
+
+func (c *countingLLM) Classify(ctx context.Context, content string, categories []string) (string, float64, error) {
+	c.classifications++
+	return c.MockLLM.Classify(ctx, content, categories)
+}
+
+func TestClarifyAgentSkipsNearDuplicates(t *testing.T) {
+	llm := &countingLLM{MockLLM: reasoning.NewMockLLM()}
+	agent := NewClarifyAgent(llm, Taxonomy{})
+	agent.SetRecentItems(NewRecentItems(100, 0.8))
+
+	first, err := agent.Process(context.Background(),
+		"Urgent: submit the quarterly bank payment report before the Friday deadline", "email", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := agent.Process(context.Background(),
+		"URGENT - submit the quarterly bank payment report before the Friday deadline!!", "email", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if llm.classifications != 1 {
+		t.Errorf("expected one classification call, got %d", llm.classifications)
+	}
+	if second.ExtractedMetadata["duplicate"] != "true" || second.ExtractedMetadata["duplicate_of"] == "" {
+		t.Errorf("expected duplicate tags, got %v", second.ExtractedMetadata)
+	}
+	if second.Classification != first.Classification || second.SuggestedArea != first.SuggestedArea {
+		t.Errorf("expected duplicate to reuse %s/%s, got %s/%s",
+			first.Classification, first.SuggestedArea, second.Classification, second.SuggestedArea)
+	}
+	if _, tagged := first.ExtractedMetadata["duplicate"]; tagged {
+		t.Error("expected the original result to stay untagged")
+	}
+
+	// Unrelated content is still classified.
+	if _, err := agent.Process(context.Background(), "Lease renewal for the apartment", "email", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if llm.classifications != 2 {
+		t.Errorf("expected distinct content to be classified, got %d calls", llm.classifications)
+	}
+}
+
+func TestRecentItemsEvictsOldest(t *testing.T) {
+	recent := NewRecentItems(2, 0.9)
+	recent.Add("first item", &ClarifyResult{Classification: "REFERENCE"})
+	recent.Add("second item", &ClarifyResult{Classification: "ACTIONABLE"})
+	recent.Add("third item", &ClarifyResult{Classification: "TRASH"})
+
+	if recent.Len() != 2 {
+		t.Fatalf("expected 2 items, got %d", recent.Len())
+	}
+	if _, _, ok := recent.Lookup("first item"); ok {
+		t.Error("expected the oldest item to be evicted")
+	}
+	if got, _, ok := recent.Lookup("Third  item."); !ok || got.Classification != "TRASH" {
+		t.Errorf("expected normalized content to match the third item, got %v (ok=%v)", got, ok)
+	}
+}
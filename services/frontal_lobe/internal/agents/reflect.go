@@ -28,14 +28,74 @@ func NewReflectAgent(llm reasoning.LLMProvider) *ReflectAgent {
 	return &ReflectAgent{llm: llm}
 }
 
+// Weekly review sections, in the order StreamWeeklyReview emits them.
+const (
+	SectionReport               = "report"
+	SectionStalledProjects      = "stalled_projects"
+	SectionSuggestedNextActions = "suggested_next_actions"
+	SectionDormantIdeas         = "dormant_ideas"
+)
+
 // GenerateWeeklyReview creates a weekly review report.
 func (a *ReflectAgent) GenerateWeeklyReview(
 	ctx context.Context,
 	startDate, endDate time.Time,
 	completedTasks, activeTasks, blockedTasks []string,
 ) (*WeeklyReviewResult, error) {
+	prompt := buildReviewPrompt(startDate, endDate, completedTasks, activeTasks, blockedTasks)
+	report, err := a.llm.Generate(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("generating review: %w", err)
+	}
 
-	// Build review prompt
+	result := reviewLists(activeTasks, blockedTasks)
+	result.ReportMarkdown = report
+	return result, nil
+}
+
+// StreamWeeklyReview creates a weekly review report and emits it section by
+// section: the LLM-written report as the provider streams it, followed by
+// the stalled projects, suggested next actions and dormant ideas. The
+// markdown of all emitted chunks concatenates to the complete report.
+func (a *ReflectAgent) StreamWeeklyReview(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	completedTasks, activeTasks, blockedTasks []string,
+	emit func(section, markdown string) error,
+) error {
+	prompt := buildReviewPrompt(startDate, endDate, completedTasks, activeTasks, blockedTasks)
+	err := reasoning.GenerateStream(ctx, a.llm, prompt, func(chunk string) error {
+		return emit(SectionReport, chunk)
+	})
+	if err != nil {
+		return fmt.Errorf("generating review: %w", err)
+	}
+
+	lists := reviewLists(activeTasks, blockedTasks)
+	sections := []struct {
+		name, title string
+		items       []string
+	}{
+		{SectionStalledProjects, "Stalled Projects", lists.StalledProjects},
+		{SectionSuggestedNextActions, "Suggested Next Actions", lists.SuggestedNextActions},
+		{SectionDormantIdeas, "Dormant Ideas", lists.DormantIdeas},
+	}
+	for _, sec := range sections {
+		if len(sec.items) == 0 {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := emit(sec.name, markdownSection(sec.title, sec.items)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildReviewPrompt builds the LLM prompt for a weekly review.
+func buildReviewPrompt(startDate, endDate time.Time, completedTasks, activeTasks, blockedTasks []string) string {
 	var sb strings.Builder
 	sb.WriteString("Generate a weekly review report.\n\n")
 	sb.WriteString(fmt.Sprintf("Period: %s to %s\n\n", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")))
@@ -54,12 +114,12 @@ func (a *ReflectAgent) GenerateWeeklyReview(
 	for _, t := range blockedTasks {
 		sb.WriteString(fmt.Sprintf("- %s\n", t))
 	}
+	return sb.String()
+}
 
-	report, err := a.llm.Generate(ctx, sb.String())
-	if err != nil {
-		return nil, fmt.Errorf("generating review: %w", err)
-	}
-
+// reviewLists derives the stalled projects, next actions and dormant ideas
+// that accompany the report.
+func reviewLists(activeTasks, blockedTasks []string) *WeeklyReviewResult {
 	// Identify stalled projects (blocked tasks indicate stalling)
 	var stalled []string
 	for _, task := range blockedTasks {
@@ -83,9 +143,164 @@ func (a *ReflectAgent) GenerateWeeklyReview(
 	}
 
 	return &WeeklyReviewResult{
-		ReportMarkdown:       report,
 		StalledProjects:      stalled,
 		SuggestedNextActions: nextActions,
 		DormantIdeas:         dormant,
+	}
+}
+
+// markdownSection renders a titled bullet list appended to the report.
+func markdownSection(title string, items []string) string {
+	var sb strings.Builder
+	sb.WriteString("\n\n## " + title + "\n")
+	for _, item := range items {
+		sb.WriteString("- " + item + "\n")
+	}
+	return sb.String()
+}
+
+// WeekActivity holds the tasks for one week of a monthly review.
+type WeekActivity struct {
+	CompletedTasks []string
+	ActiveTasks    []string
+	BlockedTasks   []string
+}
+
+// MonthlyReviewResult holds the output of a monthly review.
+type MonthlyReviewResult struct {
+	ReportMarkdown       string
+	Momentum             string
+	RecurringBlockers    []string
+	SuggestedNextActions []string
+}
+
+// Momentum values derived from weekly completion counts.
+const (
+	MomentumAccelerating = "accelerating"
+	MomentumSteady       = "steady"
+	MomentumSlowing      = "slowing"
+)
+
+// GenerateMonthlyReview aggregates several weeks of task activity, oldest
+// first, into a monthly report with trend commentary.
+func (a *ReflectAgent) GenerateMonthlyReview(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	weeks []WeekActivity,
+) (*MonthlyReviewResult, error) {
+	momentum := monthlyMomentum(weeks)
+	blockers := recurringBlockers(weeks)
+
+	// Build review prompt
+	var sb strings.Builder
+	sb.WriteString("Generate a monthly review report with trend commentary on momentum and recurring blockers.\n\n")
+	sb.WriteString(fmt.Sprintf("Period: %s to %s\n\n", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")))
+
+	for i, w := range weeks {
+		sb.WriteString(fmt.Sprintf("Week %d: %d completed, %d active, %d blocked\n",
+			i+1, len(w.CompletedTasks), len(w.ActiveTasks), len(w.BlockedTasks)))
+		for _, t := range w.CompletedTasks {
+			sb.WriteString(fmt.Sprintf("- done: %s\n", t))
+		}
+		for _, t := range w.BlockedTasks {
+			sb.WriteString(fmt.Sprintf("- blocked: %s\n", t))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("Momentum: %s\n", momentum))
+	sb.WriteString("\nRecurring Blockers:\n")
+	for _, t := range blockers {
+		sb.WriteString(fmt.Sprintf("- %s\n", t))
+	}
+
+	report, err := a.llm.Generate(ctx, sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("generating monthly review: %w", err)
+	}
+
+	// Suggest next actions
+	var nextActions []string
+	if len(blockers) > 0 {
+		nextActions = append(nextActions, "Resolve recurring blockers before starting new work")
+	}
+	if momentum == MomentumSlowing {
+		nextActions = append(nextActions, "Reduce work in progress to regain momentum")
+	}
+	nextActions = append(nextActions, "Set priorities for next month")
+
+	return &MonthlyReviewResult{
+		ReportMarkdown:       report + monthlyActivityTable(weeks),
+		Momentum:             momentum,
+		RecurringBlockers:    blockers,
+		SuggestedNextActions: nextActions,
 	}, nil
 }
+
+// monthlyMomentum compares completions in the second half of the period with
+// the first half.
+func monthlyMomentum(weeks []WeekActivity) string {
+	if len(weeks) < 2 {
+		return MomentumSteady
+	}
+	half := len(weeks) / 2
+	var early, late int
+	for i, w := range weeks {
+		if i < half {
+			early += len(w.CompletedTasks)
+		} else if i >= len(weeks)-half {
+			late += len(w.CompletedTasks)
+		}
+	}
+	switch {
+	case late > early:
+		return MomentumAccelerating
+	case late < early:
+		return MomentumSlowing
+	default:
+		return MomentumSteady
+	}
+}
+
+// recurringBlockers returns tasks blocked in more than one week, in the order
+// they were first blocked.
+func recurringBlockers(weeks []WeekActivity) []string {
+	counts := make(map[string]int)
+	var order []string
+	for _, w := range weeks {
+		seen := make(map[string]bool)
+		for _, t := range w.BlockedTasks {
+			if seen[t] {
+				continue
+			}
+			seen[t] = true
+			if counts[t] == 0 {
+				order = append(order, t)
+			}
+			counts[t]++
+		}
+	}
+	var out []string
+	for _, t := range order {
+		if counts[t] > 1 {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// monthlyActivityTable renders the weekly task counts and their totals.
+func monthlyActivityTable(weeks []WeekActivity) string {
+	var sb strings.Builder
+	sb.WriteString("\n\n## Activity\n\n| Week | Completed | Active | Blocked |\n|------|-----------|--------|---------|\n")
+	var completed, active, blocked int
+	for i, w := range weeks {
+		sb.WriteString(fmt.Sprintf("| %d | %d | %d | %d |\n",
+			i+1, len(w.CompletedTasks), len(w.ActiveTasks), len(w.BlockedTasks)))
+		completed += len(w.CompletedTasks)
+		active += len(w.ActiveTasks)
+		blocked += len(w.BlockedTasks)
+	}
+	sb.WriteString(fmt.Sprintf("| Total | %d | %d | %d |\n", completed, active, blocked))
+	return sb.String()
+}
@@ -2,6 +2,7 @@ package agents
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,3 +89,77 @@ func TestReflectAgentManyActiveTasks(t *testing.T) {
 		t.Error("expected suggestion to prioritize with many active tasks")
 	}
 }
+
+func TestReflectAgentStreamWeeklyReview(t *testing.T) {
+	agent := NewReflectAgent(reasoning.NewMockLLM())
+
+	var chunks []string
+	sections := make(map[string]int)
+	err := agent.StreamWeeklyReview(
+		context.Background(),
+		time.Now().AddDate(0, 0, -7),
+		time.Now(),
+		[]string{"Task A"},
+		[]string{"Task C"},
+		[]string{"Task D"},
+		func(section, markdown string) error {
+			chunks = append(chunks, markdown)
+			sections[section]++
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sections[SectionReport] < 2 {
+		t.Errorf("expected the report to arrive in several chunks, got %d", sections[SectionReport])
+	}
+	for _, name := range []string{SectionStalledProjects, SectionSuggestedNextActions, SectionDormantIdeas} {
+		if sections[name] != 1 {
+			t.Errorf("expected one %s chunk, got %d", name, sections[name])
+		}
+	}
+
+	report := strings.Join(chunks, "")
+	for _, want := range []string{"# Weekly Review", "## Recommendations", "## Stalled Projects\n- Task D", "## Suggested Next Actions", "## Dormant Ideas"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected concatenated report to contain %q, got:\n%s", want, report)
+		}
+	}
+}
+
+func TestReflectAgentGenerateMonthlyReview(t *testing.T) {
+	agent := NewReflectAgent(reasoning.NewMockLLM())
+
+	weeks := []WeekActivity{
+		{CompletedTasks: []string{"A"}, ActiveTasks: []string{"B", "C"}, BlockedTasks: []string{"Visa paperwork"}},
+		{CompletedTasks: []string{"B"}, ActiveTasks: []string{"C"}, BlockedTasks: []string{"Visa paperwork"}},
+		{CompletedTasks: []string{"C", "D"}, ActiveTasks: []string{"E"}},
+		{CompletedTasks: []string{"E", "F", "G"}, BlockedTasks: []string{"Grant report"}},
+	}
+	result, err := agent.GenerateMonthlyReview(context.Background(),
+		time.Now().AddDate(0, 0, -28), time.Now(), weeks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result.ReportMarkdown, "# Monthly Review") {
+		t.Errorf("expected the LLM's monthly report, got:\n%s", result.ReportMarkdown)
+	}
+	for _, row := range []string{"| 1 | 1 | 2 | 1 |", "| 4 | 3 | 0 | 1 |", "| Total | 7 | 4 | 3 |"} {
+		if !strings.Contains(result.ReportMarkdown, row) {
+			t.Errorf("expected report to contain task counts %q, got:\n%s", row, result.ReportMarkdown)
+		}
+	}
+
+	if result.Momentum != MomentumAccelerating {
+		t.Errorf("expected accelerating momentum, got %q", result.Momentum)
+	}
+	if len(result.RecurringBlockers) != 1 || result.RecurringBlockers[0] != "Visa paperwork" {
+		t.Errorf("expected Visa paperwork as the only recurring blocker, got %v", result.RecurringBlockers)
+	}
+	if len(result.SuggestedNextActions) == 0 {
+		t.Error("expected suggested next actions")
+	}
+}
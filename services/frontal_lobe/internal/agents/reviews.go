@@ -0,0 +1,83 @@
+package agents
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PendingReview is an item whose classification awaits manual confirmation.
+type PendingReview struct {
+	ID                      string
+	Content                 string
+	Source                  string
+	Metadata                map[string]string
+	SuggestedClassification string // what the Clarify agent would have routed it as
+	Confidence              float64
+	Reason                  string
+	QueuedAt                time.Time
+}
+
+// ReviewQueue holds items classified with too little confidence to route
+// until they are confirmed. It holds at most capacity items, evicting the
+// oldest.
+type ReviewQueue struct {
+	mu       sync.Mutex
+	capacity int
+	nextID   uint64
+	items    []PendingReview // oldest first
+	now      func() time.Time
+}
+
+// NewReviewQueue creates a queue of up to capacity items.
+func NewReviewQueue(capacity int) *ReviewQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ReviewQueue{capacity: capacity, now: time.Now}
+}
+
+// Add queues item, evicting the oldest item when full, and returns the ID
+// assigned to it.
+func (q *ReviewQueue) Add(item PendingReview) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.nextID++
+	item.ID = fmt.Sprintf("review-%d", q.nextID)
+	item.QueuedAt = q.now()
+	if len(q.items) >= q.capacity {
+		q.items = append(q.items[:0], q.items[len(q.items)-q.capacity+1:]...)
+	}
+	q.items = append(q.items, item)
+	return item.ID
+}
+
+// List returns the queued items, oldest first.
+func (q *ReviewQueue) List() []PendingReview {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]PendingReview(nil), q.items...)
+}
+
+// Resolve removes the item with the given ID from the queue and returns it.
+// It reports false when no such item is queued.
+func (q *ReviewQueue) Resolve(id string) (PendingReview, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, item := range q.items {
+		if item.ID == id {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return item, true
+		}
+	}
+	return PendingReview{}, false
+}
+
+// Len returns the number of queued items.
+func (q *ReviewQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
@@ -0,0 +1,65 @@
+package agents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/reasoning"
+)
+
+func TestReviewQueueEvictsOldest(t *testing.T) {
+	q := NewReviewQueue(2)
+	first := q.Add(PendingReview{Content: "a"})
+	q.Add(PendingReview{Content: "b"})
+	q.Add(PendingReview{Content: "c"})
+
+	items := q.List()
+	if len(items) != 2 || items[0].Content != "b" || items[1].Content != "c" {
+		t.Fatalf("expected the two newest items, got %+v", items)
+	}
+	if _, ok := q.Resolve(first); ok {
+		t.Error("expected the evicted item to be gone")
+	}
+	if item, ok := q.Resolve(items[0].ID); !ok || item.Content != "b" {
+		t.Errorf("expected to resolve b, got %+v (%v)", item, ok)
+	}
+	if q.Len() != 1 {
+		t.Errorf("expected 1 item left, got %d", q.Len())
+	}
+}
+
+func TestClarifyAgentQueuesLowConfidenceItems(t *testing.T) {
+	llm := &countingLLM{MockLLM: reasoning.NewMockLLM()}
+	agent := NewClarifyAgent(llm, Taxonomy{})
+	agent.SetReviewThreshold(1.1) // every classification needs review
+	agent.SetRecentItems(NewRecentItems(100, 0.8))
+	reviews := NewReviewQueue(10)
+	agent.SetReviewQueue(reviews)
+
+	content := "Maybe look into the thing from the meeting"
+	for i := 0; i < 2; i++ {
+		result, err := agent.Process(context.Background(), content, "slack", map[string]string{"channel": "general"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Classification != ClassificationNeedsReview || result.ReviewID == "" {
+			t.Fatalf("expected a queued NEEDS_REVIEW result, got %+v", result)
+		}
+	}
+
+	// Items awaiting review bypass deduplication, so each is queued.
+	items := reviews.List()
+	if len(items) != 2 || llm.classifications != 2 {
+		t.Fatalf("expected 2 queued items from 2 classifications, got %d from %d", len(items), llm.classifications)
+	}
+	item := items[0]
+	if item.Content != content || item.Source != "slack" || item.Metadata["channel"] != "general" {
+		t.Errorf("unexpected queued item %+v", item)
+	}
+	if item.SuggestedClassification == ClassificationNeedsReview || item.SuggestedClassification == "" {
+		t.Errorf("expected the low-confidence classification to be kept, got %q", item.SuggestedClassification)
+	}
+	if item.Reason == "" || item.QueuedAt.IsZero() {
+		t.Errorf("expected a reason and queue time, got %+v", item)
+	}
+}
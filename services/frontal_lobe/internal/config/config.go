@@ -1,52 +1,157 @@
 package config
 
 import (
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds configuration for the Frontal Lobe service.
 type Config struct {
-	GRPCPort    int
-	ServiceName string
+	GRPCPort    int    `yaml:"grpc_port"`
+	MetricsPort int    `yaml:"metrics_port"` // serves RPC latency on GET /metrics; 0 disables it
+	ServiceName string `yaml:"service_name"`
 
 	// LLM settings
-	LLMProvider string // "mock", "openai", "google"
-	LLMModel    string
-	LLMAPIKey   string
-	LLMBaseURL  string // Custom base URL for OpenAI-compatible endpoints
+	LLMProvider string `yaml:"llm_provider"` // "mock", "openai", "google"
+	LLMModel    string `yaml:"llm_model"`
+	LLMAPIKey   string `yaml:"llm_api_key"`
+	LLMBaseURL  string `yaml:"llm_base_url"` // Custom base URL for OpenAI-compatible endpoints
 
 	// Additional providers for routing
-	OpenAIAPIKey   string
-	OpenAIBaseURL  string
-	OpenAIModels   string // Comma-separated list of models, e.g. "gpt-4,gpt-4o"
-	GoogleAPIKey   string
-	GoogleModels   string // Comma-separated list of models, e.g. "gemini-pro,gemini-1.5-pro"
+	OpenAIAPIKey  string   `yaml:"openai_api_key"`
+	OpenAIAPIKeys []string `yaml:"openai_api_keys"` // Several keys to rotate between per call; takes precedence over OpenAIAPIKey
+	OpenAIBaseURL string   `yaml:"openai_base_url"`
+	OpenAIModels  string   `yaml:"openai_models"` // Comma-separated list of models, e.g. "gpt-4,gpt-4o"
+	GoogleAPIKey  string   `yaml:"google_api_key"`
+	GoogleModels  string   `yaml:"google_models"` // Comma-separated list of models, e.g. "gemini-pro,gemini-1.5-pro"
+
+	// API key files, re-read periodically so keys can be rotated without a
+	// restart; each takes precedence over the matching env-var key when set
+	LLMAPIKeyFile        string        `yaml:"llm_api_key_file"`
+	OpenAIAPIKeyFile     string        `yaml:"openai_api_key_file"`
+	GoogleAPIKeyFile     string        `yaml:"google_api_key_file"`
+	APIKeyReloadInterval time.Duration `yaml:"api_key_reload_interval"`
+	APIKeyCooldown       time.Duration `yaml:"api_key_cooldown"` // how long one of OpenAIAPIKeys is skipped after a 429 without Retry-After
+
+	// Routing taxonomy for the Clarify agent; empty lists use keyword heuristics
+	RoutingAreas    []string `yaml:"routing_areas"`
+	RoutingProjects []string `yaml:"routing_projects"`
+
+	// Classifications below this confidence are held for manual review; 0 disables review
+	ReviewThreshold float64 `yaml:"review_threshold"`
+	ReviewQueueSize int     `yaml:"review_queue_size"` // held items kept for ListPendingReviews, oldest evicted; 0 keeps none
+
+	// Deduplication of recently classified items; a capacity of 0 disables it
+	DedupCapacity   int     `yaml:"dedup_capacity"`
+	DedupSimilarity float64 `yaml:"dedup_similarity"` // word-set Jaccard similarity treated as a duplicate
 
 	// Timeouts
-	ReasoningTimeout time.Duration
+	ReasoningTimeout time.Duration `yaml:"reasoning_timeout"`
+
+	// Readiness; when LLMProbe is set, health checks ping the LLM provider
+	// and report NOT_SERVING while it fails
+	LLMProbe         bool          `yaml:"llm_probe"`
+	LLMProbeInterval time.Duration `yaml:"llm_probe_interval"` // how long a probe result is reused
+	LLMProbeTimeout  time.Duration `yaml:"llm_probe_timeout"`
+
+	// Approximate token budget for an assembled prompt; retrieved context
+	// beyond it is trimmed. 0 means no budget
+	PromptMaxTokens int `yaml:"prompt_max_tokens"`
+
+	// Debugging; when set, every reasoning request emits its assembled LLM prompt
+	DebugPrompts bool `yaml:"debug_prompts"`
+
+	// TLS
+	TLSCertFile string `yaml:"tls_cert_file"` // server certificate; empty serves plaintext
+	TLSKeyFile  string `yaml:"tls_key_file"`  // server private key
+
+	// Auth
+	AuthTokens []string `yaml:"auth_tokens"` // bearer tokens accepted on gRPC calls; empty disables auth
+
+	// Rate limiting (token bucket per client)
+	RateLimitRPS   float64 `yaml:"rate_limit_rps"`   // sustained calls per second per client; 0 disables limiting
+	RateLimitBurst int     `yaml:"rate_limit_burst"` // calls a client may make at once
 
 	// Observability
-	OTelEndpoint string
+	OTelEndpoint string `yaml:"otel_endpoint"`
+}
+
+// Load reads configuration from a YAML file, then environment variables,
+// which take precedence over file values. A file named by CONFIG_FILE must
+// load cleanly; an unreadable default config.yaml is logged and ignored.
+func Load() (*Config, error) {
+	base := defaults()
+	path, explicit := configFilePath()
+	if err := loadFile(base, path, explicit); err != nil {
+		if explicit {
+			return nil, err
+		}
+		slog.Warn("ignoring config file", "path", path, "error", err)
+		base = defaults()
+	}
+
+	return &Config{
+		GRPCPort:             getEnvInt("FRONTAL_LOBE_GRPC_PORT", base.GRPCPort),
+		MetricsPort:          getEnvInt("FRONTAL_LOBE_METRICS_PORT", base.MetricsPort),
+		ServiceName:          getEnv("FRONTAL_LOBE_SERVICE_NAME", base.ServiceName),
+		LLMProvider:          getEnv("LLM_PROVIDER", base.LLMProvider),
+		LLMModel:             getEnv("LLM_MODEL", base.LLMModel),
+		LLMAPIKey:            getEnv("LLM_API_KEY", base.LLMAPIKey),
+		LLMBaseURL:           getEnv("LLM_BASE_URL", base.LLMBaseURL),
+		OpenAIAPIKey:         getEnv("OPENAI_API_KEY", base.OpenAIAPIKey),
+		OpenAIAPIKeys:        getEnvList("OPENAI_API_KEYS", base.OpenAIAPIKeys),
+		OpenAIBaseURL:        getEnv("OPENAI_BASE_URL", base.OpenAIBaseURL),
+		OpenAIModels:         getEnv("OPENAI_MODELS", base.OpenAIModels),
+		GoogleAPIKey:         getEnv("GOOGLE_API_KEY", base.GoogleAPIKey),
+		GoogleModels:         getEnv("GOOGLE_MODELS", base.GoogleModels),
+		LLMAPIKeyFile:        getEnv("LLM_API_KEY_FILE", base.LLMAPIKeyFile),
+		OpenAIAPIKeyFile:     getEnv("OPENAI_API_KEY_FILE", base.OpenAIAPIKeyFile),
+		GoogleAPIKeyFile:     getEnv("GOOGLE_API_KEY_FILE", base.GoogleAPIKeyFile),
+		APIKeyReloadInterval: getDurationEnv("API_KEY_RELOAD_INTERVAL", base.APIKeyReloadInterval),
+		APIKeyCooldown:       getDurationEnv("API_KEY_COOLDOWN", base.APIKeyCooldown),
+		RoutingAreas:         getEnvList("ROUTING_AREAS", base.RoutingAreas),
+		RoutingProjects:      getEnvList("ROUTING_PROJECTS", base.RoutingProjects),
+		ReviewThreshold:      getEnvFloat("REVIEW_THRESHOLD", base.ReviewThreshold),
+		ReviewQueueSize:      getEnvInt("REVIEW_QUEUE_SIZE", base.ReviewQueueSize),
+		DedupCapacity:        getEnvInt("DEDUP_CAPACITY", base.DedupCapacity),
+		DedupSimilarity:      getEnvFloat("DEDUP_SIMILARITY", base.DedupSimilarity),
+		ReasoningTimeout:     getDurationEnv("REASONING_TIMEOUT", base.ReasoningTimeout),
+		LLMProbe:             getEnvBool("LLM_PROBE", base.LLMProbe),
+		LLMProbeInterval:     getDurationEnv("LLM_PROBE_INTERVAL", base.LLMProbeInterval),
+		LLMProbeTimeout:      getDurationEnv("LLM_PROBE_TIMEOUT", base.LLMProbeTimeout),
+		PromptMaxTokens:      getEnvInt("PROMPT_MAX_TOKENS", base.PromptMaxTokens),
+		DebugPrompts:         getEnvBool("DEBUG_PROMPTS", base.DebugPrompts),
+		TLSCertFile:          getEnv("TLS_CERT_FILE", base.TLSCertFile),
+		TLSKeyFile:           getEnv("TLS_KEY_FILE", base.TLSKeyFile),
+		AuthTokens:           getEnvList("AUTH_TOKENS", base.AuthTokens),
+		RateLimitRPS:         getEnvFloat("RATE_LIMIT_RPS", base.RateLimitRPS),
+		RateLimitBurst:       getEnvInt("RATE_LIMIT_BURST", base.RateLimitBurst),
+		OTelEndpoint:         getEnv("OTEL_ENDPOINT", base.OTelEndpoint),
+	}, nil
 }
 
-// Load reads configuration from environment variables.
-func Load() *Config {
+// defaults returns the configuration used when neither the config file nor
+// the environment sets a value.
+func defaults() *Config {
 	return &Config{
-		GRPCPort:         getEnvInt("FRONTAL_LOBE_GRPC_PORT", 50052),
-		ServiceName:      getEnv("FRONTAL_LOBE_SERVICE_NAME", "frontal-lobe"),
-		LLMProvider:      getEnv("LLM_PROVIDER", "mock"),
-		LLMModel:         getEnv("LLM_MODEL", "gpt-4"),
-		LLMAPIKey:        getEnv("LLM_API_KEY", ""),
-		LLMBaseURL:       getEnv("LLM_BASE_URL", ""),
-		OpenAIAPIKey:     getEnv("OPENAI_API_KEY", ""),
-		OpenAIBaseURL:    getEnv("OPENAI_BASE_URL", ""),
-		OpenAIModels:     getEnv("OPENAI_MODELS", ""),
-		GoogleAPIKey:     getEnv("GOOGLE_API_KEY", ""),
-		GoogleModels:     getEnv("GOOGLE_MODELS", ""),
-		ReasoningTimeout: getDurationEnv("REASONING_TIMEOUT", 2*time.Minute),
-		OTelEndpoint:     getEnv("OTEL_ENDPOINT", ""),
+		GRPCPort:             50052,
+		MetricsPort:          9092,
+		ServiceName:          "frontal-lobe",
+		LLMProvider:          "mock",
+		LLMModel:             "gpt-4",
+		ReviewThreshold:      0.6,
+		ReviewQueueSize:      1000,
+		DedupCapacity:        1000,
+		DedupSimilarity:      0.9,
+		ReasoningTimeout:     2 * time.Minute,
+		LLMProbeInterval:     30 * time.Second,
+		LLMProbeTimeout:      5 * time.Second,
+		RateLimitBurst:       10,
+		APIKeyReloadInterval: 30 * time.Second,
+		APIKeyCooldown:       time.Minute,
 	}
 }
 
@@ -66,6 +171,24 @@ func getEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+func getEnvFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
 func getDurationEnv(key string, fallback time.Duration) time.Duration {
 	if v := os.Getenv(key); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
@@ -74,3 +197,18 @@ func getDurationEnv(key string, fallback time.Duration) time.Duration {
 	}
 	return fallback
 }
+
+// getEnvList parses a comma-separated list, ignoring empty entries.
+func getEnvList(key string, fallback []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testConfigYAML = `llm_provider: openai
+openai_models: gpt-4o,gpt-4o-mini
+reasoning_timeout: 45s
+`
+
+// writeConfigFile writes content to a temp file and points CONFIG_FILE at it.
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+}
+
+// mustLoad calls Load and fails the test on error.
+func mustLoad(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+func TestLoadFromFile(t *testing.T) {
+	writeConfigFile(t, testConfigYAML)
+
+	cfg := mustLoad(t)
+
+	if got := cfg.LLMProvider; got != "openai" {
+		t.Errorf("cfg.LLMProvider: expected %q, got %q", "openai", got)
+	}
+	if got := cfg.OpenAIModels; got != "gpt-4o,gpt-4o-mini" {
+		t.Errorf("cfg.OpenAIModels: expected %q, got %q", "gpt-4o,gpt-4o-mini", got)
+	}
+	if got := cfg.ReasoningTimeout; got != 45*time.Second {
+		t.Errorf("cfg.ReasoningTimeout: expected %v, got %v", 45*time.Second, got)
+	}
+	if got := cfg.GRPCPort; got != 50052 {
+		t.Errorf("cfg.GRPCPort: expected %d, got %d", 50052, got)
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	writeConfigFile(t, testConfigYAML)
+	t.Setenv("LLM_PROVIDER", "google")
+
+	cfg := mustLoad(t)
+
+	if got := cfg.LLMProvider; got != "google" {
+		t.Errorf("cfg.LLMProvider: expected %q, got %q", "google", got)
+	}
+	if got := cfg.ReasoningTimeout; got != 45*time.Second {
+		t.Errorf("cfg.ReasoningTimeout: expected %v, got %v", 45*time.Second, got)
+	}
+}
+
+func TestLoadMissingExplicitFileFails(t *testing.T) {
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected an error for a missing CONFIG_FILE")
+	}
+}
+
+func TestLoadMalformedExplicitFileFails(t *testing.T) {
+	writeConfigFile(t, "grpc_port: [not a port\n")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected an error for a malformed CONFIG_FILE")
+	}
+}
+
+func TestLoadMissingDefaultFileUsesDefaults(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+
+	cfg := mustLoad(t)
+
+	if got := cfg.LLMProvider; got != "mock" {
+		t.Errorf("cfg.LLMProvider: expected %q, got %q", "mock", got)
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is read when present and CONFIG_FILE is unset.
+const defaultConfigFile = "config.yaml"
+
+// configFilePath returns the config file to read and whether it was named
+// explicitly through CONFIG_FILE.
+func configFilePath() (path string, explicit bool) {
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		return path, true
+	}
+	return defaultConfigFile, false
+}
+
+// loadFile overlays values from the YAML file at path onto cfg. Keys are the
+// snake_case names in the Config struct tags. A missing file is an error only
+// when it was named explicitly; the default config.yaml is optional.
+func loadFile(cfg *Config, path string, explicit bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
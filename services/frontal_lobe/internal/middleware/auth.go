@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/common/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryAuth returns a gRPC unary server interceptor that requires a bearer
+// token matching one of tokens in the "authorization" metadata. Health checks
+// are exempt so probes keep working. With no tokens, every call is allowed.
+func UnaryAuth(tokens []string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := authorize(ctx, info.FullMethod, tokens)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth returns a gRPC stream server interceptor that applies the same
+// bearer token check as UnaryAuth.
+func StreamAuth(tokens []string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod, tokens)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize checks the caller's bearer token against tokens and returns ctx
+// carrying the identity of the matched token.
+func authorize(ctx context.Context, method string, tokens []string) (context.Context, error) {
+	if len(tokens) == 0 || method == commonv1.HealthService_Check_FullMethodName {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+	for i, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			return context.WithValue(ctx, identityContextKey{}, fmt.Sprintf("token-%d", i)), nil
+		}
+	}
+	return nil, status.Error(codes.Unauthenticated, "invalid authorization token")
+}
+
+type identityContextKey struct{}
+
+// Identity returns the identity of the caller authenticated by UnaryAuth or
+// StreamAuth, named after the position of its token in the configured list,
+// or "" if the call was not authenticated.
+func Identity(ctx context.Context) string {
+	id, _ := ctx.Value(identityContextKey{}).(string)
+	return id
+}
+
+// identityStream overrides a server stream's context with one carrying the
+// caller's identity.
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context { return s.ctx }
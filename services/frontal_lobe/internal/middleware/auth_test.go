@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/common/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const streamMethod = "/cognitive_os.agent.v1.ReasoningEngine/StreamThoughtProcess"
+
+// authStream is a grpc.ServerStream that only carries a context.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context { return s.ctx }
+
+func withAuthorization(value string) context.Context {
+	if value == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func TestUnaryAuth(t *testing.T) {
+	interceptor := UnaryAuth([]string{"old-secret", "new-secret"})
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.memory.v1.MemoryService/IndexDocument"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      codes.Code
+	}{
+		{"missing token", "", codes.Unauthenticated},
+		{"wrong token", "Bearer guess", codes.Unauthenticated},
+		{"not a bearer token", "Basic new-secret", codes.Unauthenticated},
+		{"valid token", "Bearer new-secret", codes.OK},
+		{"any configured token", "Bearer old-secret", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := interceptor(withAuthorization(tt.authorization), nil, info, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("expected %v, got %v (%v)", tt.wantCode, code, err)
+			}
+			if tt.wantCode == codes.OK && resp != "ok" {
+				t.Errorf("expected handler to run, got %v", resp)
+			}
+		})
+	}
+}
+
+func TestStreamAuth(t *testing.T) {
+	interceptor := StreamAuth([]string{"secret"})
+	info := &grpc.StreamServerInfo{FullMethod: streamMethod}
+
+	called := false
+	handler := func(srv interface{}, ss grpc.ServerStream) error { called = true; return nil }
+
+	err := interceptor(nil, &authStream{ctx: withAuthorization("")}, info, handler)
+	if status.Code(err) != codes.Unauthenticated || called {
+		t.Fatalf("expected missing token to be rejected, got %v (called=%v)", err, called)
+	}
+	err = interceptor(nil, &authStream{ctx: withAuthorization("Bearer wrong")}, info, handler)
+	if status.Code(err) != codes.Unauthenticated || called {
+		t.Fatalf("expected wrong token to be rejected, got %v (called=%v)", err, called)
+	}
+	if err := interceptor(nil, &authStream{ctx: withAuthorization("Bearer secret")}, info, handler); err != nil || !called {
+		t.Fatalf("expected valid token to pass, got %v (called=%v)", err, called)
+	}
+}
+
+func TestAuthSetsIdentity(t *testing.T) {
+	tokens := []string{"old-secret", "new-secret"}
+
+	var got string
+	unary := func(ctx context.Context, req interface{}) (interface{}, error) { got = Identity(ctx); return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.memory.v1.MemoryService/IndexDocument"}
+	if _, err := UnaryAuth(tokens)(withAuthorization("Bearer new-secret"), nil, info, unary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "token-1" {
+		t.Errorf("expected identity token-1, got %q", got)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream) error { got = Identity(ss.Context()); return nil }
+	streamInfo := &grpc.StreamServerInfo{FullMethod: streamMethod}
+	if err := StreamAuth(tokens)(nil, &authStream{ctx: withAuthorization("Bearer old-secret")}, streamInfo, stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "token-0" {
+		t.Errorf("expected identity token-0, got %q", got)
+	}
+
+	if id := Identity(context.Background()); id != "" {
+		t.Errorf("expected no identity without auth, got %q", id)
+	}
+}
+
+func TestAuthDisabledAndHealthExempt(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.memory.v1.MemoryService/IndexDocument"}
+	if _, err := UnaryAuth(nil)(context.Background(), nil, info, handler); err != nil {
+		t.Errorf("expected auth to be disabled without tokens, got %v", err)
+	}
+
+	info = &grpc.UnaryServerInfo{FullMethod: commonv1.HealthService_Check_FullMethodName}
+	if _, err := UnaryAuth([]string{"secret"})(context.Background(), nil, info, handler); err != nil {
+		t.Errorf("expected health checks to skip auth, got %v", err)
+	}
+}
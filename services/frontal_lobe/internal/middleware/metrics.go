@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/metrics"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryMetrics returns a gRPC unary server interceptor that records each
+// call's latency and outcome in store.
+func UnaryMetrics(store *metrics.LatencyStore) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		store.Observe(info.FullMethod, time.Since(start), err != nil)
+		return resp, err
+	}
+}
+
+// StreamMetrics returns a gRPC stream server interceptor that records each
+// stream's total duration and outcome in store.
+func StreamMetrics(store *metrics.LatencyStore) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		store.Observe(info.FullMethod, time.Since(start), err != nil)
+		return err
+	}
+}
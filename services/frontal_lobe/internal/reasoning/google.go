@@ -7,11 +7,13 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
 // GoogleProvider calls the Google Generative AI (Gemini) API.
 type GoogleProvider struct {
+	mu      sync.RWMutex // guards apiKey
 	apiKey  string
 	baseURL string
 	model   string
@@ -34,6 +36,21 @@ func NewGoogleProvider(apiKey, model string, timeout time.Duration) *GoogleProvi
 	}
 }
 
+// SetAPIKey replaces the API key used by subsequent requests, e.g. when a
+// rotated key file is reloaded (see WatchKeyFile). Requests already in
+// flight keep the key they started with.
+func (p *GoogleProvider) SetAPIKey(key string) {
+	p.mu.Lock()
+	p.apiKey = key
+	p.mu.Unlock()
+}
+
+func (p *GoogleProvider) key() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.apiKey
+}
+
 // Generate calls the Google GenAI generateContent endpoint.
 func (p *GoogleProvider) Generate(ctx context.Context, prompt string) (string, error) {
 	reqBody := googleGenRequest{
@@ -47,7 +64,7 @@ func (p *GoogleProvider) Generate(ctx context.Context, prompt string) (string, e
 	}
 
 	url := fmt.Sprintf("%s/v1beta/models/%s:generateContent?key=%s",
-		p.baseURL, p.model, p.apiKey)
+		p.baseURL, p.model, p.key())
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url,
 		strings.NewReader(string(bodyBytes)))
 	if err != nil {
@@ -0,0 +1,71 @@
+package reasoning
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultKeyReloadInterval is how often WatchKeyFile re-reads the key file
+// when no interval is given.
+const defaultKeyReloadInterval = 30 * time.Second
+
+// WatchKeyFile reads an API key from path, passes it to apply, and then
+// re-reads the file every interval, calling apply again whenever the key
+// changes, until the returned function is called. This lets a key be rotated
+// by rewriting the file without restarting the service. Surrounding
+// whitespace is trimmed; a file that becomes unreadable or empty keeps the
+// previous key. It returns an error if the key cannot be read initially.
+func WatchKeyFile(path string, interval time.Duration, apply func(key string)) (stop func(), err error) {
+	key, err := readKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	apply(key)
+
+	if interval <= 0 {
+		interval = defaultKeyReloadInterval
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				next, err := readKeyFile(path)
+				if err != nil {
+					slog.Warn("failed to reload API key file, keeping the current key", "path", path, "error", err)
+					continue
+				}
+				if next != key {
+					key = next
+					apply(key)
+					slog.Info("reloaded API key", "path", path)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }, nil
+}
+
+// readKeyFile returns the trimmed contents of the key file at path.
+func readKeyFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading API key file: %w", err)
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("API key file %s is empty", path)
+	}
+	return key, nil
+}
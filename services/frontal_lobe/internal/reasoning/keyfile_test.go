@@ -0,0 +1,108 @@
+package reasoning
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// authRecorder is a fake OpenAI server that records the Authorization header
+// of the most recent request.
+type authRecorder struct {
+	mu   sync.Mutex
+	auth string
+}
+
+func (a *authRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.auth = r.Header.Get("Authorization")
+	a.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"choices": []map[string]interface{}{{"message": map[string]string{"content": "ok"}}},
+	})
+}
+
+func (a *authRecorder) last() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.auth
+}
+
+func TestWatchKeyFileRotatesProviderKey(t *testing.T) {
+	recorder := &authRecorder{}
+	srv := httptest.NewServer(recorder)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "openai.key")
+	if err := os.WriteFile(path, []byte("key-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewOpenAIProvider("", srv.URL, "gpt-4", 10*time.Second)
+	stop, err := WatchKeyFile(path, 10*time.Millisecond, provider.SetAPIKey)
+	if err != nil {
+		t.Fatalf("WatchKeyFile: %v", err)
+	}
+	defer stop()
+
+	if _, err := provider.Generate(context.Background(), "hello"); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if got := recorder.last(); got != "Bearer key-1" {
+		t.Fatalf("expected the key from the file, got %q", got)
+	}
+
+	if err := os.WriteFile(path, []byte("key-2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := provider.Generate(context.Background(), "hello"); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		if recorder.last() == "Bearer key-2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the rotated key to be used, still sending %q", recorder.last())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatchKeyFileKeepsKeyWhenFileEmptied(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "google.key")
+	if err := os.WriteFile(path, []byte("key-1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewGoogleProvider("", "gemini-pro", 0)
+	stop, err := WatchKeyFile(path, 10*time.Millisecond, provider.SetAPIKey)
+	if err != nil {
+		t.Fatalf("WatchKeyFile: %v", err)
+	}
+	defer stop()
+
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := provider.key(); got != "key-1" {
+		t.Errorf("expected an emptied file to keep the current key, got %q", got)
+	}
+}
+
+func TestWatchKeyFileMissing(t *testing.T) {
+	_, err := WatchKeyFile(filepath.Join(t.TempDir(), "missing.key"), time.Second, func(string) {})
+	if err == nil {
+		t.Error("expected an error for a missing key file")
+	}
+}
@@ -0,0 +1,158 @@
+package reasoning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitError reports that an LLM API rejected a request with HTTP 429.
+type RateLimitError struct {
+	API        string        // "OpenAI"
+	Message    string        // error message from the response body, if any
+	RetryAfter time.Duration // from the Retry-After header; 0 when absent
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s API rate limited: %s", e.API, e.Message)
+	}
+	return e.API + " API rate limited"
+}
+
+// newRateLimitError builds a RateLimitError from a 429 response and its body.
+func newRateLimitError(api string, resp *http.Response, body []byte) *RateLimitError {
+	e := &RateLimitError{API: api}
+	var errResp struct {
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != nil {
+		e.Message = errResp.Error.Message
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		e.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return e
+}
+
+// defaultKeyCooldown is how long a rate-limited key is skipped when the API
+// does not say how long to wait.
+const defaultKeyCooldown = time.Minute
+
+// KeyRotatingProvider spreads calls over several providers for the same
+// model, each holding a different API key, so that no single key hits its
+// rate limit. Calls go to the keys in turn; a key that gets rate limited is
+// skipped until its cooldown passes and the call is retried on the next one.
+type KeyRotatingProvider struct {
+	mu       sync.Mutex // guards next and the slots' limitedUntil
+	slots    []*keySlot
+	next     int // index of the slot the next call tries first
+	cooldown time.Duration
+	now      func() time.Time
+}
+
+type keySlot struct {
+	provider     LLMProvider
+	limitedUntil time.Time
+}
+
+// NewKeyRotatingProvider rotates over providers, which must all serve the
+// same model with different keys. A rate-limited key is skipped for the
+// Retry-After the API sends or, without one, for cooldown (one minute when
+// cooldown is 0).
+func NewKeyRotatingProvider(providers []LLMProvider, cooldown time.Duration) *KeyRotatingProvider {
+	if cooldown <= 0 {
+		cooldown = defaultKeyCooldown
+	}
+	slots := make([]*keySlot, len(providers))
+	for i, p := range providers {
+		slots[i] = &keySlot{provider: p}
+	}
+	return &KeyRotatingProvider{slots: slots, cooldown: cooldown, now: time.Now}
+}
+
+// Generate calls the next available key.
+func (r *KeyRotatingProvider) Generate(ctx context.Context, prompt string) (string, error) {
+	var text string
+	err := r.do(func(p LLMProvider) (err error) {
+		text, err = p.Generate(ctx, prompt)
+		return err
+	})
+	return text, err
+}
+
+// GenerateStream streams from the next available key. A key rate limited
+// before producing output is retried on the next one.
+func (r *KeyRotatingProvider) GenerateStream(ctx context.Context, prompt string, emit func(chunk string) error) error {
+	return r.do(func(p LLMProvider) error {
+		return GenerateStream(ctx, p, prompt, emit)
+	})
+}
+
+// Classify calls the next available key.
+func (r *KeyRotatingProvider) Classify(ctx context.Context, content string, categories []string) (string, float64, error) {
+	var category string
+	var confidence float64
+	err := r.do(func(p LLMProvider) (err error) {
+		category, confidence, err = p.Classify(ctx, content, categories)
+		return err
+	})
+	return category, confidence, err
+}
+
+// do runs call against each key in turn until one is not rate limited,
+// trying every key at most once.
+func (r *KeyRotatingProvider) do(call func(p LLMProvider) error) error {
+	if len(r.slots) == 0 {
+		return errors.New("no API keys configured")
+	}
+
+	var err error
+	for tried := 0; tried < len(r.slots); tried++ {
+		slot := r.pick()
+		err = call(slot.provider)
+
+		var limited *RateLimitError
+		if !errors.As(err, &limited) {
+			return err
+		}
+		wait := limited.RetryAfter
+		if wait <= 0 {
+			wait = r.cooldown
+		}
+		r.mu.Lock()
+		slot.limitedUntil = r.now().Add(wait)
+		r.mu.Unlock()
+	}
+	return err
+}
+
+// pick returns the next key that is not rate limited, advancing the
+// rotation past it. When every key is rate limited it returns the one whose
+// limit ends soonest.
+func (r *KeyRotatingProvider) pick() *keySlot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	best := -1
+	for i := 0; i < len(r.slots); i++ {
+		idx := (r.next + i) % len(r.slots)
+		if !r.slots[idx].limitedUntil.After(now) {
+			best = idx
+			break
+		}
+		if best < 0 || r.slots[idx].limitedUntil.Before(r.slots[best].limitedUntil) {
+			best = idx
+		}
+	}
+	r.next = (best + 1) % len(r.slots)
+	return r.slots[best]
+}
@@ -0,0 +1,124 @@
+package reasoning
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// keyServer is a fake OpenAI API that records the key of each call and
+// rejects calls made with a rate-limited key.
+type keyServer struct {
+	mu      sync.Mutex
+	keys    []string
+	limited map[string]bool
+}
+
+func (s *keyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mu.Lock()
+	s.keys = append(s.keys, key)
+	limited := s.limited[key]
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if limited {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"Rate limit reached"}}`))
+		return
+	}
+	w.Write([]byte(`{"choices":[{"message":{"content":"answered with ` + key + `"}}]}`))
+}
+
+func (s *keyServer) calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.keys...)
+}
+
+func newRotatingProvider(t *testing.T, srv *keyServer, keys ...string) *KeyRotatingProvider {
+	t.Helper()
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	providers := make([]LLMProvider, len(keys))
+	for i, key := range keys {
+		providers[i] = NewOpenAIProvider(key, ts.URL, "gpt-4o", time.Second)
+	}
+	return NewKeyRotatingProvider(providers, time.Minute)
+}
+
+func TestKeyRotatingProviderRoundRobin(t *testing.T) {
+	srv := &keyServer{}
+	p := newRotatingProvider(t, srv, "key-a", "key-b", "key-c")
+
+	for i := 0; i < 4; i++ {
+		if _, err := p.Generate(context.Background(), "hello"); err != nil {
+			t.Fatalf("Generate %d: %v", i, err)
+		}
+	}
+	if got := strings.Join(srv.calls(), ","); got != "key-a,key-b,key-c,key-a" {
+		t.Errorf("expected sequential calls to rotate keys, got %s", got)
+	}
+}
+
+func TestKeyRotatingProviderSkipsRateLimitedKey(t *testing.T) {
+	srv := &keyServer{limited: map[string]bool{"key-a": true}}
+	p := newRotatingProvider(t, srv, "key-a", "key-b")
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+
+	text, err := p.Generate(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if text != "answered with key-b" {
+		t.Errorf("expected the call retried on the next key, got %q", text)
+	}
+
+	// key-a stays skipped during its cooldown, even once it recovers.
+	srv.mu.Lock()
+	srv.limited = nil
+	srv.mu.Unlock()
+	p.Generate(context.Background(), "hello")
+	p.Generate(context.Background(), "hello")
+	if got := strings.Join(srv.calls(), ","); got != "key-a,key-b,key-b,key-b" {
+		t.Errorf("expected the rate-limited key to be skipped, got %s", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	p.Generate(context.Background(), "hello")
+	p.Generate(context.Background(), "hello")
+	if got := srv.calls()[4:]; strings.Join(got, ",") != "key-a,key-b" {
+		t.Errorf("expected the key back in rotation after its cooldown, got %v", got)
+	}
+}
+
+func TestKeyRotatingProviderAllKeysRateLimited(t *testing.T) {
+	srv := &keyServer{limited: map[string]bool{"key-a": true, "key-b": true}}
+	p := newRotatingProvider(t, srv, "key-a", "key-b")
+
+	_, err := p.Generate(context.Background(), "hello")
+	var limited *RateLimitError
+	if !errors.As(err, &limited) {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+	if limited.Message != "Rate limit reached" {
+		t.Errorf("expected the API's message, got %q", limited.Message)
+	}
+	if len(srv.calls()) != 2 {
+		t.Errorf("expected each key tried once, got %v", srv.calls())
+	}
+}
+
+func TestRateLimitErrorRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"30"}}}
+	if e := newRateLimitError("OpenAI", resp, nil); e.RetryAfter != 30*time.Second {
+		t.Errorf("expected a 30s Retry-After, got %v", e.RetryAfter)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 )
 
 // LLMProvider is the interface for LLM backends.
@@ -15,18 +16,70 @@ type LLMProvider interface {
 	Classify(ctx context.Context, content string, categories []string) (string, float64, error)
 }
 
-// MockLLM is a mock LLM provider for testing and development.
-type MockLLM struct{}
+// MockLLM is a mock LLM provider for testing and development. By default it
+// answers with canned responses chosen by prompt keywords; tests can script
+// exact output with SetResponses or SetResponder.
+type MockLLM struct {
+	mu        sync.Mutex
+	responses []string                   // queued responses, consumed in order
+	responder func(prompt string) string // used once the queue is empty
+}
 
 // NewMockLLM creates a new mock LLM.
 func NewMockLLM() *MockLLM {
 	return &MockLLM{}
 }
 
-// Generate returns a canned response based on prompt keywords.
+// SetResponses queues responses that successive Generate calls return in
+// order, replacing any still queued. Once the queue is exhausted, Generate
+// falls back to the responder, if set, or the canned responses.
+func (m *MockLLM) SetResponses(responses []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses = append([]string(nil), responses...)
+}
+
+// SetResponder sets a function that computes the response to each prompt
+// when no queued response is left, letting tests both script output and
+// inspect prompts. A nil responder restores the canned responses.
+func (m *MockLLM) SetResponder(responder func(prompt string) string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responder = responder
+}
+
+// Generate returns the next queued response, the responder's response, or a
+// canned response based on prompt keywords, in that order of preference.
 func (m *MockLLM) Generate(ctx context.Context, prompt string) (string, error) {
+	m.mu.Lock()
+	if len(m.responses) > 0 {
+		resp := m.responses[0]
+		m.responses = m.responses[1:]
+		m.mu.Unlock()
+		return resp, nil
+	}
+	responder := m.responder
+	m.mu.Unlock()
+	if responder != nil {
+		return responder(prompt), nil
+	}
+
 	lower := strings.ToLower(prompt)
 
+	if strings.Contains(lower, "monthly review") {
+		return `# Monthly Review
+
+## Summary
+The month showed consistent progress with a few recurring blockers.
+
+## Trends
+- Momentum held up across the weeks
+- Blocked work tended to carry over from week to week
+
+## Recommendations
+- Resolve recurring blockers before taking on new projects`, nil
+	}
+
 	if strings.Contains(lower, "weekly review") || strings.Contains(lower, "report") {
 		return `# Weekly Review
 
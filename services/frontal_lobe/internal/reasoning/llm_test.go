@@ -74,3 +74,60 @@ func TestMockLLMClassifyEmptyCategories(t *testing.T) {
 		t.Errorf("expected 'unknown', got %q", result)
 	}
 }
+
+func TestMockLLMResponseQueue(t *testing.T) {
+	llm := NewMockLLM()
+	llm.SetResponses([]string{"first", "second"})
+
+	for _, want := range []string{"first", "second"} {
+		resp, err := llm.Generate(context.Background(), "What is the weather?")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp != want {
+			t.Errorf("expected queued response %q, got %q", want, resp)
+		}
+	}
+
+	// An exhausted queue falls back to the canned responses.
+	resp, _ := llm.Generate(context.Background(), "What is the weather?")
+	if !strings.HasPrefix(resp, "Processed:") {
+		t.Errorf("expected the canned response once the queue is empty, got %q", resp)
+	}
+}
+
+func TestMockLLMResponder(t *testing.T) {
+	llm := NewMockLLM()
+	var prompts []string
+	llm.SetResponder(func(prompt string) string {
+		prompts = append(prompts, prompt)
+		return "answer to: " + prompt
+	})
+	llm.SetResponses([]string{"queued"})
+
+	// Queued responses take precedence over the responder.
+	if resp, _ := llm.Generate(context.Background(), "one"); resp != "queued" {
+		t.Errorf("expected the queued response first, got %q", resp)
+	}
+	if resp, _ := llm.Generate(context.Background(), "Generate a weekly review"); resp != "answer to: Generate a weekly review" {
+		t.Errorf("expected the responder's output, got %q", resp)
+	}
+	if len(prompts) != 1 || prompts[0] != "Generate a weekly review" {
+		t.Errorf("expected the responder to see only the second prompt, got %v", prompts)
+	}
+
+	// Streaming goes through the same response source.
+	var streamed string
+	err := llm.GenerateStream(context.Background(), "two", func(chunk string) error {
+		streamed += chunk
+		return nil
+	})
+	if err != nil || streamed != "answer to: two" {
+		t.Errorf("expected the responder's output streamed, got %q (%v)", streamed, err)
+	}
+
+	llm.SetResponder(nil)
+	if resp, _ := llm.Generate(context.Background(), "Classify this"); resp != "ACTIONABLE" {
+		t.Errorf("expected canned responses after clearing the responder, got %q", resp)
+	}
+}
@@ -1,17 +1,20 @@
 package reasoning
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
 // OpenAIProvider calls the OpenAI-compatible chat completions API.
 type OpenAIProvider struct {
+	mu      sync.RWMutex // guards apiKey
 	apiKey  string
 	baseURL string
 	model   string
@@ -34,13 +37,35 @@ func NewOpenAIProvider(apiKey, baseURL, model string, timeout time.Duration) *Op
 	}
 }
 
+// SetAPIKey replaces the API key used by subsequent requests, e.g. when a
+// rotated key file is reloaded (see WatchKeyFile). Requests already in
+// flight keep the key they started with.
+func (p *OpenAIProvider) SetAPIKey(key string) {
+	p.mu.Lock()
+	p.apiKey = key
+	p.mu.Unlock()
+}
+
+func (p *OpenAIProvider) key() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.apiKey
+}
+
 // Generate calls the OpenAI chat completions endpoint.
 func (p *OpenAIProvider) Generate(ctx context.Context, prompt string) (string, error) {
+	return p.GenerateWithStop(ctx, prompt, nil)
+}
+
+// GenerateWithStop calls the chat completions endpoint with stop as the
+// request's stop sequences, so the model ends its response at the first one.
+func (p *OpenAIProvider) GenerateWithStop(ctx context.Context, prompt string, stop []string) (string, error) {
 	reqBody := openAIChatRequest{
 		Model: p.model,
 		Messages: []openAIChatMessage{
 			{Role: "user", Content: prompt},
 		},
+		Stop: stop,
 	}
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
@@ -53,7 +78,7 @@ func (p *OpenAIProvider) Generate(ctx context.Context, prompt string) (string, e
 		return "", fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Authorization", "Bearer "+p.key())
 
 	resp, err := p.client.Do(req)
 	if err != nil {
@@ -65,6 +90,9 @@ func (p *OpenAIProvider) Generate(ctx context.Context, prompt string) (string, e
 	if err != nil {
 		return "", fmt.Errorf("reading response: %w", err)
 	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return "", newRateLimitError("OpenAI", resp, respBody)
+	}
 
 	var chatResp openAIChatResponse
 	if err := json.Unmarshal(respBody, &chatResp); err != nil {
@@ -81,6 +109,84 @@ func (p *OpenAIProvider) Generate(ctx context.Context, prompt string) (string, e
 	return chatResp.Choices[0].Message.Content, nil
 }
 
+// GenerateStream calls the chat completions endpoint with streaming enabled
+// and emits each content delta as it arrives.
+func (p *OpenAIProvider) GenerateStream(ctx context.Context, prompt string, emit func(chunk string) error) error {
+	reqBody := openAIChatRequest{
+		Model: p.model,
+		Messages: []openAIChatMessage{
+			{Role: "user", Content: prompt},
+		},
+		Stream: true,
+	}
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		p.baseURL+"/v1/chat/completions", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+p.key())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling OpenAI API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return newRateLimitError("OpenAI", resp, respBody)
+		}
+		var chatResp openAIChatResponse
+		if json.Unmarshal(respBody, &chatResp) == nil && chatResp.Error != nil {
+			return fmt.Errorf("OpenAI API error: %s", chatResp.Error.Message)
+		}
+		return fmt.Errorf("OpenAI API returned status %d", resp.StatusCode)
+	}
+
+	// The response is a server-sent event stream of "data: {...}" lines
+	// terminated by "data: [DONE]".
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return nil
+		}
+
+		var event openAIChatStreamEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("unmarshaling stream event: %w", err)
+		}
+		if event.Error != nil {
+			return fmt.Errorf("OpenAI API error: %s", event.Error.Message)
+		}
+		for _, choice := range event.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			if err := emit(choice.Delta.Content); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading stream: %w", err)
+	}
+	return nil
+}
+
 // Classify uses the OpenAI API to classify content into one of the given categories.
 func (p *OpenAIProvider) Classify(ctx context.Context, content string, categories []string) (string, float64, error) {
 	prompt := fmt.Sprintf(
@@ -99,6 +205,8 @@ func (p *OpenAIProvider) Classify(ctx context.Context, content string, categorie
 type openAIChatRequest struct {
 	Model    string              `json:"model"`
 	Messages []openAIChatMessage `json:"messages"`
+	Stream   bool                `json:"stream,omitempty"`
+	Stop     []string            `json:"stop,omitempty"`
 }
 
 type openAIChatMessage struct {
@@ -116,3 +224,14 @@ type openAIChatResponse struct {
 		Message string `json:"message"`
 	} `json:"error,omitempty"`
 }
+
+type openAIChatStreamEvent struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
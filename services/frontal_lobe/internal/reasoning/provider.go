@@ -13,6 +13,26 @@ type ProviderConfig struct {
 	Timeout time.Duration
 }
 
+// ProviderType returns the Name a ProviderConfig would use for p: "openai",
+// "google" or "mock", or "" for any other provider.
+func ProviderType(p LLMProvider) string {
+	switch p := p.(type) {
+	case *OpenAIProvider:
+		return "openai"
+	case *GoogleProvider:
+		return "google"
+	case *MockLLM:
+		return "mock"
+	case *KeyRotatingProvider:
+		if len(p.slots) > 0 {
+			return ProviderType(p.slots[0].provider)
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+
 // matchCategory matches an LLM classification result against known categories.
 // Returns the matched category with high confidence, or falls back to the first
 // category with low confidence.
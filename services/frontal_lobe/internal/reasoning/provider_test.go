@@ -3,9 +3,11 @@ package reasoning
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 )
@@ -60,6 +62,23 @@ func TestRouterListModels(t *testing.T) {
 	}
 }
 
+func TestRouterModelsReportsProviderTypes(t *testing.T) {
+	router := NewRouter(NewMockLLM())
+	router.Register("gpt-4", NewOpenAIProvider("key", "", "gpt-4", time.Second))
+	router.Register("gemini-pro", NewGoogleProvider("key", "gemini-pro", time.Second))
+	router.Register("mock", NewMockLLM())
+
+	want := []ModelInfo{
+		{Name: "gemini-pro", Provider: "google"},
+		{Name: "gpt-4", Provider: "openai"},
+		{Name: "mock", Provider: "mock"},
+	}
+	got := router.Models()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
 func TestRouterGenerateWithModel(t *testing.T) {
 	mock := NewMockLLM()
 	router := NewRouter(mock)
@@ -176,6 +195,80 @@ func TestOpenAIProviderAPIError(t *testing.T) {
 	}
 }
 
+func TestOpenAIProviderGenerateStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Stream {
+			t.Errorf("expected a streaming request, got %+v (%v)", req, err)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, delta := range []string{"Hello", " from", " a stream"} {
+			fmt.Fprintf(w, "data: {\"choices\":[{\"delta\":{\"content\":%q}}]}\n\n", delta)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	provider := NewOpenAIProvider("test-key", srv.URL, "gpt-4", 10*time.Second)
+	var chunks []string
+	err := provider.GenerateStream(context.Background(), "hello", func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 3 || strings.Join(chunks, "") != "Hello from a stream" {
+		t.Errorf("unexpected chunks: %q", chunks)
+	}
+}
+
+func TestOpenAIProviderGenerateWithStop(t *testing.T) {
+	var stops [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		stops = append(stops, req.Stop)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"content":"one, two"}}]}`))
+	}))
+	defer srv.Close()
+
+	// The stop sequences pass through the router and key rotation to the API.
+	provider := NewOpenAIProvider("test-key", srv.URL, "gpt-4", 10*time.Second)
+	router := NewRouter(NewKeyRotatingProvider([]LLMProvider{provider}, time.Minute))
+	if _, err := GenerateWithStop(context.Background(), router, "count", []string{"\n", "three"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := GenerateWithStop(context.Background(), router, "count", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(stops) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(stops))
+	}
+	if strings.Join(stops[0], "|") != "\n|three" {
+		t.Errorf("expected stop sequences in the request, got %q", stops[0])
+	}
+	if stops[1] != nil {
+		t.Errorf("expected no stop field without stop sequences, got %q", stops[1])
+	}
+}
+
+func TestGenerateWithStopFallsBackToGenerate(t *testing.T) {
+	llm := NewMockLLM()
+	llm.SetResponses([]string{"one\ntwo"})
+	resp, err := GenerateWithStop(context.Background(), llm, "count", []string{"\n"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp != "one\ntwo" {
+		t.Errorf("expected the whole response from a provider without stop support, got %q", resp)
+	}
+}
+
 func TestGoogleProviderGenerate(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Query().Get("key") != "test-key" {
@@ -2,6 +2,7 @@ package reasoning
 
 import (
 	"context"
+	"sort"
 	"sync"
 )
 
@@ -29,6 +30,19 @@ func (r *Router) Register(model string, provider LLMProvider) {
 	r.providers[model] = provider
 }
 
+// ModelInfo describes a model a Router routes to.
+type ModelInfo struct {
+	Name     string
+	Provider string // see ProviderType
+}
+
+// ModelLister is implemented by providers that route between several named
+// models, such as Router.
+type ModelLister interface {
+	// Models returns the models the provider routes, sorted by name.
+	Models() []ModelInfo
+}
+
 // ListModels returns all registered model names.
 func (r *Router) ListModels() []string {
 	r.mu.RLock()
@@ -40,6 +54,19 @@ func (r *Router) ListModels() []string {
 	return models
 }
 
+// Models returns the registered models and their provider types, sorted by
+// name.
+func (r *Router) Models() []ModelInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	models := make([]ModelInfo, 0, len(r.providers))
+	for m, p := range r.providers {
+		models = append(models, ModelInfo{Name: m, Provider: ProviderType(p)})
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+	return models
+}
+
 // ForModel returns the provider for the given model, or the fallback.
 func (r *Router) ForModel(model string) LLMProvider {
 	r.mu.RLock()
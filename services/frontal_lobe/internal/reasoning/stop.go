@@ -0,0 +1,36 @@
+package reasoning
+
+import "context"
+
+// StopSequenceProvider is implemented by LLM backends that can end a
+// response at caller-supplied stop sequences.
+type StopSequenceProvider interface {
+	// GenerateWithStop produces a response to prompt that ends before the
+	// first occurrence of any of stop.
+	GenerateWithStop(ctx context.Context, prompt string, stop []string) (string, error)
+}
+
+// GenerateWithStop generates the response to prompt from p, stopping at the
+// first of stop. Providers that do not implement StopSequenceProvider
+// generate their whole response, leaving the stop sequences to the caller.
+func GenerateWithStop(ctx context.Context, p LLMProvider, prompt string, stop []string) (string, error) {
+	if sp, ok := p.(StopSequenceProvider); ok && len(stop) > 0 {
+		return sp.GenerateWithStop(ctx, prompt, stop)
+	}
+	return p.Generate(ctx, prompt)
+}
+
+// GenerateWithStop routes to the fallback provider.
+func (r *Router) GenerateWithStop(ctx context.Context, prompt string, stop []string) (string, error) {
+	return GenerateWithStop(ctx, r.fallback, prompt, stop)
+}
+
+// GenerateWithStop calls the next available key.
+func (r *KeyRotatingProvider) GenerateWithStop(ctx context.Context, prompt string, stop []string) (string, error) {
+	var text string
+	err := r.do(func(p LLMProvider) (err error) {
+		text, err = GenerateWithStop(ctx, p, prompt, stop)
+		return err
+	})
+	return text, err
+}
@@ -0,0 +1,49 @@
+package reasoning
+
+import (
+	"context"
+	"strings"
+)
+
+// StreamingProvider is implemented by LLM backends that can deliver a
+// response incrementally.
+type StreamingProvider interface {
+	// GenerateStream produces a response to prompt, calling emit with each
+	// piece of text in order. An error returned by emit stops generation.
+	GenerateStream(ctx context.Context, prompt string, emit func(chunk string) error) error
+}
+
+// GenerateStream streams the response to prompt from p. Providers that do
+// not implement StreamingProvider deliver their whole response as one chunk.
+func GenerateStream(ctx context.Context, p LLMProvider, prompt string, emit func(chunk string) error) error {
+	if sp, ok := p.(StreamingProvider); ok {
+		return sp.GenerateStream(ctx, prompt, emit)
+	}
+	text, err := p.Generate(ctx, prompt)
+	if err != nil {
+		return err
+	}
+	return emit(text)
+}
+
+// GenerateStream emits the canned response one paragraph at a time.
+func (m *MockLLM) GenerateStream(ctx context.Context, prompt string, emit func(chunk string) error) error {
+	text, err := m.Generate(ctx, prompt)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range strings.SplitAfter(text, "\n\n") {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := emit(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateStream routes to the fallback provider.
+func (r *Router) GenerateStream(ctx context.Context, prompt string, emit func(chunk string) error) error {
+	return GenerateStream(ctx, r.fallback, prompt, emit)
+}
@@ -2,10 +2,18 @@ package server
 
 import (
 	"context"
+	"go/token"
 	"io"
 	"log/slog"
+	"sort"
+	"strings"
+	"text/template"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/agents"
@@ -15,6 +23,8 @@ import (
 	commonv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/common/v1"
 )
 
+var tracer = otel.Tracer("github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/server")
+
 // FrontalLobeServer implements the ReasoningEngine gRPC service.
 type FrontalLobeServer struct {
 	agentv1.UnimplementedReasoningEngineServer
@@ -24,8 +34,10 @@ type FrontalLobeServer struct {
 	cfg          *config.Config
 	llm          reasoning.LLMProvider
 	clarifyAgent *agents.ClarifyAgent
+	reviews      *agents.ReviewQueue // nil when the review queue is disabled
 	reflectAgent *agents.ReflectAgent
 	version      string
+	llmProbe     llmProbe
 }
 
 // NewFrontalLobeServer creates a new FrontalLobeServer.
@@ -34,22 +46,45 @@ func NewFrontalLobeServer(
 	cfg *config.Config,
 	llm reasoning.LLMProvider,
 ) *FrontalLobeServer {
+	clarifyAgent := agents.NewClarifyAgent(llm, agents.Taxonomy{Areas: cfg.RoutingAreas, Projects: cfg.RoutingProjects})
+	clarifyAgent.SetReviewThreshold(cfg.ReviewThreshold)
+	var reviews *agents.ReviewQueue
+	if cfg.ReviewQueueSize > 0 {
+		reviews = agents.NewReviewQueue(cfg.ReviewQueueSize)
+		clarifyAgent.SetReviewQueue(reviews)
+	}
+	if cfg.DedupCapacity > 0 {
+		clarifyAgent.SetRecentItems(agents.NewRecentItems(cfg.DedupCapacity, cfg.DedupSimilarity))
+	}
+
 	return &FrontalLobeServer{
 		logger:       logger,
 		cfg:          cfg,
 		llm:          llm,
-		clarifyAgent: agents.NewClarifyAgent(llm),
+		clarifyAgent: clarifyAgent,
+		reviews:      reviews,
 		reflectAgent: agents.NewReflectAgent(llm),
 		version:      "0.1.0",
+		llmProbe:     llmProbe{now: time.Now},
 	}
 }
 
-// Check implements the HealthService Check RPC.
+// Check implements the HealthService Check RPC. With cfg.LLMProbe set it
+// also pings the LLM provider, reporting NOT_SERVING while the provider
+// fails, e.g. because of a bad key; the result is cached for
+// cfg.LLMProbeInterval.
 func (s *FrontalLobeServer) Check(ctx context.Context, req *commonv1.HealthCheckRequest) (*commonv1.HealthCheckResponse, error) {
+	status := commonv1.HealthCheckResponse_SERVING
+	deps := s.llmStatus(ctx)
+	if deps[dependencyLLM] == dependencyUnreachable {
+		status = commonv1.HealthCheckResponse_NOT_SERVING
+	}
+
 	return &commonv1.HealthCheckResponse{
-		Status:    commonv1.HealthCheckResponse_SERVING,
-		Version:   s.version,
-		Timestamp: timestamppb.Now(),
+		Status:       status,
+		Version:      s.version,
+		Timestamp:    timestamppb.Now(),
+		Dependencies: deps,
 	}, nil
 }
 
@@ -89,7 +124,12 @@ func (s *FrontalLobeServer) StreamThoughtProcess(stream agentv1.ReasoningEngine_
 	}
 }
 
-// handleQuery generates an LLM response for a user query and sends it on the stream.
+// maxDebugPromptLen caps how much of the assembled prompt debug mode emits.
+const maxDebugPromptLen = 8000
+
+// handleQuery generates an LLM response for a user query and sends it on the
+// stream. In debug mode (the DebugPrompts config or the request's
+// debug_prompt flag) it first emits the assembled prompt as a thought.
 func (s *FrontalLobeServer) handleQuery(
 	stream agentv1.ReasoningEngine_StreamThoughtProcessServer,
 	sessionID, query string,
@@ -99,9 +139,34 @@ func (s *FrontalLobeServer) handleQuery(
 		return err
 	}
 
-	prompt := s.buildPrompt(query, ctx)
+	prompt, trim := s.buildPrompt(query, ctx)
+	if trim.trimmed() {
+		s.logger.InfoContext(stream.Context(), "trimmed prompt context to fit the token budget",
+			"session_id", sessionID,
+			"max_tokens", s.cfg.PromptMaxTokens,
+			"semantic_chunks_dropped", trim.SemanticChunks,
+			"episodic_turns_dropped", trim.EpisodicTurns,
+			"graph_triples_dropped", trim.GraphTriples,
+		)
+	}
+	if s.cfg.DebugPrompts || ctx.GetDebugPrompt() {
+		debugPrompt := reasoning.Truncate(prompt, maxDebugPromptLen)
+		s.logger.DebugContext(stream.Context(), "assembled prompt", "session_id", sessionID, "length", len(prompt), "prompt", debugPrompt)
+		if err := sendThought(stream, sessionID, "Assembled prompt:\n"+debugPrompt); err != nil {
+			return err
+		}
+	}
 
-	response, err := s.llm.Generate(stream.Context(), prompt)
+	genCtx, span := tracer.Start(stream.Context(), "frontal_lobe.Generate",
+		trace.WithAttributes(
+			attribute.Int("prompt.length", len(prompt)),
+			attribute.Bool("prompt.trimmed", trim.trimmed()),
+		))
+	response, err := reasoning.GenerateWithStop(genCtx, s.llm, prompt, ctx.GetStopSequences())
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
 	if err != nil {
 		return sendFinalResponse(stream, sessionID, "I encountered an error while processing your request.")
 	}
@@ -109,6 +174,29 @@ func (s *FrontalLobeServer) handleQuery(
 	return sendFinalResponse(stream, sessionID, response)
 }
 
+// ListModels reports the models the LLM provider routes and the type of
+// provider serving each, sorted by name. A provider that does not route
+// between models reports none.
+func (s *FrontalLobeServer) ListModels(ctx context.Context, req *agentv1.ListModelsRequest) (*agentv1.ListModelsResponse, error) {
+	resp := &agentv1.ListModelsResponse{}
+	if lister, ok := s.llm.(reasoning.ModelLister); ok {
+		for _, m := range lister.Models() {
+			resp.Models = append(resp.Models, m.Name)
+			resp.Details = append(resp.Details, &agentv1.ModelInfo{Name: m.Name, Provider: m.Provider})
+		}
+	}
+	return resp, nil
+}
+
+// classifications maps the Clarify agent's classifications to the proto enum.
+var classifications = map[string]agentv1.ClassifyResponse_Classification{
+	"ACTIONABLE": agentv1.ClassifyResponse_ACTIONABLE,
+	"REFERENCE":  agentv1.ClassifyResponse_REFERENCE,
+	"TRASH":      agentv1.ClassifyResponse_TRASH,
+
+	agents.ClassificationNeedsReview: agentv1.ClassifyResponse_NEEDS_REVIEW,
+}
+
 // ClassifyItem classifies an inbox item.
 func (s *FrontalLobeServer) ClassifyItem(ctx context.Context, req *agentv1.ClassifyRequest) (*agentv1.ClassifyResponse, error) {
 	result, err := s.clarifyAgent.Process(ctx, req.GetContent(), req.GetSource(), req.GetMetadata())
@@ -116,12 +204,7 @@ func (s *FrontalLobeServer) ClassifyItem(ctx context.Context, req *agentv1.Class
 		return nil, err
 	}
 
-	classMap := map[string]agentv1.ClassifyResponse_Classification{
-		"ACTIONABLE": agentv1.ClassifyResponse_ACTIONABLE,
-		"REFERENCE":  agentv1.ClassifyResponse_REFERENCE,
-		"TRASH":      agentv1.ClassifyResponse_TRASH,
-	}
-	classification := classMap[result.Classification]
+	classification := classifications[result.Classification]
 
 	return &agentv1.ClassifyResponse{
 		Classification:    classification,
@@ -130,6 +213,9 @@ func (s *FrontalLobeServer) ClassifyItem(ctx context.Context, req *agentv1.Class
 		Priority:          result.Priority,
 		ExtractedMetadata: result.ExtractedMetadata,
 		Confidence:        float32(result.Confidence),
+		ReviewReason:      result.ReviewReason,
+		ThoughtChain:      result.ThoughtChain,
+		ReviewId:          result.ReviewID,
 	}, nil
 }
 
@@ -161,37 +247,163 @@ func (s *FrontalLobeServer) GenerateWeeklyReview(ctx context.Context, req *agent
 	}, nil
 }
 
-func (s *FrontalLobeServer) buildPrompt(query string, ctx *agentv1.ContextSnapshot) string {
-	var prompt string
+// StreamWeeklyReview generates a weekly review report and streams it section
+// by section as it is produced.
+func (s *FrontalLobeServer) StreamWeeklyReview(req *agentv1.WeeklyReviewRequest, stream agentv1.ReasoningEngine_StreamWeeklyReviewServer) error {
+	startDate := time.Now().AddDate(0, 0, -7)
+	endDate := time.Now()
+
+	if req.GetStartDate() != nil {
+		startDate = req.GetStartDate().AsTime()
+	}
+	if req.GetEndDate() != nil {
+		endDate = req.GetEndDate().AsTime()
+	}
+
+	return s.reflectAgent.StreamWeeklyReview(
+		stream.Context(), startDate, endDate,
+		req.GetCompletedTasks(), req.GetActiveTasks(), req.GetBlockedTasks(),
+		func(section, markdown string) error {
+			return stream.Send(&agentv1.WeeklyReviewChunk{
+				Section:  section,
+				Markdown: markdown,
+			})
+		},
+	)
+}
+
+// GenerateMonthlyReview generates a monthly review report.
+func (s *FrontalLobeServer) GenerateMonthlyReview(ctx context.Context, req *agentv1.MonthlyReviewRequest) (*agentv1.MonthlyReviewResponse, error) {
+	startDate := time.Now().AddDate(0, 0, -28)
+	endDate := time.Now()
+
+	if req.GetStartDate() != nil {
+		startDate = req.GetStartDate().AsTime()
+	}
+	if req.GetEndDate() != nil {
+		endDate = req.GetEndDate().AsTime()
+	}
 
+	weeks := make([]agents.WeekActivity, 0, len(req.GetWeeks()))
+	for _, w := range req.GetWeeks() {
+		weeks = append(weeks, agents.WeekActivity{
+			CompletedTasks: w.GetCompletedTasks(),
+			ActiveTasks:    w.GetActiveTasks(),
+			BlockedTasks:   w.GetBlockedTasks(),
+		})
+	}
+
+	result, err := s.reflectAgent.GenerateMonthlyReview(ctx, startDate, endDate, weeks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &agentv1.MonthlyReviewResponse{
+		ReportMarkdown:       result.ReportMarkdown,
+		Momentum:             result.Momentum,
+		RecurringBlockers:    result.RecurringBlockers,
+		SuggestedNextActions: result.SuggestedNextActions,
+	}, nil
+}
+
+// promptTrim records what buildPrompt left out to fit the prompt token budget.
+type promptTrim struct {
+	SemanticChunks int // lowest-relevance chunks dropped
+	EpisodicTurns  int // oldest turns dropped
+	GraphTriples   int // triples dropped
+}
+
+func (t promptTrim) trimmed() bool {
+	return t.SemanticChunks+t.EpisodicTurns+t.GraphTriples > 0
+}
+
+// buildPrompt assembles the LLM prompt for a query. When the PromptMaxTokens
+// budget is set and the prompt exceeds it, the lowest-relevance semantic
+// chunks are dropped first, then the oldest episodic turns, then graph
+// triples, until it fits. The system prompt and the query are always kept,
+// even if they alone exceed the budget.
+func (s *FrontalLobeServer) buildPrompt(query string, ctx *agentv1.ContextSnapshot) (string, promptTrim) {
+	var system string
 	if ctx != nil && ctx.GetSystemPrompt() != "" {
-		prompt = ctx.GetSystemPrompt() + "\n\n"
+		system = s.renderSystemPrompt(ctx.GetSystemPrompt(), ctx.GetUserState())
 	} else {
-		prompt = "You are an expert cognitive assistant helping manage a Second Brain knowledge system.\n\n"
+		system = "You are an expert cognitive assistant helping manage a Second Brain knowledge system."
+	}
+
+	episodic := ctx.GetEpisodicMemory()
+	semantic := ctx.GetSemanticMemory()
+	graph := ctx.GetGraphContext()
+	prompt := assemblePrompt(system, query, episodic, semantic, graph, ctx.GetContextGated())
+
+	var trim promptTrim
+	budget := s.cfg.PromptMaxTokens
+	if budget <= 0 || estimateTokens(prompt) <= budget {
+		return prompt, trim
+	}
+
+	// Chunks are dropped by ascending relevance but keep their order.
+	byRelevance := make([]int, len(semantic))
+	for i := range byRelevance {
+		byRelevance[i] = i
+	}
+	sort.SliceStable(byRelevance, func(a, b int) bool {
+		return semantic[byRelevance[a]].GetRelevanceScore() < semantic[byRelevance[b]].GetRelevanceScore()
+	})
+	dropped := make(map[int]bool, len(semantic))
+
+	for estimateTokens(prompt) > budget {
+		switch {
+		case trim.SemanticChunks < len(semantic):
+			dropped[byRelevance[trim.SemanticChunks]] = true
+			trim.SemanticChunks++
+		case trim.EpisodicTurns < len(episodic):
+			trim.EpisodicTurns++
+		case trim.GraphTriples < len(graph):
+			trim.GraphTriples++
+		default:
+			return prompt, trim
+		}
+
+		kept := make([]*agentv1.SemanticChunk, 0, len(semantic)-len(dropped))
+		for i, chunk := range semantic {
+			if !dropped[i] {
+				kept = append(kept, chunk)
+			}
+		}
+		prompt = assemblePrompt(system, query, episodic[trim.EpisodicTurns:], kept, graph[trim.GraphTriples:], ctx.GetContextGated())
 	}
+	return prompt, trim
+}
+
+// assemblePrompt lays out the prompt sections in order.
+func assemblePrompt(system, query string, episodic []string, semantic []*agentv1.SemanticChunk, graph []*agentv1.GraphTriple, gated bool) string {
+	prompt := system + "\n\n"
 
 	// Add episodic memory
-	if ctx != nil && len(ctx.GetEpisodicMemory()) > 0 {
+	if len(episodic) > 0 {
 		prompt += "Recent conversation:\n"
-		for _, mem := range ctx.GetEpisodicMemory() {
+		for _, mem := range episodic {
 			prompt += "- " + mem + "\n"
 		}
 		prompt += "\n"
 	}
 
 	// Add semantic memory
-	if ctx != nil && len(ctx.GetSemanticMemory()) > 0 {
+	if len(semantic) > 0 {
 		prompt += "Relevant context:\n"
-		for _, chunk := range ctx.GetSemanticMemory() {
+		for _, chunk := range semantic {
 			prompt += "- " + chunk.GetContent() + "\n"
 		}
 		prompt += "\n"
 	}
+	if gated && len(semantic) == 0 {
+		prompt += "Relevant context: no strong context found in the knowledge base; answer from general knowledge and say so.\n\n"
+	}
 
 	// Add graph context
-	if ctx != nil && len(ctx.GetGraphContext()) > 0 {
+	if len(graph) > 0 {
 		prompt += "Knowledge graph context:\n"
-		for _, triple := range ctx.GetGraphContext() {
+		for _, triple := range graph {
 			prompt += "- " + triple.GetSubject() + " → " + triple.GetPredicate() + " → " + triple.GetObject() + "\n"
 		}
 		prompt += "\n"
@@ -202,6 +414,55 @@ func (s *FrontalLobeServer) buildPrompt(query string, ctx *agentv1.ContextSnapsh
 	return prompt
 }
 
+// estimateTokens approximates the number of model tokens in text, using the
+// common rule of thumb of four characters per token.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// promptTemplateVars are the system prompt variables always available to
+// templates, even when the snapshot does not set them.
+var promptTemplateVars = []string{"user", "date", "area"}
+
+// renderSystemPrompt expands a system prompt written as a text/template, such
+// as "You are assisting {{user}} in area {{area}}.", using the snapshot's
+// user state as variables; "date" defaults to today. Variables can be
+// written {{name}} or {{.name}}, and missing ones expand to nothing. Prompts
+// without template actions are returned as is, and a template that fails to
+// parse or execute is used verbatim.
+func (s *FrontalLobeServer) renderSystemPrompt(systemPrompt string, userState map[string]string) string {
+	if !strings.Contains(systemPrompt, "{{") {
+		return systemPrompt
+	}
+
+	vars := map[string]string{"date": time.Now().Format(time.DateOnly)}
+	for k, v := range userState {
+		vars[k] = v
+	}
+
+	funcs := template.FuncMap{}
+	for _, name := range promptTemplateVars {
+		funcs[name] = func() string { return vars[name] }
+	}
+	for name, v := range vars {
+		if token.IsIdentifier(name) {
+			funcs[name] = func() string { return v }
+		}
+	}
+
+	tmpl, err := template.New("system_prompt").Funcs(funcs).Option("missingkey=zero").Parse(systemPrompt)
+	if err != nil {
+		s.logger.Warn("invalid system prompt template, using it verbatim", "error", err)
+		return systemPrompt
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		s.logger.Warn("failed to expand system prompt template, using it verbatim", "error", err)
+		return systemPrompt
+	}
+	return b.String()
+}
+
 // --- Stream output helpers ---
 
 // sendStatus sends a progress status update to the client stream.
@@ -2,14 +2,21 @@ package server
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/config"
 	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/reasoning"
 	agentv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/agent/v1"
 	commonv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/common/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func newTestServer() *FrontalLobeServer {
@@ -50,6 +57,31 @@ func TestClassifyItemActionable(t *testing.T) {
 	}
 }
 
+func TestClassifyItemSurfacesThoughtChain(t *testing.T) {
+	s := newTestServer()
+
+	resp, err := s.ClassifyItem(context.Background(), &agentv1.ClassifyRequest{
+		Content: "Urgent deadline for project delivery",
+		Source:  "email",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chain := resp.GetThoughtChain()
+	// Each state-machine step reports itself, in order: classify, extract, route.
+	want := []string{"Analyzing content", "Classified as ACTIONABLE", "Extracting structured metadata", "Routing to area"}
+	next := 0
+	for _, step := range chain {
+		if next < len(want) && strings.HasPrefix(step, want[next]) {
+			next++
+		}
+	}
+	if next != len(want) {
+		t.Errorf("expected steps starting with %q in order, got %q", want, chain)
+	}
+}
+
 func TestClassifyItemTrash(t *testing.T) {
 	s := newTestServer()
 
@@ -91,3 +123,386 @@ func TestGenerateWeeklyReview(t *testing.T) {
 		t.Error("expected suggested next actions")
 	}
 }
+
+// confidenceLLM classifies everything as ACTIONABLE with a fixed confidence.
+type confidenceLLM struct {
+	*reasoning.MockLLM
+	confidence float64
+}
+
+func (c *confidenceLLM) Classify(ctx context.Context, content string, categories []string) (string, float64, error) {
+	return "ACTIONABLE", c.confidence, nil
+}
+
+func TestClassifyItemReviewThreshold(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	cfg := &config.Config{LLMProvider: "mock", ReviewThreshold: 0.6}
+
+	tests := []struct {
+		name       string
+		confidence float64
+		want       agentv1.ClassifyResponse_Classification
+	}{
+		{"low confidence needs review", 0.4, agentv1.ClassifyResponse_NEEDS_REVIEW},
+		{"high confidence proceeds", 0.9, agentv1.ClassifyResponse_ACTIONABLE},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewFrontalLobeServer(logger, cfg, &confidenceLLM{MockLLM: reasoning.NewMockLLM(), confidence: tc.confidence})
+
+			resp, err := s.ClassifyItem(context.Background(), &agentv1.ClassifyRequest{
+				Content: "Pay the bank by Friday",
+				Source:  "email",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Classification != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, resp.Classification)
+			}
+
+			if tc.want == agentv1.ClassifyResponse_NEEDS_REVIEW {
+				if resp.ReviewReason == "" {
+					t.Error("expected a review reason")
+				}
+				if resp.SuggestedArea != "" {
+					t.Errorf("expected item not to be routed, got area %q", resp.SuggestedArea)
+				}
+			} else {
+				if resp.ReviewReason != "" {
+					t.Errorf("expected no review reason, got %q", resp.ReviewReason)
+				}
+				if resp.SuggestedArea == "" {
+					t.Error("expected item to be routed")
+				}
+			}
+		})
+	}
+}
+
+func TestPendingReviewsListAndResolve(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	cfg := &config.Config{LLMProvider: "mock", ReviewThreshold: 0.6, ReviewQueueSize: 10}
+	s := NewFrontalLobeServer(logger, cfg, &confidenceLLM{MockLLM: reasoning.NewMockLLM(), confidence: 0.4})
+	ctx := context.Background()
+
+	resp, err := s.ClassifyItem(ctx, &agentv1.ClassifyRequest{Content: "Pay the bank by Friday", Source: "email"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ReviewId == "" {
+		t.Fatal("expected the item to be queued for review")
+	}
+
+	list, err := s.ListPendingReviews(ctx, &agentv1.ListPendingReviewsRequest{})
+	if err != nil {
+		t.Fatalf("ListPendingReviews: %v", err)
+	}
+	if len(list.Reviews) != 1 {
+		t.Fatalf("expected 1 pending review, got %d", len(list.Reviews))
+	}
+	pending := list.Reviews[0]
+	if pending.ReviewId != resp.ReviewId || pending.Content != "Pay the bank by Friday" ||
+		pending.SuggestedClassification != agentv1.ClassifyResponse_ACTIONABLE || pending.ReviewReason == "" {
+		t.Errorf("unexpected pending review %v", pending)
+	}
+
+	if _, err := s.ResolveReview(ctx, &agentv1.ResolveReviewRequest{
+		ReviewId:       resp.ReviewId,
+		Classification: agentv1.ClassifyResponse_NEEDS_REVIEW,
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument resolving as NEEDS_REVIEW, got %v", err)
+	}
+	resolved, err := s.ResolveReview(ctx, &agentv1.ResolveReviewRequest{
+		ReviewId:       resp.ReviewId,
+		Classification: agentv1.ClassifyResponse_REFERENCE,
+	})
+	if err != nil {
+		t.Fatalf("ResolveReview: %v", err)
+	}
+	if resolved.Classification != agentv1.ClassifyResponse_REFERENCE || resolved.Review.GetContent() != "Pay the bank by Friday" {
+		t.Errorf("unexpected resolution %v", resolved)
+	}
+	if _, err := s.ResolveReview(ctx, &agentv1.ResolveReviewRequest{
+		ReviewId:       resp.ReviewId,
+		Classification: agentv1.ClassifyResponse_REFERENCE,
+	}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound resolving twice, got %v", err)
+	}
+	list, _ = s.ListPendingReviews(ctx, &agentv1.ListPendingReviewsRequest{})
+	if len(list.Reviews) != 0 {
+		t.Errorf("expected an empty queue, got %d", len(list.Reviews))
+	}
+}
+
+// thoughtStream implements the server side of StreamThoughtProcess, feeding
+// scripted inputs and collecting outputs.
+type thoughtStream struct {
+	grpc.ServerStream
+	inputs  []*agentv1.AgentInput
+	outputs []*agentv1.AgentOutput
+}
+
+func (f *thoughtStream) Context() context.Context { return context.Background() }
+
+func (f *thoughtStream) Recv() (*agentv1.AgentInput, error) {
+	if len(f.inputs) == 0 {
+		return nil, io.EOF
+	}
+	in := f.inputs[0]
+	f.inputs = f.inputs[1:]
+	return in, nil
+}
+
+func (f *thoughtStream) Send(out *agentv1.AgentOutput) error {
+	f.outputs = append(f.outputs, out)
+	return nil
+}
+
+// promptThought returns the debug prompt thought among outputs, or "".
+func promptThought(outputs []*agentv1.AgentOutput) string {
+	for _, out := range outputs {
+		if thought := out.GetThoughtChain(); strings.HasPrefix(thought, "Assembled prompt:") {
+			return thought
+		}
+	}
+	return ""
+}
+
+func TestDebugPromptIncludesSemanticMemory(t *testing.T) {
+	s := newTestServer()
+	llm := reasoning.NewMockLLM()
+	var sentPrompt string
+	llm.SetResponder(func(prompt string) string {
+		sentPrompt = prompt
+		return "answer"
+	})
+	s.llm = llm
+
+	input := func(debug bool) *agentv1.AgentInput {
+		return &agentv1.AgentInput{
+			SessionId: "sess-debug",
+			InputType: &agentv1.AgentInput_UserQuery{UserQuery: "When is the launch?"},
+			Context: &agentv1.ContextSnapshot{
+				SemanticMemory: []*agentv1.SemanticChunk{{ChunkId: "c1", Content: "The launch moved to March 14."}},
+				DebugPrompt:    debug,
+			},
+		}
+	}
+
+	stream := &thoughtStream{inputs: []*agentv1.AgentInput{input(true)}}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	thought := promptThought(stream.outputs)
+	if !strings.Contains(thought, "The launch moved to March 14.") {
+		t.Errorf("expected the debug prompt to contain the injected memory, got %q", thought)
+	}
+	if !strings.Contains(thought, sentPrompt) {
+		t.Errorf("expected the debug output to match the prompt sent to the LLM")
+	}
+
+	stream = &thoughtStream{inputs: []*agentv1.AgentInput{input(false)}}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if thought := promptThought(stream.outputs); thought != "" {
+		t.Errorf("expected no debug prompt without the flag, got %q", thought)
+	}
+
+	// The config flag enables it for every request.
+	s.cfg.DebugPrompts = true
+	stream = &thoughtStream{inputs: []*agentv1.AgentInput{input(false)}}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if thought := promptThought(stream.outputs); !strings.Contains(thought, "The launch moved to March 14.") {
+		t.Errorf("expected DebugPrompts to emit the prompt, got %q", thought)
+	}
+}
+
+// stopLLM records the stop sequences it is asked to honor.
+type stopLLM struct {
+	*reasoning.MockLLM
+	stop []string
+}
+
+func (l *stopLLM) GenerateWithStop(ctx context.Context, prompt string, stop []string) (string, error) {
+	l.stop = stop
+	return "answer", nil
+}
+
+func TestStopSequencesReachProvider(t *testing.T) {
+	s := newTestServer()
+	llm := &stopLLM{MockLLM: reasoning.NewMockLLM()}
+	s.llm = llm
+
+	stream := &thoughtStream{inputs: []*agentv1.AgentInput{{
+		SessionId: "sess-stop",
+		InputType: &agentv1.AgentInput_UserQuery{UserQuery: "List three fruits"},
+		Context:   &agentv1.ContextSnapshot{StopSequences: []string{"\n\n", "END"}},
+	}}}
+	if err := s.StreamThoughtProcess(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(llm.stop, "|") != "\n\n|END" {
+		t.Errorf("expected the request's stop sequences to reach the provider, got %q", llm.stop)
+	}
+}
+
+func TestBuildPromptNotesGatedContext(t *testing.T) {
+	s := newTestServer()
+
+	prompt, _ := s.buildPrompt("what did I decide?", &agentv1.ContextSnapshot{ContextGated: true})
+	if !strings.Contains(prompt, "no strong context found") {
+		t.Errorf("expected the prompt to note missing context, got:\n%s", prompt)
+	}
+
+	prompt, _ = s.buildPrompt("what did I decide?", &agentv1.ContextSnapshot{})
+	if strings.Contains(prompt, "no strong context found") {
+		t.Errorf("expected no note without gating, got:\n%s", prompt)
+	}
+}
+
+func TestRenderSystemPromptSubstitutesVariables(t *testing.T) {
+	s := newTestServer()
+	state := map[string]string{"user": "Ada", "area": "Research", "date": "2026-10-15"}
+
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"You are assisting {{user}} in area {{area}}.", "You are assisting Ada in area Research."},
+		{"Today is {{.date}} for {{.user}}.", "Today is 2026-10-15 for Ada."},
+		{"Focus on {{area}}{{if .project}} and {{.project}}{{end}}.", "Focus on Research."},
+		{"Plain prompt without variables.", "Plain prompt without variables."},
+	}
+	for _, tt := range tests {
+		if got := s.renderSystemPrompt(tt.prompt, state); got != tt.want {
+			t.Errorf("%q: expected %q, got %q", tt.prompt, tt.want, got)
+		}
+	}
+
+	// Missing variables expand to nothing, and date defaults to today.
+	got := s.renderSystemPrompt("Hi {{user}}, it is {{date}}.", nil)
+	if want := "Hi , it is " + time.Now().Format(time.DateOnly) + "."; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestRenderSystemPromptMalformedTemplate(t *testing.T) {
+	s := newTestServer()
+
+	for _, prompt := range []string{
+		"You are assisting {{user",            // unterminated action
+		"You are assisting {{nosuchvar}}.",    // unknown function
+		"Call {{index .user 5}} on a string.", // fails at execution
+	} {
+		if got := s.renderSystemPrompt(prompt, map[string]string{"user": "Ada"}); got != prompt {
+			t.Errorf("%q: expected the prompt verbatim, got %q", prompt, got)
+		}
+	}
+
+	prompt, _ := s.buildPrompt("hello", &agentv1.ContextSnapshot{SystemPrompt: "Assist {{user"})
+	if !strings.HasPrefix(prompt, "Assist {{user\n\n") {
+		t.Errorf("expected buildPrompt to keep a malformed template verbatim, got:\n%s", prompt)
+	}
+}
+
+func TestListModelsReportsRouterModels(t *testing.T) {
+	router := reasoning.NewRouter(reasoning.NewMockLLM())
+	router.Register("gpt-4o", reasoning.NewOpenAIProvider("key", "", "gpt-4o", time.Second))
+	router.Register("gemini-pro", reasoning.NewGoogleProvider("key", "gemini-pro", time.Second))
+	router.Register("echo", reasoning.NewMockLLM())
+	s := NewFrontalLobeServer(newTestServer().logger, &config.Config{LLMProvider: "mock"}, router)
+
+	resp, err := s.ListModels(context.Background(), &agentv1.ListModelsRequest{})
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	if got := strings.Join(resp.GetModels(), ","); got != "echo,gemini-pro,gpt-4o" {
+		t.Errorf("expected every registered model sorted, got %q", got)
+	}
+	want := map[string]string{"echo": "mock", "gemini-pro": "google", "gpt-4o": "openai"}
+	if len(resp.GetDetails()) != len(want) {
+		t.Fatalf("expected details for %d models, got %v", len(want), resp.GetDetails())
+	}
+	for i, d := range resp.GetDetails() {
+		if d.GetName() != resp.GetModels()[i] || d.GetProvider() != want[d.GetName()] {
+			t.Errorf("unexpected details for model %d: %v", i, d)
+		}
+	}
+
+	// A single provider routes no named models.
+	resp, _ = newTestServer().ListModels(context.Background(), &agentv1.ListModelsRequest{})
+	if len(resp.GetModels()) != 0 || len(resp.GetDetails()) != 0 {
+		t.Errorf("expected no models without a router, got %v", resp)
+	}
+}
+
+func TestBuildPromptTrimsContextToBudget(t *testing.T) {
+	s := newTestServer()
+	s.cfg.PromptMaxTokens = 200
+	filler := strings.Repeat("x", 200)
+
+	snapshot := &agentv1.ContextSnapshot{
+		SystemPrompt:   "You are a terse assistant.",
+		EpisodicMemory: []string{"oldest turn " + filler, "middle turn " + filler, "latest turn"},
+		SemanticMemory: []*agentv1.SemanticChunk{
+			{Content: "weak chunk " + filler, RelevanceScore: 0.2},
+			{Content: "strong chunk", RelevanceScore: 0.9},
+			{Content: "medium chunk " + filler, RelevanceScore: 0.5},
+		},
+	}
+	prompt, trim := s.buildPrompt("what did I decide?", snapshot)
+
+	if got := estimateTokens(prompt); got > 200 {
+		t.Errorf("expected the prompt within 200 tokens, got %d:\n%s", got, prompt)
+	}
+	if !trim.trimmed() {
+		t.Error("expected the trim to be recorded")
+	}
+	if strings.Contains(prompt, "weak chunk") {
+		t.Error("expected the lowest-relevance chunk to be dropped")
+	}
+	if !strings.Contains(prompt, "strong chunk") {
+		t.Error("expected the highest-relevance chunk to be kept")
+	}
+	if strings.Contains(prompt, "oldest turn") && !strings.Contains(prompt, "medium chunk") {
+		t.Error("expected chunks to be trimmed before the oldest turn")
+	}
+	if !strings.Contains(prompt, "latest turn") {
+		t.Error("expected the latest turn to be kept")
+	}
+	if !strings.HasPrefix(prompt, "You are a terse assistant.") || !strings.HasSuffix(prompt, "User query: what did I decide?") {
+		t.Errorf("expected the system prompt and query to be kept, got:\n%s", prompt)
+	}
+
+	// Without a budget nothing is trimmed.
+	s.cfg.PromptMaxTokens = 0
+	prompt, trim = s.buildPrompt("what did I decide?", snapshot)
+	if trim.trimmed() || !strings.Contains(prompt, "weak chunk") || !strings.Contains(prompt, "oldest turn") {
+		t.Errorf("expected the full context without a budget, got %+v", trim)
+	}
+}
+
+func TestBuildPromptNeverDropsQuery(t *testing.T) {
+	s := newTestServer()
+	s.cfg.PromptMaxTokens = 5
+	query := "summarize " + strings.Repeat("everything ", 50)
+
+	prompt, trim := s.buildPrompt(query, &agentv1.ContextSnapshot{
+		EpisodicMemory: []string{"earlier turn"},
+		SemanticMemory: []*agentv1.SemanticChunk{{Content: "a chunk", RelevanceScore: 0.7}},
+		GraphContext:   []*agentv1.GraphTriple{{Subject: "PhaseNet", Predicate: "detects", Object: "earthquakes"}},
+	})
+	if !strings.HasSuffix(prompt, "User query: "+query) {
+		t.Errorf("expected the query to be kept, got:\n%s", prompt)
+	}
+	if trim.SemanticChunks != 1 || trim.EpisodicTurns != 1 || trim.GraphTriples != 1 {
+		t.Errorf("expected all context dropped, got %+v", trim)
+	}
+	if strings.Contains(prompt, "a chunk") || strings.Contains(prompt, "earlier turn") || strings.Contains(prompt, "PhaseNet") {
+		t.Errorf("expected only the system prompt and query, got:\n%s", prompt)
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/common/v1"
+)
+
+// dependencyLLM is the name the LLM provider is reported under in health
+// checks when the probe is enabled.
+const dependencyLLM = "llm"
+
+// dependencyUnreachable is reported for an LLM provider whose probe failed.
+const dependencyUnreachable = "UNREACHABLE"
+
+// llmProbePrompt is the prompt sent to probe the LLM provider; it is kept
+// tiny so that probes cost next to nothing.
+const llmProbePrompt = "Reply with OK."
+
+// defaultLLMProbeTimeout bounds a probe when no timeout is configured.
+const defaultLLMProbeTimeout = 5 * time.Second
+
+// llmProbe remembers the outcome of the last LLM probe so that frequent
+// health checks do not each call the provider.
+type llmProbe struct {
+	mu        sync.Mutex // held during a probe so that concurrent checks share it
+	checkedAt time.Time
+	err       error
+	now       func() time.Time
+}
+
+// checkLLM returns the result of the last probe, probing the LLM again when
+// that result is older than cfg.LLMProbeInterval.
+func (s *FrontalLobeServer) checkLLM(ctx context.Context) error {
+	p := &s.llmProbe
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if !p.checkedAt.IsZero() && now.Sub(p.checkedAt) < s.cfg.LLMProbeInterval {
+		return p.err
+	}
+
+	timeout := s.cfg.LLMProbeTimeout
+	if timeout <= 0 {
+		timeout = defaultLLMProbeTimeout
+	}
+	// The caller giving up must not be cached as an unhealthy provider.
+	probeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	_, p.err = s.llm.Generate(probeCtx, llmProbePrompt)
+	p.checkedAt = now
+	if p.err != nil {
+		s.logger.Warn("LLM provider probe failed", "error", p.err)
+	}
+	return p.err
+}
+
+// llmStatus probes the LLM when cfg.LLMProbe is set and reports its status,
+// or nil when the probe is disabled.
+func (s *FrontalLobeServer) llmStatus(ctx context.Context) map[string]string {
+	if !s.cfg.LLMProbe {
+		return nil
+	}
+	if err := s.checkLLM(ctx); err != nil {
+		return map[string]string{dependencyLLM: dependencyUnreachable}
+	}
+	return map[string]string{dependencyLLM: commonv1.HealthCheckResponse_SERVING.String()}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/config"
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/reasoning"
+	commonv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/common/v1"
+)
+
+// probedLLM counts Generate calls and fails them while err is set.
+type probedLLM struct {
+	*reasoning.MockLLM
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (p *probedLLM) Generate(ctx context.Context, prompt string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.err != nil {
+		return "", p.err
+	}
+	return "OK", nil
+}
+
+func newProbedServer(llm *probedLLM) *FrontalLobeServer {
+	llm.MockLLM = reasoning.NewMockLLM()
+	cfg := &config.Config{LLMProvider: "openai", LLMProbe: true, LLMProbeInterval: time.Minute}
+	return NewFrontalLobeServer(newTestServer().logger, cfg, llm)
+}
+
+func TestCheckReportsFailingLLMNotServing(t *testing.T) {
+	s := newProbedServer(&probedLLM{err: errors.New("401 invalid API key")})
+
+	resp, err := s.Check(context.Background(), &commonv1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if resp.GetStatus() != commonv1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected NOT_SERVING with a failing LLM, got %v", resp.GetStatus())
+	}
+	if got := resp.GetDependencies()[dependencyLLM]; got != dependencyUnreachable {
+		t.Errorf("expected the LLM reported unreachable, got %q", got)
+	}
+}
+
+func TestCheckReportsWorkingLLMServing(t *testing.T) {
+	s := newProbedServer(&probedLLM{})
+
+	resp, _ := s.Check(context.Background(), &commonv1.HealthCheckRequest{})
+	if resp.GetStatus() != commonv1.HealthCheckResponse_SERVING {
+		t.Errorf("expected SERVING with a working LLM, got %v", resp.GetStatus())
+	}
+	if got := resp.GetDependencies()[dependencyLLM]; got != "SERVING" {
+		t.Errorf("expected the LLM reported serving, got %q", got)
+	}
+}
+
+func TestCheckCachesLLMProbe(t *testing.T) {
+	llm := &probedLLM{}
+	s := newProbedServer(llm)
+	now := time.Unix(1700000000, 0)
+	s.llmProbe.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	s.Check(ctx, &commonv1.HealthCheckRequest{})
+	llm.err = errors.New("unreachable")
+	resp, _ := s.Check(ctx, &commonv1.HealthCheckRequest{})
+	if llm.calls != 1 || resp.GetStatus() != commonv1.HealthCheckResponse_SERVING {
+		t.Errorf("expected the cached result within the interval, got %v after %d probes", resp.GetStatus(), llm.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	resp, _ = s.Check(ctx, &commonv1.HealthCheckRequest{})
+	if llm.calls != 2 || resp.GetStatus() != commonv1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected a fresh probe after the interval, got %v after %d probes", resp.GetStatus(), llm.calls)
+	}
+}
+
+func TestCheckSkipsLLMProbeByDefault(t *testing.T) {
+	llm := &probedLLM{MockLLM: reasoning.NewMockLLM(), err: errors.New("unreachable")}
+	s := NewFrontalLobeServer(newTestServer().logger, &config.Config{LLMProvider: "openai"}, llm)
+
+	resp, _ := s.Check(context.Background(), &commonv1.HealthCheckRequest{})
+	if resp.GetStatus() != commonv1.HealthCheckResponse_SERVING || llm.calls != 0 {
+		t.Errorf("expected no probe unless enabled, got %v after %d probes", resp.GetStatus(), llm.calls)
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ziyixi/SecondBrain/services/frontal_lobe/internal/agents"
+	agentv1 "github.com/ziyixi/SecondBrain/services/frontal_lobe/pkg/gen/agent/v1"
+)
+
+// ListPendingReviews lists the items ClassifyItem held for manual
+// confirmation, oldest first. It returns none when the queue is disabled.
+func (s *FrontalLobeServer) ListPendingReviews(ctx context.Context, req *agentv1.ListPendingReviewsRequest) (*agentv1.ListPendingReviewsResponse, error) {
+	resp := &agentv1.ListPendingReviewsResponse{}
+	if s.reviews == nil {
+		return resp, nil
+	}
+	for _, item := range s.reviews.List() {
+		resp.Reviews = append(resp.Reviews, pendingReviewProto(item))
+	}
+	return resp, nil
+}
+
+// ResolveReview confirms the classification of a queued item and removes it
+// from the queue, returning the item so the caller can route it.
+func (s *FrontalLobeServer) ResolveReview(ctx context.Context, req *agentv1.ResolveReviewRequest) (*agentv1.ResolveReviewResponse, error) {
+	if req.GetClassification() == agentv1.ClassifyResponse_NEEDS_REVIEW {
+		return nil, status.Error(codes.InvalidArgument, "classification must be ACTIONABLE, REFERENCE or TRASH")
+	}
+	if s.reviews == nil {
+		return nil, status.Error(codes.NotFound, "review queue is disabled")
+	}
+	item, ok := s.reviews.Resolve(req.GetReviewId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no pending review %q", req.GetReviewId())
+	}
+	s.logger.InfoContext(ctx, "review resolved",
+		"review_id", item.ID,
+		"suggested", item.SuggestedClassification,
+		"confirmed", req.GetClassification().String(),
+	)
+	return &agentv1.ResolveReviewResponse{
+		Review:         pendingReviewProto(item),
+		Classification: req.GetClassification(),
+	}, nil
+}
+
+func pendingReviewProto(item agents.PendingReview) *agentv1.PendingReview {
+	return &agentv1.PendingReview{
+		ReviewId:                item.ID,
+		Content:                 item.Content,
+		Source:                  item.Source,
+		Metadata:                item.Metadata,
+		SuggestedClassification: classifications[item.SuggestedClassification],
+		Confidence:              float32(item.Confidence),
+		ReviewReason:            item.Reason,
+		QueuedAt:                timestamppb.New(item.QueuedAt),
+	}
+}
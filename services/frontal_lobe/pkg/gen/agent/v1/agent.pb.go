@@ -78,6 +78,9 @@ const (
 	ClassifyResponse_ACTIONABLE ClassifyResponse_Classification = 0
 	ClassifyResponse_REFERENCE  ClassifyResponse_Classification = 1
 	ClassifyResponse_TRASH      ClassifyResponse_Classification = 2
+	// Confidence was below the review threshold; the item awaits manual
+	// confirmation instead of being routed.
+	ClassifyResponse_NEEDS_REVIEW ClassifyResponse_Classification = 3
 )
 
 // Enum value maps for ClassifyResponse_Classification.
@@ -86,11 +89,13 @@ var (
 		0: "ACTIONABLE",
 		1: "REFERENCE",
 		2: "TRASH",
+		3: "NEEDS_REVIEW",
 	}
 	ClassifyResponse_Classification_value = map[string]int32{
-		"ACTIONABLE": 0,
-		"REFERENCE":  1,
-		"TRASH":      2,
+		"ACTIONABLE":   0,
+		"REFERENCE":    1,
+		"TRASH":        2,
+		"NEEDS_REVIEW": 3,
 	}
 )
 
@@ -245,7 +250,10 @@ type AgentOutput struct {
 	//	*AgentOutput_ToolCall
 	//	*AgentOutput_FinalResponse
 	//	*AgentOutput_Status
-	OutputType    isAgentOutput_OutputType `protobuf_oneof:"output_type"`
+	OutputType isAgentOutput_OutputType `protobuf_oneof:"output_type"`
+	// Identifies the interaction (query/answer turn) this output belongs to.
+	// Clients echo it back in FeedbackSignal.interaction_id.
+	InteractionId string `protobuf:"bytes,7,opt,name=interaction_id,json=interactionId,proto3" json:"interaction_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -337,6 +345,13 @@ func (x *AgentOutput) GetStatus() *StatusUpdate {
 	return nil
 }
 
+func (x *AgentOutput) GetInteractionId() string {
+	if x != nil {
+		return x.InteractionId
+	}
+	return ""
+}
+
 type isAgentOutput_OutputType interface {
 	isAgentOutput_OutputType()
 }
@@ -497,8 +512,11 @@ type FeedbackSignal struct {
 	state          protoimpl.MessageState   `protogen:"open.v1"`
 	Sentiment      FeedbackSignal_Sentiment `protobuf:"varint,1,opt,name=sentiment,proto3,enum=cognitive_os.agent.v1.FeedbackSignal_Sentiment" json:"sentiment,omitempty"`
 	CorrectionText string                   `protobuf:"bytes,2,opt,name=correction_text,json=correctionText,proto3" json:"correction_text,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// The interaction the feedback refers to, as reported in
+	// AgentOutput.interaction_id. Empty means session-level feedback.
+	InteractionId string `protobuf:"bytes,3,opt,name=interaction_id,json=interactionId,proto3" json:"interaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FeedbackSignal) Reset() {
@@ -545,15 +563,39 @@ func (x *FeedbackSignal) GetCorrectionText() string {
 	return ""
 }
 
+func (x *FeedbackSignal) GetInteractionId() string {
+	if x != nil {
+		return x.InteractionId
+	}
+	return ""
+}
+
 type ContextSnapshot struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	EpisodicMemory []string               `protobuf:"bytes,1,rep,name=episodic_memory,json=episodicMemory,proto3" json:"episodic_memory,omitempty"`
 	SemanticMemory []*SemanticChunk       `protobuf:"bytes,2,rep,name=semantic_memory,json=semanticMemory,proto3" json:"semantic_memory,omitempty"`
 	GraphContext   []*GraphTriple         `protobuf:"bytes,3,rep,name=graph_context,json=graphContext,proto3" json:"graph_context,omitempty"`
 	UserState      map[string]string      `protobuf:"bytes,4,rep,name=user_state,json=userState,proto3" json:"user_state,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	SystemPrompt   string                 `protobuf:"bytes,5,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// May be a Go text/template such as "You are assisting {{user}} in area
+	// {{area}}."; the Frontal Lobe expands it with user_state as variables,
+	// where "date" defaults to today.
+	SystemPrompt string `protobuf:"bytes,5,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	// Optional override for how many memory chunks to retrieve for this
+	// request. Zero uses the orchestrator's configured default.
+	RetrievalTopK int32 `protobuf:"varint,6,opt,name=retrieval_top_k,json=retrievalTopK,proto3" json:"retrieval_top_k,omitempty"`
+	// When set, the Frontal Lobe emits the assembled LLM prompt as a
+	// thought_chain output before answering, for debugging retrieval.
+	DebugPrompt bool `protobuf:"varint,7,opt,name=debug_prompt,json=debugPrompt,proto3" json:"debug_prompt,omitempty"`
+	// Set by the orchestrator when memory search found chunks but none cleared
+	// its relevance floor; the prompt then notes that no strong context was
+	// found instead of injecting weak matches.
+	ContextGated bool `protobuf:"varint,8,opt,name=context_gated,json=contextGated,proto3" json:"context_gated,omitempty"`
+	// Sequences at which generation stops, from an OpenAI-compatible request's
+	// stop field. Providers that support them stop at the first one; Cortex
+	// also cuts the response there for providers that don't.
+	StopSequences []string `protobuf:"bytes,9,rep,name=stop_sequences,json=stopSequences,proto3" json:"stop_sequences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ContextSnapshot) Reset() {
@@ -621,6 +663,34 @@ func (x *ContextSnapshot) GetSystemPrompt() string {
 	return ""
 }
 
+func (x *ContextSnapshot) GetRetrievalTopK() int32 {
+	if x != nil {
+		return x.RetrievalTopK
+	}
+	return 0
+}
+
+func (x *ContextSnapshot) GetDebugPrompt() bool {
+	if x != nil {
+		return x.DebugPrompt
+	}
+	return false
+}
+
+func (x *ContextSnapshot) GetContextGated() bool {
+	if x != nil {
+		return x.ContextGated
+	}
+	return false
+}
+
+func (x *ContextSnapshot) GetStopSequences() []string {
+	if x != nil {
+		return x.StopSequences
+	}
+	return nil
+}
+
 type SemanticChunk struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ChunkId        string                 `protobuf:"bytes,1,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
@@ -869,8 +939,16 @@ type ClassifyResponse struct {
 	Priority          string                          `protobuf:"bytes,4,opt,name=priority,proto3" json:"priority,omitempty"`
 	ExtractedMetadata map[string]string               `protobuf:"bytes,5,rep,name=extracted_metadata,json=extractedMetadata,proto3" json:"extracted_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Confidence        float32                         `protobuf:"fixed32,6,opt,name=confidence,proto3" json:"confidence,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// Why the item needs review; set only for NEEDS_REVIEW.
+	ReviewReason string `protobuf:"bytes,7,opt,name=review_reason,json=reviewReason,proto3" json:"review_reason,omitempty"`
+	// The Clarify agent's reasoning steps (classify, extract, route, ...) in
+	// the order they ran, explaining how the item was routed.
+	ThoughtChain []string `protobuf:"bytes,8,rep,name=thought_chain,json=thoughtChain,proto3" json:"thought_chain,omitempty"`
+	// ID of the item in the review queue; set only for NEEDS_REVIEW when the
+	// queue is enabled. Pass it to ResolveReview to confirm a classification.
+	ReviewId      string `protobuf:"bytes,9,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ClassifyResponse) Reset() {
@@ -945,32 +1023,57 @@ func (x *ClassifyResponse) GetConfidence() float32 {
 	return 0
 }
 
-type WeeklyReviewRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	StartDate      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	CompletedTasks []string               `protobuf:"bytes,4,rep,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
-	ActiveTasks    []string               `protobuf:"bytes,5,rep,name=active_tasks,json=activeTasks,proto3" json:"active_tasks,omitempty"`
-	BlockedTasks   []string               `protobuf:"bytes,6,rep,name=blocked_tasks,json=blockedTasks,proto3" json:"blocked_tasks,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *ClassifyResponse) GetReviewReason() string {
+	if x != nil {
+		return x.ReviewReason
+	}
+	return ""
 }
 
-func (x *WeeklyReviewRequest) Reset() {
-	*x = WeeklyReviewRequest{}
+func (x *ClassifyResponse) GetThoughtChain() []string {
+	if x != nil {
+		return x.ThoughtChain
+	}
+	return nil
+}
+
+func (x *ClassifyResponse) GetReviewId() string {
+	if x != nil {
+		return x.ReviewId
+	}
+	return ""
+}
+
+// An item held for manual confirmation of its classification.
+type PendingReview struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId string                 `protobuf:"bytes,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	Content  string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Source   string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Metadata map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The classification the Clarify agent was not confident enough to act on.
+	SuggestedClassification ClassifyResponse_Classification `protobuf:"varint,5,opt,name=suggested_classification,json=suggestedClassification,proto3,enum=cognitive_os.agent.v1.ClassifyResponse_Classification" json:"suggested_classification,omitempty"`
+	Confidence              float32                         `protobuf:"fixed32,6,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	ReviewReason            string                          `protobuf:"bytes,7,opt,name=review_reason,json=reviewReason,proto3" json:"review_reason,omitempty"`
+	QueuedAt                *timestamppb.Timestamp          `protobuf:"bytes,8,opt,name=queued_at,json=queuedAt,proto3" json:"queued_at,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *PendingReview) Reset() {
+	*x = PendingReview{}
 	mi := &file_agent_v1_agent_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WeeklyReviewRequest) String() string {
+func (x *PendingReview) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WeeklyReviewRequest) ProtoMessage() {}
+func (*PendingReview) ProtoMessage() {}
 
-func (x *WeeklyReviewRequest) ProtoReflect() protoreflect.Message {
+func (x *PendingReview) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_v1_agent_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -982,77 +1085,87 @@ func (x *WeeklyReviewRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WeeklyReviewRequest.ProtoReflect.Descriptor instead.
-func (*WeeklyReviewRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PendingReview.ProtoReflect.Descriptor instead.
+func (*PendingReview) Descriptor() ([]byte, []int) {
 	return file_agent_v1_agent_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *WeeklyReviewRequest) GetUserId() string {
+func (x *PendingReview) GetReviewId() string {
 	if x != nil {
-		return x.UserId
+		return x.ReviewId
 	}
 	return ""
 }
 
-func (x *WeeklyReviewRequest) GetStartDate() *timestamppb.Timestamp {
+func (x *PendingReview) GetContent() string {
 	if x != nil {
-		return x.StartDate
+		return x.Content
 	}
-	return nil
+	return ""
 }
 
-func (x *WeeklyReviewRequest) GetEndDate() *timestamppb.Timestamp {
+func (x *PendingReview) GetSource() string {
 	if x != nil {
-		return x.EndDate
+		return x.Source
 	}
-	return nil
+	return ""
 }
 
-func (x *WeeklyReviewRequest) GetCompletedTasks() []string {
+func (x *PendingReview) GetMetadata() map[string]string {
 	if x != nil {
-		return x.CompletedTasks
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *WeeklyReviewRequest) GetActiveTasks() []string {
+func (x *PendingReview) GetSuggestedClassification() ClassifyResponse_Classification {
 	if x != nil {
-		return x.ActiveTasks
+		return x.SuggestedClassification
 	}
-	return nil
+	return ClassifyResponse_ACTIONABLE
 }
 
-func (x *WeeklyReviewRequest) GetBlockedTasks() []string {
+func (x *PendingReview) GetConfidence() float32 {
 	if x != nil {
-		return x.BlockedTasks
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *PendingReview) GetReviewReason() string {
+	if x != nil {
+		return x.ReviewReason
+	}
+	return ""
+}
+
+func (x *PendingReview) GetQueuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.QueuedAt
 	}
 	return nil
 }
 
-type WeeklyReviewResponse struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	ReportMarkdown       string                 `protobuf:"bytes,1,opt,name=report_markdown,json=reportMarkdown,proto3" json:"report_markdown,omitempty"`
-	StalledProjects      []string               `protobuf:"bytes,2,rep,name=stalled_projects,json=stalledProjects,proto3" json:"stalled_projects,omitempty"`
-	SuggestedNextActions []string               `protobuf:"bytes,3,rep,name=suggested_next_actions,json=suggestedNextActions,proto3" json:"suggested_next_actions,omitempty"`
-	DormantIdeas         []string               `protobuf:"bytes,4,rep,name=dormant_ideas,json=dormantIdeas,proto3" json:"dormant_ideas,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+type ListPendingReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WeeklyReviewResponse) Reset() {
-	*x = WeeklyReviewResponse{}
+func (x *ListPendingReviewsRequest) Reset() {
+	*x = ListPendingReviewsRequest{}
 	mi := &file_agent_v1_agent_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WeeklyReviewResponse) String() string {
+func (x *ListPendingReviewsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WeeklyReviewResponse) ProtoMessage() {}
+func (*ListPendingReviewsRequest) ProtoMessage() {}
 
-func (x *WeeklyReviewResponse) ProtoReflect() protoreflect.Message {
+func (x *ListPendingReviewsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_v1_agent_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1064,92 +1177,772 @@ func (x *WeeklyReviewResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WeeklyReviewResponse.ProtoReflect.Descriptor instead.
-func (*WeeklyReviewResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListPendingReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingReviewsRequest) Descriptor() ([]byte, []int) {
 	return file_agent_v1_agent_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *WeeklyReviewResponse) GetReportMarkdown() string {
+type ListPendingReviewsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first.
+	Reviews       []*PendingReview `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingReviewsResponse) Reset() {
+	*x = ListPendingReviewsResponse{}
+	mi := &file_agent_v1_agent_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingReviewsResponse) ProtoMessage() {}
+
+func (x *ListPendingReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[13]
 	if x != nil {
-		return x.ReportMarkdown
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *WeeklyReviewResponse) GetStalledProjects() []string {
+// Deprecated: Use ListPendingReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListPendingReviewsResponse) GetReviews() []*PendingReview {
 	if x != nil {
-		return x.StalledProjects
+		return x.Reviews
 	}
 	return nil
 }
 
-func (x *WeeklyReviewResponse) GetSuggestedNextActions() []string {
+type ResolveReviewRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId string                 `protobuf:"bytes,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	// The confirmed classification; NEEDS_REVIEW is rejected.
+	Classification ClassifyResponse_Classification `protobuf:"varint,2,opt,name=classification,proto3,enum=cognitive_os.agent.v1.ClassifyResponse_Classification" json:"classification,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResolveReviewRequest) Reset() {
+	*x = ResolveReviewRequest{}
+	mi := &file_agent_v1_agent_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveReviewRequest) ProtoMessage() {}
+
+func (x *ResolveReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[14]
 	if x != nil {
-		return x.SuggestedNextActions
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *WeeklyReviewResponse) GetDormantIdeas() []string {
+// Deprecated: Use ResolveReviewRequest.ProtoReflect.Descriptor instead.
+func (*ResolveReviewRequest) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ResolveReviewRequest) GetReviewId() string {
 	if x != nil {
-		return x.DormantIdeas
+		return x.ReviewId
+	}
+	return ""
+}
+
+func (x *ResolveReviewRequest) GetClassification() ClassifyResponse_Classification {
+	if x != nil {
+		return x.Classification
+	}
+	return ClassifyResponse_ACTIONABLE
+}
+
+type ResolveReviewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resolved item, with the confirmed classification to route it by.
+	Review         *PendingReview                  `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	Classification ClassifyResponse_Classification `protobuf:"varint,2,opt,name=classification,proto3,enum=cognitive_os.agent.v1.ClassifyResponse_Classification" json:"classification,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResolveReviewResponse) Reset() {
+	*x = ResolveReviewResponse{}
+	mi := &file_agent_v1_agent_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveReviewResponse) ProtoMessage() {}
+
+func (x *ResolveReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveReviewResponse.ProtoReflect.Descriptor instead.
+func (*ResolveReviewResponse) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResolveReviewResponse) GetReview() *PendingReview {
+	if x != nil {
+		return x.Review
 	}
 	return nil
 }
 
-var File_agent_v1_agent_proto protoreflect.FileDescriptor
+func (x *ResolveReviewResponse) GetClassification() ClassifyResponse_Classification {
+	if x != nil {
+		return x.Classification
+	}
+	return ClassifyResponse_ACTIONABLE
+}
 
-const file_agent_v1_agent_proto_rawDesc = "" +
-	"\n" +
-	"\x14agent/v1/agent.proto\x12\x15cognitive_os.agent.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xb0\x02\n" +
-	"\n" +
-	"AgentInput\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1f\n" +
-	"\n" +
-	"user_query\x18\x02 \x01(\tH\x00R\tuserQuery\x12D\n" +
-	"\vtool_result\x18\x03 \x01(\v2!.cognitive_os.agent.v1.ToolResultH\x00R\n" +
-	"toolResult\x12L\n" +
-	"\ruser_feedback\x18\x04 \x01(\v2%.cognitive_os.agent.v1.FeedbackSignalH\x00R\fuserFeedback\x12@\n" +
-	"\acontext\x18\x05 \x01(\v2&.cognitive_os.agent.v1.ContextSnapshotR\acontextB\f\n" +
-	"\n" +
-	"input_type\"\xc4\x02\n" +
-	"\vAgentOutput\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x128\n" +
-	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12%\n" +
-	"\rthought_chain\x18\x03 \x01(\tH\x00R\fthoughtChain\x12>\n" +
-	"\ttool_call\x18\x04 \x01(\v2\x1f.cognitive_os.agent.v1.ToolCallH\x00R\btoolCall\x12'\n" +
-	"\x0efinal_response\x18\x05 \x01(\tH\x00R\rfinalResponse\x12=\n" +
-	"\x06status\x18\x06 \x01(\v2#.cognitive_os.agent.v1.StatusUpdateH\x00R\x06statusB\r\n" +
-	"\voutput_type\"\xac\x01\n" +
-	"\bToolCall\x12\x1b\n" +
-	"\ttool_name\x18\x01 \x01(\tR\btoolName\x12\x17\n" +
-	"\acall_id\x18\x02 \x01(\tR\x06callId\x125\n" +
-	"\targuments\x18\x03 \x01(\v2\x17.google.protobuf.StructR\targuments\x123\n" +
-	"\x15requires_confirmation\x18\x04 \x01(\bR\x14requiresConfirmation\"g\n" +
-	"\n" +
-	"ToolResult\x12\x17\n" +
-	"\acall_id\x18\x01 \x01(\tR\x06callId\x12\x19\n" +
-	"\bis_error\x18\x02 \x01(\bR\aisError\x12%\n" +
-	"\x0eresult_payload\x18\x03 \x01(\tR\rresultPayload\"\xc1\x01\n" +
-	"\x0eFeedbackSignal\x12M\n" +
-	"\tsentiment\x18\x01 \x01(\x0e2/.cognitive_os.agent.v1.FeedbackSignal.SentimentR\tsentiment\x12'\n" +
-	"\x0fcorrection_text\x18\x02 \x01(\tR\x0ecorrectionText\"7\n" +
-	"\tSentiment\x12\f\n" +
-	"\bPOSITIVE\x10\x00\x12\f\n" +
-	"\bNEGATIVE\x10\x01\x12\x0e\n" +
-	"\n" +
-	"CORRECTION\x10\x02\"\x8b\x03\n" +
-	"\x0fContextSnapshot\x12'\n" +
-	"\x0fepisodic_memory\x18\x01 \x03(\tR\x0eepisodicMemory\x12M\n" +
-	"\x0fsemantic_memory\x18\x02 \x03(\v2$.cognitive_os.agent.v1.SemanticChunkR\x0esemanticMemory\x12G\n" +
-	"\rgraph_context\x18\x03 \x03(\v2\".cognitive_os.agent.v1.GraphTripleR\fgraphContext\x12T\n" +
-	"\n" +
-	"user_state\x18\x04 \x03(\v25.cognitive_os.agent.v1.ContextSnapshot.UserStateEntryR\tuserState\x12#\n" +
-	"\rsystem_prompt\x18\x05 \x01(\tR\fsystemPrompt\x1a<\n" +
-	"\x0eUserStateEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+type ListModelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsRequest) Reset() {
+	*x = ListModelsRequest{}
+	mi := &file_agent_v1_agent_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsRequest) ProtoMessage() {}
+
+func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListModelsRequest) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{16}
+}
+
+type ListModelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Registered model names, sorted. Requests for any other model go to the
+	// default provider.
+	Models []string `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	// The same models with the type of provider serving each, in the same order.
+	Details       []*ModelInfo `protobuf:"bytes,2,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_agent_v1_agent_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsResponse) ProtoMessage() {}
+
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListModelsResponse) GetModels() []string {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *ListModelsResponse) GetDetails() []*ModelInfo {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type ModelInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "openai", "google" or "mock"; empty when unknown.
+	Provider      string `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelInfo) Reset() {
+	*x = ModelInfo{}
+	mi := &file_agent_v1_agent_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelInfo) ProtoMessage() {}
+
+func (x *ModelInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelInfo.ProtoReflect.Descriptor instead.
+func (*ModelInfo) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ModelInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type WeeklyReviewRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	CompletedTasks []string               `protobuf:"bytes,4,rep,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	ActiveTasks    []string               `protobuf:"bytes,5,rep,name=active_tasks,json=activeTasks,proto3" json:"active_tasks,omitempty"`
+	BlockedTasks   []string               `protobuf:"bytes,6,rep,name=blocked_tasks,json=blockedTasks,proto3" json:"blocked_tasks,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WeeklyReviewRequest) Reset() {
+	*x = WeeklyReviewRequest{}
+	mi := &file_agent_v1_agent_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeeklyReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeeklyReviewRequest) ProtoMessage() {}
+
+func (x *WeeklyReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeeklyReviewRequest.ProtoReflect.Descriptor instead.
+func (*WeeklyReviewRequest) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WeeklyReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WeeklyReviewRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *WeeklyReviewRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *WeeklyReviewRequest) GetCompletedTasks() []string {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return nil
+}
+
+func (x *WeeklyReviewRequest) GetActiveTasks() []string {
+	if x != nil {
+		return x.ActiveTasks
+	}
+	return nil
+}
+
+func (x *WeeklyReviewRequest) GetBlockedTasks() []string {
+	if x != nil {
+		return x.BlockedTasks
+	}
+	return nil
+}
+
+type WeeklyReviewResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ReportMarkdown       string                 `protobuf:"bytes,1,opt,name=report_markdown,json=reportMarkdown,proto3" json:"report_markdown,omitempty"`
+	StalledProjects      []string               `protobuf:"bytes,2,rep,name=stalled_projects,json=stalledProjects,proto3" json:"stalled_projects,omitempty"`
+	SuggestedNextActions []string               `protobuf:"bytes,3,rep,name=suggested_next_actions,json=suggestedNextActions,proto3" json:"suggested_next_actions,omitempty"`
+	DormantIdeas         []string               `protobuf:"bytes,4,rep,name=dormant_ideas,json=dormantIdeas,proto3" json:"dormant_ideas,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *WeeklyReviewResponse) Reset() {
+	*x = WeeklyReviewResponse{}
+	mi := &file_agent_v1_agent_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeeklyReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeeklyReviewResponse) ProtoMessage() {}
+
+func (x *WeeklyReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeeklyReviewResponse.ProtoReflect.Descriptor instead.
+func (*WeeklyReviewResponse) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WeeklyReviewResponse) GetReportMarkdown() string {
+	if x != nil {
+		return x.ReportMarkdown
+	}
+	return ""
+}
+
+func (x *WeeklyReviewResponse) GetStalledProjects() []string {
+	if x != nil {
+		return x.StalledProjects
+	}
+	return nil
+}
+
+func (x *WeeklyReviewResponse) GetSuggestedNextActions() []string {
+	if x != nil {
+		return x.SuggestedNextActions
+	}
+	return nil
+}
+
+func (x *WeeklyReviewResponse) GetDormantIdeas() []string {
+	if x != nil {
+		return x.DormantIdeas
+	}
+	return nil
+}
+
+type MonthlyReviewRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Task activity for each week of the month, oldest first.
+	Weeks         []*WeekActivity `protobuf:"bytes,4,rep,name=weeks,proto3" json:"weeks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonthlyReviewRequest) Reset() {
+	*x = MonthlyReviewRequest{}
+	mi := &file_agent_v1_agent_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyReviewRequest) ProtoMessage() {}
+
+func (x *MonthlyReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyReviewRequest.ProtoReflect.Descriptor instead.
+func (*MonthlyReviewRequest) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MonthlyReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MonthlyReviewRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *MonthlyReviewRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *MonthlyReviewRequest) GetWeeks() []*WeekActivity {
+	if x != nil {
+		return x.Weeks
+	}
+	return nil
+}
+
+type WeekActivity struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CompletedTasks []string               `protobuf:"bytes,1,rep,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	ActiveTasks    []string               `protobuf:"bytes,2,rep,name=active_tasks,json=activeTasks,proto3" json:"active_tasks,omitempty"`
+	BlockedTasks   []string               `protobuf:"bytes,3,rep,name=blocked_tasks,json=blockedTasks,proto3" json:"blocked_tasks,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WeekActivity) Reset() {
+	*x = WeekActivity{}
+	mi := &file_agent_v1_agent_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeekActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeekActivity) ProtoMessage() {}
+
+func (x *WeekActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeekActivity.ProtoReflect.Descriptor instead.
+func (*WeekActivity) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WeekActivity) GetCompletedTasks() []string {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return nil
+}
+
+func (x *WeekActivity) GetActiveTasks() []string {
+	if x != nil {
+		return x.ActiveTasks
+	}
+	return nil
+}
+
+func (x *WeekActivity) GetBlockedTasks() []string {
+	if x != nil {
+		return x.BlockedTasks
+	}
+	return nil
+}
+
+type MonthlyReviewResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReportMarkdown string                 `protobuf:"bytes,1,opt,name=report_markdown,json=reportMarkdown,proto3" json:"report_markdown,omitempty"`
+	// "accelerating", "steady" or "slowing", from weekly completion counts.
+	Momentum string `protobuf:"bytes,2,opt,name=momentum,proto3" json:"momentum,omitempty"`
+	// Tasks blocked in more than one week.
+	RecurringBlockers    []string `protobuf:"bytes,3,rep,name=recurring_blockers,json=recurringBlockers,proto3" json:"recurring_blockers,omitempty"`
+	SuggestedNextActions []string `protobuf:"bytes,4,rep,name=suggested_next_actions,json=suggestedNextActions,proto3" json:"suggested_next_actions,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MonthlyReviewResponse) Reset() {
+	*x = MonthlyReviewResponse{}
+	mi := &file_agent_v1_agent_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyReviewResponse) ProtoMessage() {}
+
+func (x *MonthlyReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyReviewResponse.ProtoReflect.Descriptor instead.
+func (*MonthlyReviewResponse) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MonthlyReviewResponse) GetReportMarkdown() string {
+	if x != nil {
+		return x.ReportMarkdown
+	}
+	return ""
+}
+
+func (x *MonthlyReviewResponse) GetMomentum() string {
+	if x != nil {
+		return x.Momentum
+	}
+	return ""
+}
+
+func (x *MonthlyReviewResponse) GetRecurringBlockers() []string {
+	if x != nil {
+		return x.RecurringBlockers
+	}
+	return nil
+}
+
+func (x *MonthlyReviewResponse) GetSuggestedNextActions() []string {
+	if x != nil {
+		return x.SuggestedNextActions
+	}
+	return nil
+}
+
+// WeeklyReviewChunk is one piece of a streamed weekly review. Concatenating
+// the markdown of every chunk yields the complete report.
+type WeeklyReviewChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Report section this chunk belongs to: "report", "stalled_projects",
+	// "suggested_next_actions" or "dormant_ideas".
+	Section       string `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	Markdown      string `protobuf:"bytes,2,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeeklyReviewChunk) Reset() {
+	*x = WeeklyReviewChunk{}
+	mi := &file_agent_v1_agent_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeeklyReviewChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeeklyReviewChunk) ProtoMessage() {}
+
+func (x *WeeklyReviewChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeeklyReviewChunk.ProtoReflect.Descriptor instead.
+func (*WeeklyReviewChunk) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WeeklyReviewChunk) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *WeeklyReviewChunk) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+var File_agent_v1_agent_proto protoreflect.FileDescriptor
+
+const file_agent_v1_agent_proto_rawDesc = "" +
+	"\n" +
+	"\x14agent/v1/agent.proto\x12\x15cognitive_os.agent.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xb0\x02\n" +
+	"\n" +
+	"AgentInput\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1f\n" +
+	"\n" +
+	"user_query\x18\x02 \x01(\tH\x00R\tuserQuery\x12D\n" +
+	"\vtool_result\x18\x03 \x01(\v2!.cognitive_os.agent.v1.ToolResultH\x00R\n" +
+	"toolResult\x12L\n" +
+	"\ruser_feedback\x18\x04 \x01(\v2%.cognitive_os.agent.v1.FeedbackSignalH\x00R\fuserFeedback\x12@\n" +
+	"\acontext\x18\x05 \x01(\v2&.cognitive_os.agent.v1.ContextSnapshotR\acontextB\f\n" +
+	"\n" +
+	"input_type\"\xeb\x02\n" +
+	"\vAgentOutput\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12%\n" +
+	"\rthought_chain\x18\x03 \x01(\tH\x00R\fthoughtChain\x12>\n" +
+	"\ttool_call\x18\x04 \x01(\v2\x1f.cognitive_os.agent.v1.ToolCallH\x00R\btoolCall\x12'\n" +
+	"\x0efinal_response\x18\x05 \x01(\tH\x00R\rfinalResponse\x12=\n" +
+	"\x06status\x18\x06 \x01(\v2#.cognitive_os.agent.v1.StatusUpdateH\x00R\x06status\x12%\n" +
+	"\x0einteraction_id\x18\a \x01(\tR\rinteractionIdB\r\n" +
+	"\voutput_type\"\xac\x01\n" +
+	"\bToolCall\x12\x1b\n" +
+	"\ttool_name\x18\x01 \x01(\tR\btoolName\x12\x17\n" +
+	"\acall_id\x18\x02 \x01(\tR\x06callId\x125\n" +
+	"\targuments\x18\x03 \x01(\v2\x17.google.protobuf.StructR\targuments\x123\n" +
+	"\x15requires_confirmation\x18\x04 \x01(\bR\x14requiresConfirmation\"g\n" +
+	"\n" +
+	"ToolResult\x12\x17\n" +
+	"\acall_id\x18\x01 \x01(\tR\x06callId\x12\x19\n" +
+	"\bis_error\x18\x02 \x01(\bR\aisError\x12%\n" +
+	"\x0eresult_payload\x18\x03 \x01(\tR\rresultPayload\"\xe8\x01\n" +
+	"\x0eFeedbackSignal\x12M\n" +
+	"\tsentiment\x18\x01 \x01(\x0e2/.cognitive_os.agent.v1.FeedbackSignal.SentimentR\tsentiment\x12'\n" +
+	"\x0fcorrection_text\x18\x02 \x01(\tR\x0ecorrectionText\x12%\n" +
+	"\x0einteraction_id\x18\x03 \x01(\tR\rinteractionId\"7\n" +
+	"\tSentiment\x12\f\n" +
+	"\bPOSITIVE\x10\x00\x12\f\n" +
+	"\bNEGATIVE\x10\x01\x12\x0e\n" +
+	"\n" +
+	"CORRECTION\x10\x02\"\xa2\x04\n" +
+	"\x0fContextSnapshot\x12'\n" +
+	"\x0fepisodic_memory\x18\x01 \x03(\tR\x0eepisodicMemory\x12M\n" +
+	"\x0fsemantic_memory\x18\x02 \x03(\v2$.cognitive_os.agent.v1.SemanticChunkR\x0esemanticMemory\x12G\n" +
+	"\rgraph_context\x18\x03 \x03(\v2\".cognitive_os.agent.v1.GraphTripleR\fgraphContext\x12T\n" +
+	"\n" +
+	"user_state\x18\x04 \x03(\v25.cognitive_os.agent.v1.ContextSnapshot.UserStateEntryR\tuserState\x12#\n" +
+	"\rsystem_prompt\x18\x05 \x01(\tR\fsystemPrompt\x12&\n" +
+	"\x0fretrieval_top_k\x18\x06 \x01(\x05R\rretrievalTopK\x12!\n" +
+	"\fdebug_prompt\x18\a \x01(\bR\vdebugPrompt\x12#\n" +
+	"\rcontext_gated\x18\b \x01(\bR\fcontextGated\x12%\n" +
+	"\x0estop_sequences\x18\t \x03(\tR\rstopSequences\x1a<\n" +
+	"\x0eUserStateEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfa\x01\n" +
 	"\rSemanticChunk\x12\x19\n" +
 	"\bchunk_id\x18\x01 \x01(\tR\achunkId\x12\x18\n" +
@@ -1172,7 +1965,7 @@ const file_agent_v1_agent_proto_rawDesc = "" +
 	"\bmetadata\x18\x03 \x03(\v24.cognitive_os.agent.v1.ClassifyRequest.MetadataEntryR\bmetadata\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf3\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xec\x04\n" +
 	"\x10ClassifyResponse\x12^\n" +
 	"\x0eclassification\x18\x01 \x01(\x0e26.cognitive_os.agent.v1.ClassifyResponse.ClassificationR\x0eclassification\x12+\n" +
 	"\x11suggested_project\x18\x02 \x01(\tR\x10suggestedProject\x12%\n" +
@@ -1181,15 +1974,49 @@ const file_agent_v1_agent_proto_rawDesc = "" +
 	"\x12extracted_metadata\x18\x05 \x03(\v2>.cognitive_os.agent.v1.ClassifyResponse.ExtractedMetadataEntryR\x11extractedMetadata\x12\x1e\n" +
 	"\n" +
 	"confidence\x18\x06 \x01(\x02R\n" +
-	"confidence\x1aD\n" +
+	"confidence\x12#\n" +
+	"\rreview_reason\x18\a \x01(\tR\freviewReason\x12#\n" +
+	"\rthought_chain\x18\b \x03(\tR\fthoughtChain\x12\x1b\n" +
+	"\treview_id\x18\t \x01(\tR\breviewId\x1aD\n" +
 	"\x16ExtractedMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\":\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"L\n" +
 	"\x0eClassification\x12\x0e\n" +
 	"\n" +
 	"ACTIONABLE\x10\x00\x12\r\n" +
 	"\tREFERENCE\x10\x01\x12\t\n" +
-	"\x05TRASH\x10\x02\"\x91\x02\n" +
+	"\x05TRASH\x10\x02\x12\x10\n" +
+	"\fNEEDS_REVIEW\x10\x03\"\xdc\x03\n" +
+	"\rPendingReview\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\tR\breviewId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12N\n" +
+	"\bmetadata\x18\x04 \x03(\v22.cognitive_os.agent.v1.PendingReview.MetadataEntryR\bmetadata\x12q\n" +
+	"\x18suggested_classification\x18\x05 \x01(\x0e26.cognitive_os.agent.v1.ClassifyResponse.ClassificationR\x17suggestedClassification\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x06 \x01(\x02R\n" +
+	"confidence\x12#\n" +
+	"\rreview_reason\x18\a \x01(\tR\freviewReason\x127\n" +
+	"\tqueued_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bqueuedAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x1b\n" +
+	"\x19ListPendingReviewsRequest\"\\\n" +
+	"\x1aListPendingReviewsResponse\x12>\n" +
+	"\areviews\x18\x01 \x03(\v2$.cognitive_os.agent.v1.PendingReviewR\areviews\"\x93\x01\n" +
+	"\x14ResolveReviewRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\tR\breviewId\x12^\n" +
+	"\x0eclassification\x18\x02 \x01(\x0e26.cognitive_os.agent.v1.ClassifyResponse.ClassificationR\x0eclassification\"\xb5\x01\n" +
+	"\x15ResolveReviewResponse\x12<\n" +
+	"\x06review\x18\x01 \x01(\v2$.cognitive_os.agent.v1.PendingReviewR\x06review\x12^\n" +
+	"\x0eclassification\x18\x02 \x01(\x0e26.cognitive_os.agent.v1.ClassifyResponse.ClassificationR\x0eclassification\"\x13\n" +
+	"\x11ListModelsRequest\"h\n" +
+	"\x12ListModelsResponse\x12\x16\n" +
+	"\x06models\x18\x01 \x03(\tR\x06models\x12:\n" +
+	"\adetails\x18\x02 \x03(\v2 .cognitive_os.agent.v1.ModelInfoR\adetails\";\n" +
+	"\tModelInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\"\x91\x02\n" +
 	"\x13WeeklyReviewRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
 	"\n" +
@@ -1202,11 +2029,35 @@ const file_agent_v1_agent_proto_rawDesc = "" +
 	"\x0freport_markdown\x18\x01 \x01(\tR\x0ereportMarkdown\x12)\n" +
 	"\x10stalled_projects\x18\x02 \x03(\tR\x0fstalledProjects\x124\n" +
 	"\x16suggested_next_actions\x18\x03 \x03(\tR\x14suggestedNextActions\x12#\n" +
-	"\rdormant_ideas\x18\x04 \x03(\tR\fdormantIdeas2\xc6\x02\n" +
+	"\rdormant_ideas\x18\x04 \x03(\tR\fdormantIdeas\"\xdc\x01\n" +
+	"\x14MonthlyReviewRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x129\n" +
+	"\x05weeks\x18\x04 \x03(\v2#.cognitive_os.agent.v1.WeekActivityR\x05weeks\"\x7f\n" +
+	"\fWeekActivity\x12'\n" +
+	"\x0fcompleted_tasks\x18\x01 \x03(\tR\x0ecompletedTasks\x12!\n" +
+	"\factive_tasks\x18\x02 \x03(\tR\vactiveTasks\x12#\n" +
+	"\rblocked_tasks\x18\x03 \x03(\tR\fblockedTasks\"\xc1\x01\n" +
+	"\x15MonthlyReviewResponse\x12'\n" +
+	"\x0freport_markdown\x18\x01 \x01(\tR\x0ereportMarkdown\x12\x1a\n" +
+	"\bmomentum\x18\x02 \x01(\tR\bmomentum\x12-\n" +
+	"\x12recurring_blockers\x18\x03 \x03(\tR\x11recurringBlockers\x124\n" +
+	"\x16suggested_next_actions\x18\x04 \x03(\tR\x14suggestedNextActions\"I\n" +
+	"\x11WeeklyReviewChunk\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x1a\n" +
+	"\bmarkdown\x18\x02 \x01(\tR\bmarkdown2\xf2\x06\n" +
 	"\x0fReasoningEngine\x12a\n" +
 	"\x14StreamThoughtProcess\x12!.cognitive_os.agent.v1.AgentInput\x1a\".cognitive_os.agent.v1.AgentOutput(\x010\x01\x12_\n" +
 	"\fClassifyItem\x12&.cognitive_os.agent.v1.ClassifyRequest\x1a'.cognitive_os.agent.v1.ClassifyResponse\x12o\n" +
-	"\x14GenerateWeeklyReview\x12*.cognitive_os.agent.v1.WeeklyReviewRequest\x1a+.cognitive_os.agent.v1.WeeklyReviewResponseB6Z4github.com/ziyixi/SecondBrain/proto/agent/v1;agentv1b\x06proto3"
+	"\x14GenerateWeeklyReview\x12*.cognitive_os.agent.v1.WeeklyReviewRequest\x1a+.cognitive_os.agent.v1.WeeklyReviewResponse\x12l\n" +
+	"\x12StreamWeeklyReview\x12*.cognitive_os.agent.v1.WeeklyReviewRequest\x1a(.cognitive_os.agent.v1.WeeklyReviewChunk0\x01\x12r\n" +
+	"\x15GenerateMonthlyReview\x12+.cognitive_os.agent.v1.MonthlyReviewRequest\x1a,.cognitive_os.agent.v1.MonthlyReviewResponse\x12a\n" +
+	"\n" +
+	"ListModels\x12(.cognitive_os.agent.v1.ListModelsRequest\x1a).cognitive_os.agent.v1.ListModelsResponse\x12y\n" +
+	"\x12ListPendingReviews\x120.cognitive_os.agent.v1.ListPendingReviewsRequest\x1a1.cognitive_os.agent.v1.ListPendingReviewsResponse\x12j\n" +
+	"\rResolveReview\x12+.cognitive_os.agent.v1.ResolveReviewRequest\x1a,.cognitive_os.agent.v1.ResolveReviewResponseB6Z4github.com/ziyixi/SecondBrain/proto/agent/v1;agentv1b\x06proto3"
 
 var (
 	file_agent_v1_agent_proto_rawDescOnce sync.Once
@@ -1221,7 +2072,7 @@ func file_agent_v1_agent_proto_rawDescGZIP() []byte {
 }
 
 var file_agent_v1_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_agent_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_agent_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_agent_v1_agent_proto_goTypes = []any{
 	(FeedbackSignal_Sentiment)(0),        // 0: cognitive_os.agent.v1.FeedbackSignal.Sentiment
 	(ClassifyResponse_Classification)(0), // 1: cognitive_os.agent.v1.ClassifyResponse.Classification
@@ -1236,44 +2087,78 @@ var file_agent_v1_agent_proto_goTypes = []any{
 	(*StatusUpdate)(nil),                 // 10: cognitive_os.agent.v1.StatusUpdate
 	(*ClassifyRequest)(nil),              // 11: cognitive_os.agent.v1.ClassifyRequest
 	(*ClassifyResponse)(nil),             // 12: cognitive_os.agent.v1.ClassifyResponse
-	(*WeeklyReviewRequest)(nil),          // 13: cognitive_os.agent.v1.WeeklyReviewRequest
-	(*WeeklyReviewResponse)(nil),         // 14: cognitive_os.agent.v1.WeeklyReviewResponse
-	nil,                                  // 15: cognitive_os.agent.v1.ContextSnapshot.UserStateEntry
-	nil,                                  // 16: cognitive_os.agent.v1.SemanticChunk.MetadataEntry
-	nil,                                  // 17: cognitive_os.agent.v1.ClassifyRequest.MetadataEntry
-	nil,                                  // 18: cognitive_os.agent.v1.ClassifyResponse.ExtractedMetadataEntry
-	(*timestamppb.Timestamp)(nil),        // 19: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),              // 20: google.protobuf.Struct
+	(*PendingReview)(nil),                // 13: cognitive_os.agent.v1.PendingReview
+	(*ListPendingReviewsRequest)(nil),    // 14: cognitive_os.agent.v1.ListPendingReviewsRequest
+	(*ListPendingReviewsResponse)(nil),   // 15: cognitive_os.agent.v1.ListPendingReviewsResponse
+	(*ResolveReviewRequest)(nil),         // 16: cognitive_os.agent.v1.ResolveReviewRequest
+	(*ResolveReviewResponse)(nil),        // 17: cognitive_os.agent.v1.ResolveReviewResponse
+	(*ListModelsRequest)(nil),            // 18: cognitive_os.agent.v1.ListModelsRequest
+	(*ListModelsResponse)(nil),           // 19: cognitive_os.agent.v1.ListModelsResponse
+	(*ModelInfo)(nil),                    // 20: cognitive_os.agent.v1.ModelInfo
+	(*WeeklyReviewRequest)(nil),          // 21: cognitive_os.agent.v1.WeeklyReviewRequest
+	(*WeeklyReviewResponse)(nil),         // 22: cognitive_os.agent.v1.WeeklyReviewResponse
+	(*MonthlyReviewRequest)(nil),         // 23: cognitive_os.agent.v1.MonthlyReviewRequest
+	(*WeekActivity)(nil),                 // 24: cognitive_os.agent.v1.WeekActivity
+	(*MonthlyReviewResponse)(nil),        // 25: cognitive_os.agent.v1.MonthlyReviewResponse
+	(*WeeklyReviewChunk)(nil),            // 26: cognitive_os.agent.v1.WeeklyReviewChunk
+	nil,                                  // 27: cognitive_os.agent.v1.ContextSnapshot.UserStateEntry
+	nil,                                  // 28: cognitive_os.agent.v1.SemanticChunk.MetadataEntry
+	nil,                                  // 29: cognitive_os.agent.v1.ClassifyRequest.MetadataEntry
+	nil,                                  // 30: cognitive_os.agent.v1.ClassifyResponse.ExtractedMetadataEntry
+	nil,                                  // 31: cognitive_os.agent.v1.PendingReview.MetadataEntry
+	(*timestamppb.Timestamp)(nil),        // 32: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),              // 33: google.protobuf.Struct
 }
 var file_agent_v1_agent_proto_depIdxs = []int32{
 	5,  // 0: cognitive_os.agent.v1.AgentInput.tool_result:type_name -> cognitive_os.agent.v1.ToolResult
 	6,  // 1: cognitive_os.agent.v1.AgentInput.user_feedback:type_name -> cognitive_os.agent.v1.FeedbackSignal
 	7,  // 2: cognitive_os.agent.v1.AgentInput.context:type_name -> cognitive_os.agent.v1.ContextSnapshot
-	19, // 3: cognitive_os.agent.v1.AgentOutput.timestamp:type_name -> google.protobuf.Timestamp
+	32, // 3: cognitive_os.agent.v1.AgentOutput.timestamp:type_name -> google.protobuf.Timestamp
 	4,  // 4: cognitive_os.agent.v1.AgentOutput.tool_call:type_name -> cognitive_os.agent.v1.ToolCall
 	10, // 5: cognitive_os.agent.v1.AgentOutput.status:type_name -> cognitive_os.agent.v1.StatusUpdate
-	20, // 6: cognitive_os.agent.v1.ToolCall.arguments:type_name -> google.protobuf.Struct
+	33, // 6: cognitive_os.agent.v1.ToolCall.arguments:type_name -> google.protobuf.Struct
 	0,  // 7: cognitive_os.agent.v1.FeedbackSignal.sentiment:type_name -> cognitive_os.agent.v1.FeedbackSignal.Sentiment
 	8,  // 8: cognitive_os.agent.v1.ContextSnapshot.semantic_memory:type_name -> cognitive_os.agent.v1.SemanticChunk
 	9,  // 9: cognitive_os.agent.v1.ContextSnapshot.graph_context:type_name -> cognitive_os.agent.v1.GraphTriple
-	15, // 10: cognitive_os.agent.v1.ContextSnapshot.user_state:type_name -> cognitive_os.agent.v1.ContextSnapshot.UserStateEntry
-	16, // 11: cognitive_os.agent.v1.SemanticChunk.metadata:type_name -> cognitive_os.agent.v1.SemanticChunk.MetadataEntry
-	17, // 12: cognitive_os.agent.v1.ClassifyRequest.metadata:type_name -> cognitive_os.agent.v1.ClassifyRequest.MetadataEntry
+	27, // 10: cognitive_os.agent.v1.ContextSnapshot.user_state:type_name -> cognitive_os.agent.v1.ContextSnapshot.UserStateEntry
+	28, // 11: cognitive_os.agent.v1.SemanticChunk.metadata:type_name -> cognitive_os.agent.v1.SemanticChunk.MetadataEntry
+	29, // 12: cognitive_os.agent.v1.ClassifyRequest.metadata:type_name -> cognitive_os.agent.v1.ClassifyRequest.MetadataEntry
 	1,  // 13: cognitive_os.agent.v1.ClassifyResponse.classification:type_name -> cognitive_os.agent.v1.ClassifyResponse.Classification
-	18, // 14: cognitive_os.agent.v1.ClassifyResponse.extracted_metadata:type_name -> cognitive_os.agent.v1.ClassifyResponse.ExtractedMetadataEntry
-	19, // 15: cognitive_os.agent.v1.WeeklyReviewRequest.start_date:type_name -> google.protobuf.Timestamp
-	19, // 16: cognitive_os.agent.v1.WeeklyReviewRequest.end_date:type_name -> google.protobuf.Timestamp
-	2,  // 17: cognitive_os.agent.v1.ReasoningEngine.StreamThoughtProcess:input_type -> cognitive_os.agent.v1.AgentInput
-	11, // 18: cognitive_os.agent.v1.ReasoningEngine.ClassifyItem:input_type -> cognitive_os.agent.v1.ClassifyRequest
-	13, // 19: cognitive_os.agent.v1.ReasoningEngine.GenerateWeeklyReview:input_type -> cognitive_os.agent.v1.WeeklyReviewRequest
-	3,  // 20: cognitive_os.agent.v1.ReasoningEngine.StreamThoughtProcess:output_type -> cognitive_os.agent.v1.AgentOutput
-	12, // 21: cognitive_os.agent.v1.ReasoningEngine.ClassifyItem:output_type -> cognitive_os.agent.v1.ClassifyResponse
-	14, // 22: cognitive_os.agent.v1.ReasoningEngine.GenerateWeeklyReview:output_type -> cognitive_os.agent.v1.WeeklyReviewResponse
-	20, // [20:23] is the sub-list for method output_type
-	17, // [17:20] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	30, // 14: cognitive_os.agent.v1.ClassifyResponse.extracted_metadata:type_name -> cognitive_os.agent.v1.ClassifyResponse.ExtractedMetadataEntry
+	31, // 15: cognitive_os.agent.v1.PendingReview.metadata:type_name -> cognitive_os.agent.v1.PendingReview.MetadataEntry
+	1,  // 16: cognitive_os.agent.v1.PendingReview.suggested_classification:type_name -> cognitive_os.agent.v1.ClassifyResponse.Classification
+	32, // 17: cognitive_os.agent.v1.PendingReview.queued_at:type_name -> google.protobuf.Timestamp
+	13, // 18: cognitive_os.agent.v1.ListPendingReviewsResponse.reviews:type_name -> cognitive_os.agent.v1.PendingReview
+	1,  // 19: cognitive_os.agent.v1.ResolveReviewRequest.classification:type_name -> cognitive_os.agent.v1.ClassifyResponse.Classification
+	13, // 20: cognitive_os.agent.v1.ResolveReviewResponse.review:type_name -> cognitive_os.agent.v1.PendingReview
+	1,  // 21: cognitive_os.agent.v1.ResolveReviewResponse.classification:type_name -> cognitive_os.agent.v1.ClassifyResponse.Classification
+	20, // 22: cognitive_os.agent.v1.ListModelsResponse.details:type_name -> cognitive_os.agent.v1.ModelInfo
+	32, // 23: cognitive_os.agent.v1.WeeklyReviewRequest.start_date:type_name -> google.protobuf.Timestamp
+	32, // 24: cognitive_os.agent.v1.WeeklyReviewRequest.end_date:type_name -> google.protobuf.Timestamp
+	32, // 25: cognitive_os.agent.v1.MonthlyReviewRequest.start_date:type_name -> google.protobuf.Timestamp
+	32, // 26: cognitive_os.agent.v1.MonthlyReviewRequest.end_date:type_name -> google.protobuf.Timestamp
+	24, // 27: cognitive_os.agent.v1.MonthlyReviewRequest.weeks:type_name -> cognitive_os.agent.v1.WeekActivity
+	2,  // 28: cognitive_os.agent.v1.ReasoningEngine.StreamThoughtProcess:input_type -> cognitive_os.agent.v1.AgentInput
+	11, // 29: cognitive_os.agent.v1.ReasoningEngine.ClassifyItem:input_type -> cognitive_os.agent.v1.ClassifyRequest
+	21, // 30: cognitive_os.agent.v1.ReasoningEngine.GenerateWeeklyReview:input_type -> cognitive_os.agent.v1.WeeklyReviewRequest
+	21, // 31: cognitive_os.agent.v1.ReasoningEngine.StreamWeeklyReview:input_type -> cognitive_os.agent.v1.WeeklyReviewRequest
+	23, // 32: cognitive_os.agent.v1.ReasoningEngine.GenerateMonthlyReview:input_type -> cognitive_os.agent.v1.MonthlyReviewRequest
+	18, // 33: cognitive_os.agent.v1.ReasoningEngine.ListModels:input_type -> cognitive_os.agent.v1.ListModelsRequest
+	14, // 34: cognitive_os.agent.v1.ReasoningEngine.ListPendingReviews:input_type -> cognitive_os.agent.v1.ListPendingReviewsRequest
+	16, // 35: cognitive_os.agent.v1.ReasoningEngine.ResolveReview:input_type -> cognitive_os.agent.v1.ResolveReviewRequest
+	3,  // 36: cognitive_os.agent.v1.ReasoningEngine.StreamThoughtProcess:output_type -> cognitive_os.agent.v1.AgentOutput
+	12, // 37: cognitive_os.agent.v1.ReasoningEngine.ClassifyItem:output_type -> cognitive_os.agent.v1.ClassifyResponse
+	22, // 38: cognitive_os.agent.v1.ReasoningEngine.GenerateWeeklyReview:output_type -> cognitive_os.agent.v1.WeeklyReviewResponse
+	26, // 39: cognitive_os.agent.v1.ReasoningEngine.StreamWeeklyReview:output_type -> cognitive_os.agent.v1.WeeklyReviewChunk
+	25, // 40: cognitive_os.agent.v1.ReasoningEngine.GenerateMonthlyReview:output_type -> cognitive_os.agent.v1.MonthlyReviewResponse
+	19, // 41: cognitive_os.agent.v1.ReasoningEngine.ListModels:output_type -> cognitive_os.agent.v1.ListModelsResponse
+	15, // 42: cognitive_os.agent.v1.ReasoningEngine.ListPendingReviews:output_type -> cognitive_os.agent.v1.ListPendingReviewsResponse
+	17, // 43: cognitive_os.agent.v1.ReasoningEngine.ResolveReview:output_type -> cognitive_os.agent.v1.ResolveReviewResponse
+	36, // [36:44] is the sub-list for method output_type
+	28, // [28:36] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_agent_v1_agent_proto_init() }
@@ -1298,7 +2183,7 @@ func file_agent_v1_agent_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agent_v1_agent_proto_rawDesc), len(file_agent_v1_agent_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   17,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -231,10 +231,13 @@ func (x *HealthCheckRequest) GetService() string {
 }
 
 type HealthCheckResponse struct {
-	state         protoimpl.MessageState            `protogen:"open.v1"`
-	Status        HealthCheckResponse_ServingStatus `protobuf:"varint,1,opt,name=status,proto3,enum=cognitive_os.common.v1.HealthCheckResponse_ServingStatus" json:"status,omitempty"`
-	Version       string                            `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Timestamp     *timestamppb.Timestamp            `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	state     protoimpl.MessageState            `protogen:"open.v1"`
+	Status    HealthCheckResponse_ServingStatus `protobuf:"varint,1,opt,name=status,proto3,enum=cognitive_os.common.v1.HealthCheckResponse_ServingStatus" json:"status,omitempty"`
+	Version   string                            `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Timestamp *timestamppb.Timestamp            `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Status of each downstream dependency (e.g. "frontal_lobe" -> "SERVING"),
+	// reported by services that aggregate the health of their dependencies.
+	Dependencies  map[string]string `protobuf:"bytes,4,rep,name=dependencies,proto3" json:"dependencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -290,6 +293,13 @@ func (x *HealthCheckResponse) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *HealthCheckResponse) GetDependencies() map[string]string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
 // Shared metadata for all items flowing through the system
 type ItemMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -373,11 +383,15 @@ const file_common_v1_common_proto_rawDesc = "" +
 	"\n" +
 	"\x16common/v1/common.proto\x12\x16cognitive_os.common.v1\x1a\x1fgoogle/protobuf/timestamp.proto\".\n" +
 	"\x12HealthCheckRequest\x12\x18\n" +
-	"\aservice\x18\x01 \x01(\tR\aservice\"\xf8\x01\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\"\x9c\x03\n" +
 	"\x13HealthCheckResponse\x12Q\n" +
 	"\x06status\x18\x01 \x01(\x0e29.cognitive_os.common.v1.HealthCheckResponse.ServingStatusR\x06status\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\":\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12a\n" +
+	"\fdependencies\x18\x04 \x03(\v2=.cognitive_os.common.v1.HealthCheckResponse.DependenciesEntryR\fdependencies\x1a?\n" +
+	"\x11DependenciesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\":\n" +
 	"\rServingStatus\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\v\n" +
 	"\aSERVING\x10\x01\x12\x0f\n" +
@@ -422,7 +436,7 @@ func file_common_v1_common_proto_rawDescGZIP() []byte {
 }
 
 var file_common_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_common_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_common_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_common_v1_common_proto_goTypes = []any{
 	(Priority)(0),                          // 0: cognitive_os.common.v1.Priority
 	(ProcessingStatus)(0),                  // 1: cognitive_os.common.v1.ProcessingStatus
@@ -430,22 +444,24 @@ var file_common_v1_common_proto_goTypes = []any{
 	(*HealthCheckRequest)(nil),             // 3: cognitive_os.common.v1.HealthCheckRequest
 	(*HealthCheckResponse)(nil),            // 4: cognitive_os.common.v1.HealthCheckResponse
 	(*ItemMetadata)(nil),                   // 5: cognitive_os.common.v1.ItemMetadata
-	nil,                                    // 6: cognitive_os.common.v1.ItemMetadata.LabelsEntry
-	(*timestamppb.Timestamp)(nil),          // 7: google.protobuf.Timestamp
+	nil,                                    // 6: cognitive_os.common.v1.HealthCheckResponse.DependenciesEntry
+	nil,                                    // 7: cognitive_os.common.v1.ItemMetadata.LabelsEntry
+	(*timestamppb.Timestamp)(nil),          // 8: google.protobuf.Timestamp
 }
 var file_common_v1_common_proto_depIdxs = []int32{
 	2, // 0: cognitive_os.common.v1.HealthCheckResponse.status:type_name -> cognitive_os.common.v1.HealthCheckResponse.ServingStatus
-	7, // 1: cognitive_os.common.v1.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
-	7, // 2: cognitive_os.common.v1.ItemMetadata.created_at:type_name -> google.protobuf.Timestamp
-	7, // 3: cognitive_os.common.v1.ItemMetadata.updated_at:type_name -> google.protobuf.Timestamp
-	6, // 4: cognitive_os.common.v1.ItemMetadata.labels:type_name -> cognitive_os.common.v1.ItemMetadata.LabelsEntry
-	3, // 5: cognitive_os.common.v1.HealthService.Check:input_type -> cognitive_os.common.v1.HealthCheckRequest
-	4, // 6: cognitive_os.common.v1.HealthService.Check:output_type -> cognitive_os.common.v1.HealthCheckResponse
-	6, // [6:7] is the sub-list for method output_type
-	5, // [5:6] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	8, // 1: cognitive_os.common.v1.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
+	6, // 2: cognitive_os.common.v1.HealthCheckResponse.dependencies:type_name -> cognitive_os.common.v1.HealthCheckResponse.DependenciesEntry
+	8, // 3: cognitive_os.common.v1.ItemMetadata.created_at:type_name -> google.protobuf.Timestamp
+	8, // 4: cognitive_os.common.v1.ItemMetadata.updated_at:type_name -> google.protobuf.Timestamp
+	7, // 5: cognitive_os.common.v1.ItemMetadata.labels:type_name -> cognitive_os.common.v1.ItemMetadata.LabelsEntry
+	3, // 6: cognitive_os.common.v1.HealthService.Check:input_type -> cognitive_os.common.v1.HealthCheckRequest
+	4, // 7: cognitive_os.common.v1.HealthService.Check:output_type -> cognitive_os.common.v1.HealthCheckResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_common_v1_common_proto_init() }
@@ -459,7 +475,7 @@ func file_common_v1_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_v1_common_proto_rawDesc), len(file_common_v1_common_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -8,36 +8,99 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	"time"
 
 	"github.com/ziyixi/SecondBrain/services/gateway/internal/config"
+	"github.com/ziyixi/SecondBrain/services/gateway/internal/metrics"
+	"github.com/ziyixi/SecondBrain/services/gateway/internal/middleware"
 	"github.com/ziyixi/SecondBrain/services/gateway/internal/poller"
 	"github.com/ziyixi/SecondBrain/services/gateway/internal/server"
+	"github.com/ziyixi/SecondBrain/services/gateway/internal/shutdown"
+	"github.com/ziyixi/SecondBrain/services/gateway/internal/tlsconfig"
+	"github.com/ziyixi/SecondBrain/services/gateway/internal/tracing"
 	"github.com/ziyixi/SecondBrain/services/gateway/internal/webhook"
 	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
 	ingestionv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/ingestion/v1"
 )
 
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(middleware.NewRequestIDHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 	slog.SetDefault(logger)
 
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.ServiceName, cfg.OTelEndpoint)
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Create servers
-	gatewayServer := server.NewGatewayServer(logger)
+	gatewayServer, err := newGatewayServer(logger, cfg.ItemStorePath)
+	if err != nil {
+		logger.Error("failed to load item store", "path", cfg.ItemStorePath, "error", err)
+		os.Exit(1)
+	}
+	if cfg.CortexAddr != "" {
+		// Never fall back to plaintext: the forwarder sends the Cortex token.
+		cortexCreds, err := tlsconfig.ClientCredentials(cfg.DownstreamTLS, cfg.TLSCAFile)
+		if err != nil {
+			logger.Error("failed to load TLS credentials for cortex", "error", err)
+			os.Exit(1)
+		}
+		cortexConn, err := grpc.NewClient(cfg.CortexAddr,
+			grpc.WithTransportCredentials(cortexCreds),
+			grpc.WithPerRPCCredentials(middleware.BearerToken(cfg.CortexAuthToken)),
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		)
+		if err != nil {
+			logger.Error("failed to create cortex client", "address", cfg.CortexAddr, "error", err)
+			os.Exit(1)
+		}
+		defer cortexConn.Close()
+		gatewayServer.SetForwarder(ingestionv1.NewIngestionServiceClient(cortexConn))
+	}
+	stopPurger := gatewayServer.StartPurger(cfg.ItemRetention)
+	defer stopPurger()
 	webhookHandler := webhook.NewHandler(logger, cfg.WebhookSecret)
+	webhookHandler.SetEnqueueTimeout(cfg.EnqueueTimeout)
+	webhookHandler.SetDedupWindow(cfg.DedupWindow)
 	pollerService := poller.New(logger, cfg.PollInterval)
+	for _, feedURL := range cfg.RSSFeeds {
+		src := poller.NewRSSSource(feedURL, nil)
+		pollerService.AddSource(src.Name(), cfg.RSSPollInterval, poller.FetchSource(src))
+	}
+
+	// Serve TLS when a certificate is configured
+	var serverOpts []grpc.ServerOption
+	if cfg.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			logger.Error("failed to load TLS credentials", "error", err)
+			os.Exit(1)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
 
 	// Set up gRPC server
-	grpcServer := grpc.NewServer(
+	latency := metrics.NewLatencyStore()
+	grpcServer := grpc.NewServer(append(serverOpts,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle:     15 * time.Minute,
 			MaxConnectionAge:      30 * time.Minute,
@@ -45,15 +108,30 @@ func main() {
 			Time:                  5 * time.Minute,
 			Timeout:               1 * time.Second,
 		}),
-	)
+		grpc.ChainUnaryInterceptor(
+			middleware.UnaryRequestID(),
+			middleware.UnaryRecovery(logger),
+			middleware.UnaryLogging(logger),
+			middleware.UnaryMetrics(latency),
+			middleware.UnaryAuth(cfg.AuthTokens),
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.StreamRequestID(),
+			middleware.StreamRecovery(logger),
+			middleware.StreamLogging(logger),
+			middleware.StreamMetrics(latency),
+			middleware.StreamAuth(cfg.AuthTokens),
+		),
+	)...)
 
 	ingestionv1.RegisterIngestionServiceServer(grpcServer, gatewayServer)
 	commonv1.RegisterHealthServiceServer(grpcServer, gatewayServer)
 	reflection.Register(grpcServer)
 
-	// Set up HTTP server for webhooks
+	// Set up HTTP server for webhooks and metrics
 	mux := http.NewServeMux()
 	webhookHandler.RegisterRoutes(mux)
+	latency.RegisterRoutes(mux)
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.HTTPPort),
 		Handler:      mux,
@@ -65,19 +143,18 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	// Forward webhook items to gateway server
-	go func() {
-		for item := range webhookHandler.Items() {
-			gatewayServer.AddItem(item)
-		}
-	}()
-
-	// Forward poller items to gateway server
-	go func() {
-		for item := range pollerService.Items() {
-			gatewayServer.AddItem(item)
-		}
-	}()
+	// Forward webhook and poller items to gateway server until their
+	// channels are closed on shutdown
+	var forwarders sync.WaitGroup
+	for _, items := range []<-chan *ingestionv1.InboxItem{webhookHandler.Items(), pollerService.Items()} {
+		forwarders.Add(1)
+		go func() {
+			defer forwarders.Done()
+			for item := range items {
+				gatewayServer.AddItem(item)
+			}
+		}()
+	}
 
 	// Start pollers
 	go pollerService.Start(ctx)
@@ -107,9 +184,51 @@ func main() {
 		}
 	}()
 
+	webhookHandler.SetReady(true)
+
 	<-ctx.Done()
-	logger.Info("shutting down gateway service...")
-	grpcServer.GracefulStop()
-	httpServer.Shutdown(context.Background()) //nolint:errcheck
+	logger.Info("shutting down gateway service...", "timeout", cfg.ShutdownTimeout)
+	webhookHandler.SetReady(false)
+
+	// Stop accepting requests and let in-flight ones finish, then close the
+	// webhook channel so the forwarders store every queued item. The poller
+	// closes its own channel once its sources stop.
+	drainCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := shutdown.Drain(drainCtx, grpcServer, httpServer); err != nil {
+		// A webhook may still be enqueueing, so the channel cannot be closed.
+		logger.Warn("in-flight requests did not finish before the shutdown deadline", "error", err)
+	} else {
+		webhookHandler.Close()
+	}
+
+	forwarded := make(chan struct{})
+	go func() {
+		forwarders.Wait()
+		close(forwarded)
+	}()
+	select {
+	case <-forwarded:
+	case <-drainCtx.Done():
+		logger.Warn("queued items were not all stored before the shutdown deadline")
+	}
+	if err := gatewayServer.Close(); err != nil {
+		logger.Error("failed to save item store", "path", cfg.ItemStorePath, "error", err)
+	}
 	logger.Info("gateway service stopped")
 }
+
+// newGatewayServer returns a gateway backed by a file item store when a path
+// is configured. A store that cannot be loaded is an error rather than a
+// silent fallback to in-memory items, which would lose every queued item.
+func newGatewayServer(logger *slog.Logger, storePath string) (*server.GatewayServer, error) {
+	if storePath == "" {
+		return server.NewGatewayServer(logger), nil
+	}
+	s, err := server.NewGatewayServerWithStore(logger, storePath)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("item store loaded", "path", storePath)
+	return s, nil
+}
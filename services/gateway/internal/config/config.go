@@ -1,38 +1,105 @@
 package config
 
 import (
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all configuration for the Sensory Gateway service.
 type Config struct {
-	GRPCPort    int
-	HTTPPort    int
-	ServiceName string
-	CortexAddr  string
+	GRPCPort    int    `yaml:"grpc_port"`
+	HTTPPort    int    `yaml:"http_port"`
+	ServiceName string `yaml:"service_name"`
+	CortexAddr  string `yaml:"cortex_addr"` // where stored items are forwarded; empty keeps them in the inbox
+
+	// Cortex forwarding
+	CortexAuthToken string `yaml:"cortex_auth_token"` // bearer token sent to Cortex
+
+	// Inbox persistence
+	ItemStorePath string        `yaml:"item_store_path"` // JSON file for persisting inbox items; empty keeps them in memory
+	ItemRetention time.Duration `yaml:"item_retention"`  // how long filed items are kept before being purged; 0 keeps them
 
 	// Webhook settings
-	WebhookSecret string
+	WebhookSecret  string        `yaml:"webhook_secret"`
+	EnqueueTimeout time.Duration `yaml:"webhook_enqueue_timeout"` // how long webhooks wait on a full item queue before returning 503
+	DedupWindow    time.Duration `yaml:"webhook_dedup_window"`    // how long accepted content is remembered to drop redeliveries
 
 	// Poller settings
-	PollInterval time.Duration
+	PollInterval    time.Duration `yaml:"poll_interval"`
+	RSSFeeds        []string      `yaml:"rss_feeds"`         // feed URLs polled by the RSS source
+	RSSPollInterval time.Duration `yaml:"rss_poll_interval"` // cadence for RSS feeds, independent of PollInterval
+
+	// Shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // how long shutdown waits for in-flight requests before forcing them closed
+
+	// TLS
+	TLSCertFile   string `yaml:"tls_cert_file"`  // server certificate; empty serves plaintext
+	TLSKeyFile    string `yaml:"tls_key_file"`   // server private key
+	TLSCAFile     string `yaml:"tls_ca_file"`    // CA bundle for verifying Cortex's certificate; empty uses system roots
+	DownstreamTLS bool   `yaml:"downstream_tls"` // dial Cortex over TLS
+
+	// Auth
+	AuthTokens []string `yaml:"auth_tokens"` // bearer tokens accepted on gRPC calls; empty disables auth
 
 	// Observability
-	OTelEndpoint string
+	OTelEndpoint string `yaml:"otel_endpoint"`
+}
+
+// Load reads configuration from a YAML file, then environment variables,
+// which take precedence over file values. A file named by CONFIG_FILE must
+// load cleanly; an unreadable default config.yaml is logged and ignored.
+func Load() (*Config, error) {
+	base := defaults()
+	path, explicit := configFilePath()
+	if err := loadFile(base, path, explicit); err != nil {
+		if explicit {
+			return nil, err
+		}
+		slog.Warn("ignoring config file", "path", path, "error", err)
+		base = defaults()
+	}
+
+	return &Config{
+		GRPCPort:        getEnvInt("GATEWAY_GRPC_PORT", base.GRPCPort),
+		HTTPPort:        getEnvInt("GATEWAY_HTTP_PORT", base.HTTPPort),
+		ServiceName:     getEnv("GATEWAY_SERVICE_NAME", base.ServiceName),
+		CortexAddr:      getEnv("CORTEX_ADDR", base.CortexAddr),
+		CortexAuthToken: getEnv("CORTEX_AUTH_TOKEN", base.CortexAuthToken),
+		ItemStorePath:   getEnv("ITEM_STORE_PATH", base.ItemStorePath),
+		ItemRetention:   getDurationEnv("ITEM_RETENTION", base.ItemRetention),
+		WebhookSecret:   getEnv("WEBHOOK_SECRET", base.WebhookSecret),
+		EnqueueTimeout:  getDurationEnv("WEBHOOK_ENQUEUE_TIMEOUT", base.EnqueueTimeout),
+		DedupWindow:     getDurationEnv("WEBHOOK_DEDUP_WINDOW", base.DedupWindow),
+		PollInterval:    getDurationEnv("POLL_INTERVAL", base.PollInterval),
+		RSSFeeds:        getEnvList("RSS_FEEDS", base.RSSFeeds),
+		RSSPollInterval: getDurationEnv("RSS_POLL_INTERVAL", base.RSSPollInterval),
+		ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", base.ShutdownTimeout),
+		TLSCertFile:     getEnv("TLS_CERT_FILE", base.TLSCertFile),
+		TLSKeyFile:      getEnv("TLS_KEY_FILE", base.TLSKeyFile),
+		TLSCAFile:       getEnv("TLS_CA_FILE", base.TLSCAFile),
+		DownstreamTLS:   getEnvBool("DOWNSTREAM_TLS", base.DownstreamTLS),
+		AuthTokens:      getEnvList("AUTH_TOKENS", base.AuthTokens),
+		OTelEndpoint:    getEnv("OTEL_ENDPOINT", base.OTelEndpoint),
+	}, nil
 }
 
-// Load reads configuration from environment variables with defaults.
-func Load() *Config {
+// defaults returns the configuration used when neither the config file nor
+// the environment sets a value.
+func defaults() *Config {
 	return &Config{
-		GRPCPort:      getEnvInt("GATEWAY_GRPC_PORT", 50054),
-		HTTPPort:      getEnvInt("GATEWAY_HTTP_PORT", 8081),
-		ServiceName:   getEnv("GATEWAY_SERVICE_NAME", "sensory-gateway"),
-		CortexAddr:    getEnv("CORTEX_ADDR", "localhost:50051"),
-		WebhookSecret: getEnv("WEBHOOK_SECRET", ""),
-		PollInterval:  getDurationEnv("POLL_INTERVAL", 5*time.Minute),
-		OTelEndpoint:  getEnv("OTEL_ENDPOINT", ""),
+		GRPCPort:        50054,
+		HTTPPort:        8081,
+		ServiceName:     "sensory-gateway",
+		CortexAddr:      "localhost:50051",
+		ItemRetention:   24 * time.Hour,
+		EnqueueTimeout:  2 * time.Second,
+		DedupWindow:     10 * time.Minute,
+		PollInterval:    5 * time.Minute,
+		RSSPollInterval: 15 * time.Minute,
+		ShutdownTimeout: 30 * time.Second,
 	}
 }
 
@@ -52,6 +119,30 @@ func getEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// getEnvList parses a comma-separated list, ignoring empty entries.
+func getEnvList(key string, fallback []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func getDurationEnv(key string, fallback time.Duration) time.Duration {
 	if v := os.Getenv(key); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
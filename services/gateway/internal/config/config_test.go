@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testConfigYAML = `http_port: 9090
+rss_feeds:
+  - https://example.com/a.xml
+  - https://example.com/b.xml
+webhook_dedup_window: 1m
+`
+
+// writeConfigFile writes content to a temp file and points CONFIG_FILE at it.
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+}
+
+// mustLoad calls Load and fails the test on error.
+func mustLoad(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+func TestLoadFromFile(t *testing.T) {
+	writeConfigFile(t, testConfigYAML)
+
+	cfg := mustLoad(t)
+
+	if got := cfg.HTTPPort; got != 9090 {
+		t.Errorf("cfg.HTTPPort: expected %d, got %d", 9090, got)
+	}
+	if got := len(cfg.RSSFeeds); got != 2 {
+		t.Errorf("len(cfg.RSSFeeds): expected %d, got %d", 2, got)
+	}
+	if got := cfg.DedupWindow; got != time.Minute {
+		t.Errorf("cfg.DedupWindow: expected %v, got %v", time.Minute, got)
+	}
+	if got := cfg.GRPCPort; got != 50054 {
+		t.Errorf("cfg.GRPCPort: expected %d, got %d", 50054, got)
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	writeConfigFile(t, testConfigYAML)
+	t.Setenv("GATEWAY_HTTP_PORT", "9191")
+
+	cfg := mustLoad(t)
+
+	if got := cfg.HTTPPort; got != 9191 {
+		t.Errorf("cfg.HTTPPort: expected %d, got %d", 9191, got)
+	}
+	if got := cfg.DedupWindow; got != time.Minute {
+		t.Errorf("cfg.DedupWindow: expected %v, got %v", time.Minute, got)
+	}
+}
+
+func TestLoadMissingExplicitFileFails(t *testing.T) {
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected an error for a missing CONFIG_FILE")
+	}
+}
+
+func TestLoadMalformedExplicitFileFails(t *testing.T) {
+	writeConfigFile(t, "grpc_port: [not a port\n")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected an error for a malformed CONFIG_FILE")
+	}
+}
+
+func TestLoadMissingDefaultFileUsesDefaults(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+
+	cfg := mustLoad(t)
+
+	if got := cfg.HTTPPort; got != 8081 {
+		t.Errorf("cfg.HTTPPort: expected %d, got %d", 8081, got)
+	}
+}
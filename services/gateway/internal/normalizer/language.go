@@ -0,0 +1,97 @@
+package normalizer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// undetermined is the ISO 639 code for text whose language can't be detected.
+const undetermined = "und"
+
+// minLetters is the least amount of text worth guessing a language for.
+const minLetters = 10
+
+// stopwords holds frequent function words for Latin-script languages. The
+// language whose stopwords cover the largest share of the text wins.
+var stopwords = map[string]map[string]bool{
+	"en": wordSet("the and of to in is that it for was on are with as be this have from at by not you"),
+	"es": wordSet("el la de que y en los las un una por con para es del se no al lo como más pero sus"),
+	"fr": wordSet("le la les de des et est un une du que qui en pour dans pas sur au avec ce il"),
+	"de": wordSet("der die das und ist nicht ein eine zu den mit von sich des auf für im dem auch"),
+	"pt": wordSet("o a os as de que e do da em um uma para com não é dos das no na"),
+	"it": wordSet("il lo la gli le di che e è un una per con non del della sono nel alla"),
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// DetectLanguage returns an ISO 639-1 code for the dominant language of text,
+// or "und" when the text is too short or ambiguous. Non-Latin scripts are
+// identified by character ranges; Latin-script languages by stopword ratio.
+func (n *Normalizer) DetectLanguage(text string) string {
+	var letters, han, kana, hangul, cyrillic, arabic int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		}
+	}
+
+	// Han characters carry a word's worth of meaning each, so a handful is
+	// already enough to go on.
+	if han+kana >= 2 && (han+kana)*2 >= letters {
+		if kana > 0 {
+			return "ja"
+		}
+		return "zh"
+	}
+	if letters < minLetters {
+		return undetermined
+	}
+	switch {
+	case hangul*2 >= letters:
+		return "ko"
+	case cyrillic*2 >= letters:
+		return "ru"
+	case arabic*2 >= letters:
+		return "ar"
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < 3 {
+		return undetermined
+	}
+
+	best, bestHits := undetermined, 0
+	for lang, set := range stopwords {
+		hits := 0
+		for _, w := range words {
+			if set[w] {
+				hits++
+			}
+		}
+		if hits > bestHits || (hits == bestHits && hits > 0 && lang < best) {
+			best, bestHits = lang, hits
+		}
+	}
+	return best
+}
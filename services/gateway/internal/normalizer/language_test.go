@@ -0,0 +1,66 @@
+package normalizer
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	n := New()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "english",
+			input:    "The meeting with the design team is on Friday and we need to review the new roadmap.",
+			expected: "en",
+		},
+		{
+			name:     "spanish",
+			input:    "La reunión con el equipo de diseño es el viernes y necesitamos revisar la nueva hoja de ruta.",
+			expected: "es",
+		},
+		{
+			name:     "chinese",
+			input:    "周五和设计团队开会，需要审阅新的路线图。",
+			expected: "zh",
+		},
+		{
+			name:     "japanese",
+			input:    "金曜日にデザインチームと会議があります。",
+			expected: "ja",
+		},
+		{
+			name:     "short text",
+			input:    "ok",
+			expected: "und",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "und",
+		},
+		{
+			name:     "no stopwords",
+			input:    "Kubernetes Terraform Prometheus Grafana",
+			expected: "und",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := n.DetectLanguage(tt.input); got != tt.expected {
+				t.Errorf("DetectLanguage(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeSlackMessageLanguage(t *testing.T) {
+	n := New()
+
+	_, meta := n.NormalizeSlackMessage("Can you send me the notes from the call with the client?", "#general", "U1")
+	if meta["language"] != "en" {
+		t.Errorf("expected language=en, got %q", meta["language"])
+	}
+}
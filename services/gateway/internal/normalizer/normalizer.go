@@ -2,6 +2,7 @@ package normalizer
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -41,6 +42,7 @@ func (n *Normalizer) NormalizeEmail(subject, body string, isHTML bool) (string,
 		"subject": subject,
 		"type":    "email",
 	}
+	metadata["language"] = n.DetectLanguage(subject + "\n" + content)
 
 	return content, metadata
 }
@@ -52,10 +54,24 @@ func (n *Normalizer) NormalizeSlackMessage(text, channel, user string) (string,
 		"user":    user,
 		"type":    "slack",
 	}
+	metadata["language"] = n.DetectLanguage(text)
 
 	return text, metadata
 }
 
+// NormalizeTelegramMessage normalizes a Telegram bot message.
+func (n *Normalizer) NormalizeTelegramMessage(text string, chatID int64, username string) (string, map[string]string) {
+	metadata := map[string]string{
+		"chat_id":  strconv.FormatInt(chatID, 10),
+		"username": username,
+		"type":     "telegram",
+	}
+	content := strings.TrimSpace(text)
+	metadata["language"] = n.DetectLanguage(content)
+
+	return content, metadata
+}
+
 // NormalizeGitHubWebhook normalizes a GitHub webhook payload.
 func (n *Normalizer) NormalizeGitHubWebhook(eventType string, payload map[string]interface{}) (string, map[string]string) {
 	metadata := map[string]string{
@@ -83,6 +99,7 @@ func (n *Normalizer) NormalizeGitHubWebhook(eventType string, payload map[string
 	default:
 		content = eventType + " event received"
 	}
+	metadata["language"] = n.DetectLanguage(content)
 
 	return content, metadata
 }
@@ -88,6 +88,21 @@ func TestNormalizeSlackMessage(t *testing.T) {
 	}
 }
 
+func TestNormalizeTelegramMessage(t *testing.T) {
+	n := New()
+
+	content, meta := n.NormalizeTelegramMessage("  Buy milk  ", -1001234567890, "alice")
+	if content != "Buy milk" {
+		t.Errorf("expected 'Buy milk', got %q", content)
+	}
+	if meta["chat_id"] != "-1001234567890" {
+		t.Errorf("expected chat_id=-1001234567890, got %q", meta["chat_id"])
+	}
+	if meta["username"] != "alice" {
+		t.Errorf("expected username=alice")
+	}
+}
+
 func TestNormalizeGitHubWebhookPush(t *testing.T) {
 	n := New()
 
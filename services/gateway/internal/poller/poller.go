@@ -3,6 +3,7 @@ package poller
 import (
 	"context"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,27 +25,84 @@ type RawItem struct {
 	Metadata map[string]string
 }
 
-// Poller periodically checks external sources for new data.
-type Poller struct {
-	logger   *slog.Logger
-	sources  []Source
+// FetchFunc retrieves new items from a polled source. The poller fills in
+// the ID, source, receive time, priority and content type of items that
+// leave them unset.
+type FetchFunc func(ctx context.Context) ([]*ingestionv1.InboxItem, error)
+
+// DefaultInterval is used when New is given a non-positive interval.
+const DefaultInterval = 5 * time.Minute
+
+// FetchSource adapts a Source to a FetchFunc.
+func FetchSource(src Source) FetchFunc {
+	return func(ctx context.Context) ([]*ingestionv1.InboxItem, error) {
+		raws, err := src.Poll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]*ingestionv1.InboxItem, 0, len(raws))
+		for _, raw := range raws {
+			items = append(items, &ingestionv1.InboxItem{
+				Content:     raw.Content,
+				SourceId:    raw.SourceID,
+				RawMetadata: raw.Metadata,
+			})
+		}
+		return items, nil
+	}
+}
+
+// polledSource is a registered source with its own polling cadence.
+type polledSource struct {
+	name     string
 	interval time.Duration
-	itemChan chan *ingestionv1.InboxItem
+	fetch    FetchFunc
 }
 
-// New creates a new Poller.
+// ticker abstracts time.Ticker so tests can drive polling with a fake clock.
+type ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+func newRealTicker(d time.Duration) ticker { return realTicker{time.NewTicker(d)} }
+
+// Poller periodically checks external sources for new data. Each source is
+// polled on its own ticker; all output is merged onto a single channel.
+type Poller struct {
+	logger    *slog.Logger
+	sources   []polledSource
+	interval  time.Duration
+	itemChan  chan *ingestionv1.InboxItem
+	newTicker func(time.Duration) ticker
+}
+
+// New creates a new Poller. interval is the default for sources registered
+// without their own interval; a non-positive interval uses DefaultInterval.
 func New(logger *slog.Logger, interval time.Duration) *Poller {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
 	return &Poller{
-		logger:   logger,
-		sources:  make([]Source, 0),
-		interval: interval,
-		itemChan: make(chan *ingestionv1.InboxItem, 100),
+		logger:    logger,
+		sources:   make([]polledSource, 0),
+		interval:  interval,
+		itemChan:  make(chan *ingestionv1.InboxItem, 100),
+		newTicker: newRealTicker,
 	}
 }
 
-// AddSource registers a new polling source.
-func (p *Poller) AddSource(source Source) {
-	p.sources = append(p.sources, source)
+// AddSource registers a polling source. A non-positive interval uses the
+// poller's default interval. Sources must be added before Start.
+func (p *Poller) AddSource(name string, interval time.Duration, fetch FetchFunc) {
+	if interval <= 0 {
+		interval = p.interval
+	}
+	p.sources = append(p.sources, polledSource{name: name, interval: interval, fetch: fetch})
 }
 
 // Items returns the channel of polled inbox items.
@@ -52,54 +110,72 @@ func (p *Poller) Items() <-chan *ingestionv1.InboxItem {
 	return p.itemChan
 }
 
-// Start begins polling all registered sources.
+// Start begins polling all registered sources and blocks until ctx is done.
+// Once every source has stopped it closes the Items channel, so Start must
+// be called at most once.
 func (p *Poller) Start(ctx context.Context) {
-	p.logger.Info("starting pollers", "sources", len(p.sources), "interval", p.interval)
+	p.logger.Info("starting pollers", "sources", len(p.sources), "default_interval", p.interval)
+
+	var wg sync.WaitGroup
+	for _, src := range p.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.run(ctx, src)
+		}()
+	}
+	wg.Wait()
+	close(p.itemChan)
+	p.logger.Info("pollers stopped")
+}
 
-	ticker := time.NewTicker(p.interval)
-	defer ticker.Stop()
+// run polls a single source immediately and then on every tick.
+func (p *Poller) run(ctx context.Context, src polledSource) {
+	t := p.newTicker(src.interval)
+	defer t.Stop()
 
-	// Initial poll
-	p.pollAll(ctx)
+	p.pollSource(ctx, src)
 
 	for {
 		select {
 		case <-ctx.Done():
-			p.logger.Info("pollers stopped")
 			return
-		case <-ticker.C:
-			p.pollAll(ctx)
+		case <-t.C():
+			p.pollSource(ctx, src)
 		}
 	}
 }
 
-func (p *Poller) pollAll(ctx context.Context) {
-	for _, source := range p.sources {
-		items, err := source.Poll(ctx)
-		if err != nil {
-			p.logger.Error("poll failed", "source", source.Name(), "error", err)
-			continue
-		}
+func (p *Poller) pollSource(ctx context.Context, src polledSource) {
+	items, err := src.fetch(ctx)
+	if err != nil {
+		p.logger.Error("poll failed", "source", src.name, "error", err)
+		return
+	}
 
-		for _, raw := range items {
-			item := &ingestionv1.InboxItem{
-				Id:          uuid.New().String(),
-				Content:     raw.Content,
-				Source:      source.Name(),
-				SourceId:    raw.SourceID,
-				ReceivedAt:  timestamppb.New(time.Now()),
-				RawMetadata: raw.Metadata,
-				Priority:    commonv1.Priority_PRIORITY_NORMAL,
-				ContentType: "text/plain",
-			}
-
-			select {
-			case p.itemChan <- item:
-			default:
-				p.logger.Warn("item channel full, dropping polled item", "source", source.Name())
-			}
+	for _, item := range items {
+		if item.Id == "" {
+			item.Id = uuid.New().String()
+		}
+		if item.Source == "" {
+			item.Source = src.name
+		}
+		if item.ReceivedAt == nil {
+			item.ReceivedAt = timestamppb.New(time.Now())
+		}
+		if item.Priority == commonv1.Priority_PRIORITY_UNSPECIFIED {
+			item.Priority = commonv1.Priority_PRIORITY_NORMAL
+		}
+		if item.ContentType == "" {
+			item.ContentType = "text/plain"
 		}
 
-		p.logger.Info("poll complete", "source", source.Name(), "items", len(items))
+		select {
+		case p.itemChan <- item:
+		default:
+			p.logger.Warn("item channel full, dropping polled item", "source", src.name)
+		}
 	}
+
+	p.logger.Info("poll complete", "source", src.name, "items", len(items))
 }
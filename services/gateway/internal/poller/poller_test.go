@@ -0,0 +1,158 @@
+package poller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/ingestion/v1"
+)
+
+// fakeClock hands out tickers that only fire when the test advances time.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+	created chan struct{}
+}
+
+type fakeTicker struct {
+	period time.Duration
+	next   time.Time
+	ch     chan time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.ch }
+func (t *fakeTicker) Stop()               {}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0), created: make(chan struct{}, 10)}
+}
+
+func (c *fakeClock) newTicker(d time.Duration) ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{period: d, next: c.now.Add(d), ch: make(chan time.Time)}
+	c.tickers = append(c.tickers, t)
+	c.created <- struct{}{}
+	return t
+}
+
+// advance moves the clock forward, delivering each due tick synchronously.
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	tickers := append([]*fakeTicker(nil), c.tickers...)
+	c.mu.Unlock()
+
+	for _, t := range tickers {
+		for !t.next.After(now) {
+			t.ch <- t.next
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+func TestPollerPerSourceIntervals(t *testing.T) {
+	clock := newFakeClock()
+	p := New(newTestLogger(), time.Minute)
+	p.newTicker = clock.newTicker
+
+	polls := make(chan string, 100)
+	fetch := func(name string) FetchFunc {
+		return func(ctx context.Context) ([]*ingestionv1.InboxItem, error) {
+			polls <- name
+			return []*ingestionv1.InboxItem{{Content: name + " item"}}, nil
+		}
+	}
+	p.AddSource("fast", 10*time.Second, fetch("fast"))
+	p.AddSource("slow", 30*time.Second, fetch("slow"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.Start(ctx)
+		close(done)
+	}()
+	<-clock.created
+	<-clock.created
+
+	counts := map[string]int{}
+	drain := func(n int) {
+		for i := 0; i < n; i++ {
+			select {
+			case name := <-polls:
+				counts[name]++
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for poll %d/%d (counts %v)", i+1, n, counts)
+			}
+		}
+	}
+
+	// Initial poll of both sources.
+	drain(2)
+
+	// 30s: fast ticks at 10s, 20s, 30s; slow ticks once at 30s.
+	clock.advance(30 * time.Second)
+	drain(4)
+
+	cancel()
+	<-done
+
+	if counts["fast"] != 4 {
+		t.Errorf("expected fast source polled 4 times, got %d", counts["fast"])
+	}
+	if counts["slow"] != 2 {
+		t.Errorf("expected slow source polled 2 times, got %d", counts["slow"])
+	}
+	if got := len(p.itemChan); got != 6 {
+		t.Errorf("expected 6 merged items, got %d", got)
+	}
+}
+
+func TestAddSourceDefaultInterval(t *testing.T) {
+	p := New(newTestLogger(), 5*time.Minute)
+	p.AddSource("default", 0, func(ctx context.Context) ([]*ingestionv1.InboxItem, error) { return nil, nil })
+	if got := p.sources[0].interval; got != 5*time.Minute {
+		t.Errorf("expected default interval 5m, got %v", got)
+	}
+}
+
+func TestNewDefaultsNonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if got := New(newTestLogger(), interval).interval; got != DefaultInterval {
+			t.Errorf("New(%v): expected default interval %v, got %v", interval, DefaultInterval, got)
+		}
+	}
+}
+
+func TestStartClosesItemsWhenStopped(t *testing.T) {
+	p := New(newTestLogger(), time.Minute)
+	p.newTicker = newFakeClock().newTicker
+	p.AddSource("notes", 0, func(ctx context.Context) ([]*ingestionv1.InboxItem, error) {
+		return []*ingestionv1.InboxItem{{Content: "queued"}}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.Start(ctx)
+		close(done)
+	}()
+	item := <-p.Items()
+	cancel()
+	<-done
+
+	if item.Content != "queued" {
+		t.Errorf("expected the polled item, got %q", item.Content)
+	}
+	if item.Id == "" || item.Source != "notes" || item.ReceivedAt == nil || item.Priority != commonv1.Priority_PRIORITY_NORMAL {
+		t.Errorf("expected unset item fields to be filled in, got %v", item)
+	}
+	if _, ok := <-p.Items(); ok {
+		t.Error("expected Items to be closed once pollers stopped")
+	}
+}
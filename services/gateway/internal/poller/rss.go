@@ -0,0 +1,255 @@
+package poller
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ziyixi/SecondBrain/services/gateway/internal/normalizer"
+)
+
+// maxFeedSize caps how much of a feed response is read.
+const maxFeedSize = 5 << 20 // 5MB
+
+// maxSeenGUIDs bounds how many entry GUIDs an RSSSource remembers.
+const maxSeenGUIDs = 1000
+
+// RSSSource polls a single RSS 2.0 or Atom feed. It remembers the GUIDs of
+// the entries it has emitted so each poll only returns entries it has not
+// seen, even when a feed reorders or removes entries.
+type RSSSource struct {
+	url        string
+	client     *http.Client
+	normalizer *normalizer.Normalizer
+
+	mu        sync.Mutex
+	seen      map[string]struct{}
+	seenOrder []string // oldest first, for eviction
+}
+
+// NewRSSSource creates a source for the feed at url. A nil client uses a
+// default client with a 30 second timeout.
+func NewRSSSource(url string, client *http.Client) *RSSSource {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &RSSSource{
+		url:        url,
+		client:     client,
+		normalizer: normalizer.New(),
+		seen:       make(map[string]struct{}),
+	}
+}
+
+// Name implements Source.
+func (s *RSSSource) Name() string {
+	return "rss"
+}
+
+// Poll implements Source. Entries are returned oldest first.
+func (s *RSSSource) Poll(ctx context.Context) ([]RawItem, error) {
+	entries, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Feeds list newest entries first.
+	var fresh []feedEntry
+	for _, e := range entries {
+		if _, ok := s.seen[e.GUID]; !ok {
+			fresh = append(fresh, e)
+		}
+	}
+	for i := len(fresh) - 1; i >= 0; i-- {
+		s.markSeen(fresh[i].GUID)
+	}
+
+	items := make([]RawItem, 0, len(fresh))
+	for i := len(fresh) - 1; i >= 0; i-- {
+		items = append(items, s.toRawItem(fresh[i]))
+	}
+	return items, nil
+}
+
+// markSeen remembers guid, forgetting the oldest GUID once maxSeenGUIDs are
+// held. Caller must hold s.mu.
+func (s *RSSSource) markSeen(guid string) {
+	if _, ok := s.seen[guid]; ok {
+		return
+	}
+	s.seen[guid] = struct{}{}
+	s.seenOrder = append(s.seenOrder, guid)
+	if len(s.seenOrder) > maxSeenGUIDs {
+		delete(s.seen, s.seenOrder[0])
+		s.seenOrder = s.seenOrder[1:]
+	}
+}
+
+func (s *RSSSource) fetch(ctx context.Context) ([]feedEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building feed request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching feed %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching feed %s: unexpected status %d", s.url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading feed %s: %w", s.url, err)
+	}
+
+	entries, err := parseFeed(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing feed %s: %w", s.url, err)
+	}
+	return entries, nil
+}
+
+func (s *RSSSource) toRawItem(e feedEntry) RawItem {
+	content := e.Title
+	if summary := s.normalizer.StripHTML(e.Summary); summary != "" {
+		content += "\n\n" + summary
+	}
+
+	metadata := map[string]string{
+		"type":     "rss",
+		"feed_url": s.url,
+		"title":    e.Title,
+		"language": s.normalizer.DetectLanguage(content),
+	}
+	if e.Link != "" {
+		metadata["link"] = e.Link
+	}
+	if e.Published != "" {
+		metadata["published"] = e.Published
+	}
+
+	return RawItem{
+		Content:  content,
+		SourceID: e.GUID,
+		Metadata: metadata,
+	}
+}
+
+// feedEntry is a format-neutral view of an RSS item or Atom entry.
+type feedEntry struct {
+	GUID      string
+	Title     string
+	Link      string
+	Summary   string
+	Published string
+}
+
+type rssDocument struct {
+	Channel struct {
+		Items []struct {
+			GUID        string `xml:"guid"`
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			PubDate     string `xml:"pubDate"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+type atomDocument struct {
+	Entries []struct {
+		ID    string `xml:"id"`
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Summary   string `xml:"summary"`
+		Content   string `xml:"content"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+	} `xml:"entry"`
+}
+
+// parseFeed decodes an RSS 2.0 or Atom document, detected by its root element.
+func parseFeed(data []byte) ([]feedEntry, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	switch root.XMLName.Local {
+	case "rss":
+		var doc rssDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		entries := make([]feedEntry, 0, len(doc.Channel.Items))
+		for _, it := range doc.Channel.Items {
+			guid := strings.TrimSpace(it.GUID)
+			if guid == "" {
+				guid = strings.TrimSpace(it.Link)
+			}
+			entries = append(entries, feedEntry{
+				GUID:      guid,
+				Title:     strings.TrimSpace(it.Title),
+				Link:      strings.TrimSpace(it.Link),
+				Summary:   it.Description,
+				Published: strings.TrimSpace(it.PubDate),
+			})
+		}
+		return entries, nil
+
+	case "feed":
+		var doc atomDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		entries := make([]feedEntry, 0, len(doc.Entries))
+		for _, e := range doc.Entries {
+			var link string
+			for _, l := range e.Links {
+				if l.Rel == "" || l.Rel == "alternate" {
+					link = l.Href
+					break
+				}
+			}
+			summary := e.Summary
+			if summary == "" {
+				summary = e.Content
+			}
+			published := e.Published
+			if published == "" {
+				published = e.Updated
+			}
+			entries = append(entries, feedEntry{
+				GUID:      strings.TrimSpace(e.ID),
+				Title:     strings.TrimSpace(e.Title),
+				Link:      link,
+				Summary:   summary,
+				Published: strings.TrimSpace(published),
+			})
+		}
+		return entries, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported feed format %q", root.XMLName.Local)
+	}
+}
@@ -0,0 +1,172 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+}
+
+// feedServer serves an RSS feed whose items can be replaced between polls.
+type feedServer struct {
+	mu    sync.Mutex
+	items []string // GUIDs, newest first
+	fail  bool
+}
+
+func (f *feedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		http.Error(w, "boom", http.StatusInternalServerError)
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>`)
+	for _, guid := range f.items {
+		fmt.Fprintf(&b, `<item><guid>%s</guid><title>Post %s</title><link>https://example.com/%s</link><description>&lt;p&gt;Body of %s&lt;/p&gt;</description></item>`,
+			guid, guid, guid, guid)
+	}
+	b.WriteString(`</channel></rss>`)
+	w.Header().Set("Content-Type", "application/rss+xml")
+	w.Write([]byte(b.String()))
+}
+
+func (f *feedServer) set(items ...string) {
+	f.mu.Lock()
+	f.items = items
+	f.mu.Unlock()
+}
+
+func (f *feedServer) setFail(fail bool) {
+	f.mu.Lock()
+	f.fail = fail
+	f.mu.Unlock()
+}
+
+func TestRSSSourceEmitsNewEntriesOnce(t *testing.T) {
+	feed := &feedServer{items: []string{"2", "1"}}
+	srv := httptest.NewServer(feed)
+	defer srv.Close()
+
+	p := New(newTestLogger(), 0)
+	src := NewRSSSource(srv.URL, srv.Client())
+	p.AddSource(src.Name(), 0, FetchSource(src))
+	ctx := context.Background()
+
+	p.pollSource(ctx, p.sources[0])
+	if got := len(p.itemChan); got != 2 {
+		t.Fatalf("expected 2 items on first poll, got %d", got)
+	}
+	first := <-p.Items()
+	if first.Source != "rss" || first.SourceId != "1" {
+		t.Errorf("expected oldest entry first from source rss, got %s/%s", first.Source, first.SourceId)
+	}
+	if first.Content != "Post 1\n\nBody of 1" {
+		t.Errorf("unexpected content %q", first.Content)
+	}
+	if first.RawMetadata["link"] != "https://example.com/1" {
+		t.Errorf("unexpected link %q", first.RawMetadata["link"])
+	}
+	<-p.Items()
+
+	// Nothing new: no items.
+	p.pollSource(ctx, p.sources[0])
+	if got := len(p.itemChan); got != 0 {
+		t.Fatalf("expected no items on unchanged feed, got %d", got)
+	}
+
+	// A failed fetch is logged and skipped without losing position.
+	feed.setFail(true)
+	p.pollSource(ctx, p.sources[0])
+	feed.setFail(false)
+
+	feed.set("3", "2", "1")
+	p.pollSource(ctx, p.sources[0])
+	if got := len(p.itemChan); got != 1 {
+		t.Fatalf("expected 1 new item, got %d", got)
+	}
+	if item := <-p.Items(); item.SourceId != "3" {
+		t.Errorf("expected entry 3, got %s", item.SourceId)
+	}
+}
+
+func TestRSSSourceSkipsSeenEntriesWhenReordered(t *testing.T) {
+	feed := &feedServer{items: []string{"2", "1"}}
+	srv := httptest.NewServer(feed)
+	defer srv.Close()
+
+	src := NewRSSSource(srv.URL, srv.Client())
+	ctx := context.Background()
+	if items, err := src.Poll(ctx); err != nil || len(items) != 2 {
+		t.Fatalf("expected 2 items on first poll, got %d (%v)", len(items), err)
+	}
+
+	// The newest entry is removed and an older one moves to the top.
+	feed.set("1", "3")
+	items, err := src.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].SourceID != "3" {
+		t.Errorf("expected only entry 3, got %v", items)
+	}
+}
+
+func TestRSSSourceBoundsSeenGUIDs(t *testing.T) {
+	src := NewRSSSource("", nil)
+	for i := 0; i < maxSeenGUIDs+10; i++ {
+		src.markSeen(fmt.Sprint(i))
+	}
+	if len(src.seen) != maxSeenGUIDs || len(src.seenOrder) != maxSeenGUIDs {
+		t.Fatalf("expected %d seen GUIDs, got %d/%d", maxSeenGUIDs, len(src.seen), len(src.seenOrder))
+	}
+	if _, ok := src.seen["0"]; ok {
+		t.Error("expected the oldest GUID to be forgotten")
+	}
+	if _, ok := src.seen[fmt.Sprint(maxSeenGUIDs+9)]; !ok {
+		t.Error("expected the newest GUID to be kept")
+	}
+}
+
+func TestParseAtomFeed(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Example</title>
+  <entry>
+    <id>urn:uuid:1225c695</id>
+    <title>Atom-Powered Robots Run Amok</title>
+    <link rel="alternate" href="http://example.org/2003/12/13/atom03"/>
+    <updated>2003-12-13T18:30:02Z</updated>
+    <summary>Some text.</summary>
+  </entry>
+</feed>`)
+
+	entries, err := parseFeed(data)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.GUID != "urn:uuid:1225c695" || e.Link != "http://example.org/2003/12/13/atom03" || e.Published != "2003-12-13T18:30:02Z" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+}
+
+func TestParseFeedUnsupported(t *testing.T) {
+	if _, err := parseFeed([]byte(`<html></html>`)); err == nil {
+		t.Error("expected error for non-feed document")
+	}
+}
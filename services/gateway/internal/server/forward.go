@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/ingestion/v1"
+)
+
+// forwardTimeout bounds a single hand-off of an item to the forwarder.
+const forwardTimeout = 30 * time.Second
+
+// SetForwarder makes the gateway hand every stored item to f (the Cortex
+// IngestionService) and track the outcome in the item's status. It must be
+// called before the server starts handling requests; without a forwarder
+// items stay NEW until someone else sets their status.
+func (s *GatewayServer) SetForwarder(f ingestionv1.IngestionServiceClient) {
+	s.forwarder = f
+}
+
+// forward sends a stored item to the forwarder, marking it ANALYZING while
+// the call is in flight, then FILED once accepted or ERROR if the call
+// failed or was rejected. It returns the item's resulting status.
+func (s *GatewayServer) forward(ctx context.Context, item *ingestionv1.InboxItem) commonv1.ProcessingStatus {
+	if s.forwarder == nil {
+		return commonv1.ProcessingStatus_PROCESSING_STATUS_NEW
+	}
+	s.SetItemStatus(item.Id, commonv1.ProcessingStatus_PROCESSING_STATUS_ANALYZING)
+
+	// The item is already stored, so finish the hand-off even if the caller
+	// that delivered it goes away.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), forwardTimeout)
+	defer cancel()
+
+	resp, err := s.forwarder.IngestItem(ctx, &ingestionv1.IngestRequest{Item: item})
+	switch {
+	case err != nil:
+		s.logger.WarnContext(ctx, "failed to forward item", "id", item.Id, "error", err)
+	case !resp.GetAccepted() || resp.GetStatus() == commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR:
+		s.logger.WarnContext(ctx, "forwarded item was rejected", "id", item.Id, "message", resp.GetMessage())
+	default:
+		s.MarkProcessed(item.Id)
+		return commonv1.ProcessingStatus_PROCESSING_STATUS_FILED
+	}
+	s.SetItemStatus(item.Id, commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR)
+	return commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR
+}
+
+// StartPurger periodically removes items that have been FILED for longer
+// than retention, so forwarded items don't accumulate in the inbox. It
+// returns a function that stops the purger; a non-positive retention
+// disables it.
+func (s *GatewayServer) StartPurger(retention time.Duration) (stop func()) {
+	if retention <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(retention)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if n := s.purgeFiled(s.now().Add(-retention)); n > 0 {
+					s.logger.Info("purged filed inbox items", "count", n)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/ingestion/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// fakeForwarder stands in for the Cortex IngestionService. It records the
+// item's gateway status at the time of each call.
+type fakeForwarder struct {
+	ingestionv1.IngestionServiceClient
+	s        *GatewayServer
+	resp     *ingestionv1.IngestResponse
+	err      error
+	inFlight []commonv1.ProcessingStatus
+}
+
+func (f *fakeForwarder) IngestItem(ctx context.Context, req *ingestionv1.IngestRequest, opts ...grpc.CallOption) (*ingestionv1.IngestResponse, error) {
+	f.s.mu.RLock()
+	f.inFlight = append(f.inFlight, f.s.statuses[req.GetItem().GetId()].status)
+	f.s.mu.RUnlock()
+	return f.resp, f.err
+}
+
+func TestForwardSetsItemStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *ingestionv1.IngestResponse
+		err  error
+		want commonv1.ProcessingStatus
+	}{
+		{"accepted", &ingestionv1.IngestResponse{Accepted: true}, nil, commonv1.ProcessingStatus_PROCESSING_STATUS_FILED},
+		{"rejected", &ingestionv1.IngestResponse{Accepted: false, Message: "no"}, nil, commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR},
+		{"indexing failed", &ingestionv1.IngestResponse{Accepted: true, Status: commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR}, nil, commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR},
+		{"unreachable", nil, errors.New("connection refused"), commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewGatewayServer(newTestLogger())
+			f := &fakeForwarder{s: s, resp: tt.resp, err: tt.err}
+			s.SetForwarder(f)
+
+			s.AddItem(&ingestionv1.InboxItem{Id: "webhook-1", Content: "From a webhook"})
+			resp, err := s.IngestItem(context.Background(), &ingestionv1.IngestRequest{
+				Item: &ingestionv1.InboxItem{Id: "rpc-1", Content: "From an RPC"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Status != tt.want {
+				t.Errorf("expected IngestItem to report %v, got %v", tt.want, resp.Status)
+			}
+
+			for _, id := range []string{"webhook-1", "rpc-1"} {
+				st, _ := s.GetItemStatus(context.Background(), &ingestionv1.ItemStatusRequest{ItemId: id})
+				if st.Status != tt.want {
+					t.Errorf("%s: expected %v, got %v", id, tt.want, st.Status)
+				}
+			}
+			for i, st := range f.inFlight {
+				if st != commonv1.ProcessingStatus_PROCESSING_STATUS_ANALYZING {
+					t.Errorf("call %d: expected ANALYZING while forwarding, got %v", i, st)
+				}
+			}
+			if len(f.inFlight) != 2 {
+				t.Errorf("expected 2 forwarded items, got %d", len(f.inFlight))
+			}
+		})
+	}
+}
+
+// unindexedCortex answers IngestItem the way Cortex does when Hippocampus
+// rejects the document.
+type unindexedCortex struct {
+	ingestionv1.UnimplementedIngestionServiceServer
+}
+
+func (unindexedCortex) IngestItem(ctx context.Context, req *ingestionv1.IngestRequest) (*ingestionv1.IngestResponse, error) {
+	return &ingestionv1.IngestResponse{
+		ItemId:  req.GetItem().GetId(),
+		Message: "Item could not be indexed",
+		Status:  commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR,
+	}, nil
+}
+
+func TestForwardUnindexedItemStaysRetryable(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	cortex := grpc.NewServer()
+	ingestionv1.RegisterIngestionServiceServer(cortex, unindexedCortex{})
+	go cortex.Serve(lis)
+	t.Cleanup(cortex.Stop)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial cortex: %v", err)
+	}
+	defer conn.Close()
+
+	s := NewGatewayServer(newTestLogger())
+	s.SetForwarder(ingestionv1.NewIngestionServiceClient(conn))
+	resp, err := s.IngestItem(context.Background(), &ingestionv1.IngestRequest{
+		Item: &ingestionv1.InboxItem{Id: "rpc-1", Content: "From an RPC"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR {
+		t.Errorf("expected ERROR for an unindexed item, got %v", resp.Status)
+	}
+
+	if n := s.purgeFiled(s.now().Add(time.Hour)); n != 0 {
+		t.Errorf("expected the unindexed item not to be purged, purged %d", n)
+	}
+	st, err := s.GetItemStatus(context.Background(), &ingestionv1.ItemStatusRequest{ItemId: "rpc-1"})
+	if err != nil || st.Status != commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR {
+		t.Errorf("expected the item kept as ERROR, got %v (%v)", st, err)
+	}
+}
+
+func TestNoForwarderLeavesItemsNew(t *testing.T) {
+	s := NewGatewayServer(newTestLogger())
+	resp, _ := s.IngestItem(context.Background(), &ingestionv1.IngestRequest{
+		Item: &ingestionv1.InboxItem{Id: "item-1", Content: "Content"},
+	})
+	if resp.Status != commonv1.ProcessingStatus_PROCESSING_STATUS_NEW {
+		t.Errorf("expected NEW without a forwarder, got %v", resp.Status)
+	}
+}
+
+func TestPurgeFiledKeepsRecentItems(t *testing.T) {
+	s := NewGatewayServer(newTestLogger())
+	clock := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return clock }
+
+	s.AddItem(&ingestionv1.InboxItem{Id: "old", Content: "A"})
+	s.MarkProcessed("old")
+	clock = clock.Add(2 * time.Hour)
+	s.AddItem(&ingestionv1.InboxItem{Id: "recent", Content: "B"})
+	s.MarkProcessed("recent")
+	s.AddItem(&ingestionv1.InboxItem{Id: "pending", Content: "C"})
+
+	if n := s.purgeFiled(clock.Add(-time.Hour)); n != 1 {
+		t.Fatalf("expected 1 purged item, got %d", n)
+	}
+	for id, want := range map[string]bool{"old": false, "recent": true, "pending": true} {
+		if _, ok := s.items[id]; ok != want {
+			t.Errorf("%s: expected present=%v", id, want)
+		}
+	}
+}
+
+func TestStartPurgerDisabled(t *testing.T) {
+	s := NewGatewayServer(newTestLogger())
+	stop := s.StartPurger(0)
+	stop()
+	stop()
+}
@@ -2,30 +2,68 @@ package server
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
 	ingestionv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/ingestion/v1"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// defaultFlushDelay is how long changes are batched before a file-backed
+// server writes them to disk.
+const defaultFlushDelay = 500 * time.Millisecond
+
 // GatewayServer implements the gRPC IngestionService.
 type GatewayServer struct {
 	ingestionv1.UnimplementedIngestionServiceServer
 	commonv1.UnimplementedHealthServiceServer
 
-	logger  *slog.Logger
-	items   map[string]*ingestionv1.InboxItem
-	version string
+	logger   *slog.Logger
+	mu       sync.RWMutex // guards items and statuses
+	items    map[string]*ingestionv1.InboxItem
+	statuses map[string]itemStatus // item ID -> current processing status
+	version  string
+	now      func() time.Time
+
+	// path is the JSON file items are persisted to; empty keeps them in
+	// memory only. See NewGatewayServerWithStore.
+	path         string
+	saveMu       sync.Mutex // serializes writes and guards closed
+	closed       bool
+	flushPending atomic.Bool   // a delayed flush is scheduled
+	flushDelay   time.Duration // how long persist waits to batch changes
+
+	// forwarder receives every stored item; nil leaves items NEW. See
+	// SetForwarder.
+	forwarder ingestionv1.IngestionServiceClient
+}
+
+// itemStatus is an item's current processing status and when it was set.
+type itemStatus struct {
+	status  commonv1.ProcessingStatus
+	updated time.Time
 }
 
 // NewGatewayServer creates a new GatewayServer.
 func NewGatewayServer(logger *slog.Logger) *GatewayServer {
 	return &GatewayServer{
-		logger:  logger,
-		items:   make(map[string]*ingestionv1.InboxItem),
-		version: "0.1.0",
+		logger:     logger,
+		items:      make(map[string]*ingestionv1.InboxItem),
+		statuses:   make(map[string]itemStatus),
+		version:    "0.1.0",
+		now:        time.Now,
+		flushDelay: defaultFlushDelay,
 	}
 }
 
@@ -48,14 +86,14 @@ func (s *GatewayServer) IngestItem(ctx context.Context, req *ingestionv1.IngestR
 		}, nil
 	}
 
-	s.items[item.Id] = item
+	s.storeItem(item)
 	s.logger.Info("item ingested", "id", item.Id, "source", item.Source)
 
 	return &ingestionv1.IngestResponse{
 		ItemId:   item.Id,
 		Accepted: true,
 		Message:  "item accepted",
-		Status:   commonv1.ProcessingStatus_PROCESSING_STATUS_NEW,
+		Status:   s.forward(ctx, item),
 	}, nil
 }
 
@@ -86,14 +124,18 @@ func (s *GatewayServer) StreamIngest(stream ingestionv1.IngestionService_StreamI
 			continue
 		}
 
-		s.items[item.Id] = item
+		s.storeItem(item)
+		s.forward(stream.Context(), item)
 		totalAccepted++
 	}
 }
 
 // GetItemStatus implements the IngestionService GetItemStatus RPC.
 func (s *GatewayServer) GetItemStatus(ctx context.Context, req *ingestionv1.ItemStatusRequest) (*ingestionv1.ItemStatusResponse, error) {
+	s.mu.RLock()
 	item, exists := s.items[req.ItemId]
+	st := s.statuses[req.ItemId]
+	s.mu.RUnlock()
 	if !exists {
 		return &ingestionv1.ItemStatusResponse{
 			ItemId: req.ItemId,
@@ -103,28 +145,166 @@ func (s *GatewayServer) GetItemStatus(ctx context.Context, req *ingestionv1.Item
 
 	return &ingestionv1.ItemStatusResponse{
 		ItemId:      item.Id,
-		Status:      commonv1.ProcessingStatus_PROCESSING_STATUS_NEW,
-		LastUpdated: item.ReceivedAt,
+		Status:      st.status,
+		LastUpdated: timestamppb.New(st.updated),
 	}, nil
 }
 
-// ListItems implements the IngestionService ListItems RPC.
+// ListItems implements the IngestionService ListItems RPC. Items matching
+// the optional source and status filters are ordered by ReceivedAt, then ID,
+// and paged with PageSize and the opaque PageToken returned as NextPageToken
+// by the previous page. A PageSize of zero returns every remaining item.
 func (s *GatewayServer) ListItems(ctx context.Context, req *ingestionv1.ListItemsRequest) (*ingestionv1.ListItemsResponse, error) {
-	var result []*ingestionv1.InboxItem
-	for _, item := range s.items {
-		result = append(result, item)
-		if len(result) >= int(req.PageSize) && req.PageSize > 0 {
-			break
+	var after *itemCursor
+	if req.GetPageToken() != "" {
+		c, err := decodeItemCursor(req.GetPageToken())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
 		}
+		after = &c
 	}
 
-	return &ingestionv1.ListItemsResponse{
-		Items:      result,
-		TotalCount: int32(len(s.items)),
-	}, nil
+	s.mu.RLock()
+	items := make([]*ingestionv1.InboxItem, 0, len(s.items))
+	for id, item := range s.items {
+		if req.GetSource() != "" && item.GetSource() != req.GetSource() {
+			continue
+		}
+		if req.GetStatusFilter() != commonv1.ProcessingStatus_PROCESSING_STATUS_UNSPECIFIED &&
+			s.statuses[id].status != req.GetStatusFilter() {
+			continue
+		}
+		items = append(items, item)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		return cursorOf(items[i]).before(cursorOf(items[j]))
+	})
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(items), func(i int) bool {
+			return after.before(cursorOf(items[i]))
+		})
+	}
+	end := len(items)
+	if req.GetPageSize() > 0 {
+		end = min(start+int(req.GetPageSize()), len(items))
+	}
+
+	resp := &ingestionv1.ListItemsResponse{
+		Items:      items[start:end],
+		TotalCount: int32(len(items)),
+	}
+	if end < len(items) {
+		resp.NextPageToken = cursorOf(items[end-1]).encode()
+	}
+	return resp, nil
+}
+
+// itemCursor is a position in the ListItems ordering.
+type itemCursor struct {
+	receivedAt int64 // Unix nanoseconds
+	id         string
+}
+
+func cursorOf(item *ingestionv1.InboxItem) itemCursor {
+	return itemCursor{receivedAt: item.GetReceivedAt().AsTime().UnixNano(), id: item.GetId()}
+}
+
+// before reports whether c sorts before o.
+func (c itemCursor) before(o itemCursor) bool {
+	if c.receivedAt != o.receivedAt {
+		return c.receivedAt < o.receivedAt
+	}
+	return c.id < o.id
+}
+
+func (c itemCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.receivedAt, 10) + ":" + c.id))
 }
 
-// AddItem adds an item directly (used by webhook handler).
+func decodeItemCursor(token string) (itemCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return itemCursor{}, err
+	}
+	nanos, id, ok := strings.Cut(string(data), ":")
+	if !ok {
+		return itemCursor{}, fmt.Errorf("malformed page token")
+	}
+	receivedAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return itemCursor{}, err
+	}
+	return itemCursor{receivedAt: receivedAt, id: id}, nil
+}
+
+// AddItem adds an item directly (used by the webhook and poller forwarders)
+// and hands it to the forwarder, if any.
 func (s *GatewayServer) AddItem(item *ingestionv1.InboxItem) {
+	s.storeItem(item)
+	s.forward(context.Background(), item)
+}
+
+// SetItemStatus records a status transition for an item as it is forwarded
+// and indexed: NEW on arrival, ANALYZING while being processed, then FILED
+// once indexed or ERROR if processing failed. It reports whether the item
+// exists.
+func (s *GatewayServer) SetItemStatus(id string, status commonv1.ProcessingStatus) bool {
+	s.mu.Lock()
+	_, exists := s.items[id]
+	if exists {
+		s.statuses[id] = itemStatus{status: status, updated: s.now()}
+	}
+	s.mu.Unlock()
+
+	if exists {
+		s.persist()
+	}
+	return exists
+}
+
+// MarkProcessed marks an item as filed so that PurgeProcessed removes it. It
+// reports whether the item exists.
+func (s *GatewayServer) MarkProcessed(id string) bool {
+	return s.SetItemStatus(id, commonv1.ProcessingStatus_PROCESSING_STATUS_FILED)
+}
+
+// PurgeProcessed removes every filed item and returns how many were removed.
+func (s *GatewayServer) PurgeProcessed() int {
+	return s.purgeFiled(time.Time{})
+}
+
+// purgeFiled removes filed items whose status was set before cutoff, or
+// every filed item when cutoff is zero, and returns how many were removed.
+func (s *GatewayServer) purgeFiled(cutoff time.Time) int {
+	s.mu.Lock()
+	purged := 0
+	for id, st := range s.statuses {
+		if st.status == commonv1.ProcessingStatus_PROCESSING_STATUS_FILED &&
+			(cutoff.IsZero() || st.updated.Before(cutoff)) {
+			delete(s.items, id)
+			delete(s.statuses, id)
+			purged++
+		}
+	}
+	s.mu.Unlock()
+
+	if purged > 0 {
+		s.persist()
+	}
+	return purged
+}
+
+// storeItem records an item with status NEW, replacing any item with the
+// same ID, and persists the change.
+func (s *GatewayServer) storeItem(item *ingestionv1.InboxItem) {
+	s.mu.Lock()
 	s.items[item.Id] = item
+	s.statuses[item.Id] = itemStatus{status: commonv1.ProcessingStatus_PROCESSING_STATUS_NEW, updated: s.now()}
+	s.mu.Unlock()
+
+	s.persist()
 }
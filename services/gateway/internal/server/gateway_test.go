@@ -2,12 +2,17 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
 	ingestionv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/ingestion/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -89,6 +94,44 @@ func TestGetItemStatus(t *testing.T) {
 	}
 }
 
+func TestItemStatusTransitions(t *testing.T) {
+	s := NewGatewayServer(newTestLogger())
+	clock := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return clock }
+
+	s.AddItem(&ingestionv1.InboxItem{Id: "item-1", Content: "Content"})
+
+	steps := []commonv1.ProcessingStatus{
+		commonv1.ProcessingStatus_PROCESSING_STATUS_NEW,
+		commonv1.ProcessingStatus_PROCESSING_STATUS_ANALYZING,
+		commonv1.ProcessingStatus_PROCESSING_STATUS_FILED,
+		commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR,
+	}
+	for i, want := range steps {
+		if i > 0 {
+			clock = clock.Add(time.Minute)
+			if !s.SetItemStatus("item-1", want) {
+				t.Fatalf("expected status update for item-1 to succeed")
+			}
+		}
+
+		resp, err := s.GetItemStatus(context.Background(), &ingestionv1.ItemStatusRequest{ItemId: "item-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != want {
+			t.Errorf("step %d: expected %v, got %v", i, want, resp.Status)
+		}
+		if !resp.LastUpdated.AsTime().Equal(clock) {
+			t.Errorf("step %d: expected last updated %v, got %v", i, clock, resp.LastUpdated.AsTime())
+		}
+	}
+
+	if s.SetItemStatus("missing", commonv1.ProcessingStatus_PROCESSING_STATUS_FILED) {
+		t.Error("expected status update for an unknown item to fail")
+	}
+}
+
 func TestGetItemStatusNotFound(t *testing.T) {
 	s := NewGatewayServer(newTestLogger())
 
@@ -121,3 +164,147 @@ func TestListItems(t *testing.T) {
 		t.Errorf("expected 2 items, got %d", resp.TotalCount)
 	}
 }
+
+func TestListItemsPagination(t *testing.T) {
+	s := NewGatewayServer(newTestLogger())
+	base := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		// Items 4 and 5 share a timestamp, so ID breaks the tie.
+		received := base.Add(time.Duration(min(i, 4)+max(i-5, 0)) * time.Minute)
+		s.AddItem(&ingestionv1.InboxItem{
+			Id:         fmt.Sprintf("item-%d", i),
+			Content:    "content",
+			ReceivedAt: timestamppb.New(received),
+		})
+	}
+
+	var ids []string
+	token := ""
+	for page := 0; ; page++ {
+		if page > 4 {
+			t.Fatal("expected paging to finish within 4 pages")
+		}
+		resp, err := s.ListItems(context.Background(), &ingestionv1.ListItemsRequest{PageSize: 3, PageToken: token})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.TotalCount != 10 {
+			t.Errorf("expected total count 10, got %d", resp.TotalCount)
+		}
+		if resp.NextPageToken != "" && len(resp.Items) != 3 {
+			t.Errorf("expected full pages before the last, got %d items", len(resp.Items))
+		}
+		for _, item := range resp.Items {
+			ids = append(ids, item.Id)
+		}
+		if token = resp.NextPageToken; token == "" {
+			break
+		}
+	}
+
+	if len(ids) != 10 {
+		t.Fatalf("expected 10 items across pages, got %v", ids)
+	}
+	for i, id := range ids {
+		if want := fmt.Sprintf("item-%d", i); id != want {
+			t.Errorf("position %d: expected %s, got %s", i, want, id)
+		}
+	}
+}
+
+func TestListItemsFilters(t *testing.T) {
+	s := NewGatewayServer(newTestLogger())
+	for i, source := range []string{"email", "slack", "email", "rss", "email"} {
+		s.AddItem(&ingestionv1.InboxItem{
+			Id:         fmt.Sprintf("item-%d", i),
+			Content:    "content",
+			Source:     source,
+			ReceivedAt: timestamppb.New(time.Unix(int64(i), 0)),
+		})
+	}
+	s.SetItemStatus("item-2", commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR)
+	s.SetItemStatus("item-3", commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR)
+
+	list := func(req *ingestionv1.ListItemsRequest) []string {
+		t.Helper()
+		resp, err := s.ListItems(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var ids []string
+		for _, item := range resp.Items {
+			ids = append(ids, item.Id)
+		}
+		if resp.TotalCount != int32(len(ids)) {
+			t.Errorf("expected total count %d to match the filter, got %d", len(ids), resp.TotalCount)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name string
+		req  *ingestionv1.ListItemsRequest
+		want string
+	}{
+		{"source", &ingestionv1.ListItemsRequest{Source: "email"}, "[item-0 item-2 item-4]"},
+		{"status", &ingestionv1.ListItemsRequest{StatusFilter: commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR}, "[item-2 item-3]"},
+		{"source and status", &ingestionv1.ListItemsRequest{
+			Source:       "email",
+			StatusFilter: commonv1.ProcessingStatus_PROCESSING_STATUS_ERROR,
+		}, "[item-2]"},
+		{"no match", &ingestionv1.ListItemsRequest{Source: "sms"}, "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(list(tt.req)); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	// Filters apply before paging.
+	first, _ := s.ListItems(context.Background(), &ingestionv1.ListItemsRequest{Source: "email", PageSize: 2})
+	second, _ := s.ListItems(context.Background(), &ingestionv1.ListItemsRequest{Source: "email", PageSize: 2, PageToken: first.NextPageToken})
+	if len(first.Items) != 2 || first.TotalCount != 3 || len(second.Items) != 1 || second.Items[0].Id != "item-4" || second.NextPageToken != "" {
+		t.Errorf("expected email items paged 2+1, got %v then %v", first.Items, second.Items)
+	}
+}
+
+func TestListItemsInvalidPageToken(t *testing.T) {
+	s := NewGatewayServer(newTestLogger())
+	_, err := s.ListItems(context.Background(), &ingestionv1.ListItemsRequest{PageToken: "not a token!"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestConcurrentAddAndList(t *testing.T) {
+	s := NewGatewayServer(newTestLogger())
+
+	const writers, perWriter = 8, 50
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				s.AddItem(&ingestionv1.InboxItem{Id: fmt.Sprintf("%d-%d", w, i), Content: "item"})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, err := s.ListItems(context.Background(), &ingestionv1.ListItemsRequest{PageSize: 10}); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				s.GetItemStatus(context.Background(), &ingestionv1.ItemStatusRequest{ItemId: fmt.Sprintf("%d-%d", w, i)})
+			}
+		}()
+	}
+	wg.Wait()
+
+	resp, _ := s.ListItems(context.Background(), &ingestionv1.ListItemsRequest{})
+	if resp.TotalCount != writers*perWriter {
+		t.Errorf("expected %d items, got %d", writers*perWriter, resp.TotalCount)
+	}
+}
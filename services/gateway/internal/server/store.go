@@ -0,0 +1,155 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/ziyixi/SecondBrain/services/gateway/internal/atomicfile"
+	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/ingestion/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// persistedItem is the on-disk representation of an inbox item.
+type persistedItem struct {
+	Item      json.RawMessage `json:"item"`   // protojson-encoded InboxItem
+	Status    string          `json:"status"` // ProcessingStatus name
+	UpdatedAt time.Time       `json:"updated_at"`
+
+	// Processed is the status field of stores written before statuses were
+	// tracked; it is read when Status is empty and never written.
+	Processed bool `json:"processed,omitempty"`
+}
+
+// NewGatewayServerWithStore creates a GatewayServer backed by a JSON file at
+// path. Items in the file are restored, and every subsequent change (item
+// added, status changed or purged) is flushed back to disk shortly after, so
+// queued items survive restarts. Call Close on shutdown to write the last
+// changes.
+func NewGatewayServerWithStore(logger *slog.Logger, path string) (*GatewayServer, error) {
+	s := NewGatewayServer(logger)
+	s.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading item store: %w", err)
+	}
+
+	var stored []persistedItem
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("decoding item store: %w", err)
+	}
+
+	for _, pi := range stored {
+		item := &ingestionv1.InboxItem{}
+		if err := protojson.Unmarshal(pi.Item, item); err != nil {
+			return nil, fmt.Errorf("decoding stored item: %w", err)
+		}
+		status, ok := commonv1.ProcessingStatus_value[pi.Status]
+		switch {
+		case ok:
+		case pi.Status == "" && pi.Processed:
+			status = int32(commonv1.ProcessingStatus_PROCESSING_STATUS_FILED)
+		default:
+			status = int32(commonv1.ProcessingStatus_PROCESSING_STATUS_NEW)
+		}
+		s.items[item.Id] = item
+		s.statuses[item.Id] = itemStatus{status: commonv1.ProcessingStatus(status), updated: pi.UpdatedAt}
+	}
+
+	return s, nil
+}
+
+// Flush writes all items to the backing store. It is a no-op for in-memory
+// servers.
+func (s *GatewayServer) Flush() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+	return s.write()
+}
+
+// Close writes any changes not yet flushed and stops later delayed flushes
+// from touching the backing store.
+func (s *GatewayServer) Close() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+	s.closed = true
+	return s.write()
+}
+
+// write encodes every item and replaces the backing store. The caller must
+// hold saveMu.
+func (s *GatewayServer) write() error {
+	stored, err := s.snapshot()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("encoding items: %w", err)
+	}
+	if err := atomicfile.WriteFile(s.path, data); err != nil {
+		return fmt.Errorf("saving items: %w", err)
+	}
+	return nil
+}
+
+// persist schedules a flush after a change. Changes made within flushDelay
+// of each other are written together, so a burst such as a StreamIngest
+// batch rewrites the file once rather than once per item.
+func (s *GatewayServer) persist() {
+	if s.path == "" || !s.flushPending.CompareAndSwap(false, true) {
+		return
+	}
+	time.AfterFunc(s.flushDelay, s.flushPendingChanges)
+}
+
+// flushPendingChanges writes the changes scheduled by persist, logging
+// rather than returning errors since it runs on a timer.
+func (s *GatewayServer) flushPendingChanges() {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	// Clear the flag before taking the snapshot so a change made during the
+	// write schedules another flush.
+	s.flushPending.Store(false)
+	if s.closed {
+		return
+	}
+	if err := s.write(); err != nil {
+		s.logger.Warn("failed to persist inbox items", "path", s.path, "error", err)
+	}
+}
+
+// snapshot encodes every item into its persisted form.
+func (s *GatewayServer) snapshot() ([]persistedItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]persistedItem, 0, len(s.items))
+	for id, item := range s.items {
+		data, err := protojson.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("encoding item %s: %w", id, err)
+		}
+		st := s.statuses[id]
+		out = append(out, persistedItem{Item: data, Status: st.status.String(), UpdatedAt: st.updated})
+	}
+	return out, nil
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
+	ingestionv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/ingestion/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGatewayPersistAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+
+	s, err := NewGatewayServerWithStore(newTestLogger(), path)
+	if err != nil {
+		t.Fatalf("creating server: %v", err)
+	}
+	received := timestamppb.Now()
+	s.AddItem(&ingestionv1.InboxItem{
+		Id:          "item-1",
+		Content:     "Follow up with the team",
+		Source:      "email",
+		ReceivedAt:  received,
+		RawMetadata: map[string]string{"from": "alice@example.com"},
+		Priority:    commonv1.Priority_PRIORITY_URGENT,
+	})
+	if _, err := s.IngestItem(context.Background(), &ingestionv1.IngestRequest{
+		Item: &ingestionv1.InboxItem{Id: "item-2", Content: "Read the paper", Source: "slack"},
+	}); err != nil {
+		t.Fatalf("ingest error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("closing server: %v", err)
+	}
+
+	// A fresh server on the same path simulates a restart.
+	reloaded, err := NewGatewayServerWithStore(newTestLogger(), path)
+	if err != nil {
+		t.Fatalf("reloading server: %v", err)
+	}
+
+	resp, _ := reloaded.ListItems(context.Background(), &ingestionv1.ListItemsRequest{})
+	if resp.TotalCount != 2 {
+		t.Fatalf("expected 2 items after reload, got %d", resp.TotalCount)
+	}
+	got := reloaded.items["item-1"]
+	if got.GetContent() != "Follow up with the team" || got.GetRawMetadata()["from"] != "alice@example.com" ||
+		got.GetPriority() != commonv1.Priority_PRIORITY_URGENT || !got.GetReceivedAt().AsTime().Equal(received.AsTime()) {
+		t.Errorf("unexpected item after reload: %v", got)
+	}
+}
+
+func TestGatewayMarkProcessedAndPurge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+
+	s, err := NewGatewayServerWithStore(newTestLogger(), path)
+	if err != nil {
+		t.Fatalf("creating server: %v", err)
+	}
+	s.AddItem(&ingestionv1.InboxItem{Id: "done", Content: "A"})
+	s.AddItem(&ingestionv1.InboxItem{Id: "pending", Content: "B"})
+
+	if !s.MarkProcessed("done") {
+		t.Fatal("expected done to be marked processed")
+	}
+	if s.MarkProcessed("missing") {
+		t.Error("expected marking an unknown item to fail")
+	}
+	status, _ := s.GetItemStatus(context.Background(), &ingestionv1.ItemStatusRequest{ItemId: "done"})
+	if status.Status != commonv1.ProcessingStatus_PROCESSING_STATUS_FILED {
+		t.Errorf("expected FILED status for a processed item, got %v", status.Status)
+	}
+
+	// The status survives a restart.
+	if err := s.Close(); err != nil {
+		t.Fatalf("closing server: %v", err)
+	}
+	reloaded, err := NewGatewayServerWithStore(newTestLogger(), path)
+	if err != nil {
+		t.Fatalf("reloading server: %v", err)
+	}
+	status, _ = reloaded.GetItemStatus(context.Background(), &ingestionv1.ItemStatusRequest{ItemId: "done"})
+	if status.Status != commonv1.ProcessingStatus_PROCESSING_STATUS_FILED {
+		t.Errorf("expected FILED status after reload, got %v", status.Status)
+	}
+	if n := reloaded.PurgeProcessed(); n != 1 {
+		t.Errorf("expected 1 purged item, got %d", n)
+	}
+	if err := reloaded.Close(); err != nil {
+		t.Fatalf("closing server: %v", err)
+	}
+
+	again, err := NewGatewayServerWithStore(newTestLogger(), path)
+	if err != nil {
+		t.Fatalf("reloading server: %v", err)
+	}
+	if _, ok := again.items["done"]; ok {
+		t.Error("expected purged item to stay purged")
+	}
+	if _, ok := again.items["pending"]; !ok {
+		t.Error("expected pending item to be kept")
+	}
+}
+
+func TestGatewayPersistBatchesChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+
+	s, err := NewGatewayServerWithStore(newTestLogger(), path)
+	if err != nil {
+		t.Fatalf("creating server: %v", err)
+	}
+	s.flushDelay = 50 * time.Millisecond
+	for i := range 100 {
+		s.AddItem(&ingestionv1.InboxItem{Id: fmt.Sprintf("item-%d", i), Content: "A"})
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no write before the flush delay, got %v", err)
+	}
+
+	// The batch is written once the delay passes, without an explicit flush.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		reloaded, err := NewGatewayServerWithStore(newTestLogger(), path)
+		if err != nil {
+			t.Fatalf("reloading server: %v", err)
+		}
+		if len(reloaded.items) == 100 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 100 persisted items, got %d", len(reloaded.items))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("closing server: %v", err)
+	}
+}
+
+func TestNewGatewayServerWithStoreMissingFile(t *testing.T) {
+	s, err := NewGatewayServerWithStore(newTestLogger(), filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("expected a missing file to start empty, got %v", err)
+	}
+	if len(s.items) != 0 {
+		t.Errorf("expected no items, got %d", len(s.items))
+	}
+}
+
+func TestNewGatewayServerWithStoreCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewGatewayServerWithStore(newTestLogger(), path); err == nil {
+		t.Error("expected an error for a corrupt store")
+	}
+}
+
+func TestNewGatewayServerWithStoreReadsProcessedField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+	// Stores written before statuses were tracked only have "processed".
+	legacy := `[{"item":{"id":"done","content":"A"},"processed":true},{"item":{"id":"pending","content":"B"},"processed":false}]`
+	if err := os.WriteFile(path, []byte(legacy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewGatewayServerWithStore(newTestLogger(), path)
+	if err != nil {
+		t.Fatalf("loading legacy store: %v", err)
+	}
+	for id, want := range map[string]commonv1.ProcessingStatus{
+		"done":    commonv1.ProcessingStatus_PROCESSING_STATUS_FILED,
+		"pending": commonv1.ProcessingStatus_PROCESSING_STATUS_NEW,
+	} {
+		st, _ := s.GetItemStatus(context.Background(), &ingestionv1.ItemStatusRequest{ItemId: id})
+		if st.Status != want {
+			t.Errorf("%s: expected %v, got %v", id, want, st.Status)
+		}
+	}
+	if n := s.PurgeProcessed(); n != 1 {
+		t.Errorf("expected the legacy processed item to be purged, got %d", n)
+	}
+}
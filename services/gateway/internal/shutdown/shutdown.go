@@ -0,0 +1,34 @@
+// Package shutdown drains the service's gRPC and HTTP servers on shutdown.
+package shutdown
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// Drain stops grpcServer and httpServer from accepting new requests and waits
+// for in-flight ones, including StreamIngest streams and webhook deliveries,
+// to finish. If ctx is done first, the remaining requests are closed
+// forcibly and ctx's error is returned.
+func Drain(ctx context.Context, grpcServer *grpc.Server, httpServer *http.Server) error {
+	grpcDone := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcDone)
+	}()
+
+	err := httpServer.Shutdown(ctx)
+	if err != nil {
+		httpServer.Close() //nolint:errcheck
+	}
+
+	select {
+	case <-grpcDone:
+	case <-ctx.Done():
+		grpcServer.Stop()
+		err = ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// startServers runs an idle gRPC server and an HTTP server whose /webhook
+// handler holds each request open until release is closed. started is
+// closed once a request reaches the handler.
+func startServers(t *testing.T, release chan struct{}) (grpcServer *grpc.Server, httpServer *http.Server, started chan struct{}) {
+	t.Helper()
+
+	grpcLis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcServer = grpc.NewServer()
+	go grpcServer.Serve(grpcLis)
+	t.Cleanup(grpcServer.Stop)
+
+	started = make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhook", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		io.WriteString(w, "accepted")
+	})
+	httpLis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	httpServer = &http.Server{Addr: httpLis.Addr().String(), Handler: mux}
+	go httpServer.Serve(httpLis)
+	t.Cleanup(func() { httpServer.Close() })
+
+	return grpcServer, httpServer, started
+}
+
+// postWebhook sends a webhook request and delivers its status code, or 0 if
+// the request failed.
+func postWebhook(addr string) <-chan int {
+	result := make(chan int, 1)
+	go func() {
+		resp, err := http.Post("http://"+addr+"/webhook", "application/json", nil)
+		if err != nil {
+			result <- 0
+			return
+		}
+		resp.Body.Close()
+		result <- resp.StatusCode
+	}()
+	return result
+}
+
+func TestDrainWaitsForInFlightRequests(t *testing.T) {
+	release := make(chan struct{})
+	grpcServer, httpServer, started := startServers(t, release)
+	result := postWebhook(httpServer.Addr)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	drained := make(chan error, 1)
+	go func() { drained <- Drain(ctx, grpcServer, httpServer) }()
+
+	select {
+	case err := <-drained:
+		t.Fatalf("Drain returned before the in-flight request finished: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	if code := <-result; code != http.StatusAccepted {
+		t.Errorf("expected the in-flight webhook to complete with 202, got %d", code)
+	}
+	if err := <-drained; err != nil {
+		t.Errorf("expected a clean drain, got %v", err)
+	}
+}
+
+func TestDrainForcesCloseAfterDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	grpcServer, httpServer, started := startServers(t, release)
+	result := postWebhook(httpServer.Addr)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := Drain(ctx, grpcServer, httpServer); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if code := <-result; code != 0 {
+		t.Errorf("expected the webhook to be cut off, got %d", code)
+	}
+}
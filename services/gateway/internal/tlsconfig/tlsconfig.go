@@ -0,0 +1,36 @@
+// Package tlsconfig builds gRPC transport credentials for dialing Cortex.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ClientCredentials returns credentials for dialing downstream services.
+// When enabled is false it returns insecure (plaintext) credentials. When
+// caFile is set, server certificates are verified against it instead of the
+// system roots.
+func ClientCredentials(enabled bool, caFile string) (credentials.TransportCredentials, error) {
+	if !enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return credentials.NewTLS(cfg), nil
+}
@@ -0,0 +1,148 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	commonv1 "github.com/ziyixi/SecondBrain/services/gateway/pkg/gen/common/v1"
+)
+
+type healthServer struct {
+	commonv1.UnimplementedHealthServiceServer
+}
+
+func (healthServer) Check(context.Context, *commonv1.HealthCheckRequest) (*commonv1.HealthCheckResponse, error) {
+	return &commonv1.HealthCheckResponse{Status: commonv1.HealthCheckResponse_SERVING}, nil
+}
+
+// writeSelfSignedCert writes a self-signed certificate valid for 127.0.0.1
+// and localhost and returns the certificate and key paths.
+func writeSelfSignedCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "secondbrain-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshaling key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// startServer serves the health service with the given options and returns
+// its address.
+func startServer(t *testing.T, opts ...grpc.ServerOption) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grpc.NewServer(opts...)
+	commonv1.RegisterHealthServiceServer(srv, healthServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func check(t *testing.T, addr string, enabled bool, caFile string) error {
+	t.Helper()
+
+	creds, err := ClientCredentials(enabled, caFile)
+	if err != nil {
+		t.Fatalf("ClientCredentials: %v", err)
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = commonv1.NewHealthServiceClient(conn).Check(ctx, &commonv1.HealthCheckRequest{})
+	return err
+}
+
+func TestTLSConnection(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t)
+	creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("loading certificate: %v", err)
+	}
+	addr := startServer(t, grpc.Creds(creds))
+
+	if err := check(t, addr, true, certFile); err != nil {
+		t.Fatalf("expected TLS call to succeed, got %v", err)
+	}
+	if err := check(t, addr, false, ""); err == nil {
+		t.Error("expected plaintext client to be rejected by a TLS server")
+	}
+	if err := check(t, addr, true, ""); err == nil {
+		t.Error("expected self-signed certificate to be rejected without the CA file")
+	}
+}
+
+func TestInsecureFallback(t *testing.T) {
+	addr := startServer(t)
+
+	if err := check(t, addr, false, ""); err != nil {
+		t.Fatalf("expected plaintext call to succeed, got %v", err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	if _, err := ClientCredentials(true, "/nonexistent/ca.pem"); err == nil {
+		t.Error("expected error for missing CA file")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ClientCredentials(true, empty); err == nil {
+		t.Error("expected error for CA file without certificates")
+	}
+}
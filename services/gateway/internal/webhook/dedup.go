@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSeenItems bounds the dedup cache so bursts can't grow it without limit.
+const maxSeenItems = 10000
+
+// seenItem records the ID an item was first accepted under.
+type seenItem struct {
+	itemID string
+	seenAt time.Time
+}
+
+// dedupCache remembers content hashes of recently accepted items so that
+// redelivered webhooks (sender retries) are not ingested twice.
+type dedupCache struct {
+	mu      sync.Mutex
+	window  time.Duration
+	entries map[string]seenItem
+	order   []string // insertion order, oldest first
+	now     func() time.Time
+}
+
+func newDedupCache(window time.Duration) *dedupCache {
+	return &dedupCache{
+		window:  window,
+		entries: make(map[string]seenItem),
+		now:     time.Now,
+	}
+}
+
+// contentHash returns a SHA-256 over the source and whitespace-normalized
+// content of an item.
+func contentHash(source, content string) string {
+	normalized := strings.Join(strings.Fields(content), " ")
+	sum := sha256.Sum256([]byte(source + "\x00" + normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// claim records hash as accepted under itemID unless it was already seen
+// within the window, in which case it returns the original item ID and true.
+// The check and the insert happen under one lock, so of two concurrent
+// deliveries of the same content only one is accepted.
+func (c *dedupCache) claim(hash, itemID string) (string, bool) {
+	if c.window <= 0 {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictExpired()
+	if entry, ok := c.entries[hash]; ok {
+		return entry.itemID, true
+	}
+	c.entries[hash] = seenItem{itemID: itemID, seenAt: c.now()}
+	c.order = append(c.order, hash)
+
+	for len(c.order) > maxSeenItems {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	return "", false
+}
+
+// release forgets the claim itemID holds on hash, e.g. when the item could
+// not be enqueued, so that a retried delivery is accepted.
+func (c *dedupCache) release(hash, itemID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[hash]; !ok || entry.itemID != itemID {
+		return
+	}
+	delete(c.entries, hash)
+	for i, h := range c.order {
+		if h == hash {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// evictExpired drops entries older than the window. Caller must hold c.mu.
+func (c *dedupCache) evictExpired() {
+	cutoff := c.now().Add(-c.window)
+	for len(c.order) > 0 {
+		entry, ok := c.entries[c.order[0]]
+		if ok && entry.seenAt.After(cutoff) {
+			return
+		}
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
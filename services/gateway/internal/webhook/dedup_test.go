@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDedupCacheWindow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := newDedupCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	hash := contentHash("email", "Hello   world")
+	if _, dup := c.claim(hash, "item-1"); dup {
+		t.Fatal("expected the first delivery to be accepted")
+	}
+
+	if id, dup := c.claim(contentHash("email", "Hello world"), "item-2"); !dup || id != "item-1" {
+		t.Errorf("expected whitespace-normalized content to match item-1, got %q/%v", id, dup)
+	}
+	if _, dup := c.claim(contentHash("slack", "Hello world"), "item-3"); dup {
+		t.Error("expected the same content from another source not to match")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, dup := c.claim(hash, "item-4"); dup {
+		t.Error("expected entry to expire after the window")
+	}
+}
+
+func TestDedupCacheDisabled(t *testing.T) {
+	c := newDedupCache(0)
+	hash := contentHash("email", "Hello")
+	c.claim(hash, "item-1")
+	if _, dup := c.claim(hash, "item-2"); dup {
+		t.Error("expected a zero window to disable deduplication")
+	}
+}
+
+func TestDedupCacheRelease(t *testing.T) {
+	c := newDedupCache(time.Minute)
+	hash := contentHash("email", "Hello")
+	c.claim(hash, "item-1")
+
+	c.release(hash, "item-other")
+	if _, dup := c.claim(hash, "item-2"); !dup {
+		t.Error("expected release by another item to keep the claim")
+	}
+
+	c.release(hash, "item-1")
+	if _, dup := c.claim(hash, "item-3"); dup {
+		t.Error("expected a released hash to be accepted again")
+	}
+}
+
+func TestDedupCacheConcurrentClaims(t *testing.T) {
+	c := newDedupCache(time.Minute)
+	hash := contentHash("email", "Hello")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, dup := c.claim(hash, fmt.Sprintf("item-%d", i)); !dup {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if accepted != 1 {
+		t.Errorf("expected exactly one concurrent delivery to be accepted, got %d", accepted)
+	}
+}
@@ -1,15 +1,18 @@
 package webhook
 
 import (
-	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,8 +28,24 @@ type Handler struct {
 	normalizer  *normalizer.Normalizer
 	secret      string
 	itemChan    chan *ingestionv1.InboxItem
+	// enqueueTimeout is how long a request waits for room in a full item
+	// channel before it is rejected with 503.
+	enqueueTimeout time.Duration
+	seen           *dedupCache
+	// ready is reported by /readyz; see SetReady.
+	ready atomic.Bool
 }
 
+const (
+	// defaultEnqueueTimeout is the backpressure wait used unless overridden.
+	defaultEnqueueTimeout = 2 * time.Second
+	// defaultDedupWindow is how long accepted content is remembered.
+	defaultDedupWindow = 10 * time.Minute
+)
+
+// errQueueFull is returned when an item could not be enqueued in time.
+var errQueueFull = errors.New("item queue full")
+
 // NewHandler creates a new webhook handler.
 func NewHandler(logger *slog.Logger, secret string) *Handler {
 	return &Handler{
@@ -34,21 +53,58 @@ func NewHandler(logger *slog.Logger, secret string) *Handler {
 		normalizer: normalizer.New(),
 		secret:     secret,
 		itemChan:   make(chan *ingestionv1.InboxItem, 100),
+
+		enqueueTimeout: defaultEnqueueTimeout,
+		seen:           newDedupCache(defaultDedupWindow),
 	}
 }
 
+// SetEnqueueTimeout sets how long webhook requests block waiting for room in
+// a full item channel before responding 503 so the sender can retry.
+func (h *Handler) SetEnqueueTimeout(d time.Duration) {
+	h.enqueueTimeout = d
+}
+
 // Items returns the channel of incoming inbox items.
 func (h *Handler) Items() <-chan *ingestionv1.InboxItem {
 	return h.itemChan
 }
 
+// Close closes the Items channel so consumers can drain what is queued. It
+// must only be called once no webhook request can be in flight, i.e. after
+// the HTTP server serving RegisterRoutes has shut down.
+func (h *Handler) Close() {
+	close(h.itemChan)
+}
+
 // RegisterRoutes sets up HTTP routes for webhook endpoints.
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /webhooks/email", h.handleEmail)
 	mux.HandleFunc("POST /webhooks/slack", h.handleSlack)
 	mux.HandleFunc("POST /webhooks/github", h.handleGitHub)
+	mux.HandleFunc("POST /webhooks/telegram", h.handleTelegram)
 	mux.HandleFunc("POST /webhooks/generic", h.handleGeneric)
 	mux.HandleFunc("GET /health", h.handleHealth)
+	mux.HandleFunc("GET /healthz", h.handleHealth)
+	mux.HandleFunc("GET /readyz", h.handleReadyz)
+}
+
+// SetReady sets whether the gateway is ready to take traffic, as reported by
+// the /readyz probe. The gateway marks itself ready once its servers are
+// listening and not ready when it starts shutting down.
+func (h *Handler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// handleReadyz is the readiness probe: 200 once SetReady(true), 503 before.
+// /healthz, the liveness probe, shares handleHealth and always returns 200.
+func (h *Handler) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if !h.ready.Load() {
+		h.errorResponse(w, http.StatusServiceUnavailable, "not ready")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ready"}) //nolint:errcheck
 }
 
 func (h *Handler) handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -74,16 +130,16 @@ func (h *Handler) handleEmail(w http.ResponseWriter, r *http.Request) {
 	metadata["from"] = payload.From
 
 	item := h.createInboxItem(content, "email", metadata)
-	h.enqueueItem(item)
-
-	h.successResponse(w, item.Id)
+	h.acceptItem(w, r, item)
 }
 
 func (h *Handler) handleSlack(w http.ResponseWriter, r *http.Request) {
 	var payload struct {
-		Text    string `json:"text"`
-		Channel string `json:"channel"`
-		User    string `json:"user"`
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Text      string `json:"text"`
+		Channel   string `json:"channel"`
+		User      string `json:"user"`
 	}
 
 	if err := h.decodeBody(r, &payload); err != nil {
@@ -91,17 +147,32 @@ func (h *Handler) handleSlack(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Slack's Events API verifies the endpoint by expecting the challenge
+	// value echoed back before it will deliver any events.
+	if payload.Type == "url_verification" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"challenge": payload.Challenge}) //nolint:errcheck
+		return
+	}
+
 	content, metadata := h.normalizer.NormalizeSlackMessage(payload.Text, payload.Channel, payload.User)
 	item := h.createInboxItem(content, "slack", metadata)
-	h.enqueueItem(item)
-
-	h.successResponse(w, item.Id)
+	h.acceptItem(w, r, item)
 }
 
 func (h *Handler) handleGitHub(w http.ResponseWriter, r *http.Request) {
+	// Read the body once: the signature is computed over the exact bytes
+	// that are then decoded.
+	body, err := h.readBody(r)
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid payload: "+err.Error())
+		return
+	}
+
 	// Verify webhook signature if secret is configured
 	if h.secret != "" {
-		if !h.verifyGitHubSignature(r) {
+		if !h.verifySignature(body, r.Header.Get("X-Hub-Signature-256")) {
 			h.errorResponse(w, http.StatusUnauthorized, "invalid signature")
 			return
 		}
@@ -114,27 +185,75 @@ func (h *Handler) handleGitHub(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var payload map[string]interface{}
-	if err := h.decodeBody(r, &payload); err != nil {
-		h.errorResponse(w, http.StatusBadRequest, "invalid payload: "+err.Error())
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid payload: decoding JSON: "+err.Error())
 		return
 	}
 
 	content, metadata := h.normalizer.NormalizeGitHubWebhook(eventType, payload)
 	item := h.createInboxItem(content, "github", metadata)
-	h.enqueueItem(item)
+	h.acceptItem(w, r, item)
+}
 
-	h.successResponse(w, item.Id)
+func (h *Handler) handleTelegram(w http.ResponseWriter, r *http.Request) {
+	// Subset of the Telegram Bot API Update object. Only new messages are
+	// ingested; edits, callback queries, etc. arrive without "message".
+	var update struct {
+		UpdateID int64 `json:"update_id"`
+		Message  *struct {
+			Text string `json:"text"`
+			Chat struct {
+				ID int64 `json:"id"`
+			} `json:"chat"`
+			From struct {
+				Username string `json:"username"`
+			} `json:"from"`
+		} `json:"message"`
+	}
+
+	if err := h.decodeBody(r, &update); err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid payload: "+err.Error())
+		return
+	}
+
+	// Telegram retries non-2xx responses, so unsupported updates are
+	// acknowledged rather than rejected.
+	if update.Message == nil || update.Message.Text == "" {
+		h.logger.Debug("ignoring non-message telegram update", "update_id", update.UpdateID)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"status": "ignored"}) //nolint:errcheck
+		return
+	}
+
+	msg := update.Message
+	content, metadata := h.normalizer.NormalizeTelegramMessage(msg.Text, msg.Chat.ID, msg.From.Username)
+	item := h.createInboxItem(content, "telegram", metadata)
+	h.acceptItem(w, r, item)
 }
 
 func (h *Handler) handleGeneric(w http.ResponseWriter, r *http.Request) {
+	body, err := h.readBody(r)
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid payload: "+err.Error())
+		return
+	}
+
+	// Verify webhook signature if secret is configured
+	if h.secret != "" {
+		if !h.verifySignature(body, r.Header.Get("X-Signature-256")) {
+			h.errorResponse(w, http.StatusUnauthorized, "invalid signature")
+			return
+		}
+	}
+
 	var payload struct {
 		Content  string            `json:"content"`
 		Source   string            `json:"source"`
 		Metadata map[string]string `json:"metadata"`
 	}
-
-	if err := h.decodeBody(r, &payload); err != nil {
-		h.errorResponse(w, http.StatusBadRequest, "invalid payload: "+err.Error())
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid payload: decoding JSON: "+err.Error())
 		return
 	}
 
@@ -144,9 +263,7 @@ func (h *Handler) handleGeneric(w http.ResponseWriter, r *http.Request) {
 	}
 
 	item := h.createInboxItem(payload.Content, source, payload.Metadata)
-	h.enqueueItem(item)
-
-	h.successResponse(w, item.Id)
+	h.acceptItem(w, r, item)
 }
 
 func (h *Handler) createInboxItem(content, source string, metadata map[string]string) *ingestionv1.InboxItem {
@@ -161,21 +278,63 @@ func (h *Handler) createInboxItem(content, source string, metadata map[string]st
 	}
 }
 
-func (h *Handler) enqueueItem(item *ingestionv1.InboxItem) {
+// SetDedupWindow sets how long accepted content is remembered for duplicate
+// detection. A non-positive window disables deduplication.
+func (h *Handler) SetDedupWindow(d time.Duration) {
+	h.seen.mu.Lock()
+	h.seen.window = d
+	h.seen.mu.Unlock()
+}
+
+// acceptItem enqueues item and reports the outcome to the caller: 202 once
+// the item is queued, or 503 with Retry-After when the queue stays full.
+func (h *Handler) acceptItem(w http.ResponseWriter, r *http.Request, item *ingestionv1.InboxItem) {
+	hash := contentHash(item.Source, item.Content)
+	if originalID, ok := h.seen.claim(hash, item.Id); ok {
+		h.logger.Info("duplicate item skipped", "original_id", originalID, "source", item.Source)
+		h.duplicateResponse(w, originalID)
+		return
+	}
+
+	if err := h.enqueueItem(r.Context(), item); err != nil {
+		h.seen.release(hash, item.Id)
+		w.Header().Set("Retry-After", strconv.Itoa(int(max(h.enqueueTimeout, time.Second).Seconds())))
+		h.errorResponse(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	h.successResponse(w, item.Id)
+}
+
+// enqueueItem sends item on the item channel, waiting up to enqueueTimeout
+// for room instead of dropping it when the channel is full.
+func (h *Handler) enqueueItem(ctx context.Context, item *ingestionv1.InboxItem) error {
 	select {
 	case h.itemChan <- item:
 		h.logger.Info("item enqueued", "id", item.Id, "source", item.Source)
+		return nil
 	default:
-		h.logger.Warn("item channel full, dropping item", "id", item.Id)
+	}
+
+	timer := time.NewTimer(h.enqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case h.itemChan <- item:
+		h.logger.Info("item enqueued after backpressure", "id", item.Id, "source", item.Source)
+		return nil
+	case <-timer.C:
+		h.logger.Warn("item channel full, rejecting item", "id", item.Id, "source", item.Source)
+		return errQueueFull
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
 func (h *Handler) decodeBody(r *http.Request, v interface{}) error {
-	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20)) // 1MB limit
+	body, err := h.readBody(r)
 	if err != nil {
-		return fmt.Errorf("reading body: %w", err)
+		return err
 	}
-	defer r.Body.Close()
 
 	if err := json.Unmarshal(body, v); err != nil {
 		return fmt.Errorf("decoding JSON: %w", err)
@@ -183,18 +342,23 @@ func (h *Handler) decodeBody(r *http.Request, v interface{}) error {
 	return nil
 }
 
-func (h *Handler) verifyGitHubSignature(r *http.Request) bool {
-	signature := r.Header.Get("X-Hub-Signature-256")
-	if signature == "" {
-		return false
+// readBody reads and closes the request body, up to a 1MB limit.
+func (h *Handler) readBody(r *http.Request) ([]byte, error) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20)) // 1MB limit
+	if err != nil {
+		return nil, fmt.Errorf("reading body: %w", err)
 	}
+	return body, nil
+}
 
-	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
-	if err != nil {
+// verifySignature checks a "sha256=<hex>" signature header (GitHub's
+// X-Hub-Signature-256 format) against an HMAC-SHA256 of body using the
+// webhook secret, comparing in constant time.
+func (h *Handler) verifySignature(body []byte, signature string) bool {
+	if signature == "" {
 		return false
 	}
-	// Reset body so it can be read again by the handler
-	r.Body = io.NopCloser(bytes.NewReader(body))
 
 	mac := hmac.New(sha256.New, []byte(h.secret))
 	mac.Write(body)
@@ -217,3 +381,14 @@ func (h *Handler) successResponse(w http.ResponseWriter, itemID string) {
 		"status":  "accepted",
 	})
 }
+
+// duplicateResponse acknowledges a redelivered item with the ID it was
+// originally accepted under.
+func (h *Handler) duplicateResponse(w http.ResponseWriter, itemID string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{ //nolint:errcheck
+		"item_id": itemID,
+		"status":  "duplicate",
+	})
+}
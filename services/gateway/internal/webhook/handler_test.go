@@ -2,10 +2,14 @@ package webhook
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 	"log/slog"
 	"os"
 )
@@ -74,6 +78,35 @@ func TestHandleSlack(t *testing.T) {
 	}
 }
 
+func TestHandleSlackURLVerification(t *testing.T) {
+	h := NewHandler(newTestLogger(), "")
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body := []byte(`{
+		"token": "Jhj5dZrVaK7ZwHHjRyZWjbDl",
+		"challenge": "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P",
+		"type": "url_verification"
+	}`)
+	req := httptest.NewRequest("POST", "/webhooks/slack", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp map[string]string
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp["challenge"] != "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P" {
+		t.Errorf("expected challenge to be echoed, got %q", resp["challenge"])
+	}
+	select {
+	case item := <-h.Items():
+		t.Errorf("expected no item for a challenge, got %v", item)
+	default:
+	}
+}
+
 func TestHandleGeneric(t *testing.T) {
 	h := NewHandler(newTestLogger(), "")
 	mux := http.NewServeMux()
@@ -94,6 +127,41 @@ func TestHandleGeneric(t *testing.T) {
 	}
 }
 
+func TestHandleGenericSignature(t *testing.T) {
+	body, _ := json.Marshal(map[string]interface{}{"content": "signed note", "source": "zapier"})
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		wantCode  int
+	}{
+		{"valid signature", "generic-secret", signGitHubPayload("generic-secret", body), http.StatusAccepted},
+		{"invalid signature", "generic-secret", signGitHubPayload("other-secret", body), http.StatusUnauthorized},
+		{"missing signature", "generic-secret", "", http.StatusUnauthorized},
+		{"no secret configured", "", "", http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(newTestLogger(), tt.secret)
+			mux := http.NewServeMux()
+			h.RegisterRoutes(mux)
+
+			req := httptest.NewRequest("POST", "/webhooks/generic", bytes.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set("X-Signature-256", tt.signature)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
 func TestHandleGitHubMissingHeader(t *testing.T) {
 	h := NewHandler(newTestLogger(), "")
 	mux := http.NewServeMux()
@@ -109,6 +177,129 @@ func TestHandleGitHubMissingHeader(t *testing.T) {
 	}
 }
 
+func signGitHubPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandleGitHubValidSignature(t *testing.T) {
+	h := NewHandler(newTestLogger(), "webhook-secret")
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"issue": map[string]interface{}{
+			"title": "Crash on startup",
+			"body":  "Stack trace attached",
+		},
+	})
+	req := httptest.NewRequest("POST", "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "issues")
+	req.Header.Set("X-Hub-Signature-256", signGitHubPayload("webhook-secret", body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+
+	select {
+	case item := <-h.Items():
+		if item.Content != "Crash on startup: Stack trace attached" {
+			t.Errorf("expected normalized issue content, got %q", item.Content)
+		}
+		if item.RawMetadata["event_type"] != "issues" {
+			t.Errorf("expected event_type 'issues', got %q", item.RawMetadata["event_type"])
+		}
+	default:
+		t.Error("expected item to be enqueued")
+	}
+}
+
+func TestHandleGitHubInvalidSignature(t *testing.T) {
+	h := NewHandler(newTestLogger(), "webhook-secret")
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body := []byte(`{"issue":{"title":"t","body":"b"}}`)
+	req := httptest.NewRequest("POST", "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "issues")
+	req.Header.Set("X-Hub-Signature-256", signGitHubPayload("wrong-secret", body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestHandleTelegram(t *testing.T) {
+	h := NewHandler(newTestLogger(), "")
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body := []byte(`{
+		"update_id": 10000,
+		"message": {
+			"message_id": 1365,
+			"date": 1441645532,
+			"from": {"id": 1111111, "is_bot": false, "first_name": "Alice", "username": "alice"},
+			"chat": {"id": 1111111, "type": "private", "first_name": "Alice", "username": "alice"},
+			"text": "Remember to renew the passport"
+		}
+	}`)
+	req := httptest.NewRequest("POST", "/webhooks/telegram", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+
+	select {
+	case item := <-h.Items():
+		if item.Source != "telegram" {
+			t.Errorf("expected source 'telegram', got %q", item.Source)
+		}
+		if item.Content != "Remember to renew the passport" {
+			t.Errorf("unexpected content %q", item.Content)
+		}
+		if item.RawMetadata["chat_id"] != "1111111" || item.RawMetadata["username"] != "alice" {
+			t.Errorf("unexpected metadata %v", item.RawMetadata)
+		}
+	default:
+		t.Error("expected item to be enqueued")
+	}
+}
+
+func TestHandleTelegramIgnoresNonMessageUpdates(t *testing.T) {
+	h := NewHandler(newTestLogger(), "")
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body := []byte(`{
+		"update_id": 10001,
+		"edited_message": {
+			"message_id": 1365,
+			"chat": {"id": 1111111, "type": "private"},
+			"text": "edited text"
+		}
+	}`)
+	req := httptest.NewRequest("POST", "/webhooks/telegram", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	select {
+	case item := <-h.Items():
+		t.Errorf("expected no item, got %v", item)
+	default:
+	}
+}
+
 func TestHandleInvalidJSON(t *testing.T) {
 	h := NewHandler(newTestLogger(), "")
 	mux := http.NewServeMux()
@@ -136,3 +327,121 @@ func TestHealthEndpoint(t *testing.T) {
 		t.Errorf("expected 200, got %d", w.Code)
 	}
 }
+
+func TestWebhookBackpressureReturns503(t *testing.T) {
+	h := NewHandler(newTestLogger(), "")
+	h.SetEnqueueTimeout(10 * time.Millisecond)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	// Fill the item channel without draining it.
+	for i := 0; i < cap(h.itemChan); i++ {
+		h.itemChan <- h.createInboxItem("filler", "test", nil)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"content": "one too many"})
+	req := httptest.NewRequest("POST", "/webhooks/generic", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header")
+	}
+
+	// Once the consumer catches up, the same request is accepted.
+	<-h.Items()
+	req = httptest.NewRequest("POST", "/webhooks/generic", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected 202 after draining, got %d", w.Code)
+	}
+}
+
+func TestHandleEmailDuplicateDelivery(t *testing.T) {
+	h := NewHandler(newTestLogger(), "")
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"subject": "Invoice",
+		"body":    "Your invoice is attached",
+		"from":    "billing@example.com",
+	})
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/webhooks/email", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		var resp map[string]string
+		json.NewDecoder(w.Body).Decode(&resp)
+		ids = append(ids, resp["item_id"])
+		if i == 1 && resp["status"] != "duplicate" {
+			t.Errorf("expected duplicate status on redelivery, got %q (code %d)", resp["status"], w.Code)
+		}
+	}
+
+	if ids[0] == "" || ids[0] != ids[1] {
+		t.Errorf("expected redelivery to return the original item ID, got %v", ids)
+	}
+	if got := len(h.itemChan); got != 1 {
+		t.Errorf("expected 1 enqueued item, got %d", got)
+	}
+}
+
+func TestCloseDrainsQueuedItems(t *testing.T) {
+	h := NewHandler(newTestLogger(), "")
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(map[string]string{"content": "Queued before shutdown", "source": "test"})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks/generic", bytes.NewReader(body)))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+
+	h.Close()
+
+	var got []string
+	for item := range h.Items() {
+		got = append(got, item.Content)
+	}
+	if len(got) != 1 || got[0] != "Queued before shutdown" {
+		t.Errorf("expected the queued item before the channel closed, got %v", got)
+	}
+}
+
+func TestReadyzFollowsSetReady(t *testing.T) {
+	h := NewHandler(newTestLogger(), "")
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	get := func(path string) int {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w.Code
+	}
+
+	if code := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("expected /readyz 503 before ready, got %d", code)
+	}
+	if code := get("/healthz"); code != http.StatusOK {
+		t.Errorf("expected /healthz 200 before ready, got %d", code)
+	}
+
+	h.SetReady(true)
+	if code := get("/readyz"); code != http.StatusOK {
+		t.Errorf("expected /readyz 200 once ready, got %d", code)
+	}
+
+	h.SetReady(false)
+	if code := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("expected /readyz 503 after shutdown began, got %d", code)
+	}
+}
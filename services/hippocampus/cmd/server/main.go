@@ -5,30 +5,48 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/config"
 	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/embedder"
+	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/hybrid"
+	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/metrics"
+	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/middleware"
 	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/server"
+	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/tracing"
 	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/vectorstore"
 	commonv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/common/v1"
 	memoryv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/memory/v1"
 )
 
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(middleware.NewRequestIDHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 	slog.SetDefault(logger)
 
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.ServiceName, cfg.OTelEndpoint)
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Create dependencies
 	store := vectorstore.NewInMemoryStore()
@@ -36,9 +54,40 @@ func main() {
 
 	// Create server
 	hippocampusServer := server.NewHippocampusServer(logger, cfg, store, emb)
+	switch cfg.Reranker {
+	case "":
+	case "keyword":
+		hippocampusServer.SetReranker(hybrid.NewKeywordOverlapReranker(cfg.RerankWeight))
+	default:
+		logger.Warn("unknown reranker, hybrid search will not rerank", "reranker", cfg.Reranker)
+	}
+	if cfg.SynonymsFile != "" {
+		if synonyms, err := hybrid.LoadSynonyms(cfg.SynonymsFile); err != nil {
+			logger.Warn("failed to load synonyms, query expansion disabled", "path", cfg.SynonymsFile, "error", err)
+		} else {
+			hippocampusServer.SetQueryExpander(hybrid.NewSynonymExpander(synonyms))
+		}
+	}
+	stopPurger := hippocampusServer.StartTombstonePurger()
+	defer stopPurger()
+	stopRecorder := hippocampusServer.StartStatsRecorder()
+	defer stopRecorder()
+
+	// Serve TLS when a certificate is configured
+	var serverOpts []grpc.ServerOption
+	if cfg.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			logger.Error("failed to load TLS credentials", "error", err)
+			os.Exit(1)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
 
 	// Configure gRPC server
-	grpcServer := grpc.NewServer(
+	latency := metrics.NewLatencyStore()
+	grpcServer := grpc.NewServer(append(serverOpts,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle:     15 * time.Minute,
 			MaxConnectionAge:      30 * time.Minute,
@@ -46,7 +95,21 @@ func main() {
 			Time:                  5 * time.Minute,
 			Timeout:               1 * time.Second,
 		}),
-	)
+		grpc.ChainUnaryInterceptor(
+			middleware.UnaryRequestID(),
+			middleware.UnaryRecovery(logger),
+			middleware.UnaryLogging(logger),
+			middleware.UnaryMetrics(latency),
+			middleware.UnaryAuth(cfg.AuthTokens),
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.StreamRequestID(),
+			middleware.StreamRecovery(logger),
+			middleware.StreamLogging(logger),
+			middleware.StreamMetrics(latency),
+			middleware.StreamAuth(cfg.AuthTokens),
+		),
+	)...)
 
 	memoryv1.RegisterMemoryServiceServer(grpcServer, hippocampusServer)
 	commonv1.RegisterHealthServiceServer(grpcServer, hippocampusServer)
@@ -72,8 +135,36 @@ func main() {
 		}
 	}()
 
+	metricsServer := serveMetrics(logger, cfg.MetricsPort, latency)
+
 	<-ctx.Done()
 	logger.Info("shutting down hippocampus service...")
 	grpcServer.GracefulStop()
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
 	logger.Info("hippocampus service stopped")
 }
+
+// serveMetrics serves RPC latency on GET /metrics at port in the
+// background. It returns nil, serving nothing, when port is 0.
+func serveMetrics(logger *slog.Logger, port int, latency *metrics.LatencyStore) *http.Server {
+	if port == 0 {
+		return nil
+	}
+	mux := http.NewServeMux()
+	latency.RegisterRoutes(mux)
+	srv := &http.Server{
+		Addr:        fmt.Sprintf(":%d", port),
+		Handler:     mux,
+		ReadTimeout: 10 * time.Second,
+	}
+	go func() {
+		logger.Info("metrics server starting", "address", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("metrics server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+	return srv
+}
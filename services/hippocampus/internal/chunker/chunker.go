@@ -1,12 +1,18 @@
 package chunker
 
 import (
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
-// Chunk represents a piece of text with metadata.
+// Chunk represents a piece of text with metadata. Every strategy records the
+// character range of the source text a chunk came from in the "start_offset"
+// and "end_offset" metadata keys (end exclusive), so callers can link back
+// to the passage.
 type Chunk struct {
 	ID         string
 	DocumentID string
@@ -70,7 +76,65 @@ func (c *FixedSizeChunker) Chunk(documentID, text string, metadata map[string]st
 		index++
 	}
 
-	return chunks
+	return recordOffsets(text, chunks)
+}
+
+// TokenChunker splits text into chunks of at most MaxTokens estimated model
+// tokens (see Tokenize), with Overlap tokens shared between neighbours. Use it
+// to keep chunks inside an embedding model's token window.
+type TokenChunker struct {
+	MaxTokens int
+	Overlap   int
+}
+
+// Chunk splits text into token-bounded chunks.
+func (c *TokenChunker) Chunk(documentID, text string, metadata map[string]string) []Chunk {
+	var tokens []string
+	for _, t := range Tokenize(text) {
+		if strings.TrimSpace(t) != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	maxTokens := c.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = len(tokens)
+	}
+
+	var chunks []Chunk
+	start := 0
+	index := 0
+
+	for start < len(tokens) {
+		end := start + maxTokens
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+
+		chunks = append(chunks, Chunk{
+			ID:         uuid.New().String(),
+			DocumentID: documentID,
+			Content:    strings.TrimSpace(strings.Join(tokens[start:end], "")),
+			Index:      index,
+			Metadata:   copyMetadata(metadata),
+		})
+
+		if end >= len(tokens) {
+			break
+		}
+
+		next := end - c.Overlap
+		if next <= start {
+			next = start + 1
+		}
+		start = next
+		index++
+	}
+
+	return recordOffsets(text, chunks)
 }
 
 // SemanticChunker splits text at sentence boundaries.
@@ -128,10 +192,12 @@ func (c *SemanticChunker) Chunk(documentID, text string, metadata map[string]str
 		})
 	}
 
-	return chunks
+	return recordOffsets(text, chunks)
 }
 
-// HierarchicalChunker splits text at section header boundaries.
+// HierarchicalChunker splits text at section header boundaries. Each chunk
+// records the heading of the section it came from in the "section" metadata
+// key and the section's position in "parent_index".
 type HierarchicalChunker struct {
 	MaxChunkSize int
 }
@@ -145,6 +211,7 @@ func (c *HierarchicalChunker) Chunk(documentID, text string, metadata map[string
 	sections := splitSections(text)
 	var chunks []Chunk
 	index := 0
+	parent := 0
 
 	for _, section := range sections {
 		section = strings.TrimSpace(section)
@@ -152,9 +219,15 @@ func (c *HierarchicalChunker) Chunk(documentID, text string, metadata map[string
 			continue
 		}
 
+		meta := copyMetadata(metadata)
+		meta["parent_index"] = strconv.Itoa(parent)
+		if title := sectionTitle(section); title != "" {
+			meta["section"] = title
+		}
+		parent++
+
 		words := strings.Fields(section)
 		if len(words) <= c.MaxChunkSize {
-			meta := copyMetadata(metadata)
 			chunks = append(chunks, Chunk{
 				ID:         uuid.New().String(),
 				DocumentID: documentID,
@@ -165,7 +238,7 @@ func (c *HierarchicalChunker) Chunk(documentID, text string, metadata map[string
 			index++
 		} else {
 			sub := &SemanticChunker{MaxChunkSize: c.MaxChunkSize}
-			subChunks := sub.Chunk(documentID, section, metadata)
+			subChunks := sub.Chunk(documentID, section, meta)
 			for _, sc := range subChunks {
 				sc.Index = index
 				chunks = append(chunks, sc)
@@ -174,7 +247,7 @@ func (c *HierarchicalChunker) Chunk(documentID, text string, metadata map[string
 		}
 	}
 
-	return chunks
+	return recordOffsets(text, chunks)
 }
 
 // NewStrategy creates a Strategy from a name.
@@ -184,6 +257,12 @@ func NewStrategy(name string, chunkSize, overlap int) Strategy {
 		return &SemanticChunker{MaxChunkSize: chunkSize}
 	case "hierarchical":
 		return &HierarchicalChunker{MaxChunkSize: chunkSize}
+	case "token":
+		return &TokenChunker{MaxTokens: chunkSize, Overlap: overlap}
+	case "markdown":
+		return &MarkdownChunker{MaxChunkSize: chunkSize}
+	case "recursive":
+		return &RecursiveChunker{ChunkSize: chunkSize, Overlap: overlap}
 	default:
 		return &FixedSizeChunker{ChunkSize: chunkSize, Overlap: overlap}
 	}
@@ -218,7 +297,7 @@ func splitSections(text string) []string {
 
 	for _, line := range lines {
 		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "#") || (len(trimmed) > 0 && trimmed == strings.ToUpper(trimmed) && len(trimmed) > 3) {
+		if isSectionHeader(trimmed) {
 			if len(current) > 0 {
 				sections = append(sections, strings.Join(current, "\n"))
 				current = nil
@@ -234,6 +313,69 @@ func splitSections(text string) []string {
 	return sections
 }
 
+// sectionTitle returns the heading that opens a section produced by
+// splitSections, without Markdown markers, or "" if it has none.
+func sectionTitle(section string) string {
+	first, _, _ := strings.Cut(section, "\n")
+	first = strings.TrimSpace(first)
+	if !isSectionHeader(first) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimLeft(first, "#"))
+}
+
+// isSectionHeader reports whether a trimmed line starts a new section: a
+// Markdown heading or an all-caps line.
+func isSectionHeader(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "#") || (len(trimmed) > 0 && trimmed == strings.ToUpper(trimmed) && len(trimmed) > 3)
+}
+
+// recordOffsets sets the "start_offset" and "end_offset" metadata of each
+// chunk to the range of characters of text it was taken from. Chunkers
+// normalize whitespace, so chunks are located by their non-whitespace
+// characters; each chunk is searched for after the start of the previous
+// one, and a chunk that can't be found gets no offsets.
+func recordOffsets(text string, chunks []Chunk) []Chunk {
+	src, pos := squeezeSpace(text)
+	from := 0
+	for i := range chunks {
+		needle, _ := squeezeSpace(chunks[i].Content)
+		if needle == "" {
+			continue
+		}
+		at := strings.Index(src[from:], needle)
+		if at < 0 {
+			continue
+		}
+		at += from
+		_, last := utf8.DecodeLastRuneInString(needle)
+		chunks[i].Metadata["start_offset"] = strconv.Itoa(pos[at])
+		chunks[i].Metadata["end_offset"] = strconv.Itoa(pos[at+len(needle)-last] + 1)
+
+		_, first := utf8.DecodeRuneInString(needle)
+		from = at + first
+	}
+	return chunks
+}
+
+// squeezeSpace removes whitespace from text. pos maps the byte index of each
+// rune in the result to that rune's character index in text.
+func squeezeSpace(text string) (string, []int) {
+	var b strings.Builder
+	pos := make([]int, 0, len(text))
+	i := 0
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			b.WriteRune(r)
+			for n := utf8.RuneLen(r); n > 0; n-- {
+				pos = append(pos, i)
+			}
+		}
+		i++
+	}
+	return b.String(), pos
+}
+
 func copyMetadata(m map[string]string) map[string]string {
 	if m == nil {
 		return make(map[string]string)
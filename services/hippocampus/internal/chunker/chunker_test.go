@@ -1,6 +1,8 @@
 package chunker
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -39,6 +41,68 @@ func TestFixedSizeChunkerEmpty(t *testing.T) {
 	}
 }
 
+// chunkOffsets returns a chunk's recorded start and end offsets.
+func chunkOffsets(t *testing.T, ch Chunk) (int, int) {
+	t.Helper()
+	start, err := strconv.Atoi(ch.Metadata["start_offset"])
+	if err != nil {
+		t.Fatalf("chunk %d: bad start_offset %q", ch.Index, ch.Metadata["start_offset"])
+	}
+	end, err := strconv.Atoi(ch.Metadata["end_offset"])
+	if err != nil {
+		t.Fatalf("chunk %d: bad end_offset %q", ch.Index, ch.Metadata["end_offset"])
+	}
+	return start, end
+}
+
+func TestFixedSizeChunkerOffsets(t *testing.T) {
+	c := &FixedSizeChunker{ChunkSize: 4, Overlap: 1}
+	text := "  alpha  beta gamma\ndelta épsilon zeta eta\ttheta iota kappa "
+	runes := []rune(text)
+
+	chunks := c.Chunk("doc-1", text, nil)
+	if len(chunks) < 3 {
+		t.Fatalf("expected at least 3 chunks, got %d", len(chunks))
+	}
+
+	prevStart, prevEnd := -1, -1
+	for _, ch := range chunks {
+		start, end := chunkOffsets(t, ch)
+		if start < 0 || end > len(runes) || start >= end {
+			t.Fatalf("chunk %d: invalid range [%d, %d)", ch.Index, start, end)
+		}
+		if got := strings.Join(strings.Fields(string(runes[start:end])), " "); got != ch.Content {
+			t.Errorf("chunk %d: offsets cover %q, want %q", ch.Index, got, ch.Content)
+		}
+
+		if prevStart >= 0 {
+			if start <= prevStart || end <= prevEnd {
+				t.Errorf("chunk %d: offsets [%d, %d) not after [%d, %d)", ch.Index, start, end, prevStart, prevEnd)
+			}
+			// Neighbours share exactly the overlapping words.
+			if shared := strings.Fields(string(runes[start:max(start, prevEnd)])); len(shared) != c.Overlap {
+				t.Errorf("chunk %d: expected %d overlapping word(s), got %q", ch.Index, c.Overlap, shared)
+			}
+		}
+		prevStart, prevEnd = start, end
+	}
+}
+
+func TestChunkersRecordOffsets(t *testing.T) {
+	text := "# Intro\n\nFirst sentence here. Second one follows.\n\n# Details\n\nMore text in the details section. And a final line."
+	runes := []rune(text)
+
+	for _, name := range []string{"fixed", "semantic", "hierarchical", "token", "markdown", "recursive"} {
+		for _, ch := range NewStrategy(name, 6, 1).Chunk("doc-1", text, nil) {
+			start, end := chunkOffsets(t, ch)
+			got := strings.Join(strings.Fields(string(runes[start:end])), "")
+			if want := strings.Join(strings.Fields(ch.Content), ""); got != want {
+				t.Errorf("%s chunk %d: offsets cover %q, want %q", name, ch.Index, got, want)
+			}
+		}
+	}
+}
+
 func TestSemanticChunker(t *testing.T) {
 	c := &SemanticChunker{MaxChunkSize: 10}
 	text := "This is sentence one. This is sentence two. And here is sentence three. Another sentence four."
@@ -89,6 +153,41 @@ The results show significant improvements.`
 	}
 }
 
+func TestHierarchicalChunkerSectionMetadata(t *testing.T) {
+	c := &HierarchicalChunker{MaxChunkSize: 12}
+	text := `Preamble before any heading.
+
+# Introduction
+A short introduction.
+
+## Methodology
+We collected the data over six months. We cleaned it carefully.
+We trained three models on it. We compared them on held-out data.`
+
+	chunks := c.Chunk("doc-sec", text, map[string]string{"source": "paper"})
+
+	if len(chunks) < 4 {
+		t.Fatalf("expected the methodology section to be split, got %d chunks", len(chunks))
+	}
+	if _, ok := chunks[0].Metadata["section"]; ok {
+		t.Errorf("expected no section for the preamble, got %q", chunks[0].Metadata["section"])
+	}
+	if got := chunks[1].Metadata["section"]; got != "Introduction" {
+		t.Errorf("expected section Introduction, got %q", got)
+	}
+	for _, ch := range chunks[2:] {
+		if got := ch.Metadata["section"]; got != "Methodology" {
+			t.Errorf("chunk %d: expected section Methodology, got %q", ch.Index, got)
+		}
+		if got := ch.Metadata["parent_index"]; got != "2" {
+			t.Errorf("chunk %d: expected parent_index 2, got %q", ch.Index, got)
+		}
+		if ch.Metadata["source"] != "paper" {
+			t.Errorf("chunk %d: expected caller metadata to be kept", ch.Index)
+		}
+	}
+}
+
 func TestNewStrategy(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -97,6 +196,9 @@ func TestNewStrategy(t *testing.T) {
 		{"fixed", "*chunker.FixedSizeChunker"},
 		{"semantic", "*chunker.SemanticChunker"},
 		{"hierarchical", "*chunker.HierarchicalChunker"},
+		{"token", "*chunker.TokenChunker"},
+		{"markdown", "*chunker.MarkdownChunker"},
+		{"recursive", "*chunker.RecursiveChunker"},
 		{"unknown", "*chunker.FixedSizeChunker"},
 	}
 
@@ -106,10 +208,184 @@ func TestNewStrategy(t *testing.T) {
 			if s == nil {
 				t.Fatal("expected non-nil strategy")
 			}
+			if got := fmt.Sprintf("%T", s); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
 		})
 	}
 }
 
+func TestTokenChunkerRespectsBudget(t *testing.T) {
+	text := strings.Repeat("Transformers tokenize internationalization differently, e.g. sub-word units! ", 40)
+	c := &TokenChunker{MaxTokens: 50, Overlap: 10}
+
+	chunks := c.Chunk("doc-tok", text, map[string]string{"source": "test"})
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %d", len(chunks))
+	}
+	for i, ch := range chunks {
+		if n := EstimateTokens(ch.Content); n > 50 {
+			t.Errorf("chunk %d has %d tokens, over the 50 token budget", i, n)
+		}
+		if ch.Index != i || ch.Metadata["source"] != "test" {
+			t.Errorf("chunk %d has index %d / metadata %v", i, ch.Index, ch.Metadata)
+		}
+	}
+}
+
+func TestTokenChunkerOverlap(t *testing.T) {
+	text := strings.Repeat("alpha beta gamma delta epsilon. ", 20)
+	c := &TokenChunker{MaxTokens: 30, Overlap: 8}
+
+	chunks := c.Chunk("doc-ov", text, nil)
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %d", len(chunks))
+	}
+	for i := 0; i+1 < len(chunks); i++ {
+		prev := Tokenize(chunks[i].Content)
+		next := Tokenize(chunks[i+1].Content)
+		tail := strings.TrimSpace(strings.Join(prev[len(prev)-8:], ""))
+		head := strings.TrimSpace(strings.Join(next[:8], ""))
+		if tail != head {
+			t.Errorf("chunks %d/%d: expected %q overlap, got %q", i, i+1, tail, head)
+		}
+	}
+}
+
+const mixedMarkdown = `# Setup
+
+Install the toolchain and make sure the binary is on your path before continuing.
+
+` + "```go" + `
+func main() {
+	fmt.Println("hello")
+
+	for i := 0; i < 10; i++ {
+		fmt.Println(i)
+	}
+}
+` + "```" + `
+
+After building, run the tests.
+
+| flag | meaning |
+|------|---------|
+| -v   | verbose |
+
+## Usage
+
+Call the server with the default configuration and watch the logs for errors.
+`
+
+func TestMarkdownChunkerKeepsCodeBlocksIntact(t *testing.T) {
+	for _, size := range []int{5, 12, 40, 1000} {
+		c := &MarkdownChunker{MaxChunkSize: size}
+		chunks := c.Chunk("doc-md", mixedMarkdown, nil)
+		if len(chunks) == 0 {
+			t.Fatalf("size %d: expected chunks", size)
+		}
+
+		foundCode, foundTable := false, false
+		for i, ch := range chunks {
+			if n := strings.Count(ch.Content, "```"); n%2 != 0 {
+				t.Errorf("size %d: chunk %d splits a code fence:\n%s", size, i, ch.Content)
+			}
+			if strings.Contains(ch.Content, "func main() {") {
+				foundCode = true
+				if !strings.Contains(ch.Content, "fmt.Println(i)") {
+					t.Errorf("size %d: code block split across chunks", size)
+				}
+			}
+			if strings.Contains(ch.Content, "| flag |") {
+				foundTable = true
+				if !strings.Contains(ch.Content, "| -v   |") {
+					t.Errorf("size %d: table split across chunks", size)
+				}
+			}
+			if ch.Index != i {
+				t.Errorf("size %d: chunk %d has index %d", size, i, ch.Index)
+			}
+		}
+		if !foundCode || !foundTable {
+			t.Errorf("size %d: code block or table missing from chunks", size)
+		}
+	}
+}
+
+func TestMarkdownChunkerSplitsOnHeadings(t *testing.T) {
+	c := &MarkdownChunker{MaxChunkSize: 1000}
+	chunks := c.Chunk("doc-md", mixedMarkdown, nil)
+	if len(chunks) != 2 {
+		t.Fatalf("expected one chunk per section, got %d", len(chunks))
+	}
+	if !strings.HasPrefix(chunks[1].Content, "## Usage") {
+		t.Errorf("expected second chunk to start at the heading, got %q", chunks[1].Content)
+	}
+}
+
+func TestRecursiveChunkerSeparatorlessText(t *testing.T) {
+	text := strings.Repeat("0123456789abcdef", 20) // 320 characters, no separators
+	c := &RecursiveChunker{ChunkSize: 50, Overlap: 10}
+
+	chunks := c.Chunk("doc-log", text, nil)
+	if len(chunks) < 7 {
+		t.Fatalf("expected at least 7 chunks, got %d", len(chunks))
+	}
+	for i, ch := range chunks {
+		if n := len([]rune(ch.Content)); n > 50 {
+			t.Errorf("chunk %d has %d characters, over the 50 limit", i, n)
+		}
+	}
+	for i := 0; i+1 < len(chunks); i++ {
+		prev, next := chunks[i].Content, chunks[i+1].Content
+		if !strings.HasPrefix(next, prev[len(prev)-10:]) {
+			t.Errorf("chunks %d/%d: expected 10 character overlap", i, i+1)
+		}
+	}
+}
+
+func TestRecursiveChunkerParagraphs(t *testing.T) {
+	paragraphs := []string{
+		"First paragraph is short.",
+		"Second paragraph is a little longer but still fits.",
+		strings.TrimSpace(strings.Repeat("This third paragraph is very long. ", 6)),
+	}
+	text := strings.Join(paragraphs, "\n\n")
+	c := &RecursiveChunker{ChunkSize: 80, Overlap: 0}
+
+	chunks := c.Chunk("doc-para", text, nil)
+	for i, ch := range chunks {
+		if n := len([]rune(ch.Content)); n > 80 {
+			t.Errorf("chunk %d has %d characters, over the 80 limit: %q", i, n, ch.Content)
+		}
+	}
+	// The first two paragraphs fit together and are kept whole.
+	if !strings.HasPrefix(chunks[0].Content, paragraphs[0]) || !strings.Contains(chunks[0].Content, paragraphs[1]) {
+		t.Errorf("expected the short paragraphs in the first chunk, got %q", chunks[0].Content)
+	}
+	// The long paragraph is split on sentence boundaries.
+	for _, ch := range chunks[1:] {
+		if !strings.HasPrefix(ch.Content, "This third paragraph") {
+			t.Errorf("expected sentence-aligned chunk, got %q", ch.Content)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	text := "Hello, internationalization 世界!"
+	pieces := Tokenize(text)
+	if got := strings.Join(pieces, ""); got != text {
+		t.Errorf("pieces should reassemble the text, got %q", got)
+	}
+	// Hell|o|,| inte|rnat|iona|liza|tion| 世|界|!
+	if n := EstimateTokens(text); n != 11 {
+		t.Errorf("expected 11 tokens, got %d: %q", n, pieces)
+	}
+	if n := EstimateTokens("   "); n != 0 {
+		t.Errorf("expected 0 tokens for whitespace, got %d", n)
+	}
+}
+
 func TestSplitSentences(t *testing.T) {
 	sentences := splitSentences("Hello world. How are you? Fine!")
 	if len(sentences) != 3 {
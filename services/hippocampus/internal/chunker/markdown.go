@@ -0,0 +1,167 @@
+package chunker
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MarkdownChunker splits Markdown on heading and paragraph boundaries while
+// keeping fenced code blocks and tables intact. Blocks are packed into chunks
+// of at most MaxChunkSize words; a code block or table larger than that
+// becomes a chunk of its own rather than being split.
+type MarkdownChunker struct {
+	MaxChunkSize int
+}
+
+// markdownBlock is a unit of Markdown that must not be split across chunks.
+type markdownBlock struct {
+	text    string
+	heading bool // starts a new section
+	atomic  bool // code block or table
+}
+
+// Chunk splits Markdown text into chunks.
+func (c *MarkdownChunker) Chunk(documentID, text string, metadata map[string]string) []Chunk {
+	blocks := parseMarkdownBlocks(text)
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	var chunks []Chunk
+	var current []string
+	currentSize := 0
+
+	emit := func(content string) {
+		chunks = append(chunks, Chunk{
+			ID:         uuid.New().String(),
+			DocumentID: documentID,
+			Content:    content,
+			Index:      len(chunks),
+			Metadata:   copyMetadata(metadata),
+		})
+	}
+	flush := func() {
+		if len(current) > 0 {
+			emit(strings.Join(current, "\n\n"))
+			current = nil
+			currentSize = 0
+		}
+	}
+
+	for _, b := range blocks {
+		size := len(strings.Fields(b.text))
+
+		if b.heading {
+			flush()
+		}
+
+		if c.MaxChunkSize > 0 && size > c.MaxChunkSize {
+			flush()
+			if b.atomic {
+				emit(b.text)
+			} else {
+				sub := &SemanticChunker{MaxChunkSize: c.MaxChunkSize}
+				for _, sc := range sub.Chunk(documentID, b.text, nil) {
+					emit(sc.Content)
+				}
+			}
+			continue
+		}
+
+		if c.MaxChunkSize > 0 && currentSize+size > c.MaxChunkSize {
+			flush()
+		}
+		current = append(current, b.text)
+		currentSize += size
+	}
+	flush()
+
+	return recordOffsets(text, chunks)
+}
+
+// parseMarkdownBlocks splits text into headings, paragraphs, fenced code
+// blocks, and tables.
+func parseMarkdownBlocks(text string) []markdownBlock {
+	var blocks []markdownBlock
+	var para, code, table []string
+	var fence string // opening fence marker while inside a code block
+
+	flushPara := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, markdownBlock{text: strings.Join(para, "\n")})
+			para = nil
+		}
+	}
+	flushTable := func() {
+		if len(table) > 0 {
+			blocks = append(blocks, markdownBlock{text: strings.Join(table, "\n"), atomic: true})
+			table = nil
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			code = append(code, line)
+			if closesFence(trimmed, fence) {
+				blocks = append(blocks, markdownBlock{text: strings.Join(code, "\n"), atomic: true})
+				fence, code = "", nil
+			}
+			continue
+		}
+
+		if marker := fenceMarker(trimmed); marker != "" {
+			flushPara()
+			flushTable()
+			fence = marker
+			code = []string{line}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "|") {
+			flushPara()
+			table = append(table, line)
+			continue
+		}
+		flushTable()
+
+		switch {
+		case trimmed == "":
+			flushPara()
+		case strings.HasPrefix(trimmed, "#"):
+			flushPara()
+			blocks = append(blocks, markdownBlock{text: trimmed, heading: true})
+		default:
+			para = append(para, line)
+		}
+	}
+
+	// An unterminated fence runs to the end of the document.
+	if fence != "" {
+		blocks = append(blocks, markdownBlock{text: strings.Join(code, "\n"), atomic: true})
+	}
+	flushTable()
+	flushPara()
+
+	return blocks
+}
+
+// fenceMarker returns the run of backticks or tildes (at least three) that
+// opens a code block on this line, or "" if the line is not a fence.
+func fenceMarker(trimmed string) string {
+	for _, ch := range []string{"`", "~"} {
+		if strings.HasPrefix(trimmed, ch+ch+ch) {
+			n := len(trimmed) - len(strings.TrimLeft(trimmed, ch))
+			return trimmed[:n]
+		}
+	}
+	return ""
+}
+
+// closesFence reports whether the line closes a block opened with fence: a
+// run of the same character at least as long, with nothing after it.
+func closesFence(trimmed, fence string) bool {
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
@@ -0,0 +1,117 @@
+package chunker
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// recursiveSeparators are tried in order, from coarsest to finest. The empty
+// separator means a hard cut between characters.
+var recursiveSeparators = []string{"\n\n", "\n", ". ", " ", ""}
+
+// RecursiveChunker splits text on the coarsest separator that occurs in it
+// (paragraphs, then lines, sentences, words), recursing into any piece that is
+// still too long and finally cutting between characters, so no chunk ever
+// exceeds ChunkSize characters. Adjacent pieces are merged up to ChunkSize
+// with about Overlap characters carried over between chunks. It suits text
+// without reliable structure, such as logs and transcripts.
+type RecursiveChunker struct {
+	ChunkSize int
+	Overlap   int
+}
+
+// Chunk splits text into chunks of at most ChunkSize characters.
+func (c *RecursiveChunker) Chunk(documentID, text string, metadata map[string]string) []Chunk {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	var pieces []string
+	if c.ChunkSize <= 0 {
+		pieces = []string{strings.TrimSpace(text)}
+	} else {
+		pieces = c.split(text, recursiveSeparators)
+	}
+
+	chunks := make([]Chunk, 0, len(pieces))
+	for _, p := range pieces {
+		chunks = append(chunks, Chunk{
+			ID:         uuid.New().String(),
+			DocumentID: documentID,
+			Content:    p,
+			Index:      len(chunks),
+			Metadata:   copyMetadata(metadata),
+		})
+	}
+	return recordOffsets(text, chunks)
+}
+
+// split breaks text into chunks no longer than ChunkSize using the first
+// separator in seps that occurs in text.
+func (c *RecursiveChunker) split(text string, seps []string) []string {
+	sep, rest := "", []string(nil)
+	for i, s := range seps {
+		if s == "" || strings.Contains(text, s) {
+			sep, rest = s, seps[i+1:]
+			break
+		}
+	}
+
+	if sep == "" {
+		return c.merge(splitRunes(text))
+	}
+
+	var out, fitting []string
+	for _, piece := range strings.SplitAfter(text, sep) {
+		if utf8.RuneCountInString(piece) <= c.ChunkSize {
+			fitting = append(fitting, piece)
+			continue
+		}
+		out = append(out, c.merge(fitting)...)
+		fitting = nil
+		out = append(out, c.split(piece, rest)...)
+	}
+	return append(out, c.merge(fitting)...)
+}
+
+// merge joins consecutive pieces (each at most ChunkSize long) into chunks of
+// at most ChunkSize, starting each new chunk with up to Overlap characters of
+// trailing pieces from the previous one.
+func (c *RecursiveChunker) merge(pieces []string) []string {
+	var out, current []string
+	total := 0
+
+	emit := func() {
+		if s := strings.TrimSpace(strings.Join(current, "")); s != "" {
+			out = append(out, s)
+		}
+	}
+
+	for _, p := range pieces {
+		n := utf8.RuneCountInString(p)
+		if total+n > c.ChunkSize && len(current) > 0 {
+			emit()
+			for len(current) > 0 && (total > c.Overlap || total+n > c.ChunkSize) {
+				total -= utf8.RuneCountInString(current[0])
+				current = current[1:]
+			}
+		}
+		current = append(current, p)
+		total += n
+	}
+	if len(current) > 0 {
+		emit()
+	}
+	return out
+}
+
+// splitRunes splits text into single-character strings.
+func splitRunes(text string) []string {
+	out := make([]string, 0, len(text))
+	for _, r := range text {
+		out = append(out, string(r))
+	}
+	return out
+}
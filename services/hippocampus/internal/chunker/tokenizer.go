@@ -0,0 +1,75 @@
+package chunker
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxPieceRunes approximates the average length of a BPE token for
+// alphanumeric text (~4 characters per token for English).
+const maxPieceRunes = 4
+
+// Tokenize splits text into pieces that approximate a BPE tokenizer's
+// tokens: whitespace attaches to the following piece, long words are split
+// into pieces of at most four characters, and each punctuation mark or CJK
+// character is its own piece. Concatenating the pieces reproduces text.
+func Tokenize(text string) []string {
+	var pieces []string
+	var current strings.Builder
+	wordRunes := 0 // letters/digits in the current piece
+
+	flush := func() {
+		if current.Len() > 0 {
+			pieces = append(pieces, current.String())
+			current.Reset()
+		}
+		wordRunes = 0
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			// Whitespace starts a new piece and prefixes it.
+			if wordRunes > 0 {
+				flush()
+			}
+			current.WriteRune(r)
+		case isCJK(r):
+			current.WriteRune(r)
+			flush()
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if wordRunes == maxPieceRunes {
+				flush()
+			}
+			current.WriteRune(r)
+			wordRunes++
+		default:
+			if wordRunes > 0 {
+				flush()
+			}
+			current.WriteRune(r)
+			flush()
+		}
+	}
+	flush()
+
+	return pieces
+}
+
+// EstimateTokens returns the approximate number of model tokens in text.
+func EstimateTokens(text string) int {
+	n := 0
+	for _, p := range Tokenize(text) {
+		if strings.TrimSpace(p) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
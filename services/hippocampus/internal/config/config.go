@@ -1,37 +1,140 @@
 package config
 
 import (
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds all configuration for the Hippocampus service.
 type Config struct {
-	GRPCPort    int
-	ServiceName string
+	GRPCPort    int    `yaml:"grpc_port"`
+	MetricsPort int    `yaml:"metrics_port"` // serves RPC latency on GET /metrics; 0 disables it
+	ServiceName string `yaml:"service_name"`
 
 	// Vector store
-	CollectionName    string
-	EmbeddingDimension int
+	CollectionName     string `yaml:"collection_name"`
+	EmbeddingDimension int    `yaml:"embedding_dimension"`
+	EmbedConcurrency   int    `yaml:"embed_concurrency"` // embedder calls in flight per indexed document
 
 	// Chunking
-	ChunkSize    int
-	ChunkOverlap int
+	ChunkSize     int                        `yaml:"chunk_size"`
+	ChunkOverlap  int                        `yaml:"chunk_overlap"`
+	ChunkProfiles map[string]ChunkingProfile `yaml:"chunk_profiles"` // content type -> overrides of the settings above
+
+	// Hybrid search Reciprocal Rank Fusion
+	HybridBM25Weight   float64 `yaml:"hybrid_bm25_weight"`   // weight of the BM25 ranking
+	HybridVectorWeight float64 `yaml:"hybrid_vector_weight"` // weight of the vector ranking
+	RRFConstant        float64 `yaml:"rrf_constant"`         // k in weight / (k + rank); lower favors top ranks
+	Reranker           string  `yaml:"reranker"`             // "" (none) or "keyword"
+	RerankWeight       float64 `yaml:"rerank_weight"`        // share of the final score given to the reranker
+
+	// Query expansion, used by hybrid searches that set expand_query
+	SynonymsFile     string `yaml:"synonyms_file"`      // JSON term -> synonyms file; empty disables expansion
+	MaxQueryVariants int    `yaml:"max_query_variants"` // cap on expanded variants searched per query
+
+	// Soft delete
+	TombstoneTTL           time.Duration `yaml:"tombstone_ttl"`            // how long soft-deleted documents are kept; 0 keeps them forever
+	TombstonePurgeInterval time.Duration `yaml:"tombstone_purge_interval"` // how often expired tombstones are purged
+
+	// Stats history
+	StatsSnapshotInterval time.Duration `yaml:"stats_snapshot_interval"` // how often stats are snapshotted besides after each change; 0 snapshots only on change
+	StatsHistorySize      int           `yaml:"stats_history_size"`      // snapshots kept, oldest dropped first; 0 disables the history
+
+	// TLS
+	TLSCertFile string `yaml:"tls_cert_file"` // server certificate; empty serves plaintext
+	TLSKeyFile  string `yaml:"tls_key_file"`  // server private key
+
+	// Auth
+	AuthTokens []string `yaml:"auth_tokens"` // bearer tokens accepted on gRPC calls; empty disables auth
 
 	// Observability
-	OTelEndpoint string
+	OTelEndpoint string `yaml:"otel_endpoint"`
 }
 
-// Load reads configuration from environment variables with defaults.
-func Load() *Config {
+// ChunkingProfile overrides how documents of one content type are chunked.
+// Zero fields keep the global setting.
+type ChunkingProfile struct {
+	Strategy string `yaml:"strategy"` // chunker name such as "markdown"; used when the request leaves the strategy unspecified
+	Size     int    `yaml:"size"`
+	Overlap  int    `yaml:"overlap"`
+}
+
+// Load reads configuration from a YAML file, then environment variables,
+// which take precedence over file values. A file named by CONFIG_FILE must
+// load cleanly; an unreadable default config.yaml is logged and ignored.
+func Load() (*Config, error) {
+	base := defaults()
+	path, explicit := configFilePath()
+	if err := loadFile(base, path, explicit); err != nil {
+		if explicit {
+			return nil, err
+		}
+		slog.Warn("ignoring config file", "path", path, "error", err)
+		base = defaults()
+	}
+
 	return &Config{
-		GRPCPort:           getEnvInt("HIPPOCAMPUS_GRPC_PORT", 50053),
-		ServiceName:        getEnv("HIPPOCAMPUS_SERVICE_NAME", "hippocampus"),
-		CollectionName:     getEnv("COLLECTION_NAME", "second_brain"),
-		EmbeddingDimension: getEnvInt("EMBEDDING_DIMENSION", 384),
-		ChunkSize:          getEnvInt("CHUNK_SIZE", 512),
-		ChunkOverlap:       getEnvInt("CHUNK_OVERLAP", 50),
-		OTelEndpoint:       getEnv("OTEL_ENDPOINT", ""),
+		GRPCPort:               getEnvInt("HIPPOCAMPUS_GRPC_PORT", base.GRPCPort),
+		MetricsPort:            getEnvInt("HIPPOCAMPUS_METRICS_PORT", base.MetricsPort),
+		ServiceName:            getEnv("HIPPOCAMPUS_SERVICE_NAME", base.ServiceName),
+		CollectionName:         getEnv("COLLECTION_NAME", base.CollectionName),
+		EmbeddingDimension:     getEnvInt("EMBEDDING_DIMENSION", base.EmbeddingDimension),
+		EmbedConcurrency:       getEnvInt("EMBED_CONCURRENCY", base.EmbedConcurrency),
+		ChunkSize:              getEnvInt("CHUNK_SIZE", base.ChunkSize),
+		ChunkOverlap:           getEnvInt("CHUNK_OVERLAP", base.ChunkOverlap),
+		ChunkProfiles:          getEnvChunkProfiles("CHUNK_PROFILES", base.ChunkProfiles),
+		HybridBM25Weight:       getEnvFloat("HYBRID_BM25_WEIGHT", base.HybridBM25Weight),
+		HybridVectorWeight:     getEnvFloat("HYBRID_VECTOR_WEIGHT", base.HybridVectorWeight),
+		RRFConstant:            getEnvFloat("RRF_CONSTANT", base.RRFConstant),
+		Reranker:               getEnv("RERANKER", base.Reranker),
+		RerankWeight:           getEnvFloat("RERANK_WEIGHT", base.RerankWeight),
+		SynonymsFile:           getEnv("SYNONYMS_FILE", base.SynonymsFile),
+		MaxQueryVariants:       getEnvInt("MAX_QUERY_VARIANTS", base.MaxQueryVariants),
+		TombstoneTTL:           getDurationEnv("TOMBSTONE_TTL", base.TombstoneTTL),
+		TombstonePurgeInterval: getDurationEnv("TOMBSTONE_PURGE_INTERVAL", base.TombstonePurgeInterval),
+		StatsSnapshotInterval:  getDurationEnv("STATS_SNAPSHOT_INTERVAL", base.StatsSnapshotInterval),
+		StatsHistorySize:       getEnvInt("STATS_HISTORY_SIZE", base.StatsHistorySize),
+		TLSCertFile:            getEnv("TLS_CERT_FILE", base.TLSCertFile),
+		TLSKeyFile:             getEnv("TLS_KEY_FILE", base.TLSKeyFile),
+		AuthTokens:             getEnvList("AUTH_TOKENS", base.AuthTokens),
+		OTelEndpoint:           getEnv("OTEL_ENDPOINT", base.OTelEndpoint),
+	}, nil
+}
+
+// defaults returns the configuration used when neither the config file nor
+// the environment sets a value.
+func defaults() *Config {
+	return &Config{
+		GRPCPort:               50053,
+		MetricsPort:            9093,
+		ServiceName:            "hippocampus",
+		CollectionName:         "second_brain",
+		EmbeddingDimension:     384,
+		EmbedConcurrency:       4,
+		ChunkSize:              512,
+		ChunkOverlap:           50,
+		ChunkProfiles:          defaultChunkProfiles(),
+		HybridBM25Weight:       2.0,
+		HybridVectorWeight:     1.0,
+		RRFConstant:            60,
+		RerankWeight:           0.5,
+		MaxQueryVariants:       3,
+		TombstoneTTL:           30 * 24 * time.Hour,
+		TombstonePurgeInterval: time.Hour,
+		StatsSnapshotInterval:  time.Hour,
+		StatsHistorySize:       1000,
+	}
+}
+
+// defaultChunkProfiles chunks markdown along its headings and code in larger
+// fixed windows, so functions are less often split.
+func defaultChunkProfiles() map[string]ChunkingProfile {
+	return map[string]ChunkingProfile{
+		"text/markdown": {Strategy: "markdown"},
+		"text/x-code":   {Strategy: "fixed", Size: 1024, Overlap: 100},
 	}
 }
 
@@ -50,3 +153,64 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// getEnvList parses a comma-separated list, ignoring empty entries.
+func getEnvList(key string, fallback []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// getEnvChunkProfiles parses a comma-separated list of
+// content_type=strategy[:size[:overlap]] entries, such as
+// "text/markdown=markdown,text/x-code=fixed:1024:100". Empty or malformed
+// fields are left at zero, keeping the global setting.
+func getEnvChunkProfiles(key string, fallback map[string]ChunkingProfile) map[string]ChunkingProfile {
+	if _, ok := os.LookupEnv(key); !ok {
+		return fallback
+	}
+	profiles := make(map[string]ChunkingProfile)
+	for _, entry := range getEnvList(key, nil) {
+		contentType, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		fields := strings.Split(spec, ":")
+		var p ChunkingProfile
+		p.Strategy = strings.TrimSpace(fields[0])
+		if len(fields) > 1 {
+			p.Size, _ = strconv.Atoi(strings.TrimSpace(fields[1]))
+		}
+		if len(fields) > 2 {
+			p.Overlap, _ = strconv.Atoi(strings.TrimSpace(fields[2]))
+		}
+		profiles[strings.ToLower(strings.TrimSpace(contentType))] = p
+	}
+	return profiles
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testConfigYAML = `collection_name: notes
+chunk_size: 256
+auth_tokens: [secret]
+`
+
+// writeConfigFile writes content to a temp file and points CONFIG_FILE at it.
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+}
+
+// mustLoad calls Load and fails the test on error.
+func mustLoad(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+func TestLoadFromFile(t *testing.T) {
+	writeConfigFile(t, testConfigYAML)
+
+	cfg := mustLoad(t)
+
+	if got := cfg.CollectionName; got != "notes" {
+		t.Errorf("cfg.CollectionName: expected %q, got %q", "notes", got)
+	}
+	if got := cfg.ChunkSize; got != 256 {
+		t.Errorf("cfg.ChunkSize: expected %d, got %d", 256, got)
+	}
+	if got := len(cfg.AuthTokens); got != 1 {
+		t.Errorf("len(cfg.AuthTokens): expected %d, got %d", 1, got)
+	}
+	if got := cfg.ChunkOverlap; got != 50 {
+		t.Errorf("cfg.ChunkOverlap: expected %d, got %d", 50, got)
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	writeConfigFile(t, testConfigYAML)
+	t.Setenv("CHUNK_SIZE", "128")
+
+	cfg := mustLoad(t)
+
+	if got := cfg.ChunkSize; got != 128 {
+		t.Errorf("cfg.ChunkSize: expected %d, got %d", 128, got)
+	}
+	if got := cfg.CollectionName; got != "notes" {
+		t.Errorf("cfg.CollectionName: expected %q, got %q", "notes", got)
+	}
+}
+
+func TestLoadMissingExplicitFileFails(t *testing.T) {
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected an error for a missing CONFIG_FILE")
+	}
+}
+
+func TestLoadMalformedExplicitFileFails(t *testing.T) {
+	writeConfigFile(t, "grpc_port: [not a port\n")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected an error for a malformed CONFIG_FILE")
+	}
+}
+
+func TestLoadMissingDefaultFileUsesDefaults(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+
+	cfg := mustLoad(t)
+
+	if got := cfg.ChunkSize; got != 512 {
+		t.Errorf("cfg.ChunkSize: expected %d, got %d", 512, got)
+	}
+}
+
+func TestLoadChunkProfiles(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+
+	if got := defaults().ChunkProfiles["text/markdown"].Strategy; got != "markdown" {
+		t.Errorf("expected markdown chunked by heading by default, got %q", got)
+	}
+
+	t.Setenv("CHUNK_PROFILES", "text/x-code=fixed:2048:200, Message/RFC822=recursive")
+	cfg := mustLoad(t)
+	want := map[string]ChunkingProfile{
+		"text/x-code":    {Strategy: "fixed", Size: 2048, Overlap: 200},
+		"message/rfc822": {Strategy: "recursive"},
+	}
+	if len(cfg.ChunkProfiles) != len(want) {
+		t.Fatalf("expected %v, got %v", want, cfg.ChunkProfiles)
+	}
+	for contentType, p := range want {
+		if cfg.ChunkProfiles[contentType] != p {
+			t.Errorf("%s: expected %+v, got %+v", contentType, p, cfg.ChunkProfiles[contentType])
+		}
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"math/rand"
 )
 
-// Embedder generates vector embeddings from text.
+// Embedder generates vector embeddings from text. Implementations must be
+// safe for concurrent use; a document's chunks are embedded in parallel.
 type Embedder interface {
 	Embed(texts []string) ([][]float32, error)
 	Dimension() int
+	// Model names the embedding model, for reporting in stats.
+	Model() string
 }
 
 // MockEmbedder generates deterministic random embeddings for testing/development.
@@ -38,6 +41,11 @@ func (e *MockEmbedder) Dimension() int {
 	return e.dim
 }
 
+// Model returns "mock".
+func (e *MockEmbedder) Model() string {
+	return "mock"
+}
+
 func (e *MockEmbedder) embedSingle(text string) []float32 {
 	// Use text hash as seed for deterministic embeddings
 	seed := e.seed
@@ -0,0 +1,67 @@
+package hybrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// QueryExpander proposes variants of a query, e.g. rephrasings from an LLM or
+// synonym substitutions, that are searched alongside it so that documents
+// worded differently from the query are still found.
+type QueryExpander interface {
+	Expand(query string) []string
+}
+
+// SynonymExpander expands a query by substituting synonyms for its terms,
+// one substitution per variant.
+type SynonymExpander struct {
+	synonyms map[string][]string // lowercase term -> synonyms
+}
+
+// NewSynonymExpander creates an expander from a term -> synonyms map. Terms
+// match case-insensitively.
+func NewSynonymExpander(synonyms map[string][]string) *SynonymExpander {
+	normalized := make(map[string][]string, len(synonyms))
+	for term, syns := range synonyms {
+		term = strings.ToLower(term)
+		normalized[term] = append(normalized[term], syns...)
+	}
+	return &SynonymExpander{synonyms: normalized}
+}
+
+// LoadSynonyms reads a synonym map from a JSON file of the form
+// {"term": ["synonym", ...], ...}.
+func LoadSynonyms(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading synonyms: %w", err)
+	}
+	var synonyms map[string][]string
+	if err := json.Unmarshal(data, &synonyms); err != nil {
+		return nil, fmt.Errorf("decoding synonyms: %w", err)
+	}
+	return synonyms, nil
+}
+
+// Expand returns the query with each known term replaced by each of its
+// synonyms in turn, in query order and without duplicates.
+func (e *SynonymExpander) Expand(query string) []string {
+	terms := rerankTerms(query)
+	seen := map[string]bool{strings.Join(terms, " "): true}
+	var variants []string
+	for i, term := range terms {
+		for _, syn := range e.synonyms[term] {
+			variant := make([]string, len(terms))
+			copy(variant, terms)
+			variant[i] = strings.ToLower(syn)
+			v := strings.Join(variant, " ")
+			if !seen[v] {
+				seen[v] = true
+				variants = append(variants, v)
+			}
+		}
+	}
+	return variants
+}
@@ -0,0 +1,50 @@
+package hybrid
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSynonymExpander(t *testing.T) {
+	e := NewSynonymExpander(map[string][]string{
+		"Car":    {"automobile", "vehicle"},
+		"repair": {"fix"},
+	})
+
+	got := e.Expand("car repair")
+	want := []string{"automobile repair", "vehicle repair", "car fix"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := e.Expand("sourdough bread"); len(got) != 0 {
+		t.Errorf("expected no variants for unknown terms, got %v", got)
+	}
+}
+
+func TestSynonymExpanderSkipsDuplicates(t *testing.T) {
+	e := NewSynonymExpander(map[string][]string{"car": {"car", "Auto", "auto"}})
+	if got := e.Expand("car"); !slices.Equal(got, []string{"auto"}) {
+		t.Errorf("expected a single distinct variant, got %v", got)
+	}
+}
+
+func TestLoadSynonyms(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synonyms.json")
+	if err := os.WriteFile(path, []byte(`{"car": ["automobile"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	synonyms, err := LoadSynonyms(path)
+	if err != nil {
+		t.Fatalf("LoadSynonyms: %v", err)
+	}
+	if !slices.Equal(synonyms["car"], []string{"automobile"}) {
+		t.Errorf("unexpected synonyms %v", synonyms)
+	}
+
+	if _, err := LoadSynonyms(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,65 @@
+package hybrid
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Reranker reorders fused results for a query, e.g. with a cross-encoder or
+// an LLM judging relevance, to refine the final ranking.
+type Reranker interface {
+	Rerank(query string, results []RankedResult) []RankedResult
+}
+
+// KeywordOverlapReranker blends each result's fused score with the fraction
+// of query terms its content contains.
+type KeywordOverlapReranker struct {
+	// Weight is the share of the final score given to keyword overlap, in
+	// [0, 1]; the rest comes from the fused score.
+	Weight float64
+}
+
+// NewKeywordOverlapReranker creates a reranker giving keyword overlap the
+// given share of the final score.
+func NewKeywordOverlapReranker(weight float64) *KeywordOverlapReranker {
+	return &KeywordOverlapReranker{Weight: weight}
+}
+
+// Rerank rescores results and sorts them by the blended score.
+func (r *KeywordOverlapReranker) Rerank(query string, results []RankedResult) []RankedResult {
+	terms := rerankTerms(query)
+	if len(terms) == 0 {
+		return results
+	}
+
+	reranked := make([]RankedResult, len(results))
+	for i, res := range results {
+		content := make(map[string]bool)
+		for _, t := range rerankTerms(res.Content) {
+			content[t] = true
+		}
+		matched := 0
+		for _, t := range terms {
+			if content[t] {
+				matched++
+			}
+		}
+		overlap := float64(matched) / float64(len(terms))
+
+		reranked[i] = res
+		reranked[i].Score = (1-r.Weight)*res.Score + r.Weight*overlap
+	}
+
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return reranked[i].Score > reranked[j].Score
+	})
+	return reranked
+}
+
+// rerankTerms splits text into lowercase words.
+func rerankTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,41 @@
+package hybrid
+
+import (
+	"testing"
+)
+
+func TestKeywordOverlapReranker(t *testing.T) {
+	results := []RankedResult{
+		{ID: "vague", Score: 1.0, Content: "General notes about systems"},
+		{ID: "exact", Score: 0.6, Content: "The Rust borrow checker explained"},
+		{ID: "partial", Score: 0.8, Content: "Borrowing books from the library"},
+	}
+
+	reranked := NewKeywordOverlapReranker(0.7).Rerank("rust borrow checker", results)
+
+	if len(reranked) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(reranked))
+	}
+	if reranked[0].ID != "exact" {
+		t.Errorf("expected the result containing every query term first, got %s", reranked[0].ID)
+	}
+	for i := 1; i < len(reranked); i++ {
+		if reranked[i].Score > reranked[i-1].Score {
+			t.Errorf("results not sorted by reranked score: %v", reranked)
+		}
+	}
+	if results[0].ID != "vague" || results[0].Score != 1.0 {
+		t.Error("expected the input slice to be left unchanged")
+	}
+}
+
+func TestKeywordOverlapRerankerZeroWeight(t *testing.T) {
+	results := []RankedResult{
+		{ID: "a", Score: 0.9, Content: "unrelated"},
+		{ID: "b", Score: 0.5, Content: "rust borrow checker"},
+	}
+	reranked := NewKeywordOverlapReranker(0).Rerank("rust borrow checker", results)
+	if reranked[0].ID != "a" || reranked[0].Score != 0.9 {
+		t.Errorf("expected zero weight to keep the fused ranking, got %v", reranked)
+	}
+}
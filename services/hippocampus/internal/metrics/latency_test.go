@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyEndpoint(t *testing.T) {
+	l := NewLatencyStore()
+	l.Observe("/svc/Method", 30*time.Millisecond, false)
+	l.Observe("/svc/Method", 2*time.Second, true)
+
+	mux := http.NewServeMux()
+	l.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"# TYPE secondbrain_grpc_request_duration_seconds histogram",
+		`secondbrain_grpc_request_duration_seconds_bucket{method="/svc/Method",le="0.05"} 1`,
+		`secondbrain_grpc_request_duration_seconds_bucket{method="/svc/Method",le="+Inf"} 2`,
+		`secondbrain_grpc_request_duration_seconds_count{method="/svc/Method"} 2`,
+		`secondbrain_grpc_errors_total{method="/svc/Method"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected output to contain %q\n%s", want, body)
+		}
+	}
+}
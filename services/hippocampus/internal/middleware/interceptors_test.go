@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryRecovery(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	interceptor := UnaryRecovery(logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.memory.v1.MemoryService/IndexDocument"}
+
+	panicking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		var m map[string]int
+		m["boom"]++ // assignment to entry in nil map
+		return nil, nil
+	}
+	_, err := interceptor(context.Background(), nil, info, panicking)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal after a panic, got %v", err)
+	}
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	if resp, err := interceptor(context.Background(), nil, info, ok); err != nil || resp != "ok" {
+		t.Errorf("expected handler result to pass through, got %v (%v)", resp, err)
+	}
+}
+
+func TestStreamRecovery(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	interceptor := StreamRecovery(logger)
+	info := &grpc.StreamServerInfo{FullMethod: streamMethod}
+	stream := &authStream{ctx: context.Background()}
+
+	panicking := func(srv interface{}, ss grpc.ServerStream) error {
+		var items []string
+		_ = items[3] // index out of range
+		return nil
+	}
+	if err := interceptor(nil, stream, info, panicking); status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal after a panic, got %v", err)
+	}
+
+	wantErr := status.Error(codes.NotFound, "missing")
+	failing := func(srv interface{}, ss grpc.ServerStream) error { return wantErr }
+	if err := interceptor(nil, stream, info, failing); err != wantErr {
+		t.Errorf("expected handler error to pass through, got %v", err)
+	}
+}
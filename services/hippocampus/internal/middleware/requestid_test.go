@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryRequestID(t *testing.T) {
+	interceptor := UnaryRequestID()
+	info := &grpc.UnaryServerInfo{FullMethod: "/cognitive_os.memory.v1.MemoryService/IndexDocument"}
+	var got string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = RequestID(ctx)
+		return nil, nil
+	}
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "req-123"))
+	interceptor(incoming, nil, info, handler)
+	if got != "req-123" {
+		t.Errorf("expected the caller's request ID, got %q", got)
+	}
+
+	interceptor(context.Background(), nil, info, handler)
+	if len(got) != 32 || got == "req-123" {
+		t.Errorf("expected a generated request ID, got %q", got)
+	}
+}
+
+func TestStreamRequestID(t *testing.T) {
+	interceptor := StreamRequestID()
+	info := &grpc.StreamServerInfo{FullMethod: streamMethod}
+	var got string
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got = RequestID(ss.Context())
+		return nil
+	}
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "req-456"))
+	interceptor(nil, &authStream{ctx: incoming}, info, handler)
+	if got != "req-456" {
+		t.Errorf("expected the caller's request ID, got %q", got)
+	}
+}
+
+func TestRequestIDHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewRequestIDHandler(slog.NewJSONHandler(&buf, nil))).With("service", "test")
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-789"), "handled")
+	logger.InfoContext(context.Background(), "no request")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], `"request_id":"req-789"`) || !strings.Contains(lines[0], `"service":"test"`) {
+		t.Errorf("expected request_id and logger attributes, got %s", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("expected no request_id without one in context, got %s", lines[1])
+	}
+}
@@ -4,10 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -23,6 +32,8 @@ import (
 	memoryv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/memory/v1"
 )
 
+var tracer = otel.Tracer("github.com/ziyixi/SecondBrain/services/hippocampus/internal/server")
+
 // HippocampusServer implements the MemoryService gRPC service.
 type HippocampusServer struct {
 	memoryv1.UnimplementedMemoryServiceServer
@@ -34,10 +45,21 @@ type HippocampusServer struct {
 	embedder    embedder.Embedder
 	kg          *graph.KnowledgeGraph
 	textIdx     *textindex.Index
-	docChunks   map[string][]string // document_id -> chunk_ids
+	reranker    hybrid.Reranker                                 // optional; refines hybrid search ranking
+	expander    hybrid.QueryExpander                            // optional; proposes query variants for expand_query
+	docChunks   map[string]map[string][]string                  // collection -> document_id -> chunk_ids
+	chunking    map[string]map[string]memoryv1.ChunkingStrategy // collection -> document_id -> strategy, for Reindex
+	triples     map[string]map[string]struct{}                  // collection -> IDs of the graph triples added to it
+	tombstones  map[string]map[string]time.Time                 // collection -> document_id -> soft-delete time
+	indexedAt   map[string]map[string]time.Time                 // collection -> document_id -> last index time, for ListByFilter
 	mu          sync.RWMutex
-	lastIndexed time.Time
+	writeMu     sync.RWMutex         // held shared by document writes and exclusively while Reindex swaps in vectors
+	lastIndexed map[string]time.Time // collection -> last index time
+	reindexing  atomic.Bool          // set while a Reindex is running
+	history     statsHistory         // stats snapshots over time, for GetStatsHistory
 	version     string
+
+	statsChangeDelay time.Duration // how long changes are coalesced into one stats snapshot
 }
 
 // NewHippocampusServer creates a new HippocampusServer.
@@ -48,15 +70,44 @@ func NewHippocampusServer(
 	emb embedder.Embedder,
 ) *HippocampusServer {
 	return &HippocampusServer{
-		logger:    logger,
-		cfg:       cfg,
-		store:     store,
-		embedder:  emb,
-		kg:        graph.New(),
-		textIdx:   textindex.New(),
-		docChunks: make(map[string][]string),
-		version:   "0.1.0",
+		logger:      logger,
+		cfg:         cfg,
+		store:       store,
+		embedder:    emb,
+		kg:          graph.New(),
+		textIdx:     textindex.New(),
+		docChunks:   make(map[string]map[string][]string),
+		chunking:    make(map[string]map[string]memoryv1.ChunkingStrategy),
+		triples:     make(map[string]map[string]struct{}),
+		tombstones:  make(map[string]map[string]time.Time),
+		indexedAt:   make(map[string]map[string]time.Time),
+		lastIndexed: make(map[string]time.Time),
+		version:     "0.1.0",
+
+		statsChangeDelay: defaultStatsChangeDelay,
+	}
+}
+
+// SetQueryExpander sets the expander that proposes query variants for
+// hybrid searches requesting expand_query. A nil expander, the default,
+// searches the query alone.
+func (s *HippocampusServer) SetQueryExpander(e hybrid.QueryExpander) {
+	s.expander = e
+}
+
+// SetReranker sets a reranker applied to fused hybrid search results. A nil
+// reranker, the default, keeps the fusion ranking.
+func (s *HippocampusServer) SetReranker(r hybrid.Reranker) {
+	s.reranker = r
+}
+
+// collection resolves the collection named in a request, falling back to the
+// configured default when the request leaves it empty.
+func (s *HippocampusServer) collection(name string) string {
+	if name == "" {
+		return s.cfg.CollectionName
 	}
+	return name
 }
 
 // Check implements the HealthService Check RPC.
@@ -70,6 +121,110 @@ func (s *HippocampusServer) Check(ctx context.Context, req *commonv1.HealthCheck
 
 // IndexDocument indexes a document into the vector store.
 func (s *HippocampusServer) IndexDocument(ctx context.Context, req *memoryv1.IndexRequest) (*memoryv1.IndexResponse, error) {
+	return s.indexDocument(req, nil), nil
+}
+
+// StreamIndexDocument indexes a document like IndexDocument, sending a
+// progress message after each batch of chunks is embedded and a final
+// message carrying the result.
+func (s *HippocampusServer) StreamIndexDocument(req *memoryv1.IndexRequest, stream grpc.ServerStreamingServer[memoryv1.IndexProgress]) error {
+	var sendErr error
+	resp := s.indexDocument(req, func(embedded, total int) error {
+		if sendErr = stream.Context().Err(); sendErr != nil {
+			return sendErr
+		}
+		sendErr = stream.Send(&memoryv1.IndexProgress{
+			ChunksEmbedded: int32(embedded),
+			TotalChunks:    int32(total),
+		})
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	return stream.Send(&memoryv1.IndexProgress{
+		ChunksEmbedded: resp.GetChunksCreated(),
+		TotalChunks:    resp.GetChunksCreated(),
+		Result:         resp,
+	})
+}
+
+// indexDocument chunks, embeds and stores a document, calling progress, if
+// non-nil, after each batch of chunks is embedded. An error from progress
+// aborts indexing.
+func (s *HippocampusServer) indexDocument(req *memoryv1.IndexRequest, progress func(embedded, total int) error) *memoryv1.IndexResponse {
+	doc, failed := s.prepareDocument(req)
+	if failed != nil {
+		return failed
+	}
+
+	// Generate embeddings
+	embeddings, err := s.embedChunks(doc.chunks, progress)
+	if err != nil {
+		return indexError(doc.docID, fmt.Sprintf("embedding error: %v", err))
+	}
+	resp := s.storeDocument(doc, embeddings)
+	if resp.GetSuccess() {
+		s.statsChanged()
+	}
+	return resp
+}
+
+// BatchIndexDocuments indexes several documents, embedding the chunks of all
+// of them in a single embedder call. Each document gets its own result, so
+// an invalid document does not fail the rest of the batch.
+func (s *HippocampusServer) BatchIndexDocuments(ctx context.Context, req *memoryv1.BatchIndexRequest) (*memoryv1.BatchIndexResponse, error) {
+	results := make([]*memoryv1.IndexResponse, len(req.GetDocuments()))
+	var docs []*preparedDoc
+	var slots []int // index into results for each prepared document
+	var chunks []chunker.Chunk
+	for i, docReq := range req.GetDocuments() {
+		doc, failed := s.prepareDocument(docReq)
+		if failed != nil {
+			results[i] = failed
+			continue
+		}
+		docs = append(docs, doc)
+		slots = append(slots, i)
+		chunks = append(chunks, doc.chunks...)
+	}
+	if len(docs) == 0 {
+		return &memoryv1.BatchIndexResponse{Results: results}, nil
+	}
+
+	embeddings, err := s.embedder.Embed(chunkTexts(chunks))
+	offset := 0
+	for k, doc := range docs {
+		if err != nil {
+			// The shared call failed; embed this document alone so that
+			// only the documents that cannot be embedded fail.
+			docEmbeddings, docErr := s.embedChunks(doc.chunks, nil)
+			if docErr != nil {
+				results[slots[k]] = indexError(doc.docID, fmt.Sprintf("embedding error: %v", docErr))
+				continue
+			}
+			results[slots[k]] = s.storeDocument(doc, docEmbeddings)
+			continue
+		}
+		results[slots[k]] = s.storeDocument(doc, embeddings[offset:offset+len(doc.chunks)])
+		offset += len(doc.chunks)
+	}
+	s.statsChanged()
+
+	return &memoryv1.BatchIndexResponse{Results: results}, nil
+}
+
+// preparedDoc is a chunked document awaiting embeddings.
+type preparedDoc struct {
+	req        *memoryv1.IndexRequest
+	docID      string
+	collection string
+	chunks     []chunker.Chunk
+}
+
+// prepareDocument validates and chunks a document, returning a failed
+// IndexResponse instead when it cannot be indexed.
+func (s *HippocampusServer) prepareDocument(req *memoryv1.IndexRequest) (*preparedDoc, *memoryv1.IndexResponse) {
 	docID := req.GetDocumentId()
 	if docID == "" {
 		docID = uuid.New().String()
@@ -77,57 +232,105 @@ func (s *HippocampusServer) IndexDocument(ctx context.Context, req *memoryv1.Ind
 
 	content := req.GetContent()
 	if content == "" {
-		return indexError(docID, "content is empty"), nil
+		return nil, indexError(docID, "content is empty")
+	}
+
+	collection := s.collection(req.GetCollection())
+	if dim, want := s.store.Dimension(collection), s.embedder.Dimension(); dim != 0 && dim != want {
+		return nil, indexError(docID, fmt.Sprintf(
+			"embedding dimension mismatch: collection %q holds %d-dimensional vectors but the embedder (%s) produces %d; run Reindex or index into a new collection",
+			collection, dim, s.embedder.Model(), want))
 	}
 
 	// Chunk the document
 	chunks := s.chunkDocument(docID, content, req.GetChunkingStrategy(), req.GetMetadata())
 	if len(chunks) == 0 {
-		return indexError(docID, "no chunks generated"), nil
+		return nil, indexError(docID, "no chunks generated")
 	}
 
-	// Generate embeddings
-	embeddings, err := s.embedChunks(chunks)
-	if err != nil {
-		return indexError(docID, fmt.Sprintf("embedding error: %v", err)), nil
-	}
+	return &preparedDoc{
+		req:        req,
+		docID:      docID,
+		collection: collection,
+		chunks:     chunks,
+	}, nil
+}
+
+// storeDocument stores an embedded document's chunk vectors and adds it to
+// the full-text index.
+func (s *HippocampusServer) storeDocument(doc *preparedDoc, embeddings [][]float32) *memoryv1.IndexResponse {
+	docID, collection, chunks := doc.docID, doc.collection, doc.chunks
+
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
 
 	// Store vectors
-	chunkIDs, err := s.storeChunkVectors(docID, chunks, embeddings)
+	chunkIDs, err := s.storeChunkVectors(collection, docID, chunks, embeddings)
 	if err != nil {
-		return indexError(docID, fmt.Sprintf("vector store error: %v", err)), nil
+		return indexError(docID, fmt.Sprintf("vector store error: %v", err))
 	}
 
 	s.mu.Lock()
-	s.docChunks[docID] = chunkIDs
-	s.lastIndexed = time.Now()
+	if s.docChunks[collection] == nil {
+		s.docChunks[collection] = make(map[string][]string)
+	}
+	s.docChunks[collection][docID] = chunkIDs
+	if s.chunking[collection] == nil {
+		s.chunking[collection] = make(map[string]memoryv1.ChunkingStrategy)
+	}
+	s.chunking[collection][docID] = doc.req.GetChunkingStrategy()
+	delete(s.tombstones[collection], docID)
+	now := time.Now()
+	if s.indexedAt[collection] == nil {
+		s.indexedAt[collection] = make(map[string]time.Time)
+	}
+	s.indexedAt[collection][docID] = now
+	s.lastIndexed[collection] = now
 	s.mu.Unlock()
 
 	// Also index for full-text search
-	s.textIdx.Add(s.cfg.CollectionName, textindex.Document{
+	s.textIdx.Add(collection, textindex.Document{
 		ID:       docID,
-		Content:  content,
-		Metadata: req.GetMetadata(),
+		Content:  doc.req.GetContent(),
+		Metadata: doc.req.GetMetadata(),
 	})
 
-	s.logger.Info("indexed document", "document_id", docID, "chunks", len(chunks))
+	s.logger.Info("indexed document", "document_id", docID, "collection", collection, "chunks", len(chunks))
 
 	return &memoryv1.IndexResponse{
 		DocumentId:    docID,
 		ChunksCreated: int32(len(chunks)),
 		Success:       true,
-	}, nil
+	}
 }
 
-// chunkDocument splits document content using the requested chunking strategy.
+// chunkDocument splits document content using the requested chunking
+// strategy, adjusted by the cfg.ChunkProfiles entry for the document's
+// content_type metadata, if any. An explicitly requested strategy wins over
+// the profile's.
 func (s *HippocampusServer) chunkDocument(docID, content string, strategy memoryv1.ChunkingStrategy, reqMetadata map[string]string) []chunker.Chunk {
 	strategyMap := map[memoryv1.ChunkingStrategy]string{
 		memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_UNSPECIFIED:  "fixed",
 		memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_FIXED:        "fixed",
 		memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_SEMANTIC:     "semantic",
 		memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_HIERARCHICAL: "hierarchical",
+		memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_TOKEN:        "token",
+		memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_MARKDOWN:     "markdown",
+		memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_RECURSIVE:    "recursive",
 	}
-	strat := chunker.NewStrategy(strategyMap[strategy], s.cfg.ChunkSize, s.cfg.ChunkOverlap)
+	name, size, overlap := strategyMap[strategy], s.cfg.ChunkSize, s.cfg.ChunkOverlap
+	if profile, ok := s.cfg.ChunkProfiles[mediaType(reqMetadata["content_type"])]; ok {
+		if strategy == memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_UNSPECIFIED && profile.Strategy != "" {
+			name = profile.Strategy
+		}
+		if profile.Size > 0 {
+			size = profile.Size
+		}
+		if profile.Overlap > 0 {
+			overlap = profile.Overlap
+		}
+	}
+	strat := chunker.NewStrategy(name, size, overlap)
 
 	metadata := make(map[string]string)
 	for k, v := range reqMetadata {
@@ -138,17 +341,100 @@ func (s *HippocampusServer) chunkDocument(docID, content string, strategy memory
 	return strat.Chunk(docID, content, metadata)
 }
 
-// embedChunks generates embeddings for a list of chunks.
-func (s *HippocampusServer) embedChunks(chunks []chunker.Chunk) ([][]float32, error) {
+// mediaType returns a content type without parameters, lowercased, so that
+// "Text/Markdown; charset=utf-8" matches the "text/markdown" profile.
+func mediaType(contentType string) string {
+	t, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+// embedBatchSize is how many chunks are embedded per embedder call when
+// indexing a document.
+const embedBatchSize = 8
+
+// embedChunks generates embeddings for a list of chunks. Chunks are embedded
+// in batches of embedBatchSize, with up to cfg.EmbedConcurrency batches in
+// flight at once; the embeddings keep the order of the chunks. With a
+// progress callback, progress is called after each batch completes, stopping
+// on error.
+func (s *HippocampusServer) embedChunks(chunks []chunker.Chunk, progress func(embedded, total int) error) ([][]float32, error) {
+	texts := chunkTexts(chunks)
+	embeddings := make([][]float32, len(texts))
+	batches := (len(texts) + embedBatchSize - 1) / embedBatchSize
+	workers := min(max(s.cfg.EmbedConcurrency, 1), batches)
+
+	var (
+		mu       sync.Mutex // guards embedded, firstErr and progress calls
+		embedded int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	starts := make(chan int)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range starts {
+				end := min(start+embedBatchSize, len(texts))
+				batch, err := s.embedder.Embed(texts[start:end])
+				if err == nil && len(batch) != end-start {
+					err = fmt.Errorf("embedder returned %d embeddings for %d chunks", len(batch), end-start)
+				}
+
+				mu.Lock()
+				if firstErr == nil && err == nil {
+					copy(embeddings[start:end], batch)
+					embedded += end - start
+					if progress != nil {
+						err = progress(embedded, len(texts))
+					}
+				}
+				if firstErr == nil && err != nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for start := 0; start < len(texts); start += embedBatchSize {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		starts <- start
+	}
+	close(starts)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return embeddings, nil
+}
+
+// chunkTexts returns the content of each chunk.
+func chunkTexts(chunks []chunker.Chunk) []string {
 	texts := make([]string, len(chunks))
 	for i, c := range chunks {
 		texts[i] = c.Content
 	}
-	return s.embedder.Embed(texts)
+	return texts
 }
 
 // storeChunkVectors writes chunk embeddings into the vector store and returns chunk IDs.
-func (s *HippocampusServer) storeChunkVectors(docID string, chunks []chunker.Chunk, embeddings [][]float32) ([]string, error) {
+func (s *HippocampusServer) storeChunkVectors(collection, docID string, chunks []chunker.Chunk, embeddings [][]float32) ([]string, error) {
+	records, chunkIDs := chunkRecords(docID, chunks, embeddings)
+	if err := s.store.Upsert(collection, records); err != nil {
+		return nil, err
+	}
+	return chunkIDs, nil
+}
+
+// chunkRecords builds the vector store records for a document's chunks.
+func chunkRecords(docID string, chunks []chunker.Chunk, embeddings [][]float32) ([]vectorstore.Record, []string) {
 	records := make([]vectorstore.Record, len(chunks))
 	chunkIDs := make([]string, len(chunks))
 
@@ -167,11 +453,7 @@ func (s *HippocampusServer) storeChunkVectors(docID string, chunks []chunker.Chu
 		}
 		chunkIDs[i] = c.ID
 	}
-
-	if err := s.store.Upsert(s.cfg.CollectionName, records); err != nil {
-		return nil, err
-	}
-	return chunkIDs, nil
+	return records, chunkIDs
 }
 
 // indexError builds a failed IndexResponse with the given error message.
@@ -207,27 +489,60 @@ func (s *HippocampusServer) SemanticSearch(ctx context.Context, req *memoryv1.Se
 		}
 	}
 
-	hits, err := s.store.Search(s.cfg.CollectionName, embeddings[0], topK, filters)
+	// Rank every chunk so the response can report how many matched.
+	collection := s.collection(req.GetCollection())
+	hits, err := s.vectorSearch(ctx, collection, embeddings[0], max(topK, s.store.Count(collection)), filters)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "search error: %v", err)
 	}
 
 	// Filter by min score
 	var results []*memoryv1.SearchResult
+	var belowMinScore int32
 	for _, hit := range hits {
-		if req.GetMinScore() > 0 && hit.Score < req.GetMinScore() {
+		score := similarityScore(hit.Score)
+		if req.GetMinScore() > 0 && score < req.GetMinScore() {
+			belowMinScore++
 			continue
 		}
 		results = append(results, &memoryv1.SearchResult{
 			ChunkId:    hit.ID,
 			DocumentId: hit.Payload["document_id"],
 			Content:    hit.Payload["content"],
-			Score:      hit.Score,
+			Score:      score,
 			Metadata:   hit.Payload,
 		})
 	}
 
-	return &memoryv1.SearchResponse{Results: results}, nil
+	resp := truncateResults(results, topK)
+	resp.BelowMinScore = belowMinScore
+	return resp, nil
+}
+
+// similarityScore maps a cosine similarity in [-1, 1] onto the [0, 1] score
+// range that every search mode reports, so that min_score means the same in
+// each: 1 is the same direction as the query, 0.5 orthogonal and 0 opposite.
+// A linear map, unlike clipping negatives to 0, keeps the ranking of
+// dissimilar chunks.
+func similarityScore(cosine float32) float32 {
+	return float32(clampScore((float64(cosine) + 1) / 2))
+}
+
+// clampScore bounds a score to [0, 1], absorbing float rounding and
+// rerankers that stray outside the range.
+func clampScore(score float64) float64 {
+	return min(max(score, 0), 1)
+}
+
+// truncateResults cuts ranked results to topK, recording how many matched.
+func truncateResults(results []*memoryv1.SearchResult, topK int) *memoryv1.SearchResponse {
+	resp := &memoryv1.SearchResponse{TotalMatches: int32(len(results))}
+	if len(results) > topK {
+		results = results[:topK]
+		resp.Truncated = true
+	}
+	resp.Results = results
+	return resp
 }
 
 // AddGraphTriple adds a triple to the knowledge graph.
@@ -248,6 +563,21 @@ func (s *HippocampusServer) AddGraphTriple(ctx context.Context, req *memoryv1.Gr
 		Metadata:  meta,
 	})
 
+	// Re-adding a triple leaves the count unchanged.
+	collection := s.collection(req.GetCollection())
+	s.mu.Lock()
+	ids := s.triples[collection]
+	if ids == nil {
+		ids = make(map[string]struct{})
+		s.triples[collection] = ids
+	}
+	_, exists := ids[tripleID]
+	ids[tripleID] = struct{}{}
+	s.mu.Unlock()
+	if !exists {
+		s.statsChanged()
+	}
+
 	return &memoryv1.GraphTripleResponse{
 		Success:  true,
 		TripleId: tripleID,
@@ -292,31 +622,339 @@ func (s *HippocampusServer) QueryGraph(ctx context.Context, req *memoryv1.GraphQ
 	}, nil
 }
 
-// DeleteDocument removes a document from the vector store.
+// DeleteDocument removes a document from the vector store. A soft delete
+// instead tombstones the document, hiding it from search until it is
+// restored with UndeleteDocument or purged once older than the tombstone TTL.
 func (s *HippocampusServer) DeleteDocument(ctx context.Context, req *memoryv1.DeleteRequest) (*memoryv1.DeleteResponse, error) {
+	collection := s.collection(req.GetCollection())
+	deleteFn := s.deleteDocument
+	if req.GetSoft() {
+		deleteFn = s.softDeleteDocument
+	}
+
+	deleted, err := deleteFn(collection, req.GetDocumentId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "delete error: %v", err)
+	}
+	if deleted > 0 {
+		s.statsChanged()
+	}
+
+	return &memoryv1.DeleteResponse{
+		Success:       true,
+		ChunksDeleted: int32(deleted),
+	}, nil
+}
+
+// UndeleteDocument restores a soft-deleted document, making it searchable
+// again.
+func (s *HippocampusServer) UndeleteDocument(ctx context.Context, req *memoryv1.UndeleteRequest) (*memoryv1.UndeleteResponse, error) {
+	if req.GetDocumentId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "document_id is required")
+	}
+	collection := s.collection(req.GetCollection())
+	docID := req.GetDocumentId()
+
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
 	s.mu.Lock()
-	chunkIDs := s.docChunks[req.GetDocumentId()]
-	delete(s.docChunks, req.GetDocumentId())
+	if _, ok := s.tombstones[collection][docID]; !ok {
+		s.mu.Unlock()
+		return nil, status.Errorf(codes.NotFound, "no deleted document %q in collection %q", docID, collection)
+	}
+	delete(s.tombstones[collection], docID)
+	chunkIDs := s.docChunks[collection][docID]
+	s.mu.Unlock()
+
+	restored, err := s.store.SetDeleted(collection, chunkIDs, false)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "undelete error: %v", err)
+	}
+	s.textIdx.SetDeleted(collection, docID, false)
+	s.statsChanged()
+
+	return &memoryv1.UndeleteResponse{
+		Success:        true,
+		ChunksRestored: int32(restored),
+	}, nil
+}
+
+// PurgeTombstones permanently deletes documents that were soft-deleted more
+// than ttl ago, returning how many were purged.
+func (s *HippocampusServer) PurgeTombstones(ttl time.Duration) int {
+	cutoff := time.Now().Add(-ttl)
+
+	type tombstone struct{ collection, docID string }
+	var expired []tombstone
+	s.mu.RLock()
+	for collection, docs := range s.tombstones {
+		for docID, deletedAt := range docs {
+			if deletedAt.Before(cutoff) {
+				expired = append(expired, tombstone{collection, docID})
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	purged := 0
+	for _, t := range expired {
+		if _, err := s.deleteDocument(t.collection, t.docID); err != nil {
+			s.logger.Warn("failed to purge deleted document", "document_id", t.docID, "collection", t.collection, "error", err)
+			continue
+		}
+		purged++
+	}
+	if purged > 0 {
+		s.logger.Info("purged deleted documents", "documents", purged)
+		s.statsChanged()
+	}
+	return purged
+}
+
+// StartTombstonePurger launches a background goroutine that purges
+// soft-deleted documents older than the configured tombstone TTL every
+// purge interval. It does nothing when the TTL is 0. The returned function
+// stops the purger.
+func (s *HippocampusServer) StartTombstonePurger() (stop func()) {
+	ttl, interval := s.cfg.TombstoneTTL, s.cfg.TombstonePurgeInterval
+	if ttl <= 0 || interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.PurgeTombstones(ttl)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// DeleteByFilter deletes every document in a collection whose metadata
+// matches all of the request's filters and, when indexed_before is set, that
+// was last indexed before it. An empty filter without a cutoff matches every
+// document, so it is refused unless confirm_delete_all is set.
+func (s *HippocampusServer) DeleteByFilter(ctx context.Context, req *memoryv1.DeleteByFilterRequest) (*memoryv1.DeleteByFilterResponse, error) {
+	if len(req.GetFilters()) == 0 && req.GetIndexedBefore() == nil && !req.GetConfirmDeleteAll() {
+		return nil, status.Error(codes.InvalidArgument, "an empty filter deletes every document; set confirm_delete_all to proceed")
+	}
+	collection := s.collection(req.GetCollection())
+
+	s.mu.RLock()
+	ids := make([]string, 0, len(s.docChunks[collection]))
+	for id := range s.docChunks[collection] {
+		if req.GetIndexedBefore() != nil && !s.indexedAt[collection][id].Before(req.GetIndexedBefore().AsTime()) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	s.mu.RUnlock()
+
+	resp := &memoryv1.DeleteByFilterResponse{}
+	for _, id := range ids {
+		doc, ok := s.textIdx.Get(collection, id)
+		if !ok || !matchesMetadata(doc.Metadata, req.GetFilters()) {
+			continue
+		}
+		deleted, err := s.deleteDocument(collection, id)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "delete error: %v", err)
+		}
+		resp.DocumentsDeleted++
+		resp.ChunksDeleted += int32(deleted)
+	}
+	if resp.DocumentsDeleted > 0 {
+		s.statsChanged()
+	}
+
+	s.logger.Info("deleted documents by filter", "collection", collection, "filters", req.GetFilters(),
+		"documents", resp.DocumentsDeleted, "chunks", resp.ChunksDeleted)
+	return resp, nil
+}
+
+// softDeleteDocument tombstones a document's chunk vectors and full-text
+// entry, returning the number of chunks hidden.
+func (s *HippocampusServer) softDeleteDocument(collection, docID string) (int, error) {
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
+	s.mu.Lock()
+	chunkIDs, ok := s.docChunks[collection][docID]
+	if ok {
+		if s.tombstones[collection] == nil {
+			s.tombstones[collection] = make(map[string]time.Time)
+		}
+		s.tombstones[collection][docID] = time.Now()
+	}
+	s.mu.Unlock()
+	if !ok {
+		return 0, nil
+	}
+
+	hidden, err := s.store.SetDeleted(collection, chunkIDs, true)
+	if err != nil {
+		return 0, err
+	}
+	s.textIdx.SetDeleted(collection, docID, true)
+	return hidden, nil
+}
+
+// deleteDocument removes a document's chunk vectors and full-text entry,
+// returning the number of chunks deleted.
+func (s *HippocampusServer) deleteDocument(collection, docID string) (int, error) {
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
+	s.mu.Lock()
+	chunkIDs := s.docChunks[collection][docID]
+	delete(s.docChunks[collection], docID)
+	delete(s.chunking[collection], docID)
+	delete(s.tombstones[collection], docID)
+	delete(s.indexedAt[collection], docID)
 	s.mu.Unlock()
 
 	deleted := 0
 	if len(chunkIDs) > 0 {
-		n, err := s.store.Delete(s.cfg.CollectionName, chunkIDs)
+		n, err := s.store.Delete(collection, chunkIDs)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "delete error: %v", err)
+			return 0, err
 		}
 		deleted = n
 	}
 
 	// Also remove from text index
-	s.textIdx.Delete(s.cfg.CollectionName, req.GetDocumentId())
+	s.textIdx.Delete(collection, docID)
+	return deleted, nil
+}
 
-	return &memoryv1.DeleteResponse{
-		Success:       true,
-		ChunksDeleted: int32(deleted),
+// matchesMetadata reports whether metadata has every key/value in filters.
+func matchesMetadata(metadata, filters map[string]string) bool {
+	for k, v := range filters {
+		if metadata[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// GetDocument returns a stored document's full content and metadata.
+func (s *HippocampusServer) GetDocument(ctx context.Context, req *memoryv1.GetDocumentRequest) (*memoryv1.GetDocumentResponse, error) {
+	if req.GetDocumentId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "document_id is required")
+	}
+	collection := s.collection(req.GetCollection())
+
+	s.mu.RLock()
+	_, deleted := s.tombstones[collection][req.GetDocumentId()]
+	s.mu.RUnlock()
+
+	doc, ok := s.textIdx.Get(collection, req.GetDocumentId())
+	if !ok || deleted {
+		return nil, status.Errorf(codes.NotFound, "document %q not found in collection %q", req.GetDocumentId(), collection)
+	}
+
+	s.mu.RLock()
+	chunkCount := len(s.docChunks[collection][doc.ID])
+	s.mu.RUnlock()
+
+	return &memoryv1.GetDocumentResponse{
+		DocumentId: doc.ID,
+		Content:    doc.Content,
+		Metadata:   doc.Metadata,
+		ChunkCount: int32(chunkCount),
 	}, nil
 }
 
+const (
+	// defaultListPageSize is the ListByFilter page size when none is given.
+	defaultListPageSize = 20
+	// maxListPageSize caps the ListByFilter page size.
+	maxListPageSize = 100
+)
+
+// ListByFilter lists the documents whose metadata matches the request's
+// filters, most recently indexed first, one page at a time. Page tokens are
+// offsets into that ordering, so documents indexed between calls can shift
+// the pages.
+func (s *HippocampusServer) ListByFilter(ctx context.Context, req *memoryv1.ListByFilterRequest) (*memoryv1.ListByFilterResponse, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+	if pageSize > maxListPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be at most %d", maxListPageSize)
+	}
+	offset := 0
+	if token := req.GetPageToken(); token != "" {
+		n, err := strconv.Atoi(token)
+		if err != nil || n < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token %q", token)
+		}
+		offset = n
+	}
+	collection := s.collection(req.GetCollection())
+
+	type listed struct {
+		doc       textindex.Document
+		chunks    int
+		indexedAt time.Time
+	}
+	s.mu.RLock()
+	candidates := make(map[string]listed, len(s.docChunks[collection]))
+	for id, chunkIDs := range s.docChunks[collection] {
+		if _, deleted := s.tombstones[collection][id]; deleted {
+			continue
+		}
+		candidates[id] = listed{chunks: len(chunkIDs), indexedAt: s.indexedAt[collection][id]}
+	}
+	s.mu.RUnlock()
+
+	var matches []listed
+	for id, c := range candidates {
+		doc, ok := s.textIdx.Get(collection, id)
+		if !ok || !matchesMetadata(doc.Metadata, req.GetFilters()) {
+			continue
+		}
+		c.doc = doc
+		matches = append(matches, c)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].indexedAt.Equal(matches[j].indexedAt) {
+			return matches[i].indexedAt.After(matches[j].indexedAt)
+		}
+		return matches[i].doc.ID < matches[j].doc.ID
+	})
+
+	// Compare against the length before adding, so that a huge offset
+	// cannot overflow.
+	resp := &memoryv1.ListByFilterResponse{TotalMatches: int32(len(matches))}
+	offset = min(offset, len(matches))
+	end := offset + min(pageSize, len(matches)-offset)
+	for _, m := range matches[offset:end] {
+		resp.Documents = append(resp.Documents, &memoryv1.ListedDocument{
+			DocumentId: m.doc.ID,
+			Content:    m.doc.Content,
+			Metadata:   m.doc.Metadata,
+			ChunkCount: int32(m.chunks),
+			IndexedAt:  timestamppb.New(m.indexedAt),
+		})
+	}
+	if end < len(matches) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
 // FullTextSearch performs BM25-ranked full-text search.
 // Inspired by qmd's BM25 search via FTS5.
 func (s *HippocampusServer) FullTextSearch(ctx context.Context, req *memoryv1.SearchRequest) (*memoryv1.SearchResponse, error) {
@@ -337,11 +975,14 @@ func (s *HippocampusServer) FullTextSearch(ctx context.Context, req *memoryv1.Se
 		}
 	}
 
-	hits := s.textIdx.Search(s.cfg.CollectionName, req.GetQuery(), topK, filters)
+	collection := s.collection(req.GetCollection())
+	hits := s.textSearch(ctx, collection, req.GetQuery(), max(topK, s.textIdx.Count(collection)), filters)
 
 	var results []*memoryv1.SearchResult
+	var belowMinScore int32
 	for _, hit := range hits {
 		if req.GetMinScore() > 0 && float32(hit.Score) < req.GetMinScore() {
+			belowMinScore++
 			continue
 		}
 		results = append(results, &memoryv1.SearchResult{
@@ -352,7 +993,39 @@ func (s *HippocampusServer) FullTextSearch(ctx context.Context, req *memoryv1.Se
 		})
 	}
 
-	return &memoryv1.SearchResponse{Results: results}, nil
+	resp := truncateResults(results, topK)
+	resp.BelowMinScore = belowMinScore
+	return resp, nil
+}
+
+// vectorSearch runs a vector store search inside a trace span.
+func (s *HippocampusServer) vectorSearch(ctx context.Context, collection string, vector []float32, topK int, filters map[string]string) ([]vectorstore.SearchHit, error) {
+	_, span := tracer.Start(ctx, "hippocampus.vectorSearch", trace.WithAttributes(
+		attribute.String("collection", collection),
+		attribute.Int("top_k", topK),
+	))
+	defer span.End()
+
+	hits, err := s.store.Search(collection, vector, topK, filters)
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("hits", len(hits)))
+	return hits, nil
+}
+
+// textSearch runs a BM25 full-text search inside a trace span.
+func (s *HippocampusServer) textSearch(ctx context.Context, collection, query string, topK int, filters map[string]string) []textindex.SearchHit {
+	_, span := tracer.Start(ctx, "hippocampus.textSearch", trace.WithAttributes(
+		attribute.String("collection", collection),
+		attribute.Int("top_k", topK),
+	))
+	defer span.End()
+
+	hits := s.textIdx.Search(collection, query, topK, filters)
+	span.SetAttributes(attribute.Int("hits", len(hits)))
+	return hits
 }
 
 // HybridSearch combines BM25 full-text and vector semantic search
@@ -375,77 +1048,174 @@ func (s *HippocampusServer) HybridSearch(ctx context.Context, req *memoryv1.Sear
 		}
 	}
 
-	// BM25 full-text search
-	ftsHits := s.textIdx.Search(s.cfg.CollectionName, req.GetQuery(), topK*2, filters)
-	var ftsList []hybrid.RankedResult
-	for _, h := range ftsHits {
-		ftsList = append(ftsList, hybrid.RankedResult{
-			ID: h.ID, Score: h.Score, Content: h.Content, Metadata: h.Metadata,
-		})
-	}
+	collection := s.collection(req.GetCollection())
 
-	// Vector semantic search
-	embeddings, err := s.embedder.Embed([]string{req.GetQuery()})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "embedding error: %v", err)
+	// Search the query and, when requested, its expansions; the variants
+	// are embedded together in one embedder call.
+	queries := []string{req.GetQuery()}
+	if req.GetExpandQuery() {
+		queries = append(queries, s.expandQuery(req.GetQuery())...)
 	}
 
-	vecHits, err := s.store.Search(s.cfg.CollectionName, embeddings[0], topK*2, filters)
+	// Vector semantic search. If the queries cannot be embedded, e.g.
+	// because the embedding API is down, the BM25 results are fused alone.
+	embeddings, err := s.embedder.Embed(queries)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "vector search error: %v", err)
+		s.logger.Warn("embedding failed, hybrid search using full-text results only", "error", err)
+		embeddings = nil
 	}
 
-	var vecList []hybrid.RankedResult
-	for _, h := range vecHits {
-		vecList = append(vecList, hybrid.RankedResult{
-			ID:       h.Payload["document_id"],
-			Score:    float64(h.Score),
-			Content:  h.Payload["content"],
-			Metadata: h.Payload,
-		})
-	}
+	// Reciprocal Rank Fusion, by default with BM25 weighted 2x. Expanded
+	// queries get half the original query's weights so that the original
+	// wording dominates.
+	bm25Weight := override(req.GetBm25Weight(), s.cfg.HybridBM25Weight)
+	vectorWeight := override(req.GetVectorWeight(), s.cfg.HybridVectorWeight)
+	var rankedLists [][]hybrid.RankedResult
+	var weights []float64
+	similarity := make(map[string]float32) // document ID -> best chunk similarity to any query
+	for i, query := range queries {
+		scale := 1.0
+		if i > 0 {
+			scale = expandedQueryWeight
+		}
 
-	// Reciprocal Rank Fusion with BM25 weighted 2x (original query emphasis)
-	rankedLists := [][]hybrid.RankedResult{ftsList, vecList}
-	weights := []float64{2.0, 1.0}
-	fused := hybrid.ReciprocalRankFusion(rankedLists, weights, 60)
+		// BM25 full-text search over every document containing a query term,
+		// so that each of them counts toward total_matches
+		ftsHits := s.textSearch(ctx, collection, query, max(topK, s.textIdx.Count(collection)), filters)
+		var ftsList []hybrid.RankedResult
+		for _, h := range ftsHits {
+			ftsList = append(ftsList, hybrid.RankedResult{
+				ID: h.ID, Score: h.Score, Content: h.Content, Metadata: h.Metadata,
+			})
+		}
+
+		var vecList []hybrid.RankedResult
+		if embeddings != nil {
+			// Semantic search over the query's nearest chunks
+			vecHits, err := s.vectorSearch(ctx, collection, embeddings[i], topK*2, filters)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "vector search error: %v", err)
+			}
+			for _, h := range vecHits {
+				docID := h.Payload["document_id"]
+				similarity[docID] = max(similarity[docID], similarityScore(h.Score))
+				vecList = append(vecList, hybrid.RankedResult{
+					ID:       docID,
+					Score:    float64(h.Score),
+					Content:  h.Payload["content"],
+					Metadata: h.Payload,
+				})
+			}
+		}
+
+		rankedLists = append(rankedLists, ftsList, vecList)
+		weights = append(weights, scale*bm25Weight, scale*vectorWeight)
+	}
+	fused := hybrid.ReciprocalRankFusion(rankedLists, weights, override(req.GetRrfK(), s.cfg.RRFConstant))
+
+	// Fused scores are normalized against the top hit, which always scores
+	// 1, so min_score is applied to each document's vector similarity
+	// before fusion instead; a document outside the nearest chunks counts
+	// as below it. Without embeddings only the relative fused score is left
+	// to compare against.
+	var belowMinScore int32
+	if req.GetMinScore() > 0 && embeddings != nil {
+		kept := fused[:0]
+		for _, r := range fused {
+			if similarity[r.ID] < req.GetMinScore() {
+				belowMinScore++
+				continue
+			}
+			kept = append(kept, r)
+		}
+		fused = kept
+	}
 
-	// Normalize and truncate
 	fused = hybrid.NormalizeScores(fused)
-	if len(fused) > topK {
-		fused = fused[:topK]
+	if s.reranker != nil {
+		fused = s.rerank(req.GetQuery(), fused)
 	}
 
 	var results []*memoryv1.SearchResult
 	for _, r := range fused {
-		if req.GetMinScore() > 0 && float32(r.Score) < req.GetMinScore() {
+		score := float32(clampScore(r.Score))
+		if req.GetMinScore() > 0 && embeddings == nil && score < req.GetMinScore() {
+			belowMinScore++
 			continue
 		}
 		results = append(results, &memoryv1.SearchResult{
 			DocumentId: r.ID,
 			Content:    r.Content,
-			Score:      float32(r.Score),
+			Score:      score,
 			Metadata:   r.Metadata,
 		})
 	}
 
-	return &memoryv1.SearchResponse{Results: results}, nil
+	resp := truncateResults(results, topK)
+	resp.BelowMinScore = belowMinScore
+	return resp, nil
 }
 
-// GetStats returns indexing statistics.
-func (s *HippocampusServer) GetStats(ctx context.Context, req *memoryv1.StatsRequest) (*memoryv1.StatsResponse, error) {
-	s.mu.RLock()
-	docCount := len(s.docChunks)
-	lastIndexed := s.lastIndexed
-	s.mu.RUnlock()
+// maxRerankCandidates bounds how many fused results are passed to the
+// reranker, since rerankers such as cross-encoders are costly per result.
+const maxRerankCandidates = 50
 
-	chunkCount := s.store.Count(s.cfg.CollectionName)
-	tripleCount := s.kg.TriplesCount()
+// rerank reorders the top fused results with the configured reranker,
+// leaving any candidates beyond maxRerankCandidates after them.
+func (s *HippocampusServer) rerank(query string, fused []hybrid.RankedResult) []hybrid.RankedResult {
+	n := min(len(fused), maxRerankCandidates)
+	reranked := s.reranker.Rerank(query, fused[:n])
+	return append(reranked, fused[n:]...)
+}
+
+// expandedQueryWeight scales the fusion weights of expanded query variants
+// relative to the original query.
+const expandedQueryWeight = 0.5
+
+// expandQuery returns up to cfg.MaxQueryVariants variants of query from the
+// configured expander. Each variant costs another embedding and full-text
+// search, so none are returned without an expander.
+func (s *HippocampusServer) expandQuery(query string) []string {
+	if s.expander == nil || s.cfg.MaxQueryVariants <= 0 {
+		return nil
+	}
+	variants := s.expander.Expand(query)
+	return variants[:min(len(variants), s.cfg.MaxQueryVariants)]
+}
+
+// override returns the per-request value when set, else the configured one.
+func override(requested float32, configured float64) float64 {
+	if requested > 0 {
+		return float64(requested)
+	}
+	return configured
+}
+
+// GetStats returns indexing statistics. When the request names a collection
+// the totals are scoped to it; otherwise they cover every known collection.
+// The response always carries the per-collection breakdown.
+func (s *HippocampusServer) GetStats(ctx context.Context, req *memoryv1.StatsRequest) (*memoryv1.StatsResponse, error) {
+	var names []string
+	if req.GetCollection() != "" {
+		names = []string{req.GetCollection()}
+	} else {
+		names = s.collectionNames()
+	}
 
 	resp := &memoryv1.StatsResponse{
-		TotalDocuments:   int64(docCount),
-		TotalChunks:      int64(chunkCount),
-		TotalGraphTriples: int64(tripleCount),
+		EmbeddingDimension: int32(s.embedder.Dimension()),
+		EmbeddingModel:     s.embedder.Model(),
+	}
+	var lastIndexed time.Time
+	for _, name := range names {
+		cs := s.collectionStats(name)
+		resp.Collections = append(resp.Collections, cs)
+		resp.TotalDocuments += cs.Documents
+		resp.TotalChunks += cs.Chunks
+		resp.TotalGraphTriples += cs.GraphTriples
+		if t := cs.GetLastIndexedAt(); t != nil && t.AsTime().After(lastIndexed) {
+			lastIndexed = t.AsTime()
+		}
 	}
 
 	if !lastIndexed.IsZero() {
@@ -454,3 +1224,49 @@ func (s *HippocampusServer) GetStats(ctx context.Context, req *memoryv1.StatsReq
 
 	return resp, nil
 }
+
+// collectionStats gathers document, chunk, and triple counts for one collection.
+func (s *HippocampusServer) collectionStats(name string) *memoryv1.CollectionStats {
+	s.mu.RLock()
+	docCount := len(s.docChunks[name]) - len(s.tombstones[name])
+	tripleCount := len(s.triples[name])
+	lastIndexed := s.lastIndexed[name]
+	s.mu.RUnlock()
+
+	cs := &memoryv1.CollectionStats{
+		Name:         name,
+		Documents:    int64(docCount),
+		Chunks:       int64(s.store.Count(name)),
+		GraphTriples: int64(tripleCount),
+	}
+	if !lastIndexed.IsZero() {
+		cs.LastIndexedAt = timestamppb.New(lastIndexed)
+	}
+	return cs
+}
+
+// collectionNames returns the sorted names of every collection that holds
+// documents or triples. The default collection is always included.
+func (s *HippocampusServer) collectionNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := map[string]bool{s.cfg.CollectionName: true}
+	for name, docs := range s.docChunks {
+		if len(docs) > 0 {
+			seen[name] = true
+		}
+	}
+	for name, ids := range s.triples {
+		if len(ids) > 0 {
+			seen[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -2,12 +2,28 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/config"
 	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/embedder"
+	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/hybrid"
 	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/vectorstore"
 	commonv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/common/v1"
 	memoryv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/memory/v1"
@@ -20,6 +36,9 @@ func newTestServer() *HippocampusServer {
 		EmbeddingDimension: 32,
 		ChunkSize:          50,
 		ChunkOverlap:       5,
+		HybridBM25Weight:   2.0,
+		HybridVectorWeight: 1.0,
+		RRFConstant:        60,
 	}
 	store := vectorstore.NewInMemoryStore()
 	emb := embedder.NewMockEmbedder(32)
@@ -71,6 +90,45 @@ func TestIndexAndSearch(t *testing.T) {
 	}
 }
 
+func TestSearchResultsCarryChunkOffsets(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	content := "Seismic phase picking with deep learning. Transfer learning adapts the model to new regions."
+	if resp, _ := s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "doc-offsets", Content: content}); !resp.GetSuccess() {
+		t.Fatalf("indexing failed: %s", resp.GetErrorMessage())
+	}
+
+	resp, err := s.SemanticSearch(ctx, &memoryv1.SearchRequest{Query: "seismic phase picking", TopK: 1})
+	if err != nil || len(resp.GetResults()) == 0 {
+		t.Fatalf("expected results, got %v (err %v)", resp, err)
+	}
+	meta := resp.GetResults()[0].GetMetadata()
+	if meta["start_offset"] != "0" || meta["end_offset"] != strconv.Itoa(len(content)) {
+		t.Errorf("expected the chunk to span the whole document, got offsets %q-%q", meta["start_offset"], meta["end_offset"])
+	}
+}
+
+func TestIndexTokenChunking(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	// 40 words of 16 characters each estimate to 4 tokens apiece, so a
+	// 50-token budget needs several chunks where word chunking needs one.
+	content := strings.TrimSpace(strings.Repeat("characterization ", 40))
+	resp, err := s.IndexDocument(ctx, &memoryv1.IndexRequest{
+		DocumentId:       "doc-tokens",
+		Content:          content,
+		ChunkingStrategy: memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_TOKEN,
+	})
+	if err != nil {
+		t.Fatalf("index error: %v", err)
+	}
+	if resp.ChunksCreated < 4 {
+		t.Errorf("expected token budget to force at least 4 chunks, got %d", resp.ChunksCreated)
+	}
+}
+
 func TestIndexEmptyContent(t *testing.T) {
 	s := newTestServer()
 	resp, err := s.IndexDocument(context.Background(), &memoryv1.IndexRequest{
@@ -85,6 +143,40 @@ func TestIndexEmptyContent(t *testing.T) {
 	}
 }
 
+func TestIndexRejectsEmbeddingDimensionChange(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	cfg := &config.Config{CollectionName: "test", ChunkSize: 50, ChunkOverlap: 5}
+	store := vectorstore.NewInMemoryStore()
+
+	before := NewHippocampusServer(logger, cfg, store, embedder.NewMockEmbedder(32))
+	if resp, _ := before.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "old", Content: "Indexed with the old model"}); !resp.GetSuccess() {
+		t.Fatalf("initial index failed: %s", resp.GetErrorMessage())
+	}
+
+	// The operator switches to a model with a different dimension.
+	after := NewHippocampusServer(logger, cfg, store, embedder.NewMockEmbedder(16))
+	resp, err := after.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "new", Content: "Indexed with the new model"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetSuccess() {
+		t.Fatal("expected indexing with a different dimension to fail")
+	}
+	if !strings.Contains(resp.GetErrorMessage(), "dimension mismatch") {
+		t.Errorf("expected a dimension mismatch error, got %q", resp.GetErrorMessage())
+	}
+	if store.Count("test") != 1 {
+		t.Errorf("expected the collection to be left untouched, got %d chunks", store.Count("test"))
+	}
+
+	// A fresh collection takes the new dimension.
+	resp, _ = after.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "new", Content: "Indexed with the new model", Collection: "v2"})
+	if !resp.GetSuccess() {
+		t.Errorf("expected indexing into a new collection to succeed, got %q", resp.GetErrorMessage())
+	}
+}
+
 func TestSearchEmptyQuery(t *testing.T) {
 	s := newTestServer()
 	_, err := s.SemanticSearch(context.Background(), &memoryv1.SearchRequest{
@@ -139,6 +231,260 @@ func TestAddGraphTripleMissingFields(t *testing.T) {
 	}
 }
 
+// indexProgressStream collects the messages sent by StreamIndexDocument.
+type indexProgressStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*memoryv1.IndexProgress
+}
+
+func (f *indexProgressStream) Context() context.Context { return f.ctx }
+
+func (f *indexProgressStream) Send(msg *memoryv1.IndexProgress) error {
+	f.messages = append(f.messages, msg)
+	return nil
+}
+
+func TestStreamIndexDocument(t *testing.T) {
+	s := newTestServer()
+	var words []string
+	for i := 0; i < 1000; i++ {
+		words = append(words, fmt.Sprintf("word%d", i))
+	}
+
+	stream := &indexProgressStream{ctx: context.Background()}
+	err := s.StreamIndexDocument(&memoryv1.IndexRequest{
+		DocumentId: "large-doc",
+		Content:    strings.Join(words, " "),
+	}, stream)
+	if err != nil {
+		t.Fatalf("stream index error: %v", err)
+	}
+
+	if len(stream.messages) < 3 {
+		t.Fatalf("expected several progress messages and a result, got %d messages", len(stream.messages))
+	}
+	final := stream.messages[len(stream.messages)-1]
+	result := final.GetResult()
+	if !result.GetSuccess() || result.GetDocumentId() != "large-doc" {
+		t.Fatalf("expected a successful final result, got %v", result)
+	}
+
+	progress := stream.messages[:len(stream.messages)-1]
+	prev := int32(0)
+	for _, msg := range progress {
+		if msg.GetResult() != nil {
+			t.Fatalf("expected only the final message to carry a result, got %v", msg)
+		}
+		if msg.GetTotalChunks() != result.GetChunksCreated() || msg.GetChunksEmbedded() <= prev {
+			t.Errorf("expected increasing progress out of %d chunks, got %d/%d",
+				result.GetChunksCreated(), msg.GetChunksEmbedded(), msg.GetTotalChunks())
+		}
+		prev = msg.GetChunksEmbedded()
+	}
+	if prev != result.GetChunksCreated() {
+		t.Errorf("expected progress to reach %d chunks, got %d", result.GetChunksCreated(), prev)
+	}
+
+	resp, err := s.GetDocument(context.Background(), &memoryv1.GetDocumentRequest{DocumentId: "large-doc"})
+	if err != nil || resp.GetChunkCount() != result.GetChunksCreated() {
+		t.Errorf("expected the streamed document to be stored, got %v (err %v)", resp, err)
+	}
+}
+
+func TestStreamIndexDocumentEmptyContent(t *testing.T) {
+	s := newTestServer()
+	stream := &indexProgressStream{ctx: context.Background()}
+	if err := s.StreamIndexDocument(&memoryv1.IndexRequest{DocumentId: "empty"}, stream); err != nil {
+		t.Fatalf("stream index error: %v", err)
+	}
+	if len(stream.messages) != 1 || stream.messages[0].GetResult().GetSuccess() {
+		t.Errorf("expected a single failed result, got %v", stream.messages)
+	}
+}
+
+// slowEmbedder sleeps on every Embed call, like a remote embedding service.
+type slowEmbedder struct {
+	embedder.Embedder
+	delay time.Duration
+}
+
+func (e slowEmbedder) Embed(texts []string) ([][]float32, error) {
+	time.Sleep(e.delay)
+	return e.Embedder.Embed(texts)
+}
+
+// largeDocument returns content spanning several embedding batches.
+func largeDocument(id string) string {
+	var words []string
+	for i := 0; i < 1500; i++ {
+		words = append(words, fmt.Sprintf("%s-word%d", id, i))
+	}
+	return strings.Join(words, " ")
+}
+
+func TestConcurrentIndexDocumentFasterThanSerial(t *testing.T) {
+	const delay = 30 * time.Millisecond
+	ids := []string{"doc-a", "doc-b", "doc-c", "doc-d"}
+
+	newServer := func() *HippocampusServer {
+		s := newTestServer()
+		s.cfg.EmbedConcurrency = 4
+		s.embedder = slowEmbedder{Embedder: s.embedder, delay: delay}
+		return s
+	}
+
+	serial := newServer()
+	began := time.Now()
+	for _, id := range ids {
+		resp, _ := serial.IndexDocument(context.Background(), &memoryv1.IndexRequest{DocumentId: id, Content: largeDocument(id)})
+		if !resp.Success {
+			t.Fatalf("%s: serial index failed: %s", id, resp.ErrorMessage)
+		}
+		if resp.ChunksCreated <= embedBatchSize {
+			t.Fatalf("%s: expected several embedding batches, got %d chunks", id, resp.ChunksCreated)
+		}
+	}
+	serialTime := time.Since(began)
+
+	s := newServer()
+	results := make(chan *memoryv1.IndexResponse, len(ids))
+	began = time.Now()
+	for _, id := range ids {
+		go func() {
+			resp, _ := s.IndexDocument(context.Background(), &memoryv1.IndexRequest{DocumentId: id, Content: largeDocument(id)})
+			results <- resp
+		}()
+	}
+	for range ids {
+		select {
+		case resp := <-results:
+			if !resp.Success {
+				t.Fatalf("%s: concurrent index failed: %s", resp.DocumentId, resp.ErrorMessage)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("concurrent IndexDocument calls did not complete")
+		}
+	}
+	concurrentTime := time.Since(began)
+
+	if concurrentTime >= serialTime/2 {
+		t.Errorf("expected concurrent indexing to beat serial, took %v vs %v", concurrentTime, serialTime)
+	}
+	stats, _ := s.GetStats(context.Background(), &memoryv1.StatsRequest{})
+	if stats.TotalDocuments != int64(len(ids)) {
+		t.Errorf("expected %d documents, got %d", len(ids), stats.TotalDocuments)
+	}
+
+	// Each chunk's stored vector must be its own embedding, not a
+	// neighbour's from another batch.
+	for _, id := range ids {
+		chunks := s.chunkDocument(id, largeDocument(id), memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_UNSPECIFIED, nil)
+		for _, c := range chunks {
+			vecs, _ := s.embedder.Embed([]string{c.Content})
+			hits, err := s.store.Search("test", vecs[0], 1, nil)
+			if err != nil || len(hits) != 1 || hits[0].Payload["content"] != c.Content {
+				t.Fatalf("%s: expected chunk %q to hold its own embedding, got %v (err %v)", id, c.Content, hits, err)
+			}
+		}
+	}
+}
+
+// countingEmbedder counts Embed calls and fails any call whose texts
+// contain failOn.
+type countingEmbedder struct {
+	embedder.Embedder
+	failOn string
+	calls  int
+}
+
+func (e *countingEmbedder) Embed(texts []string) ([][]float32, error) {
+	e.calls++
+	for _, text := range texts {
+		if e.failOn != "" && strings.Contains(text, e.failOn) {
+			return nil, errors.New("embedding rejected")
+		}
+	}
+	return e.Embedder.Embed(texts)
+}
+
+func TestBatchIndexDocuments(t *testing.T) {
+	docs := []*memoryv1.IndexRequest{
+		{DocumentId: "short", Content: "A short note about seismic detection."},
+		{DocumentId: "long", Content: strings.Repeat("Earthquake catalogs list events by magnitude. ", 40)},
+		{DocumentId: "notes", Content: "Meeting notes.", Collection: "notes"},
+	}
+
+	// Chunk counts from indexing each document on its own.
+	single := newTestServer()
+	want := make(map[string]int32)
+	for _, doc := range docs {
+		resp, _ := single.IndexDocument(context.Background(), doc)
+		want[doc.DocumentId] = resp.ChunksCreated
+	}
+
+	s := newTestServer()
+	emb := &countingEmbedder{Embedder: s.embedder}
+	s.embedder = emb
+	resp, err := s.BatchIndexDocuments(context.Background(), &memoryv1.BatchIndexRequest{Documents: docs})
+	if err != nil {
+		t.Fatalf("batch index error: %v", err)
+	}
+
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+	for i, r := range resp.Results {
+		if !r.Success || r.DocumentId != docs[i].DocumentId {
+			t.Errorf("result %d: expected success for %s, got %v", i, docs[i].DocumentId, r)
+		}
+		if r.ChunksCreated != want[r.DocumentId] {
+			t.Errorf("%s: expected %d chunks, got %d", r.DocumentId, want[r.DocumentId], r.ChunksCreated)
+		}
+	}
+	if want["long"] < 2 {
+		t.Errorf("expected the long document to span several chunks, got %d", want["long"])
+	}
+	if emb.calls != 1 {
+		t.Errorf("expected a single embedder call, got %d", emb.calls)
+	}
+
+	got, err := s.GetDocument(context.Background(), &memoryv1.GetDocumentRequest{DocumentId: "notes", Collection: "notes"})
+	if err != nil || got.ChunkCount != want["notes"] {
+		t.Errorf("expected notes document in its collection, got %v (err %v)", got, err)
+	}
+}
+
+func TestBatchIndexDocumentsPartialFailure(t *testing.T) {
+	s := newTestServer()
+	s.embedder = &countingEmbedder{Embedder: s.embedder, failOn: "poison"}
+
+	resp, err := s.BatchIndexDocuments(context.Background(), &memoryv1.BatchIndexRequest{Documents: []*memoryv1.IndexRequest{
+		{DocumentId: "good", Content: "Seismic detection research."},
+		{DocumentId: "empty"},
+		{DocumentId: "bad", Content: "A poison chunk the embedder rejects."},
+		{DocumentId: "also-good", Content: "Earthquake catalogs."},
+	}})
+	if err != nil {
+		t.Fatalf("batch index error: %v", err)
+	}
+
+	wantSuccess := map[string]bool{"good": true, "empty": false, "bad": false, "also-good": true}
+	if len(resp.Results) != len(wantSuccess) {
+		t.Fatalf("expected %d results, got %d", len(wantSuccess), len(resp.Results))
+	}
+	for _, r := range resp.Results {
+		if r.Success != wantSuccess[r.DocumentId] {
+			t.Errorf("%s: expected success=%v, got %v (%s)", r.DocumentId, wantSuccess[r.DocumentId], r.Success, r.ErrorMessage)
+		}
+	}
+
+	stats, _ := s.GetStats(context.Background(), &memoryv1.StatsRequest{})
+	if stats.TotalDocuments != 2 {
+		t.Errorf("expected 2 indexed documents, got %d", stats.TotalDocuments)
+	}
+}
+
 func TestDeleteDocument(t *testing.T) {
 	s := newTestServer()
 	ctx := context.Background()
@@ -164,6 +510,230 @@ func TestDeleteDocument(t *testing.T) {
 	}
 }
 
+// searchHitIDs returns the document IDs found by semantic, full-text, and
+// hybrid search for query.
+func searchHitIDs(t *testing.T, s *HippocampusServer, query string) map[string][]string {
+	t.Helper()
+	ctx := context.Background()
+	hits := make(map[string][]string)
+	for name, search := range map[string]func(context.Context, *memoryv1.SearchRequest) (*memoryv1.SearchResponse, error){
+		"semantic":  s.SemanticSearch,
+		"full-text": s.FullTextSearch,
+		"hybrid":    s.HybridSearch,
+	} {
+		resp, err := search(ctx, &memoryv1.SearchRequest{Query: query, TopK: 10})
+		if err != nil {
+			t.Fatalf("%s search error: %v", name, err)
+		}
+		for _, r := range resp.GetResults() {
+			hits[name] = append(hits[name], r.GetDocumentId())
+		}
+	}
+	return hits
+}
+
+func TestSoftDeleteAndUndelete(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "doc-soft", Content: "Tombstone candidates for the archive review"})
+
+	resp, err := s.DeleteDocument(ctx, &memoryv1.DeleteRequest{DocumentId: "doc-soft", Soft: true})
+	if err != nil || !resp.GetSuccess() || resp.GetChunksDeleted() == 0 {
+		t.Fatalf("soft delete failed: %v (err %v)", resp, err)
+	}
+	for name, ids := range searchHitIDs(t, s, "tombstone archive review") {
+		if len(ids) > 0 {
+			t.Errorf("%s search: expected the deleted document to be hidden, got %v", name, ids)
+		}
+	}
+	if _, err := s.GetDocument(ctx, &memoryv1.GetDocumentRequest{DocumentId: "doc-soft"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected GetDocument to hide the deleted document, got %v", err)
+	}
+
+	undo, err := s.UndeleteDocument(ctx, &memoryv1.UndeleteRequest{DocumentId: "doc-soft"})
+	if err != nil || undo.GetChunksRestored() != resp.GetChunksDeleted() {
+		t.Fatalf("expected %d chunks restored, got %v (err %v)", resp.GetChunksDeleted(), undo, err)
+	}
+	hits := searchHitIDs(t, s, "tombstone archive review")
+	for _, name := range []string{"semantic", "full-text", "hybrid"} {
+		if len(hits[name]) == 0 || hits[name][0] != "doc-soft" {
+			t.Errorf("%s search: expected the restored document, got %v", name, hits[name])
+		}
+	}
+
+	if _, err := s.UndeleteDocument(ctx, &memoryv1.UndeleteRequest{DocumentId: "doc-soft"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound undeleting a live document, got %v", err)
+	}
+}
+
+func TestPurgeTombstones(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	for _, id := range []string{"doc-old", "doc-recent"} {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: id, Content: "Deleted note " + id})
+		s.DeleteDocument(ctx, &memoryv1.DeleteRequest{DocumentId: id, Soft: true})
+	}
+	s.tombstones["test"]["doc-old"] = time.Now().Add(-2 * time.Hour)
+
+	if purged := s.PurgeTombstones(time.Hour); purged != 1 {
+		t.Fatalf("expected 1 document purged, got %d", purged)
+	}
+	if _, err := s.UndeleteDocument(ctx, &memoryv1.UndeleteRequest{DocumentId: "doc-old"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected the purged document to be gone for good, got %v", err)
+	}
+	if _, ok := s.textIdx.Get("test", "doc-old"); ok {
+		t.Error("expected the purged document to be removed from the text index")
+	}
+	if _, err := s.UndeleteDocument(ctx, &memoryv1.UndeleteRequest{DocumentId: "doc-recent"}); err != nil {
+		t.Errorf("expected the recent tombstone to survive the purge, got %v", err)
+	}
+}
+
+func TestDeleteByFilter(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	for id, source := range map[string]string{"slack-1": "slack", "slack-2": "slack", "notion-1": "notion"} {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{
+			DocumentId: id,
+			Content:    "A message about the quarterly planning meeting from " + source,
+			Metadata:   map[string]string{"source": source},
+		})
+	}
+	before := s.store.Count("test")
+
+	resp, err := s.DeleteByFilter(ctx, &memoryv1.DeleteByFilterRequest{Filters: map[string]string{"source": "slack"}})
+	if err != nil {
+		t.Fatalf("delete error: %v", err)
+	}
+	if resp.DocumentsDeleted != 2 {
+		t.Errorf("expected 2 documents deleted, got %d", resp.DocumentsDeleted)
+	}
+	if int(resp.ChunksDeleted) != before-s.store.Count("test") || resp.ChunksDeleted == 0 {
+		t.Errorf("chunks deleted %d does not match the store shrinking from %d to %d", resp.ChunksDeleted, before, s.store.Count("test"))
+	}
+
+	for _, id := range []string{"slack-1", "slack-2"} {
+		if _, err := s.GetDocument(ctx, &memoryv1.GetDocumentRequest{DocumentId: id}); status.Code(err) != codes.NotFound {
+			t.Errorf("expected %s to be deleted, got %v", id, err)
+		}
+	}
+	doc, err := s.GetDocument(ctx, &memoryv1.GetDocumentRequest{DocumentId: "notion-1"})
+	if err != nil || doc.GetChunkCount() == 0 {
+		t.Errorf("expected notion-1 to survive with its chunks, got %v (err %v)", doc, err)
+	}
+	if stats, _ := s.GetStats(ctx, &memoryv1.StatsRequest{}); stats.TotalDocuments != 1 {
+		t.Errorf("expected 1 document left, got %d", stats.TotalDocuments)
+	}
+}
+
+func TestDeleteByFilterRequiresConfirmForEmptyFilter(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "doc-1", Content: "Something worth keeping"})
+
+	if _, err := s.DeleteByFilter(ctx, &memoryv1.DeleteByFilterRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without confirmation, got %v", err)
+	}
+	if s.store.Count("test") == 0 {
+		t.Fatal("expected nothing to be deleted without confirmation")
+	}
+
+	resp, err := s.DeleteByFilter(ctx, &memoryv1.DeleteByFilterRequest{ConfirmDeleteAll: true})
+	if err != nil {
+		t.Fatalf("delete error: %v", err)
+	}
+	if resp.DocumentsDeleted != 1 || s.store.Count("test") != 0 {
+		t.Errorf("expected everything deleted with confirmation, got %v and %d chunks left", resp, s.store.Count("test"))
+	}
+}
+
+func TestDeleteByFilterIndexedBefore(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	for _, id := range []string{"old-1", "old-2"} {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: id, Content: "An old note about planning", Metadata: map[string]string{"source": "slack"}})
+	}
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "old-notion", Content: "An old notion page", Metadata: map[string]string{"source": "notion"}})
+	// Backdate the first documents rather than sleeping across the cutoff.
+	cutoff := time.Now()
+	s.mu.Lock()
+	for _, id := range []string{"old-1", "old-2", "old-notion"} {
+		s.indexedAt["test"][id] = cutoff.Add(-time.Hour)
+	}
+	s.mu.Unlock()
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "new-1", Content: "A new note about planning", Metadata: map[string]string{"source": "slack"}})
+
+	// The cutoff narrows the metadata filter.
+	resp, err := s.DeleteByFilter(ctx, &memoryv1.DeleteByFilterRequest{
+		Filters:       map[string]string{"source": "slack"},
+		IndexedBefore: timestamppb.New(cutoff),
+	})
+	if err != nil {
+		t.Fatalf("delete error: %v", err)
+	}
+	if resp.DocumentsDeleted != 2 {
+		t.Errorf("expected the 2 old slack documents deleted, got %d", resp.DocumentsDeleted)
+	}
+	for id, kept := range map[string]bool{"old-1": false, "old-2": false, "old-notion": true, "new-1": true} {
+		_, err := s.GetDocument(ctx, &memoryv1.GetDocumentRequest{DocumentId: id})
+		if kept && err != nil {
+			t.Errorf("expected %s to be kept, got %v", id, err)
+		}
+		if !kept && status.Code(err) != codes.NotFound {
+			t.Errorf("expected %s to be deleted, got %v", id, err)
+		}
+	}
+
+	// A cutoff alone needs no confirmation.
+	resp, err = s.DeleteByFilter(ctx, &memoryv1.DeleteByFilterRequest{IndexedBefore: timestamppb.New(cutoff)})
+	if err != nil {
+		t.Fatalf("delete error: %v", err)
+	}
+	if resp.DocumentsDeleted != 1 {
+		t.Errorf("expected only old-notion deleted, got %d", resp.DocumentsDeleted)
+	}
+	if _, err := s.GetDocument(ctx, &memoryv1.GetDocumentRequest{DocumentId: "new-1"}); err != nil {
+		t.Errorf("expected new-1 to be kept, got %v", err)
+	}
+}
+
+func TestGetDocument(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	content := "Full original content of a note about retrieval. It spans more than one sentence."
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{
+		DocumentId: "doc-get",
+		Content:    content,
+		Metadata:   map[string]string{"source": "notion"},
+	})
+
+	resp, err := s.GetDocument(ctx, &memoryv1.GetDocumentRequest{DocumentId: "doc-get"})
+	if err != nil {
+		t.Fatalf("get error: %v", err)
+	}
+	if resp.Content != content {
+		t.Errorf("expected the full original content, got %q", resp.Content)
+	}
+	if resp.Metadata["source"] != "notion" {
+		t.Errorf("expected metadata to be returned, got %v", resp.Metadata)
+	}
+	if resp.ChunkCount == 0 {
+		t.Error("expected a chunk count")
+	}
+
+	// Missing and deleted documents are not found
+	if _, err := s.GetDocument(ctx, &memoryv1.GetDocumentRequest{DocumentId: "doc-missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for a missing document, got %v", err)
+	}
+	s.DeleteDocument(ctx, &memoryv1.DeleteRequest{DocumentId: "doc-get"})
+	if _, err := s.GetDocument(ctx, &memoryv1.GetDocumentRequest{DocumentId: "doc-get"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+}
+
 func TestGetStats(t *testing.T) {
 	s := newTestServer()
 	ctx := context.Background()
@@ -195,6 +765,135 @@ func TestGetStats(t *testing.T) {
 	if stats.TotalGraphTriples != 1 {
 		t.Errorf("expected 1 triple, got %d", stats.TotalGraphTriples)
 	}
+	if stats.EmbeddingDimension != 32 {
+		t.Errorf("expected embedding dimension 32, got %d", stats.EmbeddingDimension)
+	}
+	if stats.EmbeddingModel != "mock" {
+		t.Errorf("expected embedding model %q, got %q", "mock", stats.EmbeddingModel)
+	}
+}
+
+func TestGetStatsCountsDuplicateTripleOnce(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	for _, object := range []string{"B", "B", "C"} {
+		if _, err := s.AddGraphTriple(ctx, &memoryv1.GraphTripleRequest{
+			Subject:   "A",
+			Predicate: "links",
+			Object:    object,
+		}); err != nil {
+			t.Fatalf("add triple error: %v", err)
+		}
+	}
+
+	stats, err := s.GetStats(ctx, &memoryv1.StatsRequest{})
+	if err != nil {
+		t.Fatalf("stats error: %v", err)
+	}
+	if stats.TotalGraphTriples != 2 {
+		t.Errorf("expected the duplicate triple to be counted once (2 total), got %d", stats.TotalGraphTriples)
+	}
+}
+
+func TestGetStatsCollectionFilter(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{
+		DocumentId: "doc-notes",
+		Content:    "Meeting notes about the quarterly planning session",
+		Collection: "notes",
+	})
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{
+		DocumentId: "doc-default",
+		Content:    "A document that lives in the default collection",
+	})
+	s.AddGraphTriple(ctx, &memoryv1.GraphTripleRequest{
+		Subject:    "Planning",
+		Predicate:  "part_of",
+		Object:     "Q3",
+		Collection: "notes",
+	})
+
+	stats, err := s.GetStats(ctx, &memoryv1.StatsRequest{Collection: "notes"})
+	if err != nil {
+		t.Fatalf("stats error: %v", err)
+	}
+	if stats.TotalDocuments != 1 {
+		t.Errorf("expected 1 document in notes, got %d", stats.TotalDocuments)
+	}
+	if stats.TotalChunks == 0 {
+		t.Error("expected chunks > 0 in notes")
+	}
+	if stats.TotalGraphTriples != 1 {
+		t.Errorf("expected 1 triple in notes, got %d", stats.TotalGraphTriples)
+	}
+	if len(stats.Collections) != 1 || stats.Collections[0].Name != "notes" {
+		t.Errorf("expected only the notes collection, got %v", stats.Collections)
+	}
+
+	empty, err := s.GetStats(ctx, &memoryv1.StatsRequest{Collection: "missing"})
+	if err != nil {
+		t.Fatalf("stats error: %v", err)
+	}
+	if empty.TotalDocuments != 0 || empty.TotalChunks != 0 || empty.TotalGraphTriples != 0 {
+		t.Errorf("expected zero counts for unknown collection, got %+v", empty)
+	}
+}
+
+func TestGetStatsPerCollectionSummary(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{
+		DocumentId: "doc-a",
+		Content:    "First document in the research collection",
+		Collection: "research",
+	})
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{
+		DocumentId: "doc-b",
+		Content:    "Second document in the research collection",
+		Collection: "research",
+	})
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{
+		DocumentId: "doc-c",
+		Content:    "A document in the default collection",
+	})
+
+	stats, err := s.GetStats(ctx, &memoryv1.StatsRequest{})
+	if err != nil {
+		t.Fatalf("stats error: %v", err)
+	}
+	if stats.TotalDocuments != 3 {
+		t.Errorf("expected 3 documents overall, got %d", stats.TotalDocuments)
+	}
+
+	byName := make(map[string]*memoryv1.CollectionStats)
+	for _, cs := range stats.Collections {
+		byName[cs.Name] = cs
+	}
+	if len(byName) != 2 {
+		t.Fatalf("expected 2 collections, got %v", stats.Collections)
+	}
+	if byName["research"].GetDocuments() != 2 {
+		t.Errorf("expected 2 documents in research, got %d", byName["research"].GetDocuments())
+	}
+	if byName["test"].GetDocuments() != 1 {
+		t.Errorf("expected 1 document in default collection, got %d", byName["test"].GetDocuments())
+	}
+	if byName["research"].GetLastIndexedAt() == nil {
+		t.Error("expected last indexed time for research")
+	}
+
+	// Search is scoped to the requested collection.
+	resp, err := s.FullTextSearch(ctx, &memoryv1.SearchRequest{Query: "research collection", Collection: "research"})
+	if err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Errorf("expected 2 results from research collection, got %d", len(resp.Results))
+	}
 }
 
 func TestFullTextSearch(t *testing.T) {
@@ -266,6 +965,323 @@ func TestHybridSearch(t *testing.T) {
 	}
 }
 
+func TestSearchTotalMatches(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	for i := 0; i < 8; i++ {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{
+			DocumentId: fmt.Sprintf("quake-%d", i),
+			Content:    fmt.Sprintf("Seismic report %d on earthquake detection.", i),
+		})
+	}
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{
+		DocumentId: "recipe",
+		Content:    "A recipe for sourdough bread.",
+	})
+
+	tests := []struct {
+		name      string
+		search    func(context.Context, *memoryv1.SearchRequest) (*memoryv1.SearchResponse, error)
+		wantTotal int32
+	}{
+		// Every chunk is a vector candidate; BM25 only matches documents
+		// with a query term, and hybrid adds the nearest 2 × top_k chunks,
+		// which all belong to seismic reports here.
+		{"semantic", s.SemanticSearch, 9},
+		{"full text", s.FullTextSearch, 8},
+		{"hybrid", s.HybridSearch, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.search(ctx, &memoryv1.SearchRequest{Query: "seismic earthquake", TopK: 3})
+			if err != nil {
+				t.Fatalf("search error: %v", err)
+			}
+			if len(resp.Results) != 3 {
+				t.Errorf("expected 3 results, got %d", len(resp.Results))
+			}
+			if resp.TotalMatches != tt.wantTotal || !resp.Truncated {
+				t.Errorf("expected %d total matches and truncation, got %d (truncated=%v)",
+					tt.wantTotal, resp.TotalMatches, resp.Truncated)
+			}
+
+			resp, err = tt.search(ctx, &memoryv1.SearchRequest{Query: "seismic earthquake", TopK: 20})
+			if err != nil {
+				t.Fatalf("search error: %v", err)
+			}
+			if resp.TotalMatches != int32(len(resp.Results)) || resp.Truncated {
+				t.Errorf("expected all %d matches without truncation, got %d results (truncated=%v)",
+					resp.TotalMatches, len(resp.Results), resp.Truncated)
+			}
+		})
+	}
+}
+
+// fixedEmbedder returns preset vectors for known texts and a fallback
+// vector for everything else.
+type fixedEmbedder struct {
+	vectors  map[string][]float32
+	fallback []float32
+}
+
+func (e *fixedEmbedder) Embed(texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		if v, ok := e.vectors[text]; ok {
+			out[i] = v
+		} else {
+			out[i] = e.fallback
+		}
+	}
+	return out, nil
+}
+
+func (e *fixedEmbedder) Dimension() int { return 2 }
+
+func (e *fixedEmbedder) Model() string { return "fixed" }
+
+func TestHybridSearchFusionWeights(t *testing.T) {
+	// "keyword" shares the query's terms but not its meaning; "semantic" is
+	// the reverse. Fillers sit between them in vector similarity.
+	query := "rust borrow checker"
+	emb := &fixedEmbedder{
+		vectors: map[string][]float32{
+			query:                     {1, 0},
+			"memory safety proofs":    {1, 0},
+			"the rust borrow checker": {0, 1},
+		},
+		fallback: []float32{0.7071, 0.7071},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	cfg := &config.Config{
+		CollectionName:     "test",
+		ChunkSize:          50,
+		HybridBM25Weight:   2.0,
+		HybridVectorWeight: 1.0,
+		RRFConstant:        1,
+	}
+	s := NewHippocampusServer(logger, cfg, vectorstore.NewInMemoryStore(), emb)
+	ctx := context.Background()
+
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "keyword", Content: "the rust borrow checker"})
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "semantic", Content: "memory safety proofs"})
+	for i := 0; i < 5; i++ {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: fmt.Sprintf("filler-%d", i), Content: fmt.Sprintf("filler note %d", i)})
+	}
+
+	top := func(req *memoryv1.SearchRequest) string {
+		t.Helper()
+		resp, err := s.HybridSearch(ctx, req)
+		if err != nil {
+			t.Fatalf("hybrid search error: %v", err)
+		}
+		if len(resp.Results) == 0 {
+			t.Fatal("expected hybrid search results")
+		}
+		return resp.Results[0].DocumentId
+	}
+
+	if got := top(&memoryv1.SearchRequest{Query: query, TopK: 3}); got != "keyword" {
+		t.Errorf("expected BM25-weighted config to rank keyword first, got %s", got)
+	}
+	swapped := &memoryv1.SearchRequest{Query: query, TopK: 3, Bm25Weight: 1, VectorWeight: 2}
+	if got := top(swapped); got != "semantic" {
+		t.Errorf("expected swapped request weights to rank semantic first, got %s", got)
+	}
+
+	cfg.HybridBM25Weight, cfg.HybridVectorWeight = 1.0, 2.0
+	if got := top(&memoryv1.SearchRequest{Query: query, TopK: 3}); got != "semantic" {
+		t.Errorf("expected swapped config weights to rank semantic first, got %s", got)
+	}
+}
+
+// reverseReranker reverses the fused ranking and records the order it was
+// given.
+type reverseReranker struct{ received []string }
+
+func (r *reverseReranker) Rerank(_ string, results []hybrid.RankedResult) []hybrid.RankedResult {
+	r.received = nil
+	out := make([]hybrid.RankedResult, len(results))
+	for i, res := range results {
+		r.received = append(r.received, res.ID)
+		out[len(results)-1-i] = res
+	}
+	return out
+}
+
+func TestHybridSearchReranker(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{
+			DocumentId: fmt.Sprintf("doc-%d", i),
+			Content:    fmt.Sprintf("Seismic note %d on earthquake detection.", i),
+		})
+	}
+
+	rr := &reverseReranker{}
+	s.SetReranker(rr)
+	resp, err := s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: "seismic earthquake", TopK: 10})
+	if err != nil {
+		t.Fatalf("hybrid search error: %v", err)
+	}
+	if len(resp.Results) != 4 || len(rr.received) != 4 {
+		t.Fatalf("expected 4 results through the reranker, got %d (reranker saw %d)", len(resp.Results), len(rr.received))
+	}
+	for i, r := range resp.Results {
+		if want := rr.received[len(rr.received)-1-i]; r.DocumentId != want {
+			t.Fatalf("expected fused order %v reversed, got result %d = %s", rr.received, i, r.DocumentId)
+		}
+	}
+}
+
+func TestHybridSearchTraceSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(prev)
+
+	s := newTestServer()
+	s.IndexDocument(context.Background(), &memoryv1.IndexRequest{
+		DocumentId: "doc-1",
+		Content:    "Seismic wave detection with neural networks.",
+	})
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	if _, err := s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: "seismic detection", TopK: 3}); err != nil {
+		t.Fatalf("hybrid search error: %v", err)
+	}
+	parent.End()
+
+	children := make(map[string]bool)
+	for _, span := range exporter.GetSpans() {
+		if span.Parent.SpanID() == parent.SpanContext().SpanID() {
+			children[span.Name] = true
+		}
+	}
+	for _, name := range []string{"hippocampus.textSearch", "hippocampus.vectorSearch"} {
+		if !children[name] {
+			t.Errorf("expected child span %s, got %v", name, children)
+		}
+	}
+}
+
+// failingEmbedder simulates an unavailable embedding API.
+type failingEmbedder struct{}
+
+func (failingEmbedder) Embed([]string) ([][]float32, error) {
+	return nil, errors.New("embedding API unavailable")
+}
+
+func (failingEmbedder) Dimension() int { return 2 }
+
+func (failingEmbedder) Model() string { return "failing" }
+
+func TestHybridSearchWithoutEmbeddings(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "quake", Content: "Seismic signal detection with PhaseNet."})
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "bread", Content: "A recipe for sourdough bread."})
+	s.embedder = failingEmbedder{}
+
+	resp, err := s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: "seismic detection", TopK: 5})
+	if err != nil {
+		t.Fatalf("expected hybrid search to fall back to full-text results, got %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].DocumentId != "quake" {
+		t.Errorf("expected only the BM25 hit, got %v", resp.Results)
+	}
+}
+
+// fakeExpander expands every query into fixed variants and records the
+// queries it was asked to expand.
+type fakeExpander struct {
+	variants []string
+	queries  []string
+}
+
+func (e *fakeExpander) Expand(query string) []string {
+	e.queries = append(e.queries, query)
+	return e.variants
+}
+
+func TestHybridSearchQueryExpansion(t *testing.T) {
+	s := newTestServer()
+	s.cfg.MaxQueryVariants = 3
+	ctx := context.Background()
+
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "car", Content: "Car insurance renewal is due in March."})
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "automobile", Content: "Automobile repair shop on Main Street."})
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "vehicle", Content: "Vehicle registration paperwork."})
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "bread", Content: "A recipe for sourdough bread."})
+	// Without embeddings only full-text matches are fused, so a document
+	// can only be found through a query that shares its words.
+	s.embedder = failingEmbedder{}
+	expander := &fakeExpander{variants: []string{"automobile", "vehicle"}}
+	s.SetQueryExpander(expander)
+
+	ids := func(resp *memoryv1.SearchResponse) []string {
+		var out []string
+		for _, r := range resp.Results {
+			out = append(out, r.DocumentId)
+		}
+		return out
+	}
+
+	resp, err := s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: "car", TopK: 10})
+	if err != nil {
+		t.Fatalf("hybrid search error: %v", err)
+	}
+	if got := ids(resp); len(got) != 1 || got[0] != "car" {
+		t.Errorf("expected only the literal match without expand_query, got %v", got)
+	}
+	if len(expander.queries) != 0 {
+		t.Errorf("expected the expander not to be called without expand_query, got %v", expander.queries)
+	}
+
+	resp, err = s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: "car", TopK: 10, ExpandQuery: true})
+	if err != nil {
+		t.Fatalf("expanded hybrid search error: %v", err)
+	}
+	got := ids(resp)
+	if len(got) != 3 || got[0] != "car" {
+		t.Fatalf("expected the literal match first, then the expansion matches, got %v", got)
+	}
+	for _, id := range []string{"automobile", "vehicle"} {
+		if !slices.Contains(got, id) {
+			t.Errorf("expected %s, matched only by an expansion, in %v", id, got)
+		}
+	}
+	if slices.Contains(got, "bread") {
+		t.Errorf("expected unrelated documents to stay out, got %v", got)
+	}
+}
+
+func TestHybridSearchQueryExpansionCost(t *testing.T) {
+	s := newTestServer()
+	s.cfg.MaxQueryVariants = 1
+	ctx := context.Background()
+	s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "car", Content: "Car insurance renewal."})
+
+	emb := &countingEmbedder{Embedder: s.embedder}
+	s.embedder = emb
+	s.SetQueryExpander(&fakeExpander{variants: []string{"automobile", "vehicle"}})
+
+	if _, err := s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: "car", ExpandQuery: true}); err != nil {
+		t.Fatalf("hybrid search error: %v", err)
+	}
+	if emb.calls != 1 {
+		t.Errorf("expected the query and its variants embedded in one call, got %d calls", emb.calls)
+	}
+	if variants := s.expandQuery("car"); len(variants) != 1 {
+		t.Errorf("expected MaxQueryVariants to cap expansion at 1, got %v", variants)
+	}
+}
+
 func TestHybridSearchEmptyQuery(t *testing.T) {
 	s := newTestServer()
 	_, err := s.HybridSearch(context.Background(), &memoryv1.SearchRequest{Query: ""})
@@ -296,3 +1312,221 @@ func TestFullTextSearchWithMinScore(t *testing.T) {
 		t.Errorf("expected 1 result above threshold, got %d", len(resp.Results))
 	}
 }
+
+func TestSearchScoresInUnitRange(t *testing.T) {
+	// The "opposite" note points away from the query, a cosine of -1.
+	query := "seismic waves"
+	emb := &fixedEmbedder{
+		vectors: map[string][]float32{
+			query:                     {1, 0},
+			"seismic waves recorded":  {1, 0},
+			"orthogonal seismic note": {0, 1},
+			"opposite seismic note":   {-1, 0},
+		},
+		fallback: []float32{0.6, 0.8},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	cfg := &config.Config{
+		CollectionName:     "test",
+		ChunkSize:          50,
+		HybridBM25Weight:   2.0,
+		HybridVectorWeight: 1.0,
+		RRFConstant:        60,
+	}
+	s := NewHippocampusServer(logger, cfg, vectorstore.NewInMemoryStore(), emb)
+	ctx := context.Background()
+	for id, content := range map[string]string{
+		"same":       "seismic waves recorded",
+		"orthogonal": "orthogonal seismic note",
+		"opposite":   "opposite seismic note",
+	} {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: id, Content: content})
+	}
+
+	modes := map[string]func(context.Context, *memoryv1.SearchRequest) (*memoryv1.SearchResponse, error){
+		"semantic":  s.SemanticSearch,
+		"full-text": s.FullTextSearch,
+		"hybrid":    s.HybridSearch,
+	}
+	for name, search := range modes {
+		resp, err := search(ctx, &memoryv1.SearchRequest{Query: query, TopK: 10})
+		if err != nil {
+			t.Fatalf("%s search error: %v", name, err)
+		}
+		if len(resp.Results) == 0 {
+			t.Fatalf("%s search: expected results", name)
+		}
+		for _, r := range resp.Results {
+			if r.Score < 0 || r.Score > 1 {
+				t.Errorf("%s search: %s scored %v, outside [0, 1]", name, r.DocumentId, r.Score)
+			}
+		}
+	}
+
+	resp, _ := s.SemanticSearch(ctx, &memoryv1.SearchRequest{Query: query, TopK: 10})
+	want := map[string]float32{"same": 1, "orthogonal": 0.5, "opposite": 0}
+	for _, r := range resp.Results {
+		if diff := r.Score - want[r.DocumentId]; diff < -1e-6 || diff > 1e-6 {
+			t.Errorf("semantic search: expected %s to score %v, got %v", r.DocumentId, want[r.DocumentId], r.Score)
+		}
+	}
+
+	// min_score applies on the same scale: 0.4 keeps the orthogonal note
+	// but drops the opposite one.
+	resp, _ = s.SemanticSearch(ctx, &memoryv1.SearchRequest{Query: query, TopK: 10, MinScore: 0.4})
+	if len(resp.Results) != 2 {
+		t.Errorf("expected min_score 0.4 to keep 2 results, got %d", len(resp.Results))
+	}
+}
+
+func TestHybridSearchMinScoreUsesSemanticScore(t *testing.T) {
+	// Every note shares the query's words but points away from it, so
+	// fusion still ranks one of them first with a normalized score of 1.
+	query := "roadmap decision"
+	emb := &fixedEmbedder{
+		vectors:  map[string][]float32{query: {1, 0}},
+		fallback: []float32{-0.6, 0.8},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	cfg := &config.Config{CollectionName: "test", ChunkSize: 50, HybridBM25Weight: 2.0, HybridVectorWeight: 1.0, RRFConstant: 60}
+	s := NewHippocampusServer(logger, cfg, vectorstore.NewInMemoryStore(), emb)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: fmt.Sprintf("weak-%d", i), Content: fmt.Sprintf("roadmap decision draft %d", i)})
+	}
+
+	resp, err := s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: query, TopK: 5})
+	if err != nil {
+		t.Fatalf("hybrid search error: %v", err)
+	}
+	if len(resp.Results) == 0 || resp.Results[0].Score != 1 {
+		t.Fatalf("expected the top fused hit to score 1, got %v", resp.Results)
+	}
+
+	// Each note's semantic score is (-0.6 + 1) / 2 = 0.2, below the floor.
+	resp, err = s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: query, TopK: 5, MinScore: 0.5})
+	if err != nil {
+		t.Fatalf("hybrid search error: %v", err)
+	}
+	if len(resp.Results) != 0 {
+		t.Errorf("expected every weak hit to be dropped, got %v", resp.Results)
+	}
+	if resp.BelowMinScore != 3 {
+		t.Errorf("expected 3 results below min_score, got %d", resp.BelowMinScore)
+	}
+
+	resp, _ = s.HybridSearch(ctx, &memoryv1.SearchRequest{Query: query, TopK: 5, MinScore: 0.1})
+	if len(resp.Results) != 3 || resp.BelowMinScore != 0 {
+		t.Errorf("expected a lower floor to keep all 3 results, got %d (%d below)", len(resp.Results), resp.BelowMinScore)
+	}
+}
+
+func TestListByFilter(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	docs := []struct {
+		id       string
+		metadata map[string]string
+	}{
+		{"old-paper", map[string]string{"source": "email", "type": "research"}},
+		{"newsletter", map[string]string{"source": "email", "type": "news"}},
+		{"note", map[string]string{"source": "notion", "type": "research"}},
+		{"new-paper", map[string]string{"source": "email", "type": "research"}},
+		{"removed-paper", map[string]string{"source": "email", "type": "research"}},
+	}
+	for _, d := range docs {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: d.id, Content: "Content of " + d.id, Metadata: d.metadata})
+	}
+	s.DeleteDocument(ctx, &memoryv1.DeleteRequest{DocumentId: "removed-paper", Soft: true})
+
+	filters := map[string]string{"source": "email", "type": "research"}
+	resp, err := s.ListByFilter(ctx, &memoryv1.ListByFilterRequest{Filters: filters})
+	if err != nil {
+		t.Fatalf("ListByFilter: %v", err)
+	}
+	if resp.TotalMatches != 2 || len(resp.Documents) != 2 || resp.NextPageToken != "" {
+		t.Fatalf("expected 2 matching documents on one page, got %v", resp)
+	}
+	if resp.Documents[0].DocumentId != "new-paper" || resp.Documents[1].DocumentId != "old-paper" {
+		t.Errorf("expected the newest document first, got %s, %s", resp.Documents[0].DocumentId, resp.Documents[1].DocumentId)
+	}
+	if got := resp.Documents[0]; got.Content != "Content of new-paper" || got.ChunkCount == 0 || got.IndexedAt == nil {
+		t.Errorf("expected the document's content, chunk count and index time, got %v", got)
+	}
+
+	// Page through every document one at a time.
+	var listed []string
+	token := ""
+	for {
+		page, err := s.ListByFilter(ctx, &memoryv1.ListByFilterRequest{PageSize: 1, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListByFilter page: %v", err)
+		}
+		for _, d := range page.Documents {
+			listed = append(listed, d.DocumentId)
+		}
+		if token = page.NextPageToken; token == "" {
+			break
+		}
+	}
+	want := []string{"new-paper", "note", "newsletter", "old-paper"}
+	if !slices.Equal(listed, want) {
+		t.Errorf("expected pages %v, got %v", want, listed)
+	}
+
+	if _, err := s.ListByFilter(ctx, &memoryv1.ListByFilterRequest{PageToken: "bogus"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a bad page token, got %v", err)
+	}
+	if _, err := s.ListByFilter(ctx, &memoryv1.ListByFilterRequest{PageSize: 1000}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an oversized page, got %v", err)
+	}
+
+	// A token past the end, even one that would overflow offset+page_size,
+	// returns an empty last page.
+	for _, token := range []string{"4", "9", strconv.Itoa(math.MaxInt)} {
+		page, err := s.ListByFilter(ctx, &memoryv1.ListByFilterRequest{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("token %s: unexpected error: %v", token, err)
+		}
+		if len(page.Documents) != 0 || page.NextPageToken != "" || page.TotalMatches != 4 {
+			t.Errorf("token %s: expected an empty last page, got %v", token, page)
+		}
+	}
+}
+
+func TestChunkDocumentContentTypeProfiles(t *testing.T) {
+	s := newTestServer()
+	s.cfg.ChunkProfiles = map[string]config.ChunkingProfile{
+		"text/markdown": {Strategy: "markdown"},
+		"text/x-code":   {Strategy: "fixed", Size: 1000, Overlap: 10},
+	}
+	unspecified := memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_UNSPECIFIED
+	markdown := "# Alpha\nPhaseNet picks arrivals.\n\n# Beta\nGaMMA associates phases.\n\n# Gamma\nEQTransformer detects events."
+
+	if got := s.chunkDocument("doc", markdown, unspecified, nil); len(got) != 1 {
+		t.Fatalf("expected one fixed-size chunk by default, got %d", len(got))
+	}
+	got := s.chunkDocument("doc", markdown, unspecified, map[string]string{"content_type": "text/markdown; charset=utf-8"})
+	if len(got) != 3 {
+		t.Fatalf("expected one chunk per markdown section, got %d", len(got))
+	}
+	for i, c := range got {
+		if !strings.HasPrefix(c.Content, "# ") {
+			t.Errorf("chunk %d: expected it to start at a heading, got %q", i, c.Content)
+		}
+	}
+	// An explicitly requested strategy wins over the profile's.
+	if got := s.chunkDocument("doc", markdown, memoryv1.ChunkingStrategy_CHUNKING_STRATEGY_FIXED, map[string]string{"content_type": "text/markdown"}); len(got) != 1 {
+		t.Errorf("expected the requested fixed strategy, got %d chunks", len(got))
+	}
+
+	code := strings.Repeat("func main() { return } ", 40)
+	if got := s.chunkDocument("doc", code, unspecified, nil); len(got) < 3 {
+		t.Fatalf("expected several chunks at the global size, got %d", len(got))
+	}
+	if got := s.chunkDocument("doc", code, unspecified, map[string]string{"content_type": "text/x-code"}); len(got) != 1 {
+		t.Errorf("expected one chunk at the code profile's size, got %d", len(got))
+	}
+}
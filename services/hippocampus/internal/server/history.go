@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	memoryv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/memory/v1"
+)
+
+// defaultStatsChangeDelay is how long changes are coalesced before a change
+// snapshot is taken.
+const defaultStatsChangeDelay = time.Second
+
+// statsHistory is a bounded, in-memory time series of stats snapshots. Change
+// and interval snapshots are kept in separate rings so that a burst of
+// changes cannot evict the interval series.
+type statsHistory struct {
+	mu       sync.Mutex
+	changes  snapshotRing // taken after changes, at most one per change delay
+	interval snapshotRing // taken every cfg.StatsSnapshotInterval
+
+	changePending atomic.Bool // a change snapshot is scheduled
+}
+
+// snapshotRing holds the newest snapshots in a fixed-size ring buffer.
+type snapshotRing struct {
+	buf   []*memoryv1.StatsSnapshot
+	start int // index of the oldest snapshot
+	n     int
+}
+
+// add appends snap, overwriting the oldest snapshot once size are held. The
+// ring is resized, keeping the newest snapshots, if size has changed.
+func (r *snapshotRing) add(snap *memoryv1.StatsSnapshot, size int) {
+	if len(r.buf) != size {
+		kept := r.all()
+		if len(kept) > size {
+			kept = kept[len(kept)-size:]
+		}
+		r.buf = make([]*memoryv1.StatsSnapshot, size)
+		r.start, r.n = 0, copy(r.buf, kept)
+	}
+	if r.n < size {
+		r.buf[(r.start+r.n)%size] = snap
+		r.n++
+		return
+	}
+	r.buf[r.start] = snap
+	r.start = (r.start + 1) % size
+}
+
+// all returns the held snapshots, oldest first.
+func (r *snapshotRing) all() []*memoryv1.StatsSnapshot {
+	out := make([]*memoryv1.StatsSnapshot, 0, r.n)
+	for i := 0; i < r.n; i++ {
+		out = append(out, r.buf[(r.start+i)%len(r.buf)])
+	}
+	return out
+}
+
+// GetStatsHistory returns the recorded stats snapshots, oldest first,
+// optionally limited to those taken since the requested time.
+func (s *HippocampusServer) GetStatsHistory(ctx context.Context, req *memoryv1.StatsHistoryRequest) (*memoryv1.StatsHistoryResponse, error) {
+	s.history.mu.Lock()
+	changes, interval := s.history.changes.all(), s.history.interval.all()
+	s.history.mu.Unlock()
+
+	// Merge the two series, each already in time order.
+	resp := &memoryv1.StatsHistoryResponse{}
+	for len(changes) > 0 || len(interval) > 0 {
+		var snap *memoryv1.StatsSnapshot
+		if len(interval) == 0 || (len(changes) > 0 && changes[0].GetTakenAt().AsTime().Before(interval[0].GetTakenAt().AsTime())) {
+			snap, changes = changes[0], changes[1:]
+		} else {
+			snap, interval = interval[0], interval[1:]
+		}
+		if req.GetSince() != nil && snap.GetTakenAt().AsTime().Before(req.GetSince().AsTime()) {
+			continue
+		}
+		resp.Snapshots = append(resp.Snapshots, snap)
+	}
+	return resp, nil
+}
+
+// statsChanged schedules a change snapshot after an index, delete or triple.
+// Changes within statsChangeDelay of each other share one snapshot, so a bulk
+// import neither floods the history nor recounts every chunk per document.
+func (s *HippocampusServer) statsChanged() {
+	if s.cfg.StatsHistorySize <= 0 || !s.history.changePending.CompareAndSwap(false, true) {
+		return
+	}
+	time.AfterFunc(s.statsChangeDelay, func() {
+		// Cleared before the snapshot so a change made while it is taken
+		// schedules another.
+		s.history.changePending.Store(false)
+		s.recordStats(&s.history.changes)
+	})
+}
+
+// recordStats appends a snapshot of the totals across every collection to
+// series, dropping the series' oldest snapshot once cfg.StatsHistorySize is
+// reached. A size of 0 or less disables the history.
+func (s *HippocampusServer) recordStats(series *snapshotRing) {
+	size := s.cfg.StatsHistorySize
+	if size <= 0 {
+		return
+	}
+
+	snap := &memoryv1.StatsSnapshot{}
+	for _, name := range s.collectionNames() {
+		cs := s.collectionStats(name)
+		snap.TotalDocuments += cs.Documents
+		snap.TotalChunks += cs.Chunks
+		snap.TotalGraphTriples += cs.GraphTriples
+	}
+
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+	// Taken under the lock so that snapshots stay in time order.
+	snap.TakenAt = timestamppb.Now()
+	series.add(snap, size)
+}
+
+// StartStatsRecorder launches a background goroutine that records a stats
+// snapshot every configured interval, in addition to the snapshots taken
+// after changes. It does nothing when the interval or history size is 0. The
+// returned function stops the recorder.
+func (s *HippocampusServer) StartStatsRecorder() (stop func()) {
+	interval := s.cfg.StatsSnapshotInterval
+	if interval <= 0 || s.cfg.StatsHistorySize <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.recordStats(&s.history.interval)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
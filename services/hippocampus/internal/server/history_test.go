@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	memoryv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/memory/v1"
+)
+
+func TestStatsHistoryCoalescesChanges(t *testing.T) {
+	s := newTestServer()
+	s.cfg.StatsHistorySize = 100
+	s.statsChangeDelay = 20 * time.Millisecond
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		s.IndexDocument(ctx, &memoryv1.IndexRequest{
+			DocumentId: fmt.Sprintf("doc-%d", i),
+			Content:    fmt.Sprintf("Note %d about earthquake detection.", i),
+		})
+	}
+	s.AddGraphTriple(ctx, &memoryv1.GraphTripleRequest{Subject: "PhaseNet", Predicate: "detects", Object: "earthquakes"})
+
+	// The burst of changes shares one snapshot of the final totals.
+	snaps := waitForSnapshots(t, s, 1)
+	if snap := snaps[0]; snap.GetTotalDocuments() != 3 || snap.GetTotalGraphTriples() != 1 || snap.GetTotalChunks() < 3 {
+		t.Errorf("expected a snapshot of 3 documents and 1 triple, got %v", snap)
+	}
+	if snaps[0].GetTakenAt() == nil {
+		t.Fatal("expected a timestamp")
+	}
+
+	s.DeleteDocument(ctx, &memoryv1.DeleteRequest{DocumentId: "doc-0"})
+	snaps = waitForSnapshots(t, s, 2)
+	if snaps[1].GetTotalDocuments() != 2 {
+		t.Errorf("expected a snapshot after the delete with 2 documents, got %v", snaps[1])
+	}
+	if snaps[1].GetTakenAt().AsTime().Before(snaps[0].GetTakenAt().AsTime()) {
+		t.Error("expected timestamps in order")
+	}
+}
+
+func TestStatsHistoryChangesKeepIntervalSnapshots(t *testing.T) {
+	s := newTestServer()
+	s.cfg.StatsHistorySize = 2
+	ctx := context.Background()
+
+	s.recordStats(&s.history.interval)
+	for i := 0; i < 5; i++ {
+		s.recordStats(&s.history.changes)
+	}
+
+	resp, _ := s.GetStatsHistory(ctx, &memoryv1.StatsHistoryRequest{})
+	snaps := resp.GetSnapshots()
+	if len(snaps) != 3 {
+		t.Fatalf("expected 2 change snapshots and the interval snapshot, got %d", len(snaps))
+	}
+	for i := 1; i < len(snaps); i++ {
+		if snaps[i].GetTakenAt().AsTime().Before(snaps[i-1].GetTakenAt().AsTime()) {
+			t.Errorf("snapshot %d: expected timestamps in order", i)
+		}
+	}
+}
+
+// waitForSnapshots waits for the stats history to hold n snapshots and
+// returns them.
+func waitForSnapshots(t *testing.T, s *HippocampusServer, n int) []*memoryv1.StatsSnapshot {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := s.GetStatsHistory(context.Background(), &memoryv1.StatsHistoryRequest{})
+		if err != nil {
+			t.Fatalf("GetStatsHistory: %v", err)
+		}
+		if len(resp.GetSnapshots()) >= n || time.Now().After(deadline) {
+			if len(resp.GetSnapshots()) != n {
+				t.Fatalf("expected %d snapshots, got %d", n, len(resp.GetSnapshots()))
+			}
+			return resp.GetSnapshots()
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStatsHistorySinceAndLimit(t *testing.T) {
+	s := newTestServer()
+	s.cfg.StatsHistorySize = 2
+	ctx := context.Background()
+
+	s.recordStats(&s.history.interval)
+	cutoff := time.Now()
+	s.recordStats(&s.history.interval)
+	s.recordStats(&s.history.interval)
+
+	resp, _ := s.GetStatsHistory(ctx, &memoryv1.StatsHistoryRequest{})
+	if len(resp.GetSnapshots()) != 2 {
+		t.Errorf("expected the history capped at 2 snapshots, got %d", len(resp.GetSnapshots()))
+	}
+
+	s.cfg.StatsHistorySize = 10
+	s.recordStats(&s.history.interval)
+	resp, _ = s.GetStatsHistory(ctx, &memoryv1.StatsHistoryRequest{Since: timestamppb.New(cutoff)})
+	for _, snap := range resp.GetSnapshots() {
+		if snap.GetTakenAt().AsTime().Before(cutoff) {
+			t.Errorf("expected only snapshots since %v, got one from %v", cutoff, snap.GetTakenAt().AsTime())
+		}
+	}
+	if len(resp.GetSnapshots()) != 3 {
+		t.Errorf("expected 3 snapshots since the cutoff, got %d", len(resp.GetSnapshots()))
+	}
+}
+
+func TestStatsRecorderSnapshotsOnInterval(t *testing.T) {
+	s := newTestServer()
+	s.cfg.StatsHistorySize = 100
+	s.cfg.StatsSnapshotInterval = 10 * time.Millisecond
+
+	stop := s.StartStatsRecorder()
+	time.Sleep(55 * time.Millisecond)
+	stop()
+	stop() // stopping twice is safe
+
+	resp, _ := s.GetStatsHistory(context.Background(), &memoryv1.StatsHistoryRequest{})
+	if len(resp.GetSnapshots()) < 2 {
+		t.Errorf("expected periodic snapshots, got %d", len(resp.GetSnapshots()))
+	}
+}
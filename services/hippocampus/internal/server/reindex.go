@@ -0,0 +1,168 @@
+package server
+
+import (
+	"slices"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/vectorstore"
+	memoryv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/memory/v1"
+)
+
+// reindexDoc is a stored document being rebuilt by Reindex.
+type reindexDoc struct {
+	*preparedDoc
+	oldChunkIDs []string // the chunks the document had when it was read
+	embeddings  [][]float32
+}
+
+// Reindex rebuilds the vectors of every stored document from the text kept
+// in the full-text index, re-chunking each with the strategy it was indexed
+// with and re-embedding it with the current embedder. A progress message is
+// sent after each document is embedded. Each collection's new vectors are
+// swapped in with a single vector store Replace once all of its documents
+// are embedded, so a failure leaves that collection as it was. Documents
+// written while Reindex was embedding are left as written. Only one Reindex
+// runs at a time.
+func (s *HippocampusServer) Reindex(req *memoryv1.ReindexRequest, stream grpc.ServerStreamingServer[memoryv1.ReindexProgress]) error {
+	if !s.reindexing.CompareAndSwap(false, true) {
+		return status.Error(codes.Aborted, "a reindex is already in progress")
+	}
+	defer s.reindexing.Store(false)
+
+	collections := s.collectionNames()
+	if req.GetCollection() != "" {
+		collections = []string{req.GetCollection()}
+	}
+
+	plan := make(map[string][]reindexDoc, len(collections))
+	total := 0
+	for _, collection := range collections {
+		plan[collection] = s.storedDocuments(collection)
+		total += len(plan[collection])
+	}
+
+	progress := &memoryv1.ReindexProgress{TotalDocuments: int32(total)}
+	for _, collection := range collections {
+		docs := plan[collection]
+		for i, doc := range docs {
+			if err := stream.Context().Err(); err != nil {
+				return err
+			}
+			embeddings, err := s.embedChunks(doc.chunks, nil)
+			if err != nil {
+				return status.Errorf(codes.Internal, "embedding document %s in collection %q: %v", doc.docID, collection, err)
+			}
+			docs[i].embeddings = embeddings
+
+			progress.DocumentsReindexed++
+			progress.ChunksCreated += int32(len(doc.chunks))
+			if err := stream.Send(progress); err != nil {
+				return err
+			}
+		}
+
+		replaced, err := s.replaceVectors(collection, docs)
+		if err != nil {
+			return status.Errorf(codes.Internal, "replacing vectors in collection %q: %v", collection, err)
+		}
+		s.logger.Info("reindexed collection", "collection", collection, "documents", replaced,
+			"skipped", len(docs)-replaced)
+	}
+
+	s.statsChanged()
+	progress.Done = true
+	return stream.Send(progress)
+}
+
+// storedDocuments re-chunks a collection's documents from the full-text
+// index, sorted by document ID. Unlike prepareDocument it skips the
+// embedding dimension check, since Reindex is how a collection moves to a
+// new dimension.
+func (s *HippocampusServer) storedDocuments(collection string) []reindexDoc {
+	s.mu.RLock()
+	ids := make([]string, 0, len(s.docChunks[collection]))
+	oldChunkIDs := make(map[string][]string, len(s.docChunks[collection]))
+	for id, chunkIDs := range s.docChunks[collection] {
+		ids = append(ids, id)
+		oldChunkIDs[id] = chunkIDs
+	}
+	strategies := make(map[string]memoryv1.ChunkingStrategy, len(ids))
+	for _, id := range ids {
+		strategies[id] = s.chunking[collection][id]
+	}
+	s.mu.RUnlock()
+	sort.Strings(ids)
+
+	docs := make([]reindexDoc, 0, len(ids))
+	for _, id := range ids {
+		stored, ok := s.textIdx.Get(collection, id)
+		if !ok {
+			continue
+		}
+		req := &memoryv1.IndexRequest{
+			DocumentId:       id,
+			Content:          stored.Content,
+			Metadata:         stored.Metadata,
+			ChunkingStrategy: strategies[id],
+			Collection:       collection,
+		}
+		chunks := s.chunkDocument(id, req.GetContent(), req.GetChunkingStrategy(), req.GetMetadata())
+		if len(chunks) == 0 {
+			continue
+		}
+		docs = append(docs, reindexDoc{
+			preparedDoc: &preparedDoc{req: req, docID: id, collection: collection, chunks: chunks},
+			oldChunkIDs: oldChunkIDs[id],
+		})
+	}
+	return docs
+}
+
+// replaceVectors swaps the old chunk vectors of the given documents for
+// their new ones in one vector store Replace, and returns how many documents
+// were replaced. Document writes are held off for the swap, and a document
+// whose chunks changed since Reindex read it is skipped, so re-chunked old
+// content never overwrites a newer write. All old vectors go in the same
+// step so that a collection can move to an embedder with a different
+// dimension.
+func (s *HippocampusServer) replaceVectors(collection string, docs []reindexDoc) (int, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	var oldIDs []string
+	var records []vectorstore.Record
+	newChunkIDs := make(map[string][]string, len(docs))
+	s.mu.RLock()
+	for _, doc := range docs {
+		current, ok := s.docChunks[collection][doc.docID]
+		if !ok || !slices.Equal(current, doc.oldChunkIDs) {
+			continue
+		}
+		docRecords, chunkIDs := chunkRecords(doc.docID, doc.chunks, doc.embeddings)
+		// Soft-deleted documents stay hidden with their new vectors.
+		if _, deleted := s.tombstones[collection][doc.docID]; deleted {
+			for i := range docRecords {
+				docRecords[i].Deleted = true
+			}
+		}
+		oldIDs = append(oldIDs, current...)
+		records = append(records, docRecords...)
+		newChunkIDs[doc.docID] = chunkIDs
+	}
+	s.mu.RUnlock()
+
+	if err := s.store.Replace(collection, oldIDs, records); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	for docID, chunkIDs := range newChunkIDs {
+		s.docChunks[collection][docID] = chunkIDs
+	}
+	s.mu.Unlock()
+	return len(newChunkIDs), nil
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/embedder"
+	"github.com/ziyixi/SecondBrain/services/hippocampus/internal/vectorstore"
+	memoryv1 "github.com/ziyixi/SecondBrain/services/hippocampus/pkg/gen/memory/v1"
+)
+
+// reindexStream collects the messages sent by Reindex.
+type reindexStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*memoryv1.ReindexProgress
+}
+
+func (f *reindexStream) Context() context.Context { return f.ctx }
+
+func (f *reindexStream) Send(msg *memoryv1.ReindexProgress) error {
+	f.messages = append(f.messages, msg)
+	return nil
+}
+
+func TestReindexUsesCurrentEmbedder(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	for _, req := range []*memoryv1.IndexRequest{
+		{DocumentId: "doc-a", Content: "Notes about the seismic detection pipeline"},
+		{DocumentId: "doc-b", Content: "Grocery list for the week", Collection: "personal"},
+	} {
+		if resp, _ := s.IndexDocument(ctx, req); !resp.GetSuccess() {
+			t.Fatalf("index %s failed: %s", req.GetDocumentId(), resp.GetErrorMessage())
+		}
+	}
+
+	// Switch to a model with a different dimension; new documents are
+	// rejected until the collection is reindexed.
+	newEmbedder := embedder.NewMockEmbedder(16)
+	s.embedder = newEmbedder
+
+	stream := &reindexStream{ctx: ctx}
+	if err := s.Reindex(&memoryv1.ReindexRequest{}, stream); err != nil {
+		t.Fatalf("reindex error: %v", err)
+	}
+
+	last := stream.messages[len(stream.messages)-1]
+	if !last.GetDone() || last.GetDocumentsReindexed() != 2 || last.GetTotalDocuments() != 2 {
+		t.Errorf("unexpected final progress: %v", last)
+	}
+	if len(stream.messages) != 3 {
+		t.Errorf("expected a progress message per document plus the final one, got %d", len(stream.messages))
+	}
+
+	for _, collection := range []string{"test", "personal"} {
+		if dim := s.store.Dimension(collection); dim != 16 {
+			t.Errorf("%s: expected vectors of dimension 16, got %d", collection, dim)
+		}
+	}
+	if got := s.store.Count("test") + s.store.Count("personal"); got != int(last.GetChunksCreated()) {
+		t.Errorf("expected old vectors to be replaced, got %d vectors for %d chunks", got, last.GetChunksCreated())
+	}
+
+	// The stored vector matches the new embedder's embedding of the text.
+	want, _ := newEmbedder.Embed([]string{"Notes about the seismic detection pipeline"})
+	hits, err := s.store.Search("test", want[0], 1, nil)
+	if err != nil || len(hits) != 1 || hits[0].Score < 0.999 {
+		t.Errorf("expected the reindexed vector to match the new embedding, got %v (err %v)", hits, err)
+	}
+
+	if resp, _ := s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "doc-c", Content: "Indexed after the switch"}); !resp.GetSuccess() {
+		t.Errorf("expected indexing to work after reindex, got %q", resp.GetErrorMessage())
+	}
+}
+
+func TestReindexRejectsConcurrentRuns(t *testing.T) {
+	s := newTestServer()
+	s.reindexing.Store(true)
+
+	err := s.Reindex(&memoryv1.ReindexRequest{}, &reindexStream{ctx: context.Background()})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("expected Aborted while a reindex is running, got %v", err)
+	}
+}
+
+// hookStream runs onSend before recording each Reindex message.
+type hookStream struct {
+	reindexStream
+	onSend func()
+}
+
+func (f *hookStream) Send(msg *memoryv1.ReindexProgress) error {
+	if f.onSend != nil {
+		f.onSend()
+	}
+	return f.reindexStream.Send(msg)
+}
+
+func TestReindexKeepsConcurrentWrites(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	for _, id := range []string{"doc-a", "doc-b"} {
+		if resp, _ := s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: id, Content: "Original text of " + id}); !resp.GetSuccess() {
+			t.Fatalf("index %s failed: %s", id, resp.GetErrorMessage())
+		}
+	}
+
+	// doc-a is rewritten after Reindex has read it but before the swap.
+	var rewritten []string
+	stream := &hookStream{reindexStream: reindexStream{ctx: ctx}}
+	stream.onSend = func() {
+		if rewritten != nil {
+			return
+		}
+		if resp, _ := s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "doc-a", Content: "Fresh text"}); !resp.GetSuccess() {
+			t.Fatalf("concurrent index failed: %s", resp.GetErrorMessage())
+		}
+		s.mu.RLock()
+		rewritten = s.docChunks["test"]["doc-a"]
+		s.mu.RUnlock()
+	}
+	if err := s.Reindex(&memoryv1.ReindexRequest{}, stream); err != nil {
+		t.Fatalf("reindex error: %v", err)
+	}
+
+	s.mu.RLock()
+	got := s.docChunks["test"]["doc-a"]
+	s.mu.RUnlock()
+	if len(got) != len(rewritten) || got[0] != rewritten[0] {
+		t.Errorf("expected the concurrent write's chunks %v to be kept, got %v", rewritten, got)
+	}
+	hits, _ := s.store.Search("test", mustEmbed(t, s, "Fresh text"), 1, nil)
+	if len(hits) != 1 || hits[0].Payload["content"] != "Fresh text" {
+		t.Errorf("expected the fresh content to stay searchable, got %v", hits)
+	}
+}
+
+// failingReplaceStore rejects every Replace.
+type failingReplaceStore struct {
+	*vectorstore.InMemoryStore
+}
+
+func (failingReplaceStore) Replace(string, []string, []vectorstore.Record) error {
+	return errors.New("disk full")
+}
+
+func TestReindexFailureKeepsOldVectors(t *testing.T) {
+	s := newTestServer()
+	s.store = failingReplaceStore{vectorstore.NewInMemoryStore()}
+	ctx := context.Background()
+	if resp, _ := s.IndexDocument(ctx, &memoryv1.IndexRequest{DocumentId: "doc-a", Content: "Notes about the seismic detection pipeline"}); !resp.GetSuccess() {
+		t.Fatalf("index failed: %s", resp.GetErrorMessage())
+	}
+	s.mu.RLock()
+	before := s.docChunks["test"]["doc-a"]
+	s.mu.RUnlock()
+
+	err := s.Reindex(&memoryv1.ReindexRequest{}, &reindexStream{ctx: ctx})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected an internal error, got %v", err)
+	}
+	s.mu.RLock()
+	after := s.docChunks["test"]["doc-a"]
+	s.mu.RUnlock()
+	if len(after) != len(before) || after[0] != before[0] {
+		t.Errorf("expected the old chunks to be kept, got %v", after)
+	}
+	if s.store.Count("test") != len(before) {
+		t.Errorf("expected %d old vectors to remain, got %d", len(before), s.store.Count("test"))
+	}
+}
+
+func mustEmbed(t *testing.T, s *HippocampusServer, text string) []float32 {
+	t.Helper()
+	v, err := s.embedder.Embed([]string{text})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v[0]
+}
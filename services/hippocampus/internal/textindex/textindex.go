@@ -38,6 +38,7 @@ type indexedDoc struct {
 	metadata map[string]string
 	terms    map[string]int // term -> frequency
 	length   int            // total word count
+	deleted  bool           // tombstoned: kept, but hidden from Search and Count
 }
 
 // New creates a new full-text search index with default BM25 parameters.
@@ -74,6 +75,32 @@ func (idx *Index) Delete(collection string, id string) {
 	delete(idx.docs, collection+"\x00"+id)
 }
 
+// SetDeleted marks a document as deleted or restores it, reporting whether
+// the document exists.
+func (idx *Index) SetDeleted(collection, id string, deleted bool) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	doc, ok := idx.docs[collection+"\x00"+id]
+	if ok {
+		doc.deleted = deleted
+	}
+	return ok
+}
+
+// Get returns the document stored under id in a collection, including a
+// deleted one.
+func (idx *Index) Get(collection, id string) (Document, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	doc, ok := idx.docs[collection+"\x00"+id]
+	if !ok {
+		return Document{}, false
+	}
+	return Document{ID: doc.id, Content: doc.content, Metadata: doc.metadata}, true
+}
+
 // Search performs BM25-ranked full-text search within a collection.
 func (idx *Index) Search(collection, query string, topK int, filters map[string]string) []SearchHit {
 	idx.mu.RLock()
@@ -87,7 +114,7 @@ func (idx *Index) Search(collection, query string, topK int, filters map[string]
 	// Collect docs for this collection
 	var collDocs []*indexedDoc
 	for key, doc := range idx.docs {
-		if strings.HasPrefix(key, collection+"\x00") {
+		if strings.HasPrefix(key, collection+"\x00") && !doc.deleted {
 			collDocs = append(collDocs, doc)
 		}
 	}
@@ -170,15 +197,16 @@ func (idx *Index) Search(collection, query string, topK int, filters map[string]
 	return hits
 }
 
-// Count returns the number of documents in a collection.
+// Count returns the number of documents in a collection, excluding deleted
+// ones.
 func (idx *Index) Count(collection string) int {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
 	count := 0
 	prefix := collection + "\x00"
-	for key := range idx.docs {
-		if strings.HasPrefix(key, prefix) {
+	for key, doc := range idx.docs {
+		if strings.HasPrefix(key, prefix) && !doc.deleted {
 			count++
 		}
 	}
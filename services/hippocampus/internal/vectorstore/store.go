@@ -1,6 +1,7 @@
 package vectorstore
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"sync"
@@ -11,6 +12,7 @@ type Record struct {
 	ID      string
 	Vector  []float32
 	Payload map[string]string
+	Deleted bool // tombstoned: kept, but hidden from Search and Count
 }
 
 // SearchHit represents a search result.
@@ -25,7 +27,15 @@ type Store interface {
 	Upsert(collection string, records []Record) error
 	Search(collection string, vector []float32, topK int, filters map[string]string) ([]SearchHit, error)
 	Delete(collection string, ids []string) (int, error)
+	// Replace deletes the records with the given IDs and upserts records as
+	// one step: if any record is rejected, the collection is left unchanged.
+	Replace(collection string, deleteIDs []string, records []Record) error
+	// SetDeleted tombstones or restores records, returning how many changed.
+	SetDeleted(collection string, ids []string, deleted bool) (int, error)
 	Count(collection string) int
+	// Dimension returns the vector dimension of a collection's records, or
+	// 0 if the collection is empty.
+	Dimension(collection string) int
 }
 
 // InMemoryStore is an in-memory vector store for development and testing.
@@ -50,6 +60,18 @@ func (s *InMemoryStore) Upsert(collection string, records []Record) error {
 		s.collections[collection] = make(map[string]Record)
 	}
 
+	// Vectors of different dimensions can't be compared, so every record
+	// must match the collection's existing records (or the first new one).
+	dim := s.dimension(collection)
+	for _, r := range records {
+		if dim == 0 {
+			dim = len(r.Vector)
+		}
+		if len(r.Vector) != dim {
+			return fmt.Errorf("record %s has dimension %d, collection %q expects %d", r.ID, len(r.Vector), collection, dim)
+		}
+	}
+
 	for _, r := range records {
 		s.collections[collection][r.ID] = r
 	}
@@ -74,6 +96,10 @@ func (s *InMemoryStore) Search(collection string, vector []float32, topK int, fi
 
 	var results []scored
 	for _, record := range coll {
+		if record.Deleted {
+			continue
+		}
+
 		// Apply filters
 		if filters != nil {
 			match := true
@@ -136,12 +162,93 @@ func (s *InMemoryStore) Delete(collection string, ids []string) (int, error) {
 	return deleted, nil
 }
 
-// Count returns the number of records in a collection.
+// Replace deletes the records with deleteIDs and upserts records in one
+// step. The new records need only match the dimension of the records that
+// remain, so replacing every record can change a collection's dimension.
+func (s *InMemoryStore) Replace(collection string, deleteIDs []string, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remove := make(map[string]bool, len(deleteIDs))
+	for _, id := range deleteIDs {
+		remove[id] = true
+	}
+	dim := 0
+	for id, r := range s.collections[collection] {
+		if !remove[id] {
+			dim = len(r.Vector)
+			break
+		}
+	}
+	for _, r := range records {
+		if dim == 0 {
+			dim = len(r.Vector)
+		}
+		if len(r.Vector) != dim {
+			return fmt.Errorf("record %s has dimension %d, collection %q expects %d", r.ID, len(r.Vector), collection, dim)
+		}
+	}
+
+	if _, ok := s.collections[collection]; !ok {
+		s.collections[collection] = make(map[string]Record)
+	}
+	coll := s.collections[collection]
+	for id := range remove {
+		delete(coll, id)
+	}
+	for _, r := range records {
+		coll[r.ID] = r
+	}
+	return nil
+}
+
+// SetDeleted marks records as deleted or restores them.
+func (s *InMemoryStore) SetDeleted(collection string, ids []string, deleted bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	coll := s.collections[collection]
+	changed := 0
+	for _, id := range ids {
+		if r, exists := coll[id]; exists && r.Deleted != deleted {
+			r.Deleted = deleted
+			coll[id] = r
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// Count returns the number of records in a collection, excluding deleted
+// ones.
 func (s *InMemoryStore) Count(collection string) int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return len(s.collections[collection])
+	n := 0
+	for _, r := range s.collections[collection] {
+		if !r.Deleted {
+			n++
+		}
+	}
+	return n
+}
+
+// Dimension returns the vector dimension of a collection's records, or 0 if
+// the collection is empty.
+func (s *InMemoryStore) Dimension(collection string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.dimension(collection)
+}
+
+// dimension is Dimension without locking. Callers must hold s.mu.
+func (s *InMemoryStore) dimension(collection string) int {
+	for _, r := range s.collections[collection] {
+		return len(r.Vector)
+	}
+	return 0
 }
 
 func cosineSimilarity(a, b []float32) float32 {
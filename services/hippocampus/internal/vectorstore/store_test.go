@@ -137,6 +137,82 @@ func TestInMemoryStoreUpsertOverwrite(t *testing.T) {
 	}
 }
 
+func TestInMemoryStoreDimension(t *testing.T) {
+	store := NewInMemoryStore()
+	if store.Dimension("test") != 0 {
+		t.Errorf("expected 0 for an empty collection, got %d", store.Dimension("test"))
+	}
+
+	if err := store.Upsert("test", []Record{{ID: "1", Vector: []float32{1, 0, 0}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.Dimension("test") != 3 {
+		t.Errorf("expected 3, got %d", store.Dimension("test"))
+	}
+
+	if err := store.Upsert("test", []Record{{ID: "2", Vector: []float32{1, 0}}}); err == nil {
+		t.Error("expected an error upserting a vector of a different dimension")
+	}
+	if store.Count("test") != 1 {
+		t.Errorf("expected the mismatched record to be rejected, got %d records", store.Count("test"))
+	}
+}
+
+func TestInMemoryStoreReplace(t *testing.T) {
+	store := NewInMemoryStore()
+	store.Upsert("test", []Record{
+		{ID: "old-1", Vector: []float32{1, 0, 0}},
+		{ID: "old-2", Vector: []float32{0, 1, 0}},
+	})
+
+	// A rejected record leaves the collection as it was.
+	err := store.Replace("test", []string{"old-1"}, []Record{{ID: "new-1", Vector: []float32{1, 0}}})
+	if err == nil {
+		t.Fatal("expected a dimension mismatch with a remaining record")
+	}
+	if store.Count("test") != 2 {
+		t.Errorf("expected the failed replace to keep 2 records, got %d", store.Count("test"))
+	}
+
+	// Replacing every record may change the dimension.
+	err = store.Replace("test", []string{"old-1", "old-2"}, []Record{
+		{ID: "new-1", Vector: []float32{1, 0}},
+		{ID: "new-2", Vector: []float32{0, 1}, Deleted: true},
+	})
+	if err != nil {
+		t.Fatalf("replace error: %v", err)
+	}
+	if store.Count("test") != 1 || store.Dimension("test") != 2 {
+		t.Errorf("expected 1 visible 2-dimensional record, got %d of dimension %d", store.Count("test"), store.Dimension("test"))
+	}
+}
+
+func TestInMemoryStoreSetDeleted(t *testing.T) {
+	store := NewInMemoryStore()
+	store.Upsert("test", []Record{
+		{ID: "1", Vector: []float32{1, 0, 0}},
+		{ID: "2", Vector: []float32{0, 1, 0}},
+	})
+
+	if n, _ := store.SetDeleted("test", []string{"1", "missing"}, true); n != 1 {
+		t.Errorf("expected 1 record tombstoned, got %d", n)
+	}
+	hits, _ := store.Search("test", []float32{1, 0, 0}, 10, nil)
+	if len(hits) != 1 || hits[0].ID != "2" {
+		t.Errorf("expected the deleted record to be hidden from search, got %v", hits)
+	}
+	if store.Count("test") != 1 {
+		t.Errorf("expected Count to exclude deleted records, got %d", store.Count("test"))
+	}
+
+	if n, _ := store.SetDeleted("test", []string{"1"}, false); n != 1 {
+		t.Errorf("expected 1 record restored, got %d", n)
+	}
+	if store.Count("test") != 2 {
+		t.Errorf("expected 2 records after restoring, got %d", store.Count("test"))
+	}
+}
+
 func TestCosineSimilarity(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -29,6 +29,11 @@ const (
 	ChunkingStrategy_CHUNKING_STRATEGY_FIXED        ChunkingStrategy = 1
 	ChunkingStrategy_CHUNKING_STRATEGY_SEMANTIC     ChunkingStrategy = 2
 	ChunkingStrategy_CHUNKING_STRATEGY_HIERARCHICAL ChunkingStrategy = 3
+	// Fixed-size chunks measured in estimated model tokens rather than words.
+	ChunkingStrategy_CHUNKING_STRATEGY_TOKEN ChunkingStrategy = 4
+	// Markdown-aware chunks that never split fenced code blocks or tables.
+	ChunkingStrategy_CHUNKING_STRATEGY_MARKDOWN  ChunkingStrategy = 5
+	ChunkingStrategy_CHUNKING_STRATEGY_RECURSIVE ChunkingStrategy = 6
 )
 
 // Enum value maps for ChunkingStrategy.
@@ -38,12 +43,18 @@ var (
 		1: "CHUNKING_STRATEGY_FIXED",
 		2: "CHUNKING_STRATEGY_SEMANTIC",
 		3: "CHUNKING_STRATEGY_HIERARCHICAL",
+		4: "CHUNKING_STRATEGY_TOKEN",
+		5: "CHUNKING_STRATEGY_MARKDOWN",
+		6: "CHUNKING_STRATEGY_RECURSIVE",
 	}
 	ChunkingStrategy_value = map[string]int32{
 		"CHUNKING_STRATEGY_UNSPECIFIED":  0,
 		"CHUNKING_STRATEGY_FIXED":        1,
 		"CHUNKING_STRATEGY_SEMANTIC":     2,
 		"CHUNKING_STRATEGY_HIERARCHICAL": 3,
+		"CHUNKING_STRATEGY_TOKEN":        4,
+		"CHUNKING_STRATEGY_MARKDOWN":     5,
+		"CHUNKING_STRATEGY_RECURSIVE":    6,
 	}
 )
 
@@ -80,8 +91,10 @@ type IndexRequest struct {
 	Content          string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	Metadata         map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	ChunkingStrategy ChunkingStrategy       `protobuf:"varint,4,opt,name=chunking_strategy,json=chunkingStrategy,proto3,enum=cognitive_os.memory.v1.ChunkingStrategy" json:"chunking_strategy,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Collection to index into; empty uses the service's default collection.
+	Collection    string `protobuf:"bytes,5,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *IndexRequest) Reset() {
@@ -142,6 +155,13 @@ func (x *IndexRequest) GetChunkingStrategy() ChunkingStrategy {
 	return ChunkingStrategy_CHUNKING_STRATEGY_UNSPECIFIED
 }
 
+func (x *IndexRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
 type IndexResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
@@ -210,30 +230,27 @@ func (x *IndexResponse) GetErrorMessage() string {
 	return ""
 }
 
-type SearchRequest struct {
+type BatchIndexRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	TopK          int32                  `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
-	Filters       map[string]string      `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	MinScore      float32                `protobuf:"fixed32,4,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	Documents     []*IndexRequest        `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchRequest) Reset() {
-	*x = SearchRequest{}
+func (x *BatchIndexRequest) Reset() {
+	*x = BatchIndexRequest{}
 	mi := &file_memory_v1_memory_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchRequest) String() string {
+func (x *BatchIndexRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest) ProtoMessage() {}
+func (*BatchIndexRequest) ProtoMessage() {}
 
-func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchIndexRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_memory_v1_memory_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -245,60 +262,41 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
-func (*SearchRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchIndexRequest.ProtoReflect.Descriptor instead.
+func (*BatchIndexRequest) Descriptor() ([]byte, []int) {
 	return file_memory_v1_memory_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SearchRequest) GetQuery() string {
-	if x != nil {
-		return x.Query
-	}
-	return ""
-}
-
-func (x *SearchRequest) GetTopK() int32 {
-	if x != nil {
-		return x.TopK
-	}
-	return 0
-}
-
-func (x *SearchRequest) GetFilters() map[string]string {
+func (x *BatchIndexRequest) GetDocuments() []*IndexRequest {
 	if x != nil {
-		return x.Filters
+		return x.Documents
 	}
 	return nil
 }
 
-func (x *SearchRequest) GetMinScore() float32 {
-	if x != nil {
-		return x.MinScore
-	}
-	return 0
-}
-
-type SearchResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+type BatchIndexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per requested document, in request order. A failed document
+	// does not affect the others.
+	Results       []*IndexResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResponse) Reset() {
-	*x = SearchResponse{}
+func (x *BatchIndexResponse) Reset() {
+	*x = BatchIndexResponse{}
 	mi := &file_memory_v1_memory_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchResponse) String() string {
+func (x *BatchIndexResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResponse) ProtoMessage() {}
+func (*BatchIndexResponse) ProtoMessage() {}
 
-func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchIndexResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_memory_v1_memory_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -310,43 +308,41 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
-func (*SearchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchIndexResponse.ProtoReflect.Descriptor instead.
+func (*BatchIndexResponse) Descriptor() ([]byte, []int) {
 	return file_memory_v1_memory_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *SearchResponse) GetResults() []*SearchResult {
+func (x *BatchIndexResponse) GetResults() []*IndexResponse {
 	if x != nil {
 		return x.Results
 	}
 	return nil
 }
 
-type SearchResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChunkId       string                 `protobuf:"bytes,1,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
-	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	Score         float32                `protobuf:"fixed32,4,opt,name=score,proto3" json:"score,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+// ReindexRequest selects the collections Reindex rebuilds.
+type ReindexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Collection to reindex; empty reindexes every collection.
+	Collection    string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResult) Reset() {
-	*x = SearchResult{}
+func (x *ReindexRequest) Reset() {
+	*x = ReindexRequest{}
 	mi := &file_memory_v1_memory_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchResult) String() string {
+func (x *ReindexRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResult) ProtoMessage() {}
+func (*ReindexRequest) ProtoMessage() {}
 
-func (x *SearchResult) ProtoReflect() protoreflect.Message {
+func (x *ReindexRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_memory_v1_memory_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -358,70 +354,45 @@ func (x *SearchResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
-func (*SearchResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReindexRequest.ProtoReflect.Descriptor instead.
+func (*ReindexRequest) Descriptor() ([]byte, []int) {
 	return file_memory_v1_memory_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *SearchResult) GetChunkId() string {
-	if x != nil {
-		return x.ChunkId
-	}
-	return ""
-}
-
-func (x *SearchResult) GetDocumentId() string {
-	if x != nil {
-		return x.DocumentId
-	}
-	return ""
-}
-
-func (x *SearchResult) GetContent() string {
+func (x *ReindexRequest) GetCollection() string {
 	if x != nil {
-		return x.Content
+		return x.Collection
 	}
 	return ""
 }
 
-func (x *SearchResult) GetScore() float32 {
-	if x != nil {
-		return x.Score
-	}
-	return 0
-}
-
-func (x *SearchResult) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-type GraphTripleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
-	Predicate     string                 `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"`
-	Object        string                 `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+// ReindexProgress is one Reindex update, sent after each document is
+// embedded and once more when every collection has been replaced.
+type ReindexProgress struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DocumentsReindexed int32                  `protobuf:"varint,1,opt,name=documents_reindexed,json=documentsReindexed,proto3" json:"documents_reindexed,omitempty"`
+	TotalDocuments     int32                  `protobuf:"varint,2,opt,name=total_documents,json=totalDocuments,proto3" json:"total_documents,omitempty"`
+	ChunksCreated      int32                  `protobuf:"varint,3,opt,name=chunks_created,json=chunksCreated,proto3" json:"chunks_created,omitempty"`
+	// Set only on the final message.
+	Done          bool `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GraphTripleRequest) Reset() {
-	*x = GraphTripleRequest{}
+func (x *ReindexProgress) Reset() {
+	*x = ReindexProgress{}
 	mi := &file_memory_v1_memory_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GraphTripleRequest) String() string {
+func (x *ReindexProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GraphTripleRequest) ProtoMessage() {}
+func (*ReindexProgress) ProtoMessage() {}
 
-func (x *GraphTripleRequest) ProtoReflect() protoreflect.Message {
+func (x *ReindexProgress) ProtoReflect() protoreflect.Message {
 	mi := &file_memory_v1_memory_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -433,61 +404,65 @@ func (x *GraphTripleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GraphTripleRequest.ProtoReflect.Descriptor instead.
-func (*GraphTripleRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReindexProgress.ProtoReflect.Descriptor instead.
+func (*ReindexProgress) Descriptor() ([]byte, []int) {
 	return file_memory_v1_memory_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GraphTripleRequest) GetSubject() string {
+func (x *ReindexProgress) GetDocumentsReindexed() int32 {
 	if x != nil {
-		return x.Subject
+		return x.DocumentsReindexed
 	}
-	return ""
+	return 0
 }
 
-func (x *GraphTripleRequest) GetPredicate() string {
+func (x *ReindexProgress) GetTotalDocuments() int32 {
 	if x != nil {
-		return x.Predicate
+		return x.TotalDocuments
 	}
-	return ""
+	return 0
 }
 
-func (x *GraphTripleRequest) GetObject() string {
+func (x *ReindexProgress) GetChunksCreated() int32 {
 	if x != nil {
-		return x.Object
+		return x.ChunksCreated
 	}
-	return ""
+	return 0
 }
 
-func (x *GraphTripleRequest) GetMetadata() map[string]string {
+func (x *ReindexProgress) GetDone() bool {
 	if x != nil {
-		return x.Metadata
+		return x.Done
 	}
-	return nil
+	return false
 }
 
-type GraphTripleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	TripleId      string                 `protobuf:"bytes,2,opt,name=triple_id,json=tripleId,proto3" json:"triple_id,omitempty"`
+// IndexProgress is one StreamIndexDocument update. Progress messages report
+// how many chunks have been embedded; the last message carries the result.
+type IndexProgress struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChunksEmbedded int32                  `protobuf:"varint,1,opt,name=chunks_embedded,json=chunksEmbedded,proto3" json:"chunks_embedded,omitempty"`
+	TotalChunks    int32                  `protobuf:"varint,2,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
+	// Set only on the final message.
+	Result        *IndexResponse `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GraphTripleResponse) Reset() {
-	*x = GraphTripleResponse{}
+func (x *IndexProgress) Reset() {
+	*x = IndexProgress{}
 	mi := &file_memory_v1_memory_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GraphTripleResponse) String() string {
+func (x *IndexProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GraphTripleResponse) ProtoMessage() {}
+func (*IndexProgress) ProtoMessage() {}
 
-func (x *GraphTripleResponse) ProtoReflect() protoreflect.Message {
+func (x *IndexProgress) ProtoReflect() protoreflect.Message {
 	mi := &file_memory_v1_memory_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -499,48 +474,72 @@ func (x *GraphTripleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GraphTripleResponse.ProtoReflect.Descriptor instead.
-func (*GraphTripleResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use IndexProgress.ProtoReflect.Descriptor instead.
+func (*IndexProgress) Descriptor() ([]byte, []int) {
 	return file_memory_v1_memory_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GraphTripleResponse) GetSuccess() bool {
+func (x *IndexProgress) GetChunksEmbedded() int32 {
 	if x != nil {
-		return x.Success
+		return x.ChunksEmbedded
 	}
-	return false
+	return 0
 }
 
-func (x *GraphTripleResponse) GetTripleId() string {
+func (x *IndexProgress) GetTotalChunks() int32 {
 	if x != nil {
-		return x.TripleId
+		return x.TotalChunks
 	}
-	return ""
+	return 0
 }
 
-type GraphQueryRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Entity             string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
-	MaxHops            int32                  `protobuf:"varint,2,opt,name=max_hops,json=maxHops,proto3" json:"max_hops,omitempty"`
-	RelationshipFilter string                 `protobuf:"bytes,3,opt,name=relationship_filter,json=relationshipFilter,proto3" json:"relationship_filter,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *IndexProgress) GetResult() *IndexResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
 }
 
-func (x *GraphQueryRequest) Reset() {
-	*x = GraphQueryRequest{}
+type SearchRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Query   string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	TopK    int32                  `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	Filters map[string]string      `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Drops results scoring below this, on the [0, 1] scale shared by every
+	// search mode. HybridSearch compares it against each document's best
+	// semantic score rather than the fused score, which is relative to the
+	// top hit; it falls back to the fused score when the query can't be
+	// embedded.
+	MinScore float32 `protobuf:"fixed32,4,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	// Collection to search; empty uses the service's default collection.
+	Collection string `protobuf:"bytes,5,opt,name=collection,proto3" json:"collection,omitempty"`
+	// HybridSearch fusion overrides; zero uses the service's configured value.
+	Bm25Weight   float32 `protobuf:"fixed32,6,opt,name=bm25_weight,json=bm25Weight,proto3" json:"bm25_weight,omitempty"`
+	VectorWeight float32 `protobuf:"fixed32,7,opt,name=vector_weight,json=vectorWeight,proto3" json:"vector_weight,omitempty"`
+	RrfK         float32 `protobuf:"fixed32,8,opt,name=rrf_k,json=rrfK,proto3" json:"rrf_k,omitempty"`
+	// HybridSearch also searches variants of the query proposed by the
+	// service's query expander, fusing them at half the original query's
+	// weights. Each variant costs another embedding and full-text search.
+	// Ignored when no expander is configured.
+	ExpandQuery   bool `protobuf:"varint,9,opt,name=expand_query,json=expandQuery,proto3" json:"expand_query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
 	mi := &file_memory_v1_memory_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GraphQueryRequest) String() string {
+func (x *SearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GraphQueryRequest) ProtoMessage() {}
+func (*SearchRequest) ProtoMessage() {}
 
-func (x *GraphQueryRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_memory_v1_memory_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -552,108 +551,104 @@ func (x *GraphQueryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GraphQueryRequest.ProtoReflect.Descriptor instead.
-func (*GraphQueryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
 	return file_memory_v1_memory_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GraphQueryRequest) GetEntity() string {
+func (x *SearchRequest) GetQuery() string {
 	if x != nil {
-		return x.Entity
+		return x.Query
 	}
 	return ""
 }
 
-func (x *GraphQueryRequest) GetMaxHops() int32 {
+func (x *SearchRequest) GetTopK() int32 {
 	if x != nil {
-		return x.MaxHops
+		return x.TopK
 	}
 	return 0
 }
 
-func (x *GraphQueryRequest) GetRelationshipFilter() string {
+func (x *SearchRequest) GetFilters() map[string]string {
 	if x != nil {
-		return x.RelationshipFilter
+		return x.Filters
 	}
-	return ""
-}
-
-type GraphQueryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Nodes         []*GraphNode           `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Edges         []*GraphEdge           `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return nil
 }
 
-func (x *GraphQueryResponse) Reset() {
-	*x = GraphQueryResponse{}
-	mi := &file_memory_v1_memory_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SearchRequest) GetMinScore() float32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
 }
 
-func (x *GraphQueryResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SearchRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
 }
 
-func (*GraphQueryResponse) ProtoMessage() {}
-
-func (x *GraphQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_memory_v1_memory_proto_msgTypes[8]
+func (x *SearchRequest) GetBm25Weight() float32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Bm25Weight
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GraphQueryResponse.ProtoReflect.Descriptor instead.
-func (*GraphQueryResponse) Descriptor() ([]byte, []int) {
-	return file_memory_v1_memory_proto_rawDescGZIP(), []int{8}
+func (x *SearchRequest) GetVectorWeight() float32 {
+	if x != nil {
+		return x.VectorWeight
+	}
+	return 0
 }
 
-func (x *GraphQueryResponse) GetNodes() []*GraphNode {
+func (x *SearchRequest) GetRrfK() float32 {
 	if x != nil {
-		return x.Nodes
+		return x.RrfK
 	}
-	return nil
+	return 0
 }
 
-func (x *GraphQueryResponse) GetEdges() []*GraphEdge {
+func (x *SearchRequest) GetExpandQuery() bool {
 	if x != nil {
-		return x.Edges
+		return x.ExpandQuery
 	}
-	return nil
+	return false
 }
 
-type GraphNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	Properties    map[string]string      `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+type SearchResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Number of candidates that passed the filters and min_score before
+	// results were cut to top_k. HybridSearch candidates are the documents
+	// containing a query term plus those of the 2 × top_k nearest chunks.
+	TotalMatches int32 `protobuf:"varint,2,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	// Whether results omit some of the total_matches candidates.
+	Truncated bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Number of candidates dropped for scoring below min_score.
+	BelowMinScore int32 `protobuf:"varint,4,opt,name=below_min_score,json=belowMinScore,proto3" json:"below_min_score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GraphNode) Reset() {
-	*x = GraphNode{}
-	mi := &file_memory_v1_memory_proto_msgTypes[9]
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GraphNode) String() string {
+func (x *SearchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GraphNode) ProtoMessage() {}
+func (*SearchResponse) ProtoMessage() {}
 
-func (x *GraphNode) ProtoReflect() protoreflect.Message {
-	mi := &file_memory_v1_memory_proto_msgTypes[9]
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,57 +659,1194 @@ func (x *GraphNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GraphNode.ProtoReflect.Descriptor instead.
-func (*GraphNode) Descriptor() ([]byte, []int) {
-	return file_memory_v1_memory_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GraphNode) GetId() string {
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTotalMatches() int32 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *SearchResponse) GetBelowMinScore() int32 {
+	if x != nil {
+		return x.BelowMinScore
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ChunkId    string                 `protobuf:"bytes,1,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
+	DocumentId string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Content    string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Relevance in [0, 1], higher is better, in every search mode: semantic
+	// search maps cosine similarity c to (c + 1) / 2, full-text search divides
+	// BM25 by the top score and hybrid search divides the fused score by the
+	// top score.
+	Score float32 `protobuf:"fixed32,4,opt,name=score,proto3" json:"score,omitempty"`
+	// For chunk results, includes "start_offset" and "end_offset": the
+	// character range of the chunk in its source document, end exclusive.
+	Metadata      map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_memory_v1_memory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchResult) GetChunkId() string {
+	if x != nil {
+		return x.ChunkId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SearchResult) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GraphTripleRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Subject   string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Predicate string                 `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	Object    string                 `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
+	Metadata  map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Collection the triple belongs to; empty uses the default collection.
+	Collection    string `protobuf:"bytes,5,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphTripleRequest) Reset() {
+	*x = GraphTripleRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphTripleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphTripleRequest) ProtoMessage() {}
+
+func (x *GraphTripleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphTripleRequest.ProtoReflect.Descriptor instead.
+func (*GraphTripleRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GraphTripleRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *GraphTripleRequest) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *GraphTripleRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *GraphTripleRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GraphTripleRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type GraphTripleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TripleId      string                 `protobuf:"bytes,2,opt,name=triple_id,json=tripleId,proto3" json:"triple_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphTripleResponse) Reset() {
+	*x = GraphTripleResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphTripleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphTripleResponse) ProtoMessage() {}
+
+func (x *GraphTripleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphTripleResponse.ProtoReflect.Descriptor instead.
+func (*GraphTripleResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GraphTripleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GraphTripleResponse) GetTripleId() string {
+	if x != nil {
+		return x.TripleId
+	}
+	return ""
+}
+
+type GraphQueryRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Entity             string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	MaxHops            int32                  `protobuf:"varint,2,opt,name=max_hops,json=maxHops,proto3" json:"max_hops,omitempty"`
+	RelationshipFilter string                 `protobuf:"bytes,3,opt,name=relationship_filter,json=relationshipFilter,proto3" json:"relationship_filter,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GraphQueryRequest) Reset() {
+	*x = GraphQueryRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphQueryRequest) ProtoMessage() {}
+
+func (x *GraphQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphQueryRequest.ProtoReflect.Descriptor instead.
+func (*GraphQueryRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GraphQueryRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *GraphQueryRequest) GetMaxHops() int32 {
+	if x != nil {
+		return x.MaxHops
+	}
+	return 0
+}
+
+func (x *GraphQueryRequest) GetRelationshipFilter() string {
+	if x != nil {
+		return x.RelationshipFilter
+	}
+	return ""
+}
+
+type GraphQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*GraphNode           `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*GraphEdge           `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphQueryResponse) Reset() {
+	*x = GraphQueryResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphQueryResponse) ProtoMessage() {}
+
+func (x *GraphQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphQueryResponse.ProtoReflect.Descriptor instead.
+func (*GraphQueryResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GraphQueryResponse) GetNodes() []*GraphNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *GraphQueryResponse) GetEdges() []*GraphEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+type GraphNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Properties    map[string]string      `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphNode) Reset() {
+	*x = GraphNode{}
+	mi := &file_memory_v1_memory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphNode) ProtoMessage() {}
+
+func (x *GraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphNode.ProtoReflect.Descriptor instead.
+func (*GraphNode) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GraphNode) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *GraphNode) GetLabel() string {
+func (x *GraphNode) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *GraphNode) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type GraphEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Relationship  string                 `protobuf:"bytes,3,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	Properties    map[string]string      `protobuf:"bytes,4,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphEdge) Reset() {
+	*x = GraphEdge{}
+	mi := &file_memory_v1_memory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphEdge) ProtoMessage() {}
+
+func (x *GraphEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphEdge.ProtoReflect.Descriptor instead.
+func (*GraphEdge) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GraphEdge) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetRelationship() string {
+	if x != nil {
+		return x.Relationship
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// Collection to delete from; empty uses the service's default collection.
+	Collection string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	// Mark the document deleted, hiding it from search, instead of removing
+	// it. It can be restored with UndeleteDocument until it is purged.
+	Soft          bool `protobuf:"varint,3,opt,name=soft,proto3" json:"soft,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetSoft() bool {
+	if x != nil {
+		return x.Soft
+	}
+	return false
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ChunksDeleted int32                  `protobuf:"varint,2,opt,name=chunks_deleted,json=chunksDeleted,proto3" json:"chunks_deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteResponse) GetChunksDeleted() int32 {
+	if x != nil {
+		return x.ChunksDeleted
+	}
+	return 0
+}
+
+type UndeleteRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// Collection the document is in; empty uses the service's default collection.
+	Collection    string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteRequest) Reset() {
+	*x = UndeleteRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteRequest) ProtoMessage() {}
+
+func (x *UndeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteRequest.ProtoReflect.Descriptor instead.
+func (*UndeleteRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UndeleteRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *UndeleteRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type UndeleteResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ChunksRestored int32                  `protobuf:"varint,2,opt,name=chunks_restored,json=chunksRestored,proto3" json:"chunks_restored,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UndeleteResponse) Reset() {
+	*x = UndeleteResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteResponse) ProtoMessage() {}
+
+func (x *UndeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteResponse.ProtoReflect.Descriptor instead.
+func (*UndeleteResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UndeleteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UndeleteResponse) GetChunksRestored() int32 {
+	if x != nil {
+		return x.ChunksRestored
+	}
+	return 0
+}
+
+type DeleteByFilterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Metadata a document must match on every key to be deleted.
+	Filters map[string]string `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Must be set to delete with an empty filter, which matches every document.
+	ConfirmDeleteAll bool `protobuf:"varint,2,opt,name=confirm_delete_all,json=confirmDeleteAll,proto3" json:"confirm_delete_all,omitempty"`
+	// Collection to delete from; empty uses the service's default collection.
+	Collection string `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	// When set, only documents last indexed before this time are deleted, e.g.
+	// to expire old notes. It narrows an empty filter, so confirm_delete_all
+	// is not needed with it.
+	IndexedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=indexed_before,json=indexedBefore,proto3" json:"indexed_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteByFilterRequest) Reset() {
+	*x = DeleteByFilterRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteByFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteByFilterRequest) ProtoMessage() {}
+
+func (x *DeleteByFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteByFilterRequest.ProtoReflect.Descriptor instead.
+func (*DeleteByFilterRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteByFilterRequest) GetFilters() map[string]string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *DeleteByFilterRequest) GetConfirmDeleteAll() bool {
+	if x != nil {
+		return x.ConfirmDeleteAll
+	}
+	return false
+}
+
+func (x *DeleteByFilterRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DeleteByFilterRequest) GetIndexedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IndexedBefore
+	}
+	return nil
+}
+
+type DeleteByFilterResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DocumentsDeleted int32                  `protobuf:"varint,1,opt,name=documents_deleted,json=documentsDeleted,proto3" json:"documents_deleted,omitempty"`
+	ChunksDeleted    int32                  `protobuf:"varint,2,opt,name=chunks_deleted,json=chunksDeleted,proto3" json:"chunks_deleted,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteByFilterResponse) Reset() {
+	*x = DeleteByFilterResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteByFilterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteByFilterResponse) ProtoMessage() {}
+
+func (x *DeleteByFilterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteByFilterResponse.ProtoReflect.Descriptor instead.
+func (*DeleteByFilterResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteByFilterResponse) GetDocumentsDeleted() int32 {
+	if x != nil {
+		return x.DocumentsDeleted
+	}
+	return 0
+}
+
+func (x *DeleteByFilterResponse) GetChunksDeleted() int32 {
+	if x != nil {
+		return x.ChunksDeleted
+	}
+	return 0
+}
+
+type ListByFilterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Metadata key/values every listed document must have; empty lists every
+	// document.
+	Filters map[string]string `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Documents per page; zero uses 20. At most 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous page; empty starts at the newest
+	// document.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Collection to list; empty uses the service's default collection.
+	Collection    string `protobuf:"bytes,4,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByFilterRequest) Reset() {
+	*x = ListByFilterRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByFilterRequest) ProtoMessage() {}
+
+func (x *ListByFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByFilterRequest.ProtoReflect.Descriptor instead.
+func (*ListByFilterRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListByFilterRequest) GetFilters() map[string]string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *ListByFilterRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListByFilterRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListByFilterRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type ListedDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ChunkCount    int32                  `protobuf:"varint,4,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	IndexedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListedDocument) Reset() {
+	*x = ListedDocument{}
+	mi := &file_memory_v1_memory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListedDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListedDocument) ProtoMessage() {}
+
+func (x *ListedDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListedDocument.ProtoReflect.Descriptor instead.
+func (*ListedDocument) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListedDocument) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *ListedDocument) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ListedDocument) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListedDocument) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *ListedDocument) GetIndexedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return nil
+}
+
+type ListByFilterResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matching documents, most recently indexed first.
+	Documents []*ListedDocument `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of documents matching the filters across all pages.
+	TotalMatches  int32 `protobuf:"varint,3,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByFilterResponse) Reset() {
+	*x = ListByFilterResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByFilterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByFilterResponse) ProtoMessage() {}
+
+func (x *ListByFilterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByFilterResponse.ProtoReflect.Descriptor instead.
+func (*ListByFilterResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListByFilterResponse) GetDocuments() []*ListedDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *ListByFilterResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListByFilterResponse) GetTotalMatches() int32 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
+}
+
+type GetDocumentRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// Collection to read from; empty uses the service's default collection.
+	Collection    string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentRequest) Reset() {
+	*x = GetDocumentRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentRequest) ProtoMessage() {}
+
+func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetDocumentRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *GetDocumentRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type GetDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ChunkCount    int32                  `protobuf:"varint,4,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentResponse) Reset() {
+	*x = GetDocumentResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentResponse) ProtoMessage() {}
+
+func (x *GetDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentResponse.ProtoReflect.Descriptor instead.
+func (*GetDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetDocumentResponse) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *GetDocumentResponse) GetContent() string {
 	if x != nil {
-		return x.Label
+		return x.Content
 	}
 	return ""
 }
 
-func (x *GraphNode) GetProperties() map[string]string {
+func (x *GetDocumentResponse) GetMetadata() map[string]string {
 	if x != nil {
-		return x.Properties
+		return x.Metadata
 	}
 	return nil
 }
 
-type GraphEdge struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	Relationship  string                 `protobuf:"bytes,3,opt,name=relationship,proto3" json:"relationship,omitempty"`
-	Properties    map[string]string      `protobuf:"bytes,4,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+func (x *GetDocumentResponse) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+type StatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional collection filter. When set, totals are scoped to that
+	// collection; when empty, totals cover every collection.
+	Collection    string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GraphEdge) Reset() {
-	*x = GraphEdge{}
-	mi := &file_memory_v1_memory_proto_msgTypes[10]
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GraphEdge) String() string {
+func (x *StatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GraphEdge) ProtoMessage() {}
+func (*StatsRequest) ProtoMessage() {}
 
-func (x *GraphEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_memory_v1_memory_proto_msgTypes[10]
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -725,61 +1857,136 @@ func (x *GraphEdge) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GraphEdge.ProtoReflect.Descriptor instead.
-func (*GraphEdge) Descriptor() ([]byte, []int) {
-	return file_memory_v1_memory_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *GraphEdge) GetSource() string {
+func (x *StatsRequest) GetCollection() string {
 	if x != nil {
-		return x.Source
+		return x.Collection
 	}
 	return ""
 }
 
-func (x *GraphEdge) GetTarget() string {
+type StatsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalDocuments    int64                  `protobuf:"varint,1,opt,name=total_documents,json=totalDocuments,proto3" json:"total_documents,omitempty"`
+	TotalChunks       int64                  `protobuf:"varint,2,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
+	TotalGraphTriples int64                  `protobuf:"varint,3,opt,name=total_graph_triples,json=totalGraphTriples,proto3" json:"total_graph_triples,omitempty"`
+	LastIndexedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_indexed_at,json=lastIndexedAt,proto3" json:"last_indexed_at,omitempty"`
+	// Per-collection breakdown of the counts above.
+	Collections []*CollectionStats `protobuf:"bytes,5,rep,name=collections,proto3" json:"collections,omitempty"`
+	// Vector dimension and model name of the configured embedder.
+	EmbeddingDimension int32  `protobuf:"varint,6,opt,name=embedding_dimension,json=embeddingDimension,proto3" json:"embedding_dimension,omitempty"`
+	EmbeddingModel     string `protobuf:"bytes,7,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[28]
 	if x != nil {
-		return x.Target
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *GraphEdge) GetRelationship() string {
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StatsResponse) GetTotalDocuments() int64 {
 	if x != nil {
-		return x.Relationship
+		return x.TotalDocuments
 	}
-	return ""
+	return 0
 }
 
-func (x *GraphEdge) GetProperties() map[string]string {
+func (x *StatsResponse) GetTotalChunks() int64 {
 	if x != nil {
-		return x.Properties
+		return x.TotalChunks
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetTotalGraphTriples() int64 {
+	if x != nil {
+		return x.TotalGraphTriples
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetLastIndexedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastIndexedAt
 	}
 	return nil
 }
 
-type DeleteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+func (x *StatsResponse) GetCollections() []*CollectionStats {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetEmbeddingDimension() int32 {
+	if x != nil {
+		return x.EmbeddingDimension
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetEmbeddingModel() string {
+	if x != nil {
+		return x.EmbeddingModel
+	}
+	return ""
+}
+
+type StatsHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only snapshots taken at or after this time; unset returns every
+	// snapshot kept.
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
-	mi := &file_memory_v1_memory_proto_msgTypes[11]
+func (x *StatsHistoryRequest) Reset() {
+	*x = StatsHistoryRequest{}
+	mi := &file_memory_v1_memory_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRequest) String() string {
+func (x *StatsHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*StatsHistoryRequest) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_memory_v1_memory_proto_msgTypes[11]
+func (x *StatsHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -790,41 +1997,44 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_memory_v1_memory_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use StatsHistoryRequest.ProtoReflect.Descriptor instead.
+func (*StatsHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *DeleteRequest) GetDocumentId() string {
+func (x *StatsHistoryRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.DocumentId
+		return x.Since
 	}
-	return ""
+	return nil
 }
 
-type DeleteResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	ChunksDeleted int32                  `protobuf:"varint,2,opt,name=chunks_deleted,json=chunksDeleted,proto3" json:"chunks_deleted,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Totals across every collection at one point in time.
+type StatsSnapshot struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TakenAt           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=taken_at,json=takenAt,proto3" json:"taken_at,omitempty"`
+	TotalDocuments    int64                  `protobuf:"varint,2,opt,name=total_documents,json=totalDocuments,proto3" json:"total_documents,omitempty"`
+	TotalChunks       int64                  `protobuf:"varint,3,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
+	TotalGraphTriples int64                  `protobuf:"varint,4,opt,name=total_graph_triples,json=totalGraphTriples,proto3" json:"total_graph_triples,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *DeleteResponse) Reset() {
-	*x = DeleteResponse{}
-	mi := &file_memory_v1_memory_proto_msgTypes[12]
+func (x *StatsSnapshot) Reset() {
+	*x = StatsSnapshot{}
+	mi := &file_memory_v1_memory_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteResponse) String() string {
+func (x *StatsSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteResponse) ProtoMessage() {}
+func (*StatsSnapshot) ProtoMessage() {}
 
-func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_memory_v1_memory_proto_msgTypes[12]
+func (x *StatsSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,46 +2045,64 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_memory_v1_memory_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use StatsSnapshot.ProtoReflect.Descriptor instead.
+func (*StatsSnapshot) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *DeleteResponse) GetSuccess() bool {
+func (x *StatsSnapshot) GetTakenAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Success
+		return x.TakenAt
 	}
-	return false
+	return nil
 }
 
-func (x *DeleteResponse) GetChunksDeleted() int32 {
+func (x *StatsSnapshot) GetTotalDocuments() int64 {
 	if x != nil {
-		return x.ChunksDeleted
+		return x.TotalDocuments
 	}
 	return 0
 }
 
-type StatsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *StatsSnapshot) GetTotalChunks() int64 {
+	if x != nil {
+		return x.TotalChunks
+	}
+	return 0
+}
+
+func (x *StatsSnapshot) GetTotalGraphTriples() int64 {
+	if x != nil {
+		return x.TotalGraphTriples
+	}
+	return 0
+}
+
+type StatsHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first. Snapshots are taken on an interval and after changes,
+	// with changes close together sharing one snapshot. The two series are
+	// capped separately at the service's history limit, oldest dropped first.
+	Snapshots     []*StatsSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StatsRequest) Reset() {
-	*x = StatsRequest{}
-	mi := &file_memory_v1_memory_proto_msgTypes[13]
+func (x *StatsHistoryResponse) Reset() {
+	*x = StatsHistoryResponse{}
+	mi := &file_memory_v1_memory_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatsRequest) String() string {
+func (x *StatsHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatsRequest) ProtoMessage() {}
+func (*StatsHistoryResponse) ProtoMessage() {}
 
-func (x *StatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_memory_v1_memory_proto_msgTypes[13]
+func (x *StatsHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -885,36 +2113,44 @@ func (x *StatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
-func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return file_memory_v1_memory_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use StatsHistoryResponse.ProtoReflect.Descriptor instead.
+func (*StatsHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{31}
 }
 
-type StatsResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	TotalDocuments    int64                  `protobuf:"varint,1,opt,name=total_documents,json=totalDocuments,proto3" json:"total_documents,omitempty"`
-	TotalChunks       int64                  `protobuf:"varint,2,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
-	TotalGraphTriples int64                  `protobuf:"varint,3,opt,name=total_graph_triples,json=totalGraphTriples,proto3" json:"total_graph_triples,omitempty"`
-	LastIndexedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_indexed_at,json=lastIndexedAt,proto3" json:"last_indexed_at,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *StatsHistoryResponse) GetSnapshots() []*StatsSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
 }
 
-func (x *StatsResponse) Reset() {
-	*x = StatsResponse{}
-	mi := &file_memory_v1_memory_proto_msgTypes[14]
+type CollectionStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Documents     int64                  `protobuf:"varint,2,opt,name=documents,proto3" json:"documents,omitempty"`
+	Chunks        int64                  `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	GraphTriples  int64                  `protobuf:"varint,4,opt,name=graph_triples,json=graphTriples,proto3" json:"graph_triples,omitempty"`
+	LastIndexedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_indexed_at,json=lastIndexedAt,proto3" json:"last_indexed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionStats) Reset() {
+	*x = CollectionStats{}
+	mi := &file_memory_v1_memory_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatsResponse) String() string {
+func (x *CollectionStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatsResponse) ProtoMessage() {}
+func (*CollectionStats) ProtoMessage() {}
 
-func (x *StatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_memory_v1_memory_proto_msgTypes[14]
+func (x *CollectionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_memory_v1_memory_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,33 +2161,40 @@ func (x *StatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
-func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return file_memory_v1_memory_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use CollectionStats.ProtoReflect.Descriptor instead.
+func (*CollectionStats) Descriptor() ([]byte, []int) {
+	return file_memory_v1_memory_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *StatsResponse) GetTotalDocuments() int64 {
+func (x *CollectionStats) GetName() string {
 	if x != nil {
-		return x.TotalDocuments
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CollectionStats) GetDocuments() int64 {
+	if x != nil {
+		return x.Documents
 	}
 	return 0
 }
 
-func (x *StatsResponse) GetTotalChunks() int64 {
+func (x *CollectionStats) GetChunks() int64 {
 	if x != nil {
-		return x.TotalChunks
+		return x.Chunks
 	}
 	return 0
 }
 
-func (x *StatsResponse) GetTotalGraphTriples() int64 {
+func (x *CollectionStats) GetGraphTriples() int64 {
 	if x != nil {
-		return x.TotalGraphTriples
+		return x.GraphTriples
 	}
 	return 0
 }
 
-func (x *StatsResponse) GetLastIndexedAt() *timestamppb.Timestamp {
+func (x *CollectionStats) GetLastIndexedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.LastIndexedAt
 	}
@@ -962,13 +2205,16 @@ var File_memory_v1_memory_proto protoreflect.FileDescriptor
 
 const file_memory_v1_memory_proto_rawDesc = "" +
 	"\n" +
-	"\x16memory/v1/memory.proto\x12\x16cognitive_os.memory.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xad\x02\n" +
+	"\x16memory/v1/memory.proto\x12\x16cognitive_os.memory.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcd\x02\n" +
 	"\fIndexRequest\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
 	"documentId\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12N\n" +
 	"\bmetadata\x18\x03 \x03(\v22.cognitive_os.memory.v1.IndexRequest.MetadataEntryR\bmetadata\x12U\n" +
-	"\x11chunking_strategy\x18\x04 \x01(\x0e2(.cognitive_os.memory.v1.ChunkingStrategyR\x10chunkingStrategy\x1a;\n" +
+	"\x11chunking_strategy\x18\x04 \x01(\x0e2(.cognitive_os.memory.v1.ChunkingStrategyR\x10chunkingStrategy\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x05 \x01(\tR\n" +
+	"collection\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x96\x01\n" +
@@ -977,17 +2223,45 @@ const file_memory_v1_memory_proto_rawDesc = "" +
 	"documentId\x12%\n" +
 	"\x0echunks_created\x18\x02 \x01(\x05R\rchunksCreated\x12\x18\n" +
 	"\asuccess\x18\x03 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"\xe1\x01\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"W\n" +
+	"\x11BatchIndexRequest\x12B\n" +
+	"\tdocuments\x18\x01 \x03(\v2$.cognitive_os.memory.v1.IndexRequestR\tdocuments\"U\n" +
+	"\x12BatchIndexResponse\x12?\n" +
+	"\aresults\x18\x01 \x03(\v2%.cognitive_os.memory.v1.IndexResponseR\aresults\"0\n" +
+	"\x0eReindexRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\"\xa6\x01\n" +
+	"\x0fReindexProgress\x12/\n" +
+	"\x13documents_reindexed\x18\x01 \x01(\x05R\x12documentsReindexed\x12'\n" +
+	"\x0ftotal_documents\x18\x02 \x01(\x05R\x0etotalDocuments\x12%\n" +
+	"\x0echunks_created\x18\x03 \x01(\x05R\rchunksCreated\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\bR\x04done\"\x9a\x01\n" +
+	"\rIndexProgress\x12'\n" +
+	"\x0fchunks_embedded\x18\x01 \x01(\x05R\x0echunksEmbedded\x12!\n" +
+	"\ftotal_chunks\x18\x02 \x01(\x05R\vtotalChunks\x12=\n" +
+	"\x06result\x18\x03 \x01(\v2%.cognitive_os.memory.v1.IndexResponseR\x06result\"\xff\x02\n" +
 	"\rSearchRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x13\n" +
 	"\x05top_k\x18\x02 \x01(\x05R\x04topK\x12L\n" +
 	"\afilters\x18\x03 \x03(\v22.cognitive_os.memory.v1.SearchRequest.FiltersEntryR\afilters\x12\x1b\n" +
-	"\tmin_score\x18\x04 \x01(\x02R\bminScore\x1a:\n" +
+	"\tmin_score\x18\x04 \x01(\x02R\bminScore\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x05 \x01(\tR\n" +
+	"collection\x12\x1f\n" +
+	"\vbm25_weight\x18\x06 \x01(\x02R\n" +
+	"bm25Weight\x12#\n" +
+	"\rvector_weight\x18\a \x01(\x02R\fvectorWeight\x12\x13\n" +
+	"\x05rrf_k\x18\b \x01(\x02R\x04rrfK\x12!\n" +
+	"\fexpand_query\x18\t \x01(\bR\vexpandQuery\x1a:\n" +
 	"\fFiltersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"P\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbb\x01\n" +
 	"\x0eSearchResponse\x12>\n" +
-	"\aresults\x18\x01 \x03(\v2$.cognitive_os.memory.v1.SearchResultR\aresults\"\x87\x02\n" +
+	"\aresults\x18\x01 \x03(\v2$.cognitive_os.memory.v1.SearchResultR\aresults\x12#\n" +
+	"\rtotal_matches\x18\x02 \x01(\x05R\ftotalMatches\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x12&\n" +
+	"\x0fbelow_min_score\x18\x04 \x01(\x05R\rbelowMinScore\"\x87\x02\n" +
 	"\fSearchResult\x12\x19\n" +
 	"\bchunk_id\x18\x01 \x01(\tR\achunkId\x12\x1f\n" +
 	"\vdocument_id\x18\x02 \x01(\tR\n" +
@@ -997,12 +2271,15 @@ const file_memory_v1_memory_proto_rawDesc = "" +
 	"\bmetadata\x18\x05 \x03(\v22.cognitive_os.memory.v1.SearchResult.MetadataEntryR\bmetadata\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf7\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x97\x02\n" +
 	"\x12GraphTripleRequest\x12\x18\n" +
 	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1c\n" +
 	"\tpredicate\x18\x02 \x01(\tR\tpredicate\x12\x16\n" +
 	"\x06object\x18\x03 \x01(\tR\x06object\x12T\n" +
-	"\bmetadata\x18\x04 \x03(\v28.cognitive_os.memory.v1.GraphTripleRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x04 \x03(\v28.cognitive_os.memory.v1.GraphTripleRequest.MetadataEntryR\bmetadata\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x05 \x01(\tR\n" +
+	"collection\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"L\n" +
@@ -1034,34 +2311,135 @@ const file_memory_v1_memory_proto_rawDesc = "" +
 	"properties\x1a=\n" +
 	"\x0fPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"0\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"d\n" +
 	"\rDeleteRequest\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
-	"documentId\"Q\n" +
+	"documentId\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x02 \x01(\tR\n" +
+	"collection\x12\x12\n" +
+	"\x04soft\x18\x03 \x01(\bR\x04soft\"Q\n" +
 	"\x0eDeleteResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12%\n" +
-	"\x0echunks_deleted\x18\x02 \x01(\x05R\rchunksDeleted\"\x0e\n" +
-	"\fStatsRequest\"\xcf\x01\n" +
+	"\x0echunks_deleted\x18\x02 \x01(\x05R\rchunksDeleted\"R\n" +
+	"\x0fUndeleteRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x02 \x01(\tR\n" +
+	"collection\"U\n" +
+	"\x10UndeleteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12'\n" +
+	"\x0fchunks_restored\x18\x02 \x01(\x05R\x0echunksRestored\"\xba\x02\n" +
+	"\x15DeleteByFilterRequest\x12T\n" +
+	"\afilters\x18\x01 \x03(\v2:.cognitive_os.memory.v1.DeleteByFilterRequest.FiltersEntryR\afilters\x12,\n" +
+	"\x12confirm_delete_all\x18\x02 \x01(\bR\x10confirmDeleteAll\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12A\n" +
+	"\x0eindexed_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rindexedBefore\x1a:\n" +
+	"\fFiltersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"l\n" +
+	"\x16DeleteByFilterResponse\x12+\n" +
+	"\x11documents_deleted\x18\x01 \x01(\x05R\x10documentsDeleted\x12%\n" +
+	"\x0echunks_deleted\x18\x02 \x01(\x05R\rchunksDeleted\"\x81\x02\n" +
+	"\x13ListByFilterRequest\x12R\n" +
+	"\afilters\x18\x01 \x03(\v28.cognitive_os.memory.v1.ListByFilterRequest.FiltersEntryR\afilters\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x04 \x01(\tR\n" +
+	"collection\x1a:\n" +
+	"\fFiltersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb6\x02\n" +
+	"\x0eListedDocument\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12P\n" +
+	"\bmetadata\x18\x03 \x03(\v24.cognitive_os.memory.v1.ListedDocument.MetadataEntryR\bmetadata\x12\x1f\n" +
+	"\vchunk_count\x18\x04 \x01(\x05R\n" +
+	"chunkCount\x129\n" +
+	"\n" +
+	"indexed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa9\x01\n" +
+	"\x14ListByFilterResponse\x12D\n" +
+	"\tdocuments\x18\x01 \x03(\v2&.cognitive_os.memory.v1.ListedDocumentR\tdocuments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12#\n" +
+	"\rtotal_matches\x18\x03 \x01(\x05R\ftotalMatches\"U\n" +
+	"\x12GetDocumentRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x02 \x01(\tR\n" +
+	"collection\"\x85\x02\n" +
+	"\x13GetDocumentResponse\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12U\n" +
+	"\bmetadata\x18\x03 \x03(\v29.cognitive_os.memory.v1.GetDocumentResponse.MetadataEntryR\bmetadata\x12\x1f\n" +
+	"\vchunk_count\x18\x04 \x01(\x05R\n" +
+	"chunkCount\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\".\n" +
+	"\fStatsRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\"\xf4\x02\n" +
 	"\rStatsResponse\x12'\n" +
 	"\x0ftotal_documents\x18\x01 \x01(\x03R\x0etotalDocuments\x12!\n" +
 	"\ftotal_chunks\x18\x02 \x01(\x03R\vtotalChunks\x12.\n" +
 	"\x13total_graph_triples\x18\x03 \x01(\x03R\x11totalGraphTriples\x12B\n" +
-	"\x0flast_indexed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rlastIndexedAt*\x96\x01\n" +
+	"\x0flast_indexed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rlastIndexedAt\x12I\n" +
+	"\vcollections\x18\x05 \x03(\v2'.cognitive_os.memory.v1.CollectionStatsR\vcollections\x12/\n" +
+	"\x13embedding_dimension\x18\x06 \x01(\x05R\x12embeddingDimension\x12'\n" +
+	"\x0fembedding_model\x18\a \x01(\tR\x0eembeddingModel\"G\n" +
+	"\x13StatsHistoryRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\xc2\x01\n" +
+	"\rStatsSnapshot\x125\n" +
+	"\btaken_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\atakenAt\x12'\n" +
+	"\x0ftotal_documents\x18\x02 \x01(\x03R\x0etotalDocuments\x12!\n" +
+	"\ftotal_chunks\x18\x03 \x01(\x03R\vtotalChunks\x12.\n" +
+	"\x13total_graph_triples\x18\x04 \x01(\x03R\x11totalGraphTriples\"[\n" +
+	"\x14StatsHistoryResponse\x12C\n" +
+	"\tsnapshots\x18\x01 \x03(\v2%.cognitive_os.memory.v1.StatsSnapshotR\tsnapshots\"\xc4\x01\n" +
+	"\x0fCollectionStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tdocuments\x18\x02 \x01(\x03R\tdocuments\x12\x16\n" +
+	"\x06chunks\x18\x03 \x01(\x03R\x06chunks\x12#\n" +
+	"\rgraph_triples\x18\x04 \x01(\x03R\fgraphTriples\x12B\n" +
+	"\x0flast_indexed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rlastIndexedAt*\xf4\x01\n" +
 	"\x10ChunkingStrategy\x12!\n" +
 	"\x1dCHUNKING_STRATEGY_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17CHUNKING_STRATEGY_FIXED\x10\x01\x12\x1e\n" +
 	"\x1aCHUNKING_STRATEGY_SEMANTIC\x10\x02\x12\"\n" +
-	"\x1eCHUNKING_STRATEGY_HIERARCHICAL\x10\x032\x98\x06\n" +
+	"\x1eCHUNKING_STRATEGY_HIERARCHICAL\x10\x03\x12\x1b\n" +
+	"\x17CHUNKING_STRATEGY_TOKEN\x10\x04\x12\x1e\n" +
+	"\x1aCHUNKING_STRATEGY_MARKDOWN\x10\x05\x12\x1f\n" +
+	"\x1bCHUNKING_STRATEGY_RECURSIVE\x10\x062\xe3\f\n" +
 	"\rMemoryService\x12\\\n" +
-	"\rIndexDocument\x12$.cognitive_os.memory.v1.IndexRequest\x1a%.cognitive_os.memory.v1.IndexResponse\x12_\n" +
+	"\rIndexDocument\x12$.cognitive_os.memory.v1.IndexRequest\x1a%.cognitive_os.memory.v1.IndexResponse\x12d\n" +
+	"\x13StreamIndexDocument\x12$.cognitive_os.memory.v1.IndexRequest\x1a%.cognitive_os.memory.v1.IndexProgress0\x01\x12l\n" +
+	"\x13BatchIndexDocuments\x12).cognitive_os.memory.v1.BatchIndexRequest\x1a*.cognitive_os.memory.v1.BatchIndexResponse\x12_\n" +
 	"\x0eSemanticSearch\x12%.cognitive_os.memory.v1.SearchRequest\x1a&.cognitive_os.memory.v1.SearchResponse\x12_\n" +
 	"\x0eFullTextSearch\x12%.cognitive_os.memory.v1.SearchRequest\x1a&.cognitive_os.memory.v1.SearchResponse\x12]\n" +
 	"\fHybridSearch\x12%.cognitive_os.memory.v1.SearchRequest\x1a&.cognitive_os.memory.v1.SearchResponse\x12i\n" +
 	"\x0eAddGraphTriple\x12*.cognitive_os.memory.v1.GraphTripleRequest\x1a+.cognitive_os.memory.v1.GraphTripleResponse\x12c\n" +
 	"\n" +
 	"QueryGraph\x12).cognitive_os.memory.v1.GraphQueryRequest\x1a*.cognitive_os.memory.v1.GraphQueryResponse\x12_\n" +
-	"\x0eDeleteDocument\x12%.cognitive_os.memory.v1.DeleteRequest\x1a&.cognitive_os.memory.v1.DeleteResponse\x12W\n" +
-	"\bGetStats\x12$.cognitive_os.memory.v1.StatsRequest\x1a%.cognitive_os.memory.v1.StatsResponseB8Z6github.com/ziyixi/SecondBrain/proto/memory/v1;memoryv1b\x06proto3"
+	"\x0eDeleteDocument\x12%.cognitive_os.memory.v1.DeleteRequest\x1a&.cognitive_os.memory.v1.DeleteResponse\x12e\n" +
+	"\x10UndeleteDocument\x12'.cognitive_os.memory.v1.UndeleteRequest\x1a(.cognitive_os.memory.v1.UndeleteResponse\x12o\n" +
+	"\x0eDeleteByFilter\x12-.cognitive_os.memory.v1.DeleteByFilterRequest\x1a..cognitive_os.memory.v1.DeleteByFilterResponse\x12f\n" +
+	"\vGetDocument\x12*.cognitive_os.memory.v1.GetDocumentRequest\x1a+.cognitive_os.memory.v1.GetDocumentResponse\x12i\n" +
+	"\fListByFilter\x12+.cognitive_os.memory.v1.ListByFilterRequest\x1a,.cognitive_os.memory.v1.ListByFilterResponse\x12W\n" +
+	"\bGetStats\x12$.cognitive_os.memory.v1.StatsRequest\x1a%.cognitive_os.memory.v1.StatsResponse\x12l\n" +
+	"\x0fGetStatsHistory\x12+.cognitive_os.memory.v1.StatsHistoryRequest\x1a,.cognitive_os.memory.v1.StatsHistoryResponse\x12\\\n" +
+	"\aReindex\x12&.cognitive_os.memory.v1.ReindexRequest\x1a'.cognitive_os.memory.v1.ReindexProgress0\x01B8Z6github.com/ziyixi/SecondBrain/proto/memory/v1;memoryv1b\x06proto3"
 
 var (
 	file_memory_v1_memory_proto_rawDescOnce sync.Once
@@ -1076,65 +2454,118 @@ func file_memory_v1_memory_proto_rawDescGZIP() []byte {
 }
 
 var file_memory_v1_memory_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_memory_v1_memory_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_memory_v1_memory_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_memory_v1_memory_proto_goTypes = []any{
-	(ChunkingStrategy)(0),         // 0: cognitive_os.memory.v1.ChunkingStrategy
-	(*IndexRequest)(nil),          // 1: cognitive_os.memory.v1.IndexRequest
-	(*IndexResponse)(nil),         // 2: cognitive_os.memory.v1.IndexResponse
-	(*SearchRequest)(nil),         // 3: cognitive_os.memory.v1.SearchRequest
-	(*SearchResponse)(nil),        // 4: cognitive_os.memory.v1.SearchResponse
-	(*SearchResult)(nil),          // 5: cognitive_os.memory.v1.SearchResult
-	(*GraphTripleRequest)(nil),    // 6: cognitive_os.memory.v1.GraphTripleRequest
-	(*GraphTripleResponse)(nil),   // 7: cognitive_os.memory.v1.GraphTripleResponse
-	(*GraphQueryRequest)(nil),     // 8: cognitive_os.memory.v1.GraphQueryRequest
-	(*GraphQueryResponse)(nil),    // 9: cognitive_os.memory.v1.GraphQueryResponse
-	(*GraphNode)(nil),             // 10: cognitive_os.memory.v1.GraphNode
-	(*GraphEdge)(nil),             // 11: cognitive_os.memory.v1.GraphEdge
-	(*DeleteRequest)(nil),         // 12: cognitive_os.memory.v1.DeleteRequest
-	(*DeleteResponse)(nil),        // 13: cognitive_os.memory.v1.DeleteResponse
-	(*StatsRequest)(nil),          // 14: cognitive_os.memory.v1.StatsRequest
-	(*StatsResponse)(nil),         // 15: cognitive_os.memory.v1.StatsResponse
-	nil,                           // 16: cognitive_os.memory.v1.IndexRequest.MetadataEntry
-	nil,                           // 17: cognitive_os.memory.v1.SearchRequest.FiltersEntry
-	nil,                           // 18: cognitive_os.memory.v1.SearchResult.MetadataEntry
-	nil,                           // 19: cognitive_os.memory.v1.GraphTripleRequest.MetadataEntry
-	nil,                           // 20: cognitive_os.memory.v1.GraphNode.PropertiesEntry
-	nil,                           // 21: cognitive_os.memory.v1.GraphEdge.PropertiesEntry
-	(*timestamppb.Timestamp)(nil), // 22: google.protobuf.Timestamp
+	(ChunkingStrategy)(0),          // 0: cognitive_os.memory.v1.ChunkingStrategy
+	(*IndexRequest)(nil),           // 1: cognitive_os.memory.v1.IndexRequest
+	(*IndexResponse)(nil),          // 2: cognitive_os.memory.v1.IndexResponse
+	(*BatchIndexRequest)(nil),      // 3: cognitive_os.memory.v1.BatchIndexRequest
+	(*BatchIndexResponse)(nil),     // 4: cognitive_os.memory.v1.BatchIndexResponse
+	(*ReindexRequest)(nil),         // 5: cognitive_os.memory.v1.ReindexRequest
+	(*ReindexProgress)(nil),        // 6: cognitive_os.memory.v1.ReindexProgress
+	(*IndexProgress)(nil),          // 7: cognitive_os.memory.v1.IndexProgress
+	(*SearchRequest)(nil),          // 8: cognitive_os.memory.v1.SearchRequest
+	(*SearchResponse)(nil),         // 9: cognitive_os.memory.v1.SearchResponse
+	(*SearchResult)(nil),           // 10: cognitive_os.memory.v1.SearchResult
+	(*GraphTripleRequest)(nil),     // 11: cognitive_os.memory.v1.GraphTripleRequest
+	(*GraphTripleResponse)(nil),    // 12: cognitive_os.memory.v1.GraphTripleResponse
+	(*GraphQueryRequest)(nil),      // 13: cognitive_os.memory.v1.GraphQueryRequest
+	(*GraphQueryResponse)(nil),     // 14: cognitive_os.memory.v1.GraphQueryResponse
+	(*GraphNode)(nil),              // 15: cognitive_os.memory.v1.GraphNode
+	(*GraphEdge)(nil),              // 16: cognitive_os.memory.v1.GraphEdge
+	(*DeleteRequest)(nil),          // 17: cognitive_os.memory.v1.DeleteRequest
+	(*DeleteResponse)(nil),         // 18: cognitive_os.memory.v1.DeleteResponse
+	(*UndeleteRequest)(nil),        // 19: cognitive_os.memory.v1.UndeleteRequest
+	(*UndeleteResponse)(nil),       // 20: cognitive_os.memory.v1.UndeleteResponse
+	(*DeleteByFilterRequest)(nil),  // 21: cognitive_os.memory.v1.DeleteByFilterRequest
+	(*DeleteByFilterResponse)(nil), // 22: cognitive_os.memory.v1.DeleteByFilterResponse
+	(*ListByFilterRequest)(nil),    // 23: cognitive_os.memory.v1.ListByFilterRequest
+	(*ListedDocument)(nil),         // 24: cognitive_os.memory.v1.ListedDocument
+	(*ListByFilterResponse)(nil),   // 25: cognitive_os.memory.v1.ListByFilterResponse
+	(*GetDocumentRequest)(nil),     // 26: cognitive_os.memory.v1.GetDocumentRequest
+	(*GetDocumentResponse)(nil),    // 27: cognitive_os.memory.v1.GetDocumentResponse
+	(*StatsRequest)(nil),           // 28: cognitive_os.memory.v1.StatsRequest
+	(*StatsResponse)(nil),          // 29: cognitive_os.memory.v1.StatsResponse
+	(*StatsHistoryRequest)(nil),    // 30: cognitive_os.memory.v1.StatsHistoryRequest
+	(*StatsSnapshot)(nil),          // 31: cognitive_os.memory.v1.StatsSnapshot
+	(*StatsHistoryResponse)(nil),   // 32: cognitive_os.memory.v1.StatsHistoryResponse
+	(*CollectionStats)(nil),        // 33: cognitive_os.memory.v1.CollectionStats
+	nil,                            // 34: cognitive_os.memory.v1.IndexRequest.MetadataEntry
+	nil,                            // 35: cognitive_os.memory.v1.SearchRequest.FiltersEntry
+	nil,                            // 36: cognitive_os.memory.v1.SearchResult.MetadataEntry
+	nil,                            // 37: cognitive_os.memory.v1.GraphTripleRequest.MetadataEntry
+	nil,                            // 38: cognitive_os.memory.v1.GraphNode.PropertiesEntry
+	nil,                            // 39: cognitive_os.memory.v1.GraphEdge.PropertiesEntry
+	nil,                            // 40: cognitive_os.memory.v1.DeleteByFilterRequest.FiltersEntry
+	nil,                            // 41: cognitive_os.memory.v1.ListByFilterRequest.FiltersEntry
+	nil,                            // 42: cognitive_os.memory.v1.ListedDocument.MetadataEntry
+	nil,                            // 43: cognitive_os.memory.v1.GetDocumentResponse.MetadataEntry
+	(*timestamppb.Timestamp)(nil),  // 44: google.protobuf.Timestamp
 }
 var file_memory_v1_memory_proto_depIdxs = []int32{
-	16, // 0: cognitive_os.memory.v1.IndexRequest.metadata:type_name -> cognitive_os.memory.v1.IndexRequest.MetadataEntry
+	34, // 0: cognitive_os.memory.v1.IndexRequest.metadata:type_name -> cognitive_os.memory.v1.IndexRequest.MetadataEntry
 	0,  // 1: cognitive_os.memory.v1.IndexRequest.chunking_strategy:type_name -> cognitive_os.memory.v1.ChunkingStrategy
-	17, // 2: cognitive_os.memory.v1.SearchRequest.filters:type_name -> cognitive_os.memory.v1.SearchRequest.FiltersEntry
-	5,  // 3: cognitive_os.memory.v1.SearchResponse.results:type_name -> cognitive_os.memory.v1.SearchResult
-	18, // 4: cognitive_os.memory.v1.SearchResult.metadata:type_name -> cognitive_os.memory.v1.SearchResult.MetadataEntry
-	19, // 5: cognitive_os.memory.v1.GraphTripleRequest.metadata:type_name -> cognitive_os.memory.v1.GraphTripleRequest.MetadataEntry
-	10, // 6: cognitive_os.memory.v1.GraphQueryResponse.nodes:type_name -> cognitive_os.memory.v1.GraphNode
-	11, // 7: cognitive_os.memory.v1.GraphQueryResponse.edges:type_name -> cognitive_os.memory.v1.GraphEdge
-	20, // 8: cognitive_os.memory.v1.GraphNode.properties:type_name -> cognitive_os.memory.v1.GraphNode.PropertiesEntry
-	21, // 9: cognitive_os.memory.v1.GraphEdge.properties:type_name -> cognitive_os.memory.v1.GraphEdge.PropertiesEntry
-	22, // 10: cognitive_os.memory.v1.StatsResponse.last_indexed_at:type_name -> google.protobuf.Timestamp
-	1,  // 11: cognitive_os.memory.v1.MemoryService.IndexDocument:input_type -> cognitive_os.memory.v1.IndexRequest
-	3,  // 12: cognitive_os.memory.v1.MemoryService.SemanticSearch:input_type -> cognitive_os.memory.v1.SearchRequest
-	3,  // 13: cognitive_os.memory.v1.MemoryService.FullTextSearch:input_type -> cognitive_os.memory.v1.SearchRequest
-	3,  // 14: cognitive_os.memory.v1.MemoryService.HybridSearch:input_type -> cognitive_os.memory.v1.SearchRequest
-	6,  // 15: cognitive_os.memory.v1.MemoryService.AddGraphTriple:input_type -> cognitive_os.memory.v1.GraphTripleRequest
-	8,  // 16: cognitive_os.memory.v1.MemoryService.QueryGraph:input_type -> cognitive_os.memory.v1.GraphQueryRequest
-	12, // 17: cognitive_os.memory.v1.MemoryService.DeleteDocument:input_type -> cognitive_os.memory.v1.DeleteRequest
-	14, // 18: cognitive_os.memory.v1.MemoryService.GetStats:input_type -> cognitive_os.memory.v1.StatsRequest
-	2,  // 19: cognitive_os.memory.v1.MemoryService.IndexDocument:output_type -> cognitive_os.memory.v1.IndexResponse
-	4,  // 20: cognitive_os.memory.v1.MemoryService.SemanticSearch:output_type -> cognitive_os.memory.v1.SearchResponse
-	4,  // 21: cognitive_os.memory.v1.MemoryService.FullTextSearch:output_type -> cognitive_os.memory.v1.SearchResponse
-	4,  // 22: cognitive_os.memory.v1.MemoryService.HybridSearch:output_type -> cognitive_os.memory.v1.SearchResponse
-	7,  // 23: cognitive_os.memory.v1.MemoryService.AddGraphTriple:output_type -> cognitive_os.memory.v1.GraphTripleResponse
-	9,  // 24: cognitive_os.memory.v1.MemoryService.QueryGraph:output_type -> cognitive_os.memory.v1.GraphQueryResponse
-	13, // 25: cognitive_os.memory.v1.MemoryService.DeleteDocument:output_type -> cognitive_os.memory.v1.DeleteResponse
-	15, // 26: cognitive_os.memory.v1.MemoryService.GetStats:output_type -> cognitive_os.memory.v1.StatsResponse
-	19, // [19:27] is the sub-list for method output_type
-	11, // [11:19] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	1,  // 2: cognitive_os.memory.v1.BatchIndexRequest.documents:type_name -> cognitive_os.memory.v1.IndexRequest
+	2,  // 3: cognitive_os.memory.v1.BatchIndexResponse.results:type_name -> cognitive_os.memory.v1.IndexResponse
+	2,  // 4: cognitive_os.memory.v1.IndexProgress.result:type_name -> cognitive_os.memory.v1.IndexResponse
+	35, // 5: cognitive_os.memory.v1.SearchRequest.filters:type_name -> cognitive_os.memory.v1.SearchRequest.FiltersEntry
+	10, // 6: cognitive_os.memory.v1.SearchResponse.results:type_name -> cognitive_os.memory.v1.SearchResult
+	36, // 7: cognitive_os.memory.v1.SearchResult.metadata:type_name -> cognitive_os.memory.v1.SearchResult.MetadataEntry
+	37, // 8: cognitive_os.memory.v1.GraphTripleRequest.metadata:type_name -> cognitive_os.memory.v1.GraphTripleRequest.MetadataEntry
+	15, // 9: cognitive_os.memory.v1.GraphQueryResponse.nodes:type_name -> cognitive_os.memory.v1.GraphNode
+	16, // 10: cognitive_os.memory.v1.GraphQueryResponse.edges:type_name -> cognitive_os.memory.v1.GraphEdge
+	38, // 11: cognitive_os.memory.v1.GraphNode.properties:type_name -> cognitive_os.memory.v1.GraphNode.PropertiesEntry
+	39, // 12: cognitive_os.memory.v1.GraphEdge.properties:type_name -> cognitive_os.memory.v1.GraphEdge.PropertiesEntry
+	40, // 13: cognitive_os.memory.v1.DeleteByFilterRequest.filters:type_name -> cognitive_os.memory.v1.DeleteByFilterRequest.FiltersEntry
+	44, // 14: cognitive_os.memory.v1.DeleteByFilterRequest.indexed_before:type_name -> google.protobuf.Timestamp
+	41, // 15: cognitive_os.memory.v1.ListByFilterRequest.filters:type_name -> cognitive_os.memory.v1.ListByFilterRequest.FiltersEntry
+	42, // 16: cognitive_os.memory.v1.ListedDocument.metadata:type_name -> cognitive_os.memory.v1.ListedDocument.MetadataEntry
+	44, // 17: cognitive_os.memory.v1.ListedDocument.indexed_at:type_name -> google.protobuf.Timestamp
+	24, // 18: cognitive_os.memory.v1.ListByFilterResponse.documents:type_name -> cognitive_os.memory.v1.ListedDocument
+	43, // 19: cognitive_os.memory.v1.GetDocumentResponse.metadata:type_name -> cognitive_os.memory.v1.GetDocumentResponse.MetadataEntry
+	44, // 20: cognitive_os.memory.v1.StatsResponse.last_indexed_at:type_name -> google.protobuf.Timestamp
+	33, // 21: cognitive_os.memory.v1.StatsResponse.collections:type_name -> cognitive_os.memory.v1.CollectionStats
+	44, // 22: cognitive_os.memory.v1.StatsHistoryRequest.since:type_name -> google.protobuf.Timestamp
+	44, // 23: cognitive_os.memory.v1.StatsSnapshot.taken_at:type_name -> google.protobuf.Timestamp
+	31, // 24: cognitive_os.memory.v1.StatsHistoryResponse.snapshots:type_name -> cognitive_os.memory.v1.StatsSnapshot
+	44, // 25: cognitive_os.memory.v1.CollectionStats.last_indexed_at:type_name -> google.protobuf.Timestamp
+	1,  // 26: cognitive_os.memory.v1.MemoryService.IndexDocument:input_type -> cognitive_os.memory.v1.IndexRequest
+	1,  // 27: cognitive_os.memory.v1.MemoryService.StreamIndexDocument:input_type -> cognitive_os.memory.v1.IndexRequest
+	3,  // 28: cognitive_os.memory.v1.MemoryService.BatchIndexDocuments:input_type -> cognitive_os.memory.v1.BatchIndexRequest
+	8,  // 29: cognitive_os.memory.v1.MemoryService.SemanticSearch:input_type -> cognitive_os.memory.v1.SearchRequest
+	8,  // 30: cognitive_os.memory.v1.MemoryService.FullTextSearch:input_type -> cognitive_os.memory.v1.SearchRequest
+	8,  // 31: cognitive_os.memory.v1.MemoryService.HybridSearch:input_type -> cognitive_os.memory.v1.SearchRequest
+	11, // 32: cognitive_os.memory.v1.MemoryService.AddGraphTriple:input_type -> cognitive_os.memory.v1.GraphTripleRequest
+	13, // 33: cognitive_os.memory.v1.MemoryService.QueryGraph:input_type -> cognitive_os.memory.v1.GraphQueryRequest
+	17, // 34: cognitive_os.memory.v1.MemoryService.DeleteDocument:input_type -> cognitive_os.memory.v1.DeleteRequest
+	19, // 35: cognitive_os.memory.v1.MemoryService.UndeleteDocument:input_type -> cognitive_os.memory.v1.UndeleteRequest
+	21, // 36: cognitive_os.memory.v1.MemoryService.DeleteByFilter:input_type -> cognitive_os.memory.v1.DeleteByFilterRequest
+	26, // 37: cognitive_os.memory.v1.MemoryService.GetDocument:input_type -> cognitive_os.memory.v1.GetDocumentRequest
+	23, // 38: cognitive_os.memory.v1.MemoryService.ListByFilter:input_type -> cognitive_os.memory.v1.ListByFilterRequest
+	28, // 39: cognitive_os.memory.v1.MemoryService.GetStats:input_type -> cognitive_os.memory.v1.StatsRequest
+	30, // 40: cognitive_os.memory.v1.MemoryService.GetStatsHistory:input_type -> cognitive_os.memory.v1.StatsHistoryRequest
+	5,  // 41: cognitive_os.memory.v1.MemoryService.Reindex:input_type -> cognitive_os.memory.v1.ReindexRequest
+	2,  // 42: cognitive_os.memory.v1.MemoryService.IndexDocument:output_type -> cognitive_os.memory.v1.IndexResponse
+	7,  // 43: cognitive_os.memory.v1.MemoryService.StreamIndexDocument:output_type -> cognitive_os.memory.v1.IndexProgress
+	4,  // 44: cognitive_os.memory.v1.MemoryService.BatchIndexDocuments:output_type -> cognitive_os.memory.v1.BatchIndexResponse
+	9,  // 45: cognitive_os.memory.v1.MemoryService.SemanticSearch:output_type -> cognitive_os.memory.v1.SearchResponse
+	9,  // 46: cognitive_os.memory.v1.MemoryService.FullTextSearch:output_type -> cognitive_os.memory.v1.SearchResponse
+	9,  // 47: cognitive_os.memory.v1.MemoryService.HybridSearch:output_type -> cognitive_os.memory.v1.SearchResponse
+	12, // 48: cognitive_os.memory.v1.MemoryService.AddGraphTriple:output_type -> cognitive_os.memory.v1.GraphTripleResponse
+	14, // 49: cognitive_os.memory.v1.MemoryService.QueryGraph:output_type -> cognitive_os.memory.v1.GraphQueryResponse
+	18, // 50: cognitive_os.memory.v1.MemoryService.DeleteDocument:output_type -> cognitive_os.memory.v1.DeleteResponse
+	20, // 51: cognitive_os.memory.v1.MemoryService.UndeleteDocument:output_type -> cognitive_os.memory.v1.UndeleteResponse
+	22, // 52: cognitive_os.memory.v1.MemoryService.DeleteByFilter:output_type -> cognitive_os.memory.v1.DeleteByFilterResponse
+	27, // 53: cognitive_os.memory.v1.MemoryService.GetDocument:output_type -> cognitive_os.memory.v1.GetDocumentResponse
+	25, // 54: cognitive_os.memory.v1.MemoryService.ListByFilter:output_type -> cognitive_os.memory.v1.ListByFilterResponse
+	29, // 55: cognitive_os.memory.v1.MemoryService.GetStats:output_type -> cognitive_os.memory.v1.StatsResponse
+	32, // 56: cognitive_os.memory.v1.MemoryService.GetStatsHistory:output_type -> cognitive_os.memory.v1.StatsHistoryResponse
+	6,  // 57: cognitive_os.memory.v1.MemoryService.Reindex:output_type -> cognitive_os.memory.v1.ReindexProgress
+	42, // [42:58] is the sub-list for method output_type
+	26, // [26:42] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_memory_v1_memory_proto_init() }
@@ -1148,7 +2579,7 @@ func file_memory_v1_memory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_memory_v1_memory_proto_rawDesc), len(file_memory_v1_memory_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   21,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},